@@ -5,6 +5,7 @@ const (
 	ExecutionPayloadBlindedHeader = "Eth-Execution-Payload-Blinded"
 	ExecutionPayloadValueHeader   = "Eth-Execution-Payload-Value"
 	ConsensusBlockValueHeader     = "Eth-Consensus-Block-Value"
+	LastEventIDHeader             = "Last-Event-ID"
 	JsonMediaType                 = "application/json"
 	OctetStreamMediaType          = "application/octet-stream"
 	EventStreamMediaType          = "text/event-stream"
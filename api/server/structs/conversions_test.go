@@ -24,3 +24,28 @@ func TestDepositSnapshotFromConsensus(t *testing.T) {
 	require.Equal(t, "0x1234", res.ExecutionBlockHash)
 	require.Equal(t, "67890", res.ExecutionBlockHeight)
 }
+
+func TestAttestationElectra_ToConsensus(t *testing.T) {
+	root := make([]byte, 32)
+	root[0] = 0xaa
+	att := &eth.AttestationElectra{
+		AggregationBits: []byte{0x03},
+		Data: &eth.AttestationData{
+			Slot:            7,
+			BeaconBlockRoot: root,
+			Source:          &eth.Checkpoint{Epoch: 1, Root: root},
+			Target:          &eth.Checkpoint{Epoch: 2, Root: root},
+		},
+		Signature:     make([]byte, 96),
+		CommitteeBits: []byte{0x04, 0, 0, 0, 0, 0, 0, 0},
+	}
+
+	res, err := AttElectraFromConsensus(att).ToConsensus()
+	require.NoError(t, err)
+	require.DeepEqual(t, att, res)
+
+	invalid := AttElectraFromConsensus(att)
+	invalid.CommitteeBits = "foo"
+	_, err = invalid.ToConsensus()
+	require.ErrorContains(t, "CommitteeBits", err)
+}
@@ -24,6 +24,11 @@ type ListAttestationsResponse struct {
 	Data []*Attestation `json:"data"`
 }
 
+type ListAttestationsV2Response struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
 type SubmitAttestationsRequest struct {
 	Data []*Attestation `json:"data"`
 }
@@ -97,6 +102,8 @@ type GetValidatorBalancesResponse struct {
 	ExecutionOptimistic bool                `json:"execution_optimistic"`
 	Finalized           bool                `json:"finalized"`
 	Data                []*ValidatorBalance `json:"data"`
+	NextPageToken       string              `json:"next_page_token,omitempty"`
+	TotalSize           string              `json:"total_size,omitempty"`
 }
 
 type ValidatorContainer struct {
@@ -153,6 +160,27 @@ type Randao struct {
 	Randao string `json:"randao"`
 }
 
+type GetPendingDepositsResponse struct {
+	Version             string                   `json:"version"`
+	ExecutionOptimistic bool                     `json:"execution_optimistic"`
+	Finalized           bool                     `json:"finalized"`
+	Data                []*PendingBalanceDeposit `json:"data"`
+}
+
+type GetPendingPartialWithdrawalsResponse struct {
+	Version             string                      `json:"version"`
+	ExecutionOptimistic bool                        `json:"execution_optimistic"`
+	Finalized           bool                        `json:"finalized"`
+	Data                []*PendingPartialWithdrawal `json:"data"`
+}
+
+type GetPendingConsolidationsResponse struct {
+	Version             string                  `json:"version"`
+	ExecutionOptimistic bool                    `json:"execution_optimistic"`
+	Finalized           bool                    `json:"finalized"`
+	Data                []*PendingConsolidation `json:"data"`
+}
+
 type GetSyncCommitteeResponse struct {
 	ExecutionOptimistic bool                     `json:"execution_optimistic"`
 	Finalized           bool                     `json:"finalized"`
@@ -172,6 +200,11 @@ type GetAttesterSlashingsResponse struct {
 	Data []*AttesterSlashing `json:"data"`
 }
 
+type GetAttesterSlashingsV2Response struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
 type GetProposerSlashingsResponse struct {
 	Data []*ProposerSlashing `json:"data"`
 }
@@ -54,4 +54,6 @@ type ForkChoiceNodeExtraData struct {
 	Balance                  string `json:"balance"`
 	ExecutionOptimistic      bool   `json:"execution_optimistic"`
 	TimeStamp                string `json:"timestamp"`
+	Timely                   bool   `json:"timely"`
+	TargetRoot               string `json:"target_root"`
 }
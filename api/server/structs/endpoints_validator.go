@@ -117,4 +117,21 @@ type GetValidatorPerformanceResponse struct {
 	BalancesAfterEpochTransition  []uint64 `json:"balances_after_epoch_transition,omitempty"`
 	MissingValidators             [][]byte `json:"missing_validators,omitempty"`
 	InactivityScores              []uint64 `json:"inactivity_scores,omitempty"`
+	MaxEffectiveBalances          []uint64 `json:"max_effective_balances,omitempty"`
+}
+
+type GetValidatorRegistrationsResponse struct {
+	Data []*ValidatorRegistrationStatus `json:"data"`
+}
+
+type ValidatorRegistrationStatus struct {
+	Pubkey        string                       `json:"pubkey"`
+	Registration  *SignedValidatorRegistration `json:"registration"`
+	LastSubmitted string                       `json:"last_submitted"`
+	RelayErrors   []*RelayError                `json:"relay_errors"`
+}
+
+type RelayError struct {
+	Relay string `json:"relay"`
+	Error string `json:"error"`
 }
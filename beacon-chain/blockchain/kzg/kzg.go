@@ -0,0 +1,35 @@
+package kzg
+
+import (
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const blobCommitmentVersionKZG uint8 = 0x01
+
+// BlobToKZGCommitment computes the KZG commitment of the blob. Start must have been called.
+func BlobToKZGCommitment(blob []byte) ([]byte, error) {
+	commitment, err := kzgContext.BlobToKZGCommitment(bytesToBlob(blob), 0)
+	if err != nil {
+		return nil, err
+	}
+	return commitment[:], nil
+}
+
+// ComputeBlobKZGProof computes the KZG proof of the blob for its commitment. Start must have been called.
+func ComputeBlobKZGProof(blob []byte, commitment []byte) ([]byte, error) {
+	proof, err := kzgContext.ComputeBlobKZGProof(bytesToBlob(blob), bytesToCommitment(commitment), 0)
+	if err != nil {
+		return nil, err
+	}
+	return proof[:], nil
+}
+
+// ConvertKzgCommitmentToVersionedHash returns the versioned hash the execution layer refers to the blob
+// of the commitment with.
+func ConvertKzgCommitmentToVersionedHash(commitment []byte) common.Hash {
+	versionedHash := sha256.Sum256(commitment)
+	versionedHash[0] = blobCommitmentVersionKZG
+	return versionedHash
+}
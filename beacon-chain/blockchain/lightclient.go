@@ -12,11 +12,13 @@ import (
 	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 const (
-	finalityBranchNumOfLeaves = 6
+	finalityBranchNumOfLeaves        = 6
+	finalityBranchNumOfLeavesElectra = 7
 )
 
 // CreateLightClientFinalityUpdate - implements https://github.com/ethereum/consensus-specs/blob/3d235740e5f1e641d3b160c8688f26e7dc5a1894/specs/altair/light-client/full-node.md#create_light_client_finality_update
@@ -215,8 +217,12 @@ func NewLightClientFinalityUpdateFromBeaconState(
 			BodyRoot:      make([]byte, 32),
 		}
 
-		finalityBranch = make([][]byte, finalityBranchNumOfLeaves)
-		for i := 0; i < finalityBranchNumOfLeaves; i++ {
+		numOfLeaves := finalityBranchNumOfLeaves
+		if attestedState.Version() >= version.Electra {
+			numOfLeaves = finalityBranchNumOfLeavesElectra
+		}
+		finalityBranch = make([][]byte, numOfLeaves)
+		for i := 0; i < numOfLeaves; i++ {
 			finalityBranch[i] = make([]byte, 32)
 		}
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 type Option func(s *Service) error
@@ -183,6 +184,15 @@ func WithClockSynchronizer(gs *startup.ClockSynchronizer) Option {
 	}
 }
 
+// WithSlotClock sets the clock driving the per-slot tasks of the service. Tests can use a fake
+// clock to drive them deterministically.
+func WithSlotClock(c slots.Clock) Option {
+	return func(s *Service) error {
+		s.cfg.SlotClock = c
+		return nil
+	}
+}
+
 // WithSyncComplete sets a channel that is used to notify blockchain service that the node has synced to head.
 func WithSyncComplete(c chan struct{}) Option {
 	return func(s *Service) error {
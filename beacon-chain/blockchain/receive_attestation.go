@@ -88,7 +88,11 @@ func (s *Service) spawnProcessAttestationsRoutine() {
 		}
 
 		reorgInterval := time.Second*time.Duration(params.BeaconConfig().SecondsPerSlot) - reorgLateBlockCountAttestations
-		ticker := slots.NewSlotTickerWithIntervals(s.genesisTime, []time.Duration{0, reorgInterval})
+		clock := s.cfg.SlotClock
+		if clock == nil {
+			clock = slots.SystemClock{}
+		}
+		ticker := slots.NewSlotTickerWithIntervalsAndClock(s.genesisTime, []time.Duration{0, reorgInterval}, clock)
 		for {
 			select {
 			case <-s.ctx.Done():
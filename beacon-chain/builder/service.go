@@ -23,12 +23,16 @@ import (
 // ErrNoBuilder is used when builder endpoint is not configured.
 var ErrNoBuilder = errors.New("builder endpoint not configured")
 
+// ErrNoRegistrationCache is used when the registrations are not kept in the registration cache.
+var ErrNoRegistrationCache = errors.New("validator registration cache not enabled")
+
 // BlockBuilder defines the interface for interacting with the block builder
 type BlockBuilder interface {
 	SubmitBlindedBlock(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error)
 	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error)
 	RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	RegistrationStatuses(pubkeys [][48]byte) (map[[48]byte]*cache.RegistrationStatus, error)
 	Configured() bool
 }
 
@@ -131,7 +135,9 @@ func (s *Service) Status() error {
 }
 
 // RegisterValidator registers a validator with the builder relay network.
-// It also saves the registration object to the DB.
+// It also saves the registration object to the DB, and records the outcome of the submission
+// of each registration in the registration cache. The registrations accepted by the relay are
+// saved even when the relay rejects some of the others.
 func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
 	ctx, span := trace.StartSpan(ctx, "builder.RegisterValidator")
 	defer span.End()
@@ -143,12 +149,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		return ErrNoBuilder
 	}
 
-	// should be removed if db is removed
-	idxs := make([]primitives.ValidatorIndex, 0)
-	msgs := make([]*ethpb.ValidatorRegistrationV1, 0)
-
-	indexToRegistration := make(map[primitives.ValidatorIndex]*ethpb.ValidatorRegistrationV1)
-
+	indices := make([]primitives.ValidatorIndex, 0)
 	valid := make([]*ethpb.SignedValidatorRegistrationV1, 0)
 	for i := 0; i < len(reg); i++ {
 		r := reg[i]
@@ -159,23 +160,83 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 			log.Warnf("Skipping validator registration for pubkey=%#x - not in current validator set.", r.Message.Pubkey)
 			continue
 		}
-		idxs = append(idxs, nx)
-		msgs = append(msgs, r.Message)
+		indices = append(indices, nx)
 		valid = append(valid, r)
-		indexToRegistration[nx] = r.Message
 	}
+	submitted := time.Now()
+	relayErrs := make([]error, len(valid))
 	if err := s.c.RegisterValidator(ctx, valid); err != nil {
-		return errors.Wrap(err, "could not register validator(s)")
+		if len(valid) == 0 {
+			return errors.Wrap(err, "could not register validator(s)")
+		}
+		relayErrs = s.resubmitRegistrations(ctx, valid, err)
+	}
+
+	// should be removed if db is removed
+	idxs := make([]primitives.ValidatorIndex, 0)
+	msgs := make([]*ethpb.ValidatorRegistrationV1, 0)
+
+	indexToRegistration := make(map[primitives.ValidatorIndex]*ethpb.ValidatorRegistrationV1)
+
+	var registerErr error
+	failed := 0
+	for i, r := range valid {
+		if s.registrationCache != nil {
+			s.registrationCache.UpdateRegistrationStatus(s.c.NodeURL(), r, submitted, relayErrs[i])
+		}
+		if relayErrs[i] != nil {
+			if registerErr == nil {
+				registerErr = relayErrs[i]
+			}
+			failed++
+			continue
+		}
+		idxs = append(idxs, indices[i])
+		msgs = append(msgs, r.Message)
+		indexToRegistration[indices[i]] = r.Message
 	}
 
 	if len(indexToRegistration) != len(msgs) {
 		return errors.New("ids and registrations must be the same length")
 	}
-	if s.registrationCache != nil {
-		s.registrationCache.UpdateIndexToRegisteredMap(ctx, indexToRegistration)
-		return nil
-	} else {
-		return s.cfg.beaconDB.SaveRegistrationsByValidatorIDs(ctx, idxs, msgs)
+	if len(msgs) > 0 {
+		if s.registrationCache != nil {
+			s.registrationCache.UpdateIndexToRegisteredMap(ctx, indexToRegistration)
+		} else if err := s.cfg.beaconDB.SaveRegistrationsByValidatorIDs(ctx, idxs, msgs); err != nil {
+			return err
+		}
+	}
+	if registerErr != nil {
+		return errors.Wrapf(registerErr, "could not register %d of %d validator(s)", failed, len(valid))
+	}
+	return nil
+}
+
+// resubmitRegistrations returns the outcome of each registration after the relay failed to register them all
+// at once. A relay rejects the whole request when one of the registrations is invalid, in which case the
+// registrations are bisected so that the valid ones are still registered, with a number of requests that only
+// grows with the logarithm of the number of registrations for each invalid one. Other failures are reported
+// for all the registrations of the request.
+func (s *Service) resubmitRegistrations(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1, err error) []error {
+	errs := make([]error, len(reg))
+	s.bisectRegistrations(ctx, reg, err, errs)
+	return errs
+}
+
+// bisectRegistrations records in errs the outcome of each of the registrations rejected by the relay with err.
+func (s *Service) bisectRegistrations(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1, err error, errs []error) {
+	if !errors.Is(err, builder.ErrBadRequest) || len(reg) == 1 {
+		for i := range errs {
+			errs[i] = err
+		}
+		return
+	}
+	mid := len(reg) / 2
+	for _, bounds := range [][2]int{{0, mid}, {mid, len(reg)}} {
+		half := reg[bounds[0]:bounds[1]]
+		if err := s.c.RegisterValidator(ctx, half); err != nil {
+			s.bisectRegistrations(ctx, half, err, errs[bounds[0]:bounds[1]])
+		}
 	}
 }
 
@@ -191,6 +252,30 @@ func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.V
 	}
 }
 
+// RegistrationStatuses returns the outcome of submitting the registrations of the validators with the given public
+// keys to the relay, or of all the submitted registrations when no public key is given. Validators whose
+// registration was never submitted are not part of the result.
+func (s *Service) RegistrationStatuses(pubkeys [][48]byte) (map[[48]byte]*cache.RegistrationStatus, error) {
+	if s.registrationCache == nil {
+		return nil, ErrNoRegistrationCache
+	}
+	if len(pubkeys) == 0 {
+		return s.registrationCache.RegistrationStatuses(), nil
+	}
+	statuses := make(map[[48]byte]*cache.RegistrationStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		status, err := s.registrationCache.RegistrationStatusByPubkey(pubkey)
+		if errors.Is(err, cache.ErrNotFoundRegistration) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		statuses[pubkey] = status
+	}
+	return statuses, nil
+}
+
 // Configured returns true if the user has configured a builder client.
 func (s *Service) Configured() bool {
 	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
@@ -2,12 +2,21 @@ package builder
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
 	buildertesting "github.com/prysmaticlabs/prysm/v5/api/client/builder/testing"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	blockchainTesting "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	dbtesting "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -54,6 +63,190 @@ func Test_RegisterValidator_WithCache(t *testing.T) {
 	require.DeepEqual(t, reg, registration)
 }
 
+// indexedHeadFetcher maps the public keys of the registered validators to distinct validator indices.
+type indexedHeadFetcher struct {
+	*blockchainTesting.ChainService
+	indices map[[48]byte]primitives.ValidatorIndex
+}
+
+func (f *indexedHeadFetcher) HeadPublicKeyToValidatorIndex(pubkey [48]byte) (primitives.ValidatorIndex, bool) {
+	idx, ok := f.indices[pubkey]
+	return idx, ok
+}
+
+// rejectedKeys holds the hex encoded public keys whose registration the relay rejects, and counts the
+// registration requests the relay receives.
+type rejectedKeys struct {
+	sync.Mutex
+	keys     map[string]bool
+	requests int
+}
+
+func (r *rejectedKeys) countRequest() {
+	r.Lock()
+	defer r.Unlock()
+	r.requests++
+}
+
+func (r *rejectedKeys) requestCount() int {
+	r.Lock()
+	defer r.Unlock()
+	return r.requests
+}
+
+func (r *rejectedKeys) has(pubkey string) bool {
+	r.Lock()
+	defer r.Unlock()
+	return r.keys[pubkey]
+}
+
+func (r *rejectedKeys) remove(pubkey string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.keys, pubkey)
+}
+
+// mockRelay serves the validator registration endpoint of a relay, which rejects the whole request when it
+// contains the registration of one of the rejected public keys.
+func mockRelay(rejected *rejectedKeys) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/builder/validators" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		rejected.countRequest()
+		var regs []*structs.SignedValidatorRegistration
+		if err := json.NewDecoder(r.Body).Decode(&regs); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		for _, reg := range regs {
+			if rejected.has(reg.Message.Pubkey) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&builder.ErrorMessage{
+					Code:    http.StatusBadRequest,
+					Message: "invalid registration for " + reg.Message.Pubkey,
+				})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// testRegistrations returns n registrations of validators known to the returned head fetcher.
+func testRegistrations(n int) ([]*eth.SignedValidatorRegistrationV1, *indexedHeadFetcher) {
+	headFetcher := &indexedHeadFetcher{
+		ChainService: &blockchainTesting.ChainService{},
+		indices:      make(map[[48]byte]primitives.ValidatorIndex),
+	}
+	regs := make([]*eth.SignedValidatorRegistrationV1, n)
+	for i := range regs {
+		pubkey := bytesutil.ToBytes48([]byte{byte(i + 1)})
+		headFetcher.indices[pubkey] = primitives.ValidatorIndex(i)
+		regs[i] = &eth.SignedValidatorRegistrationV1{
+			Message: &eth.ValidatorRegistrationV1{
+				Pubkey:       pubkey[:],
+				FeeRecipient: make([]byte, 20),
+				GasLimit:     30000000,
+				Timestamp:    uint64(time.Now().UTC().Unix()),
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+	return regs, headFetcher
+}
+
+func Test_RegisterValidator_RelayRejectsSomeKeys(t *testing.T) {
+	ctx := context.Background()
+	regs, headFetcher := testRegistrations(4)
+	rejected := &rejectedKeys{keys: map[string]bool{
+		hexutil.Encode(regs[1].Message.Pubkey): true,
+		hexutil.Encode(regs[3].Message.Pubkey): true,
+	}}
+	relay := mockRelay(rejected)
+	defer relay.Close()
+	client, err := builder.NewClient(relay.URL)
+	require.NoError(t, err)
+	s, err := NewService(ctx, WithRegistrationCache(), WithHeadFetcher(headFetcher), WithBuilderClient(client))
+	require.NoError(t, err)
+
+	err = s.RegisterValidator(ctx, regs)
+	require.ErrorContains(t, "could not register 2 of 4 validator(s)", err)
+	statuses, err := s.RegistrationStatuses(nil)
+	require.NoError(t, err)
+	require.Equal(t, len(regs), len(statuses))
+	for i, reg := range regs {
+		status := statuses[bytesutil.ToBytes48(reg.Message.Pubkey)]
+		require.NotNil(t, status)
+		if i%2 == 1 {
+			assert.Equal(t, (*eth.SignedValidatorRegistrationV1)(nil), status.Registration)
+			assert.Equal(t, true, status.LastSubmitted.IsZero())
+			assert.StringContains(t, "invalid registration for "+hexutil.Encode(reg.Message.Pubkey), status.RelayErrors[relay.URL])
+			_, err := s.RegistrationByValidatorID(ctx, primitives.ValidatorIndex(i))
+			require.ErrorIs(t, err, cache.ErrNotFoundRegistration)
+			continue
+		}
+		assert.DeepEqual(t, reg, status.Registration)
+		assert.Equal(t, false, status.LastSubmitted.IsZero())
+		assert.Equal(t, 0, len(status.RelayErrors))
+		registration, err := s.RegistrationByValidatorID(ctx, primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		assert.DeepEqual(t, reg.Message, registration)
+	}
+
+	t.Run("accepted on retry", func(t *testing.T) {
+		rejected.remove(hexutil.Encode(regs[1].Message.Pubkey))
+		require.NoError(t, s.RegisterValidator(ctx, regs[1:2]))
+		statuses, err := s.RegistrationStatuses([][48]byte{bytesutil.ToBytes48(regs[1].Message.Pubkey), bytesutil.ToBytes48(regs[3].Message.Pubkey)})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(statuses))
+		status := statuses[bytesutil.ToBytes48(regs[1].Message.Pubkey)]
+		assert.DeepEqual(t, regs[1], status.Registration)
+		assert.Equal(t, 0, len(status.RelayErrors))
+		status = statuses[bytesutil.ToBytes48(regs[3].Message.Pubkey)]
+		assert.Equal(t, 1, len(status.RelayErrors))
+	})
+	t.Run("unknown keys are skipped", func(t *testing.T) {
+		statuses, err := s.RegistrationStatuses([][48]byte{bytesutil.ToBytes48([]byte("unknown"))})
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(statuses))
+	})
+}
+
+func Test_RegisterValidator_BisectsRejectedRequest(t *testing.T) {
+	ctx := context.Background()
+	regs, headFetcher := testRegistrations(64)
+	rejected := &rejectedKeys{keys: map[string]bool{
+		hexutil.Encode(regs[37].Message.Pubkey): true,
+	}}
+	relay := mockRelay(rejected)
+	defer relay.Close()
+	client, err := builder.NewClient(relay.URL)
+	require.NoError(t, err)
+	s, err := NewService(ctx, WithRegistrationCache(), WithHeadFetcher(headFetcher), WithBuilderClient(client))
+	require.NoError(t, err)
+
+	err = s.RegisterValidator(ctx, regs)
+	require.ErrorContains(t, "could not register 1 of 64 validator(s)", err)
+	// The rejected request, then two requests for each of the 6 halvings down to the rejected registration.
+	assert.Equal(t, 1+2*6, rejected.requestCount())
+	statuses, err := s.RegistrationStatuses(nil)
+	require.NoError(t, err)
+	for i, reg := range regs {
+		status := statuses[bytesutil.ToBytes48(reg.Message.Pubkey)]
+		require.NotNil(t, status)
+		assert.Equal(t, i == 37, len(status.RelayErrors) == 1)
+	}
+}
+
+func Test_RegistrationStatuses_WithoutCache(t *testing.T) {
+	s, err := NewService(context.Background(), WithBuilderClient(&buildertesting.MockClient{}))
+	require.NoError(t, err)
+	_, err = s.RegistrationStatuses(nil)
+	require.ErrorIs(t, err, ErrNoRegistrationCache)
+}
+
 func Test_BuilderMethodsWithouClient(t *testing.T) {
 	s, err := NewService(context.Background())
 	require.NoError(t, err)
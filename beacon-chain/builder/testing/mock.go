@@ -96,6 +96,24 @@ func (s *MockBuilderService) RegistrationByValidatorID(ctx context.Context, id p
 	return nil, cache.ErrNotFoundRegistration
 }
 
+// RegistrationStatuses returns the registration statuses from the cache.
+func (s *MockBuilderService) RegistrationStatuses(pubkeys [][48]byte) (map[[48]byte]*cache.RegistrationStatus, error) {
+	if s.RegistrationCache == nil {
+		return nil, errors.New("registration cache not set")
+	}
+	statuses := s.RegistrationCache.RegistrationStatuses()
+	if len(pubkeys) == 0 {
+		return statuses, nil
+	}
+	filtered := make(map[[48]byte]*cache.RegistrationStatus, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		if status, ok := statuses[pubkey]; ok {
+			filtered[pubkey] = status
+		}
+	}
+	return filtered, nil
+}
+
 // RegisterValidator for mocking.
 func (s *MockBuilderService) RegisterValidator(context.Context, []*ethpb.SignedValidatorRegistrationV1) error {
 	return s.ErrRegisterValidator
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// maxDutiesCacheSize is the number of states kept in the duties cache, which is enough for the
+// attester and proposer duties of the current and next epochs.
+const maxDutiesCacheSize = 4
+
+// DutyKind is the kind of validator duties a cached state is used for.
+type DutyKind uint8
+
+const (
+	// AttesterDuties is the kind of the states attester duties are computed from.
+	AttesterDuties DutyKind = iota
+	// ProposerDuties is the kind of the states proposer duties are computed from.
+	ProposerDuties
+)
+
+// DutiesKey identifies a state in the duties cache. Slot is the slot of the state, which
+// tells apart states of the same epoch and dependent root, such as the state used for the
+// proposer lookahead of the next epoch and the state of that epoch once it has started.
+type DutiesKey struct {
+	Kind          DutyKind
+	Epoch         primitives.Epoch
+	Slot          primitives.Slot
+	DependentRoot [32]byte
+}
+
+// DutiesCache is a cache that keeps track of the states validator duties of recent
+// epochs are computed from. The state of an epoch is keyed by the dependent root of
+// the duties, so that a reorg replacing the dependent block invalidates the state.
+// At most maxDutiesCacheSize states are kept, dropping the states of the lowest epochs.
+type DutiesCache struct {
+	states map[DutiesKey]state.BeaconState
+	sync.Mutex
+}
+
+// NewDutiesCache returns a new duties cache
+func NewDutiesCache() *DutiesCache {
+	return &DutiesCache{states: make(map[DutiesKey]state.BeaconState)}
+}
+
+// State returns the duties state saved with the given key.
+func (d *DutiesCache) State(key DutiesKey) (state.BeaconState, bool) {
+	d.Lock()
+	defer d.Unlock()
+	st, ok := d.states[key]
+	return st, ok
+}
+
+// Set saves the duties state with the given key. It also prunes the states of older epochs
+// and the states over the size of the cache.
+func (d *DutiesCache) Set(key DutiesKey, st state.BeaconState) {
+	d.Lock()
+	defer d.Unlock()
+	if key.Epoch > 0 {
+		d.prune(key.Epoch - 1)
+	}
+	d.states[key] = st
+	for len(d.states) > maxDutiesCacheSize {
+		d.evictLowestEpoch()
+	}
+}
+
+// prune prunes the states of epochs older than the given epoch. Requires a Lock in the cache
+func (d *DutiesCache) prune(epoch primitives.Epoch) {
+	for key := range d.states {
+		if key.Epoch < epoch {
+			delete(d.states, key)
+		}
+	}
+}
+
+// evictLowestEpoch drops a state of the lowest epoch in the cache. Requires a Lock in the cache
+func (d *DutiesCache) evictLowestEpoch() {
+	var lowest DutiesKey
+	found := false
+	for key := range d.states {
+		if !found || key.Epoch < lowest.Epoch {
+			lowest = key
+			found = true
+		}
+	}
+	delete(d.states, lowest)
+}
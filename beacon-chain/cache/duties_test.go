@@ -0,0 +1,114 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func attesterKey(epoch primitives.Epoch, root [32]byte) cache.DutiesKey {
+	return cache.DutiesKey{Kind: cache.AttesterDuties, Epoch: epoch, DependentRoot: root}
+}
+
+func TestDutiesCache_StateAndSet(t *testing.T) {
+	c := cache.NewDutiesCache()
+	st, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{Slot: 64})
+	require.NoError(t, err)
+	r := [32]byte{1, 2, 3}
+	_, ok := c.State(attesterKey(2, r))
+	require.Equal(t, false, ok)
+
+	c.Set(attesterKey(2, r), st)
+	got, ok := c.State(attesterKey(2, r))
+	require.Equal(t, true, ok)
+	require.Equal(t, st, got)
+	_, ok = c.State(attesterKey(3, r))
+	require.Equal(t, false, ok)
+
+	// A reorg replaces the block the state was computed on top of.
+	reorged := [32]byte{4, 5, 6}
+	_, ok = c.State(attesterKey(2, reorged))
+	require.Equal(t, false, ok)
+	reorgedSt := st.Copy()
+	c.Set(attesterKey(2, reorged), reorgedSt)
+	got, ok = c.State(attesterKey(2, reorged))
+	require.Equal(t, true, ok)
+	require.Equal(t, reorgedSt, got)
+	got, ok = c.State(attesterKey(2, r))
+	require.Equal(t, true, ok)
+	require.Equal(t, st, got)
+}
+
+func TestDutiesCache_Prune(t *testing.T) {
+	c := cache.NewDutiesCache()
+	st, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{})
+	require.NoError(t, err)
+	r := [32]byte{1}
+	c.Set(attesterKey(1, r), st)
+	c.Set(attesterKey(2, r), st)
+	c.Set(attesterKey(3, r), st)
+	_, ok := c.State(attesterKey(1, r))
+	require.Equal(t, false, ok)
+	_, ok = c.State(attesterKey(2, r))
+	require.Equal(t, true, ok)
+	_, ok = c.State(attesterKey(3, r))
+	require.Equal(t, true, ok)
+}
+
+func TestDutiesCache_Size(t *testing.T) {
+	c := cache.NewDutiesCache()
+	st, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{})
+	require.NoError(t, err)
+	// States requested for historical epochs in descending order are not all kept.
+	for epoch := primitives.Epoch(20); epoch > 10; epoch-- {
+		c.Set(attesterKey(epoch, [32]byte{byte(epoch)}), st)
+	}
+	kept := 0
+	for epoch := primitives.Epoch(20); epoch > 10; epoch-- {
+		if _, ok := c.State(attesterKey(epoch, [32]byte{byte(epoch)})); ok {
+			kept++
+		}
+	}
+	require.Equal(t, 4, kept)
+
+	// The states of the lowest epochs are dropped first.
+	c = cache.NewDutiesCache()
+	for i := byte(0); i < 3; i++ {
+		c.Set(attesterKey(5, [32]byte{i}), st)
+		c.Set(attesterKey(6, [32]byte{i}), st)
+	}
+	for i := byte(0); i < 3; i++ {
+		_, ok := c.State(attesterKey(6, [32]byte{i}))
+		require.Equal(t, true, ok)
+	}
+	kept = 0
+	for i := byte(0); i < 3; i++ {
+		if _, ok := c.State(attesterKey(5, [32]byte{i})); ok {
+			kept++
+		}
+	}
+	require.Equal(t, 1, kept)
+}
+
+func TestDutiesCache_KindAndSlot(t *testing.T) {
+	c := cache.NewDutiesCache()
+	lookahead, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{Slot: 32})
+	require.NoError(t, err)
+	r := [32]byte{1}
+	// The proposer lookahead of epoch 2 is computed from a state of epoch 1.
+	c.Set(cache.DutiesKey{Kind: cache.ProposerDuties, Epoch: 2, Slot: 32, DependentRoot: r}, lookahead)
+
+	// Once epoch 2 starts, its proposer duties are computed from a state of epoch 2.
+	_, ok := c.State(cache.DutiesKey{Kind: cache.ProposerDuties, Epoch: 2, Slot: 64, DependentRoot: r})
+	require.Equal(t, false, ok)
+	// Attester duties sharing the epoch, slot and dependent root do not get the proposer state.
+	_, ok = c.State(cache.DutiesKey{Kind: cache.AttesterDuties, Epoch: 2, Slot: 32, DependentRoot: r})
+	require.Equal(t, false, ok)
+	got, ok := c.State(cache.DutiesKey{Kind: cache.ProposerDuties, Epoch: 2, Slot: 32, DependentRoot: r})
+	require.Equal(t, true, ok)
+	require.Equal(t, lookahead, got)
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -15,13 +16,25 @@ import (
 // beacon api /eth/v1/validator/register_validator
 type RegistrationCache struct {
 	indexToRegistration map[primitives.ValidatorIndex]*ethpb.ValidatorRegistrationV1
+	pubkeyToStatus      map[[48]byte]*RegistrationStatus
 	lock                sync.RWMutex
 }
 
+// RegistrationStatus is the outcome of submitting the registration of a validator to the builder relays.
+type RegistrationStatus struct {
+	// Registration is the latest registration accepted by a relay, nil if none was accepted.
+	Registration *ethpb.SignedValidatorRegistrationV1
+	// LastSubmitted is the time of the latest submission accepted by a relay.
+	LastSubmitted time.Time
+	// RelayErrors holds the error of the latest submission to each relay that did not accept it.
+	RelayErrors map[string]string
+}
+
 // NewRegistrationCache initializes the map and underlying cache.
 func NewRegistrationCache() *RegistrationCache {
 	return &RegistrationCache{
 		indexToRegistration: make(map[primitives.ValidatorIndex]*ethpb.ValidatorRegistrationV1),
+		pubkeyToStatus:      make(map[[48]byte]*RegistrationStatus),
 		lock:                sync.RWMutex{},
 	}
 }
@@ -53,3 +66,75 @@ func (regCache *RegistrationCache) UpdateIndexToRegisteredMap(ctx context.Contex
 		}
 	}
 }
+
+// UpdateRegistrationStatus records the outcome of submitting the registration to the relay at the given time.
+// An accepted submission replaces the registration of the validator and clears the error of the relay.
+func (regCache *RegistrationCache) UpdateRegistrationStatus(relay string, reg *ethpb.SignedValidatorRegistrationV1, submitted time.Time, err error) {
+	if reg == nil || reg.Message == nil {
+		return
+	}
+	regCache.lock.Lock()
+	defer regCache.lock.Unlock()
+	pubkey := bytesutil.ToBytes48(reg.Message.Pubkey)
+	status, ok := regCache.pubkeyToStatus[pubkey]
+	if !ok {
+		status = &RegistrationStatus{RelayErrors: make(map[string]string)}
+		regCache.pubkeyToStatus[pubkey] = status
+	}
+	if err != nil {
+		status.RelayErrors[relay] = err.Error()
+		return
+	}
+	status.Registration = copySignedRegistration(reg)
+	status.LastSubmitted = submitted
+	delete(status.RelayErrors, relay)
+}
+
+// RegistrationStatusByPubkey returns a copy of the registration status of the validator with the given public key.
+func (regCache *RegistrationCache) RegistrationStatusByPubkey(pubkey [48]byte) (*RegistrationStatus, error) {
+	regCache.lock.RLock()
+	defer regCache.lock.RUnlock()
+	status, ok := regCache.pubkeyToStatus[pubkey]
+	if !ok {
+		return nil, errors.Wrapf(ErrNotFoundRegistration, "validator pubkey %#x", pubkey)
+	}
+	return status.copy(), nil
+}
+
+// RegistrationStatuses returns copies of the registration statuses of all validators in the cache.
+func (regCache *RegistrationCache) RegistrationStatuses() map[[48]byte]*RegistrationStatus {
+	regCache.lock.RLock()
+	defer regCache.lock.RUnlock()
+	statuses := make(map[[48]byte]*RegistrationStatus, len(regCache.pubkeyToStatus))
+	for pubkey, status := range regCache.pubkeyToStatus {
+		statuses[pubkey] = status.copy()
+	}
+	return statuses
+}
+
+func (s *RegistrationStatus) copy() *RegistrationStatus {
+	relayErrors := make(map[string]string, len(s.RelayErrors))
+	for relay, err := range s.RelayErrors {
+		relayErrors[relay] = err
+	}
+	return &RegistrationStatus{
+		Registration:  copySignedRegistration(s.Registration),
+		LastSubmitted: s.LastSubmitted,
+		RelayErrors:   relayErrors,
+	}
+}
+
+func copySignedRegistration(reg *ethpb.SignedValidatorRegistrationV1) *ethpb.SignedValidatorRegistrationV1 {
+	if reg == nil || reg.Message == nil {
+		return nil
+	}
+	return &ethpb.SignedValidatorRegistrationV1{
+		Message: &ethpb.ValidatorRegistrationV1{
+			Pubkey:       bytesutil.SafeCopyBytes(reg.Message.Pubkey),
+			FeeRecipient: bytesutil.SafeCopyBytes(reg.Message.FeeRecipient),
+			GasLimit:     reg.Message.GasLimit,
+			Timestamp:    reg.Message.Timestamp,
+		},
+		Signature: bytesutil.SafeCopyBytes(reg.Signature),
+	}
+}
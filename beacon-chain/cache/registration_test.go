@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
@@ -44,3 +46,61 @@ func TestRegistrationCache(t *testing.T) {
 		require.Equal(t, string(reg.Pubkey), string(pubkey))
 	})
 }
+
+func TestRegistrationCache_RegistrationStatus(t *testing.T) {
+	pubkey, err := hexutil.Decode("0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a")
+	require.NoError(t, err)
+	cache := NewRegistrationCache()
+	_, err = cache.RegistrationStatusByPubkey(bytesutil.ToBytes48(pubkey))
+	require.ErrorIs(t, err, ErrNotFoundRegistration)
+
+	reg := &ethpb.SignedValidatorRegistrationV1{
+		Message: &ethpb.ValidatorRegistrationV1{
+			FeeRecipient: make([]byte, 20),
+			GasLimit:     100,
+			Timestamp:    uint64(time.Now().Unix()),
+			Pubkey:       pubkey,
+		},
+		Signature: make([]byte, 96),
+	}
+	submitted := time.Now()
+	cache.UpdateRegistrationStatus("http://relay-a", reg, submitted, nil)
+	cache.UpdateRegistrationStatus("http://relay-b", reg, submitted.Add(time.Second), errors.New("relay-b failure"))
+
+	status, err := cache.RegistrationStatusByPubkey(bytesutil.ToBytes48(pubkey))
+	require.NoError(t, err)
+	require.DeepEqual(t, reg, status.Registration)
+	require.Equal(t, submitted, status.LastSubmitted)
+	require.DeepEqual(t, map[string]string{"http://relay-b": "relay-b failure"}, status.RelayErrors)
+
+	t.Run("accepted submission clears the relay error", func(t *testing.T) {
+		updated := &ethpb.SignedValidatorRegistrationV1{
+			Message: &ethpb.ValidatorRegistrationV1{
+				FeeRecipient: make([]byte, 20),
+				GasLimit:     200,
+				Timestamp:    reg.Message.Timestamp + 1,
+				Pubkey:       pubkey,
+			},
+			Signature: make([]byte, 96),
+		}
+		resubmitted := submitted.Add(time.Minute)
+		cache.UpdateRegistrationStatus("http://relay-b", updated, resubmitted, nil)
+		status, err := cache.RegistrationStatusByPubkey(bytesutil.ToBytes48(pubkey))
+		require.NoError(t, err)
+		require.DeepEqual(t, updated, status.Registration)
+		require.Equal(t, resubmitted, status.LastSubmitted)
+		require.Equal(t, 0, len(status.RelayErrors))
+	})
+	t.Run("returns copies", func(t *testing.T) {
+		statuses := cache.RegistrationStatuses()
+		require.Equal(t, 1, len(statuses))
+		status := statuses[bytesutil.ToBytes48(pubkey)]
+		require.NotNil(t, status)
+		status.Registration.Message.GasLimit = 0
+		status.RelayErrors["http://relay-a"] = "modified"
+		status, err := cache.RegistrationStatusByPubkey(bytesutil.ToBytes48(pubkey))
+		require.NoError(t, err)
+		require.Equal(t, uint64(200), status.Registration.Message.GasLimit)
+		require.Equal(t, 0, len(status.RelayErrors))
+	})
+}
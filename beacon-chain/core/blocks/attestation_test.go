@@ -626,6 +626,16 @@ func TestRetrieveAttestationSignatureSet_VerifiesMultipleAttestations(t *testing
 		verified, err := set.Verify()
 		require.NoError(t, err)
 		assert.Equal(t, true, verified, "Multiple signatures were unable to be verified.")
+		invalid, err := bls.VerifyBatch(set.Signatures, set.Messages, set.PublicKeys)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(invalid))
+
+		att2.Signature = att1.Signature
+		set, err = blocks.AttestationSignatureBatch(ctx, st, []ethpb.Att{att1, att2})
+		require.NoError(t, err)
+		invalid, err = bls.VerifyBatch(set.Signatures, set.Messages, set.PublicKeys)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []int{1}, invalid)
 	})
 	t.Run("post-Electra", func(t *testing.T) {
 		st, err := util.NewBeaconStateElectra()
@@ -7,11 +7,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/container/slice"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/slashings"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -151,3 +153,48 @@ func SlashableAttesterIndices(slashing ethpb.AttSlashing) []uint64 {
 	indices2 := slashing.SecondAttestation().GetAttestingIndices()
 	return slice.IntersectionUint64(indices1, indices2)
 }
+
+// AttesterSlashingForVersion returns the slashing as an attester slashing of the given fork. The indexed
+// attestations of Electra only differ from the previous ones by their maximum number of attesting indices,
+// so any slashing can be converted to an Electra slashing, while an Electra slashing can only be converted
+// back when the attesting indices of its attestations fit in a committee.
+func AttesterSlashingForVersion(slashing ethpb.AttSlashing, v int) (ethpb.AttSlashing, error) {
+	if slashing == nil || slashing.FirstAttestation() == nil || slashing.SecondAttestation() == nil {
+		return nil, errors.New("nil attestation")
+	}
+	if (slashing.Version() >= version.Electra) == (v >= version.Electra) {
+		return slashing, nil
+	}
+	att1 := slashing.FirstAttestation()
+	att2 := slashing.SecondAttestation()
+	if v >= version.Electra {
+		return &ethpb.AttesterSlashingElectra{
+			Attestation_1: &ethpb.IndexedAttestationElectra{
+				AttestingIndices: att1.GetAttestingIndices(),
+				Data:             att1.GetData(),
+				Signature:        att1.GetSignature(),
+			},
+			Attestation_2: &ethpb.IndexedAttestationElectra{
+				AttestingIndices: att2.GetAttestingIndices(),
+				Data:             att2.GetData(),
+				Signature:        att2.GetSignature(),
+			},
+		}, nil
+	}
+	maxIndices := params.BeaconConfig().MaxValidatorsPerCommittee
+	if uint64(len(att1.GetAttestingIndices())) > maxIndices || uint64(len(att2.GetAttestingIndices())) > maxIndices {
+		return nil, errors.Errorf("attestations of the slashing have more than %d attesting indices", maxIndices)
+	}
+	return &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{
+			AttestingIndices: att1.GetAttestingIndices(),
+			Data:             att1.GetData(),
+			Signature:        att1.GetSignature(),
+		},
+		Attestation_2: &ethpb.IndexedAttestation{
+			AttestingIndices: att2.GetAttestingIndices(),
+			Data:             att2.GetData(),
+			Signature:        att2.GetSignature(),
+		},
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
@@ -395,3 +396,44 @@ func TestProcessAttesterSlashings_AppliesCorrectStatusCapella(t *testing.T) {
 	require.Equal(t, uint64(31000000000), newState.Balances()[1])
 	require.Equal(t, uint64(32000000000), newState.Balances()[2])
 }
+
+func TestAttesterSlashingForVersion(t *testing.T) {
+	data1 := util.HydrateAttestationData(&ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 1}})
+	data2 := util.HydrateAttestationData(&ethpb.AttestationData{Target: &ethpb.Checkpoint{Epoch: 1}, Slot: 1})
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}, Data: data1}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2, 3}, Data: data2}),
+	}
+	slashingElectra := &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: slashing.Attestation_1.AttestingIndices,
+			Data:             slashing.Attestation_1.Data,
+			Signature:        slashing.Attestation_1.Signature,
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: slashing.Attestation_2.AttestingIndices,
+			Data:             slashing.Attestation_2.Data,
+			Signature:        slashing.Attestation_2.Signature,
+		},
+	}
+
+	got, err := blocks.AttesterSlashingForVersion(slashing, version.Deneb)
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.AttSlashing(slashing), got)
+	got, err = blocks.AttesterSlashingForVersion(slashing, version.Electra)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slashingElectra, got)
+	got, err = blocks.AttesterSlashingForVersion(slashingElectra, version.Electra)
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.AttSlashing(slashingElectra), got)
+	got, err = blocks.AttesterSlashingForVersion(slashingElectra, version.Phase0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slashing, got)
+
+	// An Electra attestation may have more attesting indices than a committee.
+	slashingElectra.Attestation_1.AttestingIndices = make([]uint64, params.BeaconConfig().MaxValidatorsPerCommittee+1)
+	_, err = blocks.AttesterSlashingForVersion(slashingElectra, version.Deneb)
+	require.ErrorContains(t, "more than", err)
+	_, err = blocks.AttesterSlashingForVersion(nil, version.Deneb)
+	require.ErrorContains(t, "nil attestation", err)
+}
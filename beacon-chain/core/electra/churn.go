@@ -43,11 +43,10 @@ func ComputeConsolidationEpochAndUpdateChurn(ctx context.Context, s state.Beacon
 		return 0, err
 	}
 	earliestConsolidationEpoch := max(earliestEpoch, helpers.ActivationExitEpoch(slots.ToEpoch(s.Slot())))
-	activeBal, err := helpers.TotalActiveBalance(s)
+	perEpochConsolidationChurn, err := helpers.ConsolidationChurnLimitFromState(s)
 	if err != nil {
 		return 0, err
 	}
-	perEpochConsolidationChurn := helpers.ConsolidationChurnLimit(primitives.Gwei(activeBal))
 
 	// New epoch for consolidations.
 	var consolidationBalanceToConsume primitives.Gwei
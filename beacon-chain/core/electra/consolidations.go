@@ -161,12 +161,12 @@ func ProcessConsolidations(ctx context.Context, st state.BeaconState, cs []*ethp
 		return err
 	}
 
-	totalBalance, err := helpers.TotalActiveBalance(st)
+	consolidationChurn, err := helpers.ConsolidationChurnLimitFromState(st)
 	if err != nil {
 		return err
 	}
 
-	if helpers.ConsolidationChurnLimit(primitives.Gwei(totalBalance)) <= primitives.Gwei(params.BeaconConfig().MinActivationBalance) {
+	if consolidationChurn <= primitives.Gwei(params.BeaconConfig().MinActivationBalance) {
 		return errors.New("too little available consolidation churn limit")
 	}
 
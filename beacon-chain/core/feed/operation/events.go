@@ -3,6 +3,7 @@ package operation
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -24,7 +25,7 @@ const (
 	// BLSToExecutionChangeReceived is sent after a BLS to execution change object has been received from gossip or rpc.
 	BLSToExecutionChangeReceived
 
-	// BlobSidecarReceived is sent after a blob sidecar is received from gossip or rpc.
+	// BlobSidecarReceived is sent after a blob sidecar passes gossip validation or is received from rpc.
 	BlobSidecarReceived = 6
 
 	// ProposerSlashingReceived is sent after a proposer slashing is received from gossip or rpc
@@ -32,6 +33,9 @@ const (
 
 	// AttesterSlashingReceived is sent after an attester slashing is received from gossip or rpc
 	AttesterSlashingReceived = 8
+
+	// BlockGossipReceived is sent after a block passes gossip validation, before it is imported.
+	BlockGossipReceived = 9
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -77,3 +81,9 @@ type ProposerSlashingReceivedData struct {
 type AttesterSlashingReceivedData struct {
 	AttesterSlashing ethpb.AttSlashing
 }
+
+// BlockGossipReceivedData is the data sent with BlockGossipReceived events.
+type BlockGossipReceivedData struct {
+	// SignedBlock is the block that passed gossip validation.
+	SignedBlock interfaces.ReadOnlySignedBeaconBlock
+}
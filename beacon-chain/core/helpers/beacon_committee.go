@@ -30,6 +30,14 @@ var (
 	proposerIndicesCache = cache.NewProposerIndicesCache()
 )
 
+var (
+	// ErrInvalidCommitteeIndex is returned when a committee index is not below the number of
+	// committees of a slot.
+	ErrInvalidCommitteeIndex = errors.New("invalid committee index")
+	// ErrNotCommitteeMember is returned when a validator is not a member of a committee.
+	ErrNotCommitteeMember = errors.New("validator is not a member of the committee")
+)
+
 // SlotCommitteeCount returns the number of beacon committees of a slot. The
 // active validator count is provided as an argument rather than an imported implementation
 // from the spec definition. Having the active validator count as an argument allows for
@@ -198,8 +206,8 @@ func verifyAssignmentEpoch(epoch primitives.Epoch, state state.BeaconState) erro
 }
 
 // ProposerAssignments calculates proposer assignments for each validator during the specified epoch.
-// It verifies the validity of the epoch, then iterates through each slot in the epoch to determine the
-// proposer for that slot and assigns them accordingly.
+// It verifies the validity of the epoch, then computes the proposers of every slot in the epoch at once
+// and assigns each slot to its proposer.
 func ProposerAssignments(ctx context.Context, state state.BeaconState, epoch primitives.Epoch) (map[primitives.ValidatorIndex][]primitives.Slot, error) {
 	// Verify if the epoch is valid for assignment based on the provided state.
 	if err := verifyAssignmentEpoch(epoch, state); err != nil {
@@ -210,36 +218,19 @@ func ProposerAssignments(ctx context.Context, state state.BeaconState, epoch pri
 		return nil, err
 	}
 
-	proposerAssignments := make(map[primitives.ValidatorIndex][]primitives.Slot)
-
-	originalStateSlot := state.Slot()
+	proposerIndices, err := ProposerIndicesForEpoch(ctx, state, epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not compute proposers of epoch %d", epoch)
+	}
 
-	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch; slot++ {
+	proposerAssignments := make(map[primitives.ValidatorIndex][]primitives.Slot)
+	for i, index := range proposerIndices {
+		slot := startSlot + primitives.Slot(i)
 		// Skip proposer assignment for genesis slot.
 		if slot == 0 {
 			continue
 		}
-		// Set the state's current slot.
-		if err := state.SetSlot(slot); err != nil {
-			return nil, err
-		}
-
-		// Determine the proposer index for the current slot.
-		i, err := BeaconProposerIndex(ctx, state)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not check proposer at slot %d", state.Slot())
-		}
-
-		// Append the slot to the proposer's assignments.
-		if _, ok := proposerAssignments[i]; !ok {
-			proposerAssignments[i] = make([]primitives.Slot, 0)
-		}
-		proposerAssignments[i] = append(proposerAssignments[i], slot)
-	}
-
-	// Reset state back to its original slot.
-	if err := state.SetSlot(originalStateSlot); err != nil {
-		return nil, err
+		proposerAssignments[index] = append(proposerAssignments[index], slot)
 	}
 
 	return proposerAssignments, nil
@@ -311,6 +302,44 @@ func VerifyBitfieldLength(bf bitfield.Bitfield, committeeSize uint64) error {
 	return nil
 }
 
+// AttestationBits returns the aggregation bits of an attestation made by the given participants
+// of the committee with the given index at the slot. The bitlist has the length of the committee,
+// with the bits at the positions of the participants set. ErrInvalidCommitteeIndex is returned if
+// the slot has no committee with the index, and ErrNotCommitteeMember if a participant is not a
+// member of the committee.
+func AttestationBits(
+	ctx context.Context,
+	st state.ReadOnlyBeaconState,
+	slot primitives.Slot,
+	committeeIndex primitives.CommitteeIndex,
+	participants []primitives.ValidatorIndex,
+) (bitfield.Bitlist, error) {
+	activeCount, err := ActiveValidatorCount(ctx, st, slots.ToEpoch(slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator count")
+	}
+	if committeesPerSlot := SlotCommitteeCount(activeCount); uint64(committeeIndex) >= committeesPerSlot {
+		return nil, errors.Wrapf(ErrInvalidCommitteeIndex, "index %d is out of range for %d committees at slot %d", committeeIndex, committeesPerSlot, slot)
+	}
+	committee, err := BeaconCommitteeFromState(ctx, st, slot, committeeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get beacon committee")
+	}
+	positions := make(map[primitives.ValidatorIndex]uint64, len(committee))
+	for i, idx := range committee {
+		positions[idx] = uint64(i)
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	for _, idx := range participants {
+		pos, ok := positions[idx]
+		if !ok {
+			return nil, errors.Wrapf(ErrNotCommitteeMember, "validator %d, committee %d at slot %d", idx, committeeIndex, slot)
+		}
+		bits.SetBitAt(pos, true)
+	}
+	return bits, nil
+}
+
 // VerifyAttestationBitfieldLengths verifies that an attestations aggregation bitfields is
 // a valid length matching the size of the committee.
 func VerifyAttestationBitfieldLengths(ctx context.Context, state state.ReadOnlyBeaconState, att ethpb.Att) error {
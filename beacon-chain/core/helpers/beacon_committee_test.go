@@ -411,6 +411,45 @@ func TestVerifyAttestationBitfieldLengths_OK(t *testing.T) {
 	}
 }
 
+func TestAttestationBits(t *testing.T) {
+	helpers.ClearCache()
+	validators := make([]*ethpb.Validator, 2*params.BeaconConfig().SlotsPerEpoch)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	state, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{
+		Validators:  validators,
+		RandaoMixes: make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	slot := primitives.Slot(3)
+
+	committee, err := helpers.BeaconCommitteeFromState(ctx, state, slot, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(committee))
+
+	bits, err := helpers.AttestationBits(ctx, state, slot, 0, []primitives.ValidatorIndex{committee[1]})
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(committee)), bits.Len())
+	require.DeepEqual(t, []int{1}, bits.BitIndices())
+
+	bits, err = helpers.AttestationBits(ctx, state, slot, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(committee)), bits.Len())
+	require.Equal(t, uint64(0), bits.Count())
+
+	_, err = helpers.AttestationBits(ctx, state, slot, 1, nil)
+	require.ErrorIs(t, err, helpers.ErrInvalidCommitteeIndex)
+
+	other, err := helpers.BeaconCommitteeFromState(ctx, state, slot+1, 0)
+	require.NoError(t, err)
+	_, err = helpers.AttestationBits(ctx, state, slot, 0, []primitives.ValidatorIndex{committee[0], other[0]})
+	require.ErrorIs(t, err, helpers.ErrNotCommitteeMember)
+}
+
 func TestUpdateCommitteeCache_CanUpdate(t *testing.T) {
 	helpers.ClearCache()
 
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"fmt"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -29,12 +31,16 @@ func Seed(state state.ReadOnlyBeaconState, epoch primitives.Epoch, domain [bls.D
 	if err != nil {
 		return [32]byte{}, err
 	}
-	seed := append(domain[:], bytesutil.Bytes8(uint64(epoch))...)
-	seed = append(seed, randaoMix...)
-
-	seed32 := hash.Hash(seed)
+	return SeedForEpoch(randaoMix, epoch, domain), nil
+}
 
-	return seed32, nil
+// SeedForEpoch returns the seed of the given epoch and domain from an already retrieved randao mix.
+// The mix must be the one at epoch + EPOCHS_PER_HISTORICAL_VECTOR - MIN_SEED_LOOKAHEAD - 1, as used by Seed.
+// This lets callers that fetched a range of mixes with RandaoMixes compute seeds without going back to the state.
+func SeedForEpoch(mix []byte, epoch primitives.Epoch, domain [bls.DomainByteLength]byte) [32]byte {
+	seed := append(domain[:], bytesutil.Bytes8(uint64(epoch))...)
+	seed = append(seed, mix...)
+	return hash.Hash(seed)
 }
 
 // RandaoMix returns the randao mix (xor'ed seed)
@@ -50,3 +56,30 @@ func Seed(state state.ReadOnlyBeaconState, epoch primitives.Epoch, domain [bls.D
 func RandaoMix(state state.ReadOnlyBeaconState, epoch primitives.Epoch) ([]byte, error) {
 	return state.RandaoMixAtIndex(uint64(epoch % params.BeaconConfig().EpochsPerHistoricalVector))
 }
+
+// RandaoMixes returns the randao mixes of the epochs from fromEpoch to toEpoch, both inclusive, in order.
+// The range may wrap around the EPOCHS_PER_HISTORICAL_VECTOR circular buffer but can't be longer than it.
+func RandaoMixes(state state.ReadOnlyBeaconState, fromEpoch, toEpoch primitives.Epoch) ([][]byte, error) {
+	if toEpoch < fromEpoch {
+		return nil, fmt.Errorf("from epoch %d is after to epoch %d", fromEpoch, toEpoch)
+	}
+	vectorLength := uint64(params.BeaconConfig().EpochsPerHistoricalVector)
+	if uint64(toEpoch-fromEpoch) >= vectorLength {
+		return nil, fmt.Errorf("requested range %d-%d is longer than EPOCHS_PER_HISTORICAL_VECTOR %d", fromEpoch, toEpoch, vectorLength)
+	}
+	if uint64(state.RandaoMixesLength()) != vectorLength {
+		return nil, fmt.Errorf("state has %d randao mixes, expected %d", state.RandaoMixesLength(), vectorLength)
+	}
+	// Only the requested mixes are read, the vector is not copied as a whole.
+	count := uint64(toEpoch-fromEpoch) + 1
+	start := uint64(fromEpoch) % vectorLength
+	mixes := make([][]byte, count)
+	for i := uint64(0); i < count; i++ {
+		mix, err := state.RandaoMixAtIndex((start + i) % vectorLength)
+		if err != nil {
+			return nil, err
+		}
+		mixes[i] = mix
+	}
+	return mixes, nil
+}
@@ -2,6 +2,7 @@ package helpers_test
 
 import (
 	"encoding/binary"
+	"math/rand"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
@@ -115,3 +116,73 @@ func TestGenerateSeed_OK(t *testing.T) {
 		103, 68, 126, 195, 143, 190, 249, 210, 85, 138, 196, 158, 208, 11, 18, 136, 23}
 	assert.Equal(t, wanted, got, "Incorrect generated seeds")
 }
+
+func TestRandaoMixes_MatchesRandaoMix(t *testing.T) {
+	randaoMixes := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
+	for i := 0; i < len(randaoMixes); i++ {
+		intInBytes := make([]byte, 32)
+		binary.LittleEndian.PutUint64(intInBytes, uint64(i))
+		randaoMixes[i] = intInBytes
+	}
+	state, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{RandaoMixes: randaoMixes})
+	require.NoError(t, err)
+
+	vectorLength := primitives.Epoch(params.BeaconConfig().EpochsPerHistoricalVector)
+	r := rand.New(rand.NewSource(2))
+	ranges := [][2]primitives.Epoch{
+		{0, 0},
+		{10, 20},
+		{vectorLength - 3, vectorLength + 3},
+		{3*vectorLength - 1, 4*vectorLength - 2},
+	}
+	for i := 0; i < 20; i++ {
+		from := primitives.Epoch(r.Uint64() % uint64(4*vectorLength))
+		ranges = append(ranges, [2]primitives.Epoch{from, from + primitives.Epoch(r.Uint64()%uint64(vectorLength))})
+	}
+
+	for _, rng := range ranges {
+		mixes, err := helpers.RandaoMixes(state, rng[0], rng[1])
+		require.NoError(t, err)
+		require.Equal(t, int(rng[1]-rng[0])+1, len(mixes))
+		for i, mix := range mixes {
+			want, err := helpers.RandaoMix(state, rng[0]+primitives.Epoch(i))
+			require.NoError(t, err)
+			require.DeepEqual(t, want, mix)
+		}
+	}
+}
+
+func TestRandaoMixes_InvalidRange(t *testing.T) {
+	randaoMixes := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
+	for i := 0; i < len(randaoMixes); i++ {
+		randaoMixes[i] = make([]byte, 32)
+	}
+	state, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{RandaoMixes: randaoMixes})
+	require.NoError(t, err)
+
+	_, err = helpers.RandaoMixes(state, 5, 4)
+	require.ErrorContains(t, "is after to epoch", err)
+	_, err = helpers.RandaoMixes(state, 0, primitives.Epoch(params.BeaconConfig().EpochsPerHistoricalVector))
+	require.ErrorContains(t, "longer than EPOCHS_PER_HISTORICAL_VECTOR", err)
+}
+
+func TestSeedForEpoch_MatchesSeed(t *testing.T) {
+	randaoMixes := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
+	for i := 0; i < len(randaoMixes); i++ {
+		intInBytes := make([]byte, 32)
+		binary.LittleEndian.PutUint64(intInBytes, uint64(i))
+		randaoMixes[i] = intInBytes
+	}
+	state, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{RandaoMixes: randaoMixes})
+	require.NoError(t, err)
+
+	lookahead := params.BeaconConfig().EpochsPerHistoricalVector - params.BeaconConfig().MinSeedLookahead - 1
+	mixes, err := helpers.RandaoMixes(state, 10+lookahead, 20+lookahead)
+	require.NoError(t, err)
+	for i, mix := range mixes {
+		epoch := primitives.Epoch(10 + i)
+		want, err := helpers.Seed(state, epoch, params.BeaconConfig().DomainBeaconProposer)
+		require.NoError(t, err)
+		assert.Equal(t, want, helpers.SeedForEpoch(mix, epoch, params.BeaconConfig().DomainBeaconProposer))
+	}
+}
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
@@ -51,3 +53,34 @@ func ActivationExitChurnLimit(activeBalance primitives.Gwei) primitives.Gwei {
 func ConsolidationChurnLimit(activeBalance primitives.Gwei) primitives.Gwei {
 	return BalanceChurnLimit(activeBalance) - ActivationExitChurnLimit(activeBalance)
 }
+
+// BalanceChurnLimitFromState returns the balance churn limit of the state's current epoch. The total
+// active balance is read through the total active balance cache, so repeated calls for the same state
+// do not iterate over the validator registry.
+func BalanceChurnLimitFromState(s state.ReadOnlyBeaconState) (primitives.Gwei, error) {
+	activeBalance, err := TotalActiveBalance(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get total active balance")
+	}
+	return BalanceChurnLimit(primitives.Gwei(activeBalance)), nil
+}
+
+// ActivationExitChurnLimitFromState returns the activation and exit churn limit of the state's
+// current epoch, see BalanceChurnLimitFromState.
+func ActivationExitChurnLimitFromState(s state.ReadOnlyBeaconState) (primitives.Gwei, error) {
+	activeBalance, err := TotalActiveBalance(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get total active balance")
+	}
+	return ActivationExitChurnLimit(primitives.Gwei(activeBalance)), nil
+}
+
+// ConsolidationChurnLimitFromState returns the consolidation churn limit of the state's current
+// epoch, see BalanceChurnLimitFromState.
+func ConsolidationChurnLimitFromState(s state.ReadOnlyBeaconState) (primitives.Gwei, error) {
+	activeBalance, err := TotalActiveBalance(s)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get total active balance")
+	}
+	return ConsolidationChurnLimit(primitives.Gwei(activeBalance)), nil
+}
@@ -4,9 +4,13 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
 func TestBalanceChurnLimit(t *testing.T) {
@@ -70,3 +74,48 @@ func FuzzConsolidationChurnLimit(f *testing.F) {
 		helpers.ConsolidationChurnLimit(primitives.Gwei(activeBalance))
 	})
 }
+
+func TestChurnLimitsFromState(t *testing.T) {
+	cfg := params.BeaconConfig()
+	newState := func(t *testing.T, numVals int, effectiveBalance uint64) state.BeaconState {
+		vals := make([]*ethpb.Validator, numVals)
+		for i := range vals {
+			vals[i] = &ethpb.Validator{
+				ExitEpoch:        cfg.FarFutureEpoch,
+				EffectiveBalance: effectiveBalance,
+			}
+		}
+		st, err := state_native.InitializeFromProtoElectra(&ethpb.BeaconStateElectra{Validators: vals})
+		require.NoError(t, err)
+		return st
+	}
+
+	t.Run("at MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA floor", func(t *testing.T) {
+		helpers.ClearCache()
+		st := newState(t, 64, cfg.MinActivationBalance)
+		balanceChurn, err := helpers.BalanceChurnLimitFromState(st)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Gwei(128_000_000_000), balanceChurn)
+		activationExitChurn, err := helpers.ActivationExitChurnLimitFromState(st)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Gwei(128_000_000_000), activationExitChurn)
+		consolidationChurn, err := helpers.ConsolidationChurnLimitFromState(st)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Gwei(0), consolidationChurn)
+	})
+
+	t.Run("above MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT", func(t *testing.T) {
+		helpers.ClearCache()
+		// 10 validators with a total active balance of 300 ETH * CHURN_LIMIT_QUOTIENT.
+		st := newState(t, 10, 30*cfg.ChurnLimitQuotient*cfg.EffectiveBalanceIncrement)
+		balanceChurn, err := helpers.BalanceChurnLimitFromState(st)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Gwei(300_000_000_000), balanceChurn)
+		activationExitChurn, err := helpers.ActivationExitChurnLimitFromState(st)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Gwei(256_000_000_000), activationExitChurn)
+		consolidationChurn, err := helpers.ConsolidationChurnLimitFromState(st)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Gwei(44_000_000_000), consolidationChurn)
+	})
+}
@@ -268,19 +268,6 @@ func BeaconProposerIndex(ctx context.Context, state state.ReadOnlyBeaconState) (
 	return BeaconProposerIndexAtSlot(ctx, state, state.Slot())
 }
 
-// cachedProposerIndexAtSlot returns the proposer index at the given slot from
-// the cache at the given root key.
-func cachedProposerIndexAtSlot(slot primitives.Slot, root [32]byte) (primitives.ValidatorIndex, error) {
-	proposerIndices, has := proposerIndicesCache.ProposerIndices(slots.ToEpoch(slot), root)
-	if !has {
-		return 0, errProposerIndexMiss
-	}
-	if len(proposerIndices) != int(params.BeaconConfig().SlotsPerEpoch) {
-		return 0, errProposerIndexMiss
-	}
-	return proposerIndices[slot%params.BeaconConfig().SlotsPerEpoch], nil
-}
-
 // ProposerIndexAtSlotFromCheckpoint returns the proposer index at the given
 // slot from the cache at the given checkpoint
 func ProposerIndexAtSlotFromCheckpoint(c *forkchoicetypes.Checkpoint, slot primitives.Slot) (primitives.ValidatorIndex, error) {
@@ -298,30 +285,12 @@ func ProposerIndexAtSlotFromCheckpoint(c *forkchoicetypes.Checkpoint, slot primi
 // point of view of the given state as head state
 func BeaconProposerIndexAtSlot(ctx context.Context, state state.ReadOnlyBeaconState, slot primitives.Slot) (primitives.ValidatorIndex, error) {
 	e := slots.ToEpoch(slot)
-	// The cache uses the state root of the previous epoch - minimum_seed_lookahead last slot as key. (e.g. Starting epoch 1, slot 32, the key would be block root at slot 31)
-	// For simplicity, the node will skip caching of genesis epoch.
-	if e > params.BeaconConfig().GenesisEpoch+params.BeaconConfig().MinSeedLookahead {
-		s, err := slots.EpochEnd(e - 1)
-		if err != nil {
-			return 0, err
-		}
-		r, err := StateRootAtSlot(state, s)
-		if err != nil {
-			return 0, err
-		}
-		if r != nil && !bytes.Equal(r, params.BeaconConfig().ZeroHash[:]) {
-			pid, err := cachedProposerIndexAtSlot(slot, [32]byte(r))
-			if err == nil {
-				return pid, nil
-			}
-			if err := UpdateProposerIndicesInCache(ctx, state, e); err != nil {
-				return 0, errors.Wrap(err, "could not update proposer index cache")
-			}
-			pid, err = cachedProposerIndexAtSlot(slot, [32]byte(r))
-			if err == nil {
-				return pid, nil
-			}
-		}
+	proposerIndices, err := cachedProposerIndices(ctx, state, e)
+	if err != nil {
+		return 0, err
+	}
+	if proposerIndices != nil {
+		return proposerIndices[slot%params.BeaconConfig().SlotsPerEpoch], nil
 	}
 
 	seed, err := Seed(state, e, params.BeaconConfig().DomainBeaconProposer)
@@ -340,6 +309,69 @@ func BeaconProposerIndexAtSlot(ctx context.Context, state state.ReadOnlyBeaconSt
 	return ComputeProposerIndex(state, indices, seedWithSlotHash)
 }
 
+// ProposerIndicesForEpoch returns the proposer indices of every slot in the given epoch from the
+// point of view of the given state, the index of the list represents the slot offset in the epoch.
+// The seed and active validator indices are computed once for the whole epoch, and the result is
+// shared with BeaconProposerIndexAtSlot through the proposer indices cache. The proposers of the
+// next epoch are looked ahead without the cache, as the state root keying them is not known yet.
+func ProposerIndicesForEpoch(ctx context.Context, state state.ReadOnlyBeaconState, epoch primitives.Epoch) ([]primitives.ValidatorIndex, error) {
+	if epoch <= time.CurrentEpoch(state) {
+		proposerIndices, err := cachedProposerIndices(ctx, state, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if proposerIndices != nil {
+			return proposerIndices, nil
+		}
+	}
+	indices, err := ActiveValidatorIndices(ctx, state, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active indices")
+	}
+	return PrecomputeProposerIndices(state, indices, epoch)
+}
+
+// cachedProposerIndices returns the proposer indices of the given epoch from the proposer
+// indices cache, filling the cache on a miss. It returns nil without an error when the epoch
+// isn't cached, and an error when the state root keying the epoch is not in the state's history.
+func cachedProposerIndices(ctx context.Context, state state.ReadOnlyBeaconState, e primitives.Epoch) ([]primitives.ValidatorIndex, error) {
+	// The cache uses the state root of the previous epoch - minimum_seed_lookahead last slot as key. (e.g. Starting epoch 1, slot 32, the key would be block root at slot 31)
+	// For simplicity, the node will skip caching of genesis epoch.
+	if e <= params.BeaconConfig().GenesisEpoch+params.BeaconConfig().MinSeedLookahead {
+		return nil, nil
+	}
+	s, err := slots.EpochEnd(e - 1)
+	if err != nil {
+		return nil, err
+	}
+	r, err := StateRootAtSlot(state, s)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil || bytes.Equal(r, params.BeaconConfig().ZeroHash[:]) {
+		return nil, nil
+	}
+	if proposerIndices, has := proposerIndicesFromCache(e, [32]byte(r)); has {
+		return proposerIndices, nil
+	}
+	if err := UpdateProposerIndicesInCache(ctx, state, e); err != nil {
+		return nil, errors.Wrap(err, "could not update proposer index cache")
+	}
+	proposerIndices, _ := proposerIndicesFromCache(e, [32]byte(r))
+	return proposerIndices, nil
+}
+
+// proposerIndicesFromCache returns the proposer indices of the given epoch from
+// the cache at the given root key. The cached arrays hold fieldparams.SlotsPerEpoch
+// indices, of which the configured number of slots per epoch are returned.
+func proposerIndicesFromCache(e primitives.Epoch, root [32]byte) ([]primitives.ValidatorIndex, bool) {
+	proposerIndices, has := proposerIndicesCache.ProposerIndices(e, root)
+	if !has {
+		return nil, false
+	}
+	return proposerIndices[:params.BeaconConfig().SlotsPerEpoch], true
+}
+
 // ComputeProposerIndex returns the index sampled by effective balance, which is used to calculate proposer.
 //
 // nolint:dupword
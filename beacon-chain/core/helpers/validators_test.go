@@ -3,6 +3,7 @@ package helpers_test
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
@@ -19,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 func TestIsActiveValidator_OK(t *testing.T) {
@@ -835,6 +837,61 @@ func TestLastActivatedValidatorIndex_OK(t *testing.T) {
 	require.Equal(t, index, primitives.ValidatorIndex(3))
 }
 
+func TestProposerIndicesForEpoch_MatchesPerSlot(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 4; i++ {
+		helpers.ClearCache()
+
+		validators := make([]*ethpb.Validator, 512)
+		for j := 0; j < len(validators); j++ {
+			validators[j] = &ethpb.Validator{
+				EffectiveBalance: uint64(r.Intn(32)+1) * params.BeaconConfig().EffectiveBalanceIncrement,
+				ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			}
+		}
+		mixes := make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector)
+		for j := range mixes {
+			mixes[j] = make([]byte, fieldparams.RootLength)
+			_, err := r.Read(mixes[j])
+			require.NoError(t, err)
+		}
+		st, err := util.NewBeaconState(util.FillRootsNaturalOpt)
+		require.NoError(t, err)
+		require.NoError(t, st.SetValidators(validators))
+		require.NoError(t, st.SetRandaoMixes(mixes))
+		require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch*primitives.Slot(i+2)+1))
+
+		for _, epoch := range []primitives.Epoch{0, time.CurrentEpoch(st), time.NextEpoch(st)} {
+			proposerIndices, err := helpers.ProposerIndicesForEpoch(ctx, st, epoch)
+			require.NoError(t, err)
+			require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(proposerIndices))
+
+			activeIndices, err := helpers.ActiveValidatorIndices(ctx, st, epoch)
+			require.NoError(t, err)
+			seed, err := helpers.Seed(st, epoch, params.BeaconConfig().DomainBeaconProposer)
+			require.NoError(t, err)
+			start, err := slots.EpochStart(epoch)
+			require.NoError(t, err)
+			for j, got := range proposerIndices {
+				slot := start + primitives.Slot(j)
+				want, err := helpers.ComputeProposerIndex(st, activeIndices, hash.Hash(append(seed[:], bytesutil.Bytes8(uint64(slot))...)))
+				require.NoError(t, err)
+				require.Equal(t, want, got)
+
+				atSlot, err := helpers.BeaconProposerIndexAtSlot(ctx, st, slot)
+				if epoch > time.CurrentEpoch(st) {
+					// The state root keying the next epoch in the cache is not in the state's history yet.
+					require.ErrorContains(t, "out of bounds", err)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, want, atSlot)
+			}
+		}
+	}
+}
+
 func TestProposerIndexFromCheckpoint(t *testing.T) {
 	helpers.ClearCache()
 
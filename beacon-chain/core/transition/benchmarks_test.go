@@ -4,16 +4,19 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	coreState "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/benchmark"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -173,6 +176,80 @@ func BenchmarkUnmarshalState_FullState(b *testing.B) {
 	})
 }
 
+func BenchmarkProcessSlots_10Epochs100kValidators(b *testing.B) {
+	beaconState := benchmarkStateWithValidators(b, 100_000)
+	target := params.BeaconConfig().SlotsPerEpoch * 10
+
+	b.Run("ProcessSlots", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := coreState.ProcessSlots(context.Background(), beaconState.Copy(), target)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("ProcessSlotsUnsafeFastForward", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := coreState.ProcessSlotsUnsafeFastForward(context.Background(), beaconState.Copy(), target)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func BenchmarkExecuteStateTransition_MaxBlockBellatrix(b *testing.B) {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+	// The committees of a single slot must hold MaxAttestations attesters.
+	numValidators := cfg.MaxAttestations * uint64(cfg.SlotsPerEpoch)
+	beaconState, privs := util.DeterministicGenesisStateBellatrix(b, numValidators)
+	committee, err := altair.NextSyncCommittee(ctx, beaconState)
+	require.NoError(b, err)
+	require.NoError(b, beaconState.SetCurrentSyncCommittee(committee))
+	require.NoError(b, beaconState.SetNextSyncCommittee(committee))
+	deposits, _, err := util.DeterministicDepositsAndKeys(numValidators + cfg.MaxDeposits)
+	require.NoError(b, err)
+	eth1Data, err := util.DeterministicEth1Data(len(deposits))
+	require.NoError(b, err)
+	require.NoError(b, beaconState.SetEth1Data(eth1Data))
+	// Moving the state forward so that validators can exit.
+	require.NoError(b, beaconState.SetSlot(cfg.SlotsPerEpoch.Mul(uint64(cfg.ShardCommitteePeriod)).Add(3)))
+	block, err := util.GenerateMaxBlockBellatrix(beaconState, privs)
+	require.NoError(b, err)
+	wsb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := coreState.ExecuteStateTransition(ctx, beaconState.Copy(), wsb)
+		require.NoError(b, err)
+	}
+}
+
+// benchmarkStateWithValidators returns a genesis state with n active validators. The validators
+// have no usable keys, which is fine for benchmarking empty slot processing.
+func benchmarkStateWithValidators(b *testing.B, n int) state.BeaconState {
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	vals := make([]*ethpb.Validator, n)
+	bals := make([]uint64, n)
+	for i := 0; i < n; i++ {
+		vals[i] = &ethpb.Validator{
+			PublicKey:             make([]byte, fieldparams.BLSPubkeyLength),
+			WithdrawalCredentials: make([]byte, fieldparams.RootLength),
+			EffectiveBalance:      maxBalance,
+			ExitEpoch:             farFuture,
+			WithdrawableEpoch:     farFuture,
+		}
+		bals[i] = maxBalance
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(b, err)
+	require.NoError(b, st.SetValidators(vals))
+	require.NoError(b, st.SetBalances(bals))
+	return st
+}
+
 func clonedStates(beaconState state.BeaconState) []state.BeaconState {
 	clonedStates := make([]state.BeaconState, runAmount)
 	for i := 0; i < runAmount; i++ {
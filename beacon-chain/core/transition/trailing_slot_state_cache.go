@@ -82,3 +82,14 @@ func LastCachedState() ([]byte, state.BeaconState) {
 	}
 	return bytesutil.SafeCopyBytes(nsc.lastRoot), nsc.lastState.Copy()
 }
+
+// ClearNextSlotCache removes all entries from the next slot cache. This is used by tests
+// which compare results with and without a primed cache.
+func ClearNextSlotCache() {
+	nsc.Lock()
+	defer nsc.Unlock()
+	nsc.prevRoot = nil
+	nsc.prevState = nil
+	nsc.lastRoot = nil
+	nsc.lastState = nil
+}
@@ -47,3 +47,17 @@ func TestTrailingSlotState_StateAdvancedBeyondRequest(t *testing.T) {
 	s = transition.NextSlotState(r, 1)
 	require.Equal(t, nil, s)
 }
+
+func TestTrailingSlotState_Clear(t *testing.T) {
+	ctx := context.Background()
+	r := []byte{'b'}
+	s, _ := util.DeterministicGenesisState(t, 1)
+	require.NoError(t, transition.UpdateNextSlotCache(ctx, r, s))
+	require.NotNil(t, transition.NextSlotState(r, 1))
+
+	transition.ClearNextSlotCache()
+	require.Equal(t, nil, transition.NextSlotState(r, 1))
+	lastRoot, lastState := transition.LastCachedState()
+	require.Equal(t, 0, len(lastRoot))
+	require.Equal(t, nil, lastState)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/math"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
@@ -250,22 +251,10 @@ func ProcessSlots(ctx context.Context, state state.BeaconState, slot primitives.
 			return nil, errors.Wrap(err, "could not process slot")
 		}
 		if time.CanProcessEpoch(state) {
-			if state.Version() == version.Phase0 {
-				state, err = ProcessEpochPrecompute(ctx, state)
-				if err != nil {
-					tracing.AnnotateError(span, err)
-					return nil, errors.Wrap(err, "could not process epoch with optimizations")
-				}
-			} else if state.Version() <= version.Deneb {
-				if err = altair.ProcessEpoch(ctx, state); err != nil {
-					tracing.AnnotateError(span, err)
-					return nil, errors.Wrap(err, fmt.Sprintf("could not process %s epoch", version.String(state.Version())))
-				}
-			} else {
-				if err = electra.ProcessEpoch(ctx, state); err != nil {
-					tracing.AnnotateError(span, err)
-					return nil, errors.Wrap(err, fmt.Sprintf("could not process %s epoch", version.String(state.Version())))
-				}
+			state, err = processEpoch(ctx, state)
+			if err != nil {
+				tracing.AnnotateError(span, err)
+				return nil, err
 			}
 		}
 		if err := state.SetSlot(state.Slot() + 1); err != nil {
@@ -287,6 +276,111 @@ func ProcessSlots(ctx context.Context, state state.BeaconState, slot primitives.
 	return state, nil
 }
 
+// ProcessSlotsUnsafeFastForward advances the state to the given slot like ProcessSlots, but only
+// hashes the state once per run of empty slots. The state root computed at the first slot after
+// the start, an epoch transition or a fork upgrade is reused for every following slot of that run,
+// so the state_roots entries written for those slots do not match the spec.
+//
+// WARNING: This is only meant for trusted states in tests and fixture generation. Do not use it on
+// states that are served, persisted or compared against other clients. It also bypasses the skip
+// slot cache so non-canonical states are never cached.
+func ProcessSlotsUnsafeFastForward(ctx context.Context, state state.BeaconState, slot primitives.Slot) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "core.state.ProcessSlotsUnsafeFastForward")
+	defer span.End()
+	if state == nil || state.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	if state.Slot() >= slot {
+		err := fmt.Errorf("expected state.slot %d < slot %d", state.Slot(), slot)
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+
+	var err error
+	var cachedRoot [32]byte
+	dirty := true
+	for state.Slot() < slot {
+		if ctx.Err() != nil {
+			tracing.AnnotateError(span, ctx.Err())
+			return nil, ctx.Err()
+		}
+		if dirty {
+			state, err = ProcessSlot(ctx, state)
+			if err != nil {
+				tracing.AnnotateError(span, err)
+				return nil, errors.Wrap(err, "could not process slot")
+			}
+			r, err := state.StateRootAtIndex(uint64(state.Slot() % params.BeaconConfig().SlotsPerHistoricalRoot))
+			if err != nil {
+				tracing.AnnotateError(span, err)
+				return nil, errors.Wrap(err, "could not read state root")
+			}
+			cachedRoot = bytesutil.ToBytes32(r)
+			dirty = false
+		} else if err := fastForwardSlot(state, cachedRoot); err != nil {
+			tracing.AnnotateError(span, err)
+			return nil, errors.Wrap(err, "could not fast forward slot")
+		}
+		if time.CanProcessEpoch(state) {
+			state, err = processEpoch(ctx, state)
+			if err != nil {
+				tracing.AnnotateError(span, err)
+				return nil, err
+			}
+			dirty = true
+		}
+		if err := state.SetSlot(state.Slot() + 1); err != nil {
+			tracing.AnnotateError(span, err)
+			return nil, errors.Wrap(err, "failed to increment state slot")
+		}
+
+		preVersion := state.Version()
+		state, err = UpgradeState(ctx, state)
+		if err != nil {
+			tracing.AnnotateError(span, err)
+			return nil, errors.Wrap(err, "failed to upgrade state")
+		}
+		if state.Version() != preVersion {
+			dirty = true
+		}
+	}
+	return state, nil
+}
+
+// fastForwardSlot records the given state root and the latest block header root for the current
+// slot without hashing the state.
+func fastForwardSlot(state state.BeaconState, stateRoot [32]byte) error {
+	idx := uint64(state.Slot() % params.BeaconConfig().SlotsPerHistoricalRoot)
+	if err := state.UpdateStateRootAtIndex(idx, stateRoot); err != nil {
+		return err
+	}
+	blockRoot, err := state.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not determine prev block root")
+	}
+	return state.UpdateBlockRootAtIndex(idx, blockRoot)
+}
+
+// processEpoch runs the epoch transition matching the version of the given state.
+func processEpoch(ctx context.Context, state state.BeaconState) (state.BeaconState, error) {
+	var err error
+	if state.Version() == version.Phase0 {
+		state, err = ProcessEpochPrecompute(ctx, state)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not process epoch with optimizations")
+		}
+	} else if state.Version() <= version.Deneb {
+		if err = altair.ProcessEpoch(ctx, state); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("could not process %s epoch", version.String(state.Version())))
+		}
+	} else {
+		if err = electra.ProcessEpoch(ctx, state); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("could not process %s epoch", version.String(state.Version())))
+		}
+	}
+	return state, nil
+}
+
 // UpgradeState upgrades the state to the next version if possible.
 func UpgradeState(ctx context.Context, state state.BeaconState) (state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "core.state.UpgradeState")
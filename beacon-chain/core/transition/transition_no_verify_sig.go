@@ -117,15 +117,33 @@ func CalculateStateRoot(
 ) ([32]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "core.state.CalculateStateRoot")
 	defer span.End()
+	_, root, err := CalculatePostStateAndRoot(ctx, state, signed)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return [32]byte{}, err
+	}
+	return root, nil
+}
+
+// CalculatePostStateAndRoot works like CalculateStateRoot but also returns the post-state, so
+// callers that need both the block's state root and the resulting state only run the transition
+// once. Signatures are not verified and the input state is not mutated.
+func CalculatePostStateAndRoot(
+	ctx context.Context,
+	state state.BeaconState,
+	signed interfaces.ReadOnlySignedBeaconBlock,
+) (state.BeaconState, [32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "core.state.CalculatePostStateAndRoot")
+	defer span.End()
 	if ctx.Err() != nil {
 		tracing.AnnotateError(span, ctx.Err())
-		return [32]byte{}, ctx.Err()
+		return nil, [32]byte{}, ctx.Err()
 	}
 	if state == nil || state.IsNil() {
-		return [32]byte{}, errors.New("nil state")
+		return nil, [32]byte{}, errors.New("nil state")
 	}
 	if signed == nil || signed.IsNil() || signed.Block().IsNil() {
-		return [32]byte{}, errors.New("nil block")
+		return nil, [32]byte{}, errors.New("nil block")
 	}
 
 	// Copy state to avoid mutating the state reference.
@@ -136,16 +154,20 @@ func CalculateStateRoot(
 	parentRoot := signed.Block().ParentRoot()
 	state, err = ProcessSlotsUsingNextSlotCache(ctx, state, parentRoot[:], signed.Block().Slot())
 	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not process slots")
+		return nil, [32]byte{}, errors.Wrap(err, "could not process slots")
 	}
 
 	// Execute per block transition.
 	state, err = ProcessBlockForStateRoot(ctx, state, signed)
 	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not process block")
+		return nil, [32]byte{}, errors.Wrap(err, "could not process block")
 	}
 
-	return state.HashTreeRoot(ctx)
+	root, err := state.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	return state, root, nil
 }
 
 // ProcessBlockNoVerifyAnySig creates a new, modified beacon state by applying block operation
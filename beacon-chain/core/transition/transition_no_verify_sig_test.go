@@ -204,6 +204,34 @@ func TestCalculateStateRootAltair_OK(t *testing.T) {
 	require.DeepNotEqual(t, params.BeaconConfig().ZeroHash, r)
 }
 
+func TestCalculatePostStateAndRoot(t *testing.T) {
+	ctx := context.Background()
+	beaconState, block := createFullAltairBlockWithOperations(t)
+	preSlot := beaconState.Slot()
+	wsb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+
+	post, r, err := transition.CalculatePostStateAndRoot(ctx, beaconState, wsb)
+	require.NoError(t, err)
+	require.Equal(t, preSlot, beaconState.Slot())
+	require.Equal(t, block.Block.Slot, post.Slot())
+	postRoot, err := post.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, postRoot, r)
+
+	want, err := transition.CalculateStateRoot(ctx, beaconState, wsb)
+	require.NoError(t, err)
+	require.Equal(t, want, r)
+	block.Block.StateRoot = r[:]
+	wsb, err = blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+	_, executed, err := transition.ExecuteStateTransitionNoVerifyAnySig(ctx, beaconState, wsb)
+	require.NoError(t, err)
+	executedRoot, err := executed.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, executedRoot, r)
+}
+
 func TestProcessBlockDifferentVersion(t *testing.T) {
 	beaconState, _ := util.DeterministicGenesisState(t, 64) // Phase 0 state
 	_, block := createFullAltairBlockWithOperations(t)
@@ -665,6 +665,53 @@ func TestProcessSlots_ThroughElectraEpoch(t *testing.T) {
 	require.Equal(t, params.BeaconConfig().SlotsPerEpoch*10, st.Slot())
 }
 
+func TestProcessSlotsUnsafeFastForward(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	target := params.BeaconConfig().SlotsPerEpoch*3 + 2
+
+	want, err := transition.ProcessSlots(ctx, st.Copy(), target)
+	require.NoError(t, err)
+	got, err := transition.ProcessSlotsUnsafeFastForward(ctx, st.Copy(), target)
+	require.NoError(t, err)
+
+	require.Equal(t, want.Slot(), got.Slot())
+	require.DeepSSZEqual(t, want.LatestBlockHeader(), got.LatestBlockHeader())
+	require.DeepSSZEqual(t, want.BlockRoots(), got.BlockRoots())
+	require.DeepSSZEqual(t, want.Balances(), got.Balances())
+	require.DeepSSZEqual(t, want.RandaoMixes(), got.RandaoMixes())
+	require.DeepSSZEqual(t, want.FinalizedCheckpoint(), got.FinalizedCheckpoint())
+
+	// The first slot is hashed exactly like ProcessSlots does.
+	wantRoot, err := want.StateRootAtIndex(0)
+	require.NoError(t, err)
+	gotRoot, err := got.StateRootAtIndex(0)
+	require.NoError(t, err)
+	require.DeepEqual(t, wantRoot, gotRoot)
+}
+
+func TestProcessSlotsUnsafeFastForward_ThroughAltairEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	conf := params.BeaconConfig()
+	conf.AltairForkEpoch = 2
+	params.OverrideBeaconConfig(conf)
+
+	st, _ := util.DeterministicGenesisState(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	st, err := transition.ProcessSlotsUnsafeFastForward(context.Background(), st, params.BeaconConfig().SlotsPerEpoch*4)
+	require.NoError(t, err)
+	require.Equal(t, version.Altair, st.Version())
+	require.Equal(t, params.BeaconConfig().SlotsPerEpoch*4, st.Slot())
+}
+
+func TestProcessSlotsUnsafeFastForward_LowerSlotAsParentState(t *testing.T) {
+	slot := primitives.Slot(2)
+	parentState, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{Slot: slot})
+	require.NoError(t, err)
+
+	_, err = transition.ProcessSlotsUnsafeFastForward(context.Background(), parentState, slot)
+	assert.ErrorContains(t, "expected state.slot 2 < slot 2", err)
+}
+
 func TestProcessSlotsUsingNextSlotCache(t *testing.T) {
 	s, _ := util.DeterministicGenesisState(t, 1)
 	r := []byte{'a'}
@@ -12,6 +12,7 @@ import (
 	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
@@ -861,3 +862,80 @@ func TestForkChoiceSlot(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, primitives.Slot(3), slot)
 }
+
+func TestForkChoice_ForkChoiceDump_Reorg(t *testing.T) {
+	f := setup(0, 0)
+	ctx := context.Background()
+	f.justifiedBalances = []uint64{10, 20}
+	epochStart := params.BeaconConfig().SlotsPerEpoch
+
+	// Build the tree
+	//      0
+	//      |
+	//      a (first slot of epoch 1)
+	//     / \
+	//    b   c
+	// where b arrives late and c arrives on time, getting the proposer boost.
+	driftGenesisTime(f, epochStart+1, 0)
+	st, root, err := prepareForkchoiceState(ctx, epochStart, [32]byte{'a'}, params.BeaconConfig().ZeroHash, [32]byte{'A'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	driftGenesisTime(f, epochStart+1, params.BeaconConfig().SecondsPerSlot-1)
+	st, root, err = prepareForkchoiceState(ctx, epochStart+1, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	driftGenesisTime(f, epochStart+1, 0)
+	st, root, err = prepareForkchoiceState(ctx, epochStart+1, [32]byte{'c'}, [32]byte{'a'}, [32]byte{'C'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	f.store.nodeByRoot[[32]byte{'b'}].timestamp = f.store.genesisTime + uint64(epochStart+1)*params.BeaconConfig().SecondsPerSlot + params.BeaconConfig().SecondsPerSlot - 1
+	require.NoError(t, f.SetOptimisticToValid(ctx, [32]byte{'a'}))
+
+	nodesByRoot := func(dump *forkchoice2.Dump) map[[32]byte]*forkchoice2.Node {
+		nodes := make(map[[32]byte]*forkchoice2.Node, len(dump.ForkChoiceNodes))
+		for _, n := range dump.ForkChoiceNodes {
+			nodes[[32]byte(n.BlockRoot)] = n
+		}
+		return nodes
+	}
+
+	// b is the head with the vote of the first validator.
+	f.ProcessAttestation(ctx, []uint64{0}, [32]byte{'b'}, 1)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{'b'}, head)
+	dump, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, head[:], dump.HeadRoot)
+	require.DeepEqual(t, []byte{'c', 31: 0}, dump.ProposerBoostRoot)
+	require.Equal(t, 4, len(dump.ForkChoiceNodes))
+	nodes := nodesByRoot(dump)
+	a, b, c := nodes[[32]byte{'a'}], nodes[[32]byte{'b'}], nodes[[32]byte{'c'}]
+	require.Equal(t, uint64(10), a.Weight)
+	require.Equal(t, uint64(10), b.Weight)
+	require.Equal(t, uint64(0), c.Weight)
+	require.Equal(t, forkchoice2.Valid, a.Validity)
+	require.Equal(t, forkchoice2.Optimistic, b.Validity)
+	require.Equal(t, forkchoice2.Optimistic, c.Validity)
+	require.DeepEqual(t, []byte{'B', 31: 0}, b.ExecutionBlockHash)
+	require.DeepEqual(t, []byte{'a', 31: 0}, a.TargetRoot)
+	require.DeepEqual(t, []byte{'a', 31: 0}, b.TargetRoot)
+	require.DeepEqual(t, []byte{'a', 31: 0}, c.TargetRoot)
+	require.Equal(t, false, b.Timely)
+	require.Equal(t, true, c.Timely)
+
+	// c becomes the head with the heavier vote of the second validator.
+	f.ProcessAttestation(ctx, []uint64{1}, [32]byte{'c'}, 1)
+	head, err = f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{'c'}, head)
+	dump, err = f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, head[:], dump.HeadRoot)
+	nodes = nodesByRoot(dump)
+	a, b, c = nodes[[32]byte{'a'}], nodes[[32]byte{'b'}], nodes[[32]byte{'c'}]
+	require.Equal(t, uint64(30), a.Weight)
+	require.Equal(t, uint64(10), b.Weight)
+	require.Equal(t, uint64(20), c.Weight)
+	require.DeepEqual(t, []byte{'a', 31: 0}, c.ParentRoot)
+}
@@ -148,7 +148,7 @@ func (n *Node) arrivedAfterOrphanCheck(genesisTime uint64) (bool, error) {
 }
 
 // nodeTreeDump appends to the given list all the nodes descending from this one
-func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*forkchoice2.Node, error) {
+func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node, genesisTime uint64) ([]*forkchoice2.Node, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -156,6 +156,15 @@ func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*
 	if n.parent != nil {
 		parentRoot = n.parent.root
 	}
+	var targetRoot [32]byte
+	if n.target != nil {
+		targetRoot = n.target.root
+	}
+	// A node inserted before the start of its slot, like the tree root node, is not timely.
+	timely, err := n.arrivedEarly(genesisTime)
+	if err != nil {
+		timely = false
+	}
 	thisNode := &forkchoice2.Node{
 		Slot:                     n.slot,
 		BlockRoot:                n.root[:],
@@ -169,6 +178,8 @@ func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*
 		ExecutionOptimistic:      n.optimistic,
 		ExecutionBlockHash:       n.payloadHash[:],
 		Timestamp:                n.timestamp,
+		Timely:                   timely,
+		TargetRoot:               targetRoot[:],
 	}
 	if n.optimistic {
 		thisNode.Validity = forkchoice2.Optimistic
@@ -177,9 +188,8 @@ func (n *Node) nodeTreeDump(ctx context.Context, nodes []*forkchoice2.Node) ([]*
 	}
 
 	nodes = append(nodes, thisNode)
-	var err error
 	for _, child := range n.children {
-		nodes, err = child.nodeTreeDump(ctx, nodes)
+		nodes, err = child.nodeTreeDump(ctx, nodes, genesisTime)
 		if err != nil {
 			return nil, err
 		}
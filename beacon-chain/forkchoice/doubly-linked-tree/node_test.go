@@ -240,7 +240,7 @@ func TestNode_SetFullyValidated(t *testing.T) {
 	require.Equal(t, false, opt)
 
 	respNodes := make([]*forkchoice.Node, 0)
-	respNodes, err = f.store.treeRootNode.nodeTreeDump(ctx, respNodes)
+	respNodes, err = f.store.treeRootNode.nodeTreeDump(ctx, respNodes, f.store.genesisTime)
 	require.NoError(t, err)
 	require.Equal(t, len(respNodes), f.NodeCount())
 
@@ -964,6 +964,11 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 	mockEth1DataVotes := b.cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	enableDebugRPCEndpoints := !b.cliCtx.Bool(flags.DisableDebugRPCEndpoints.Name)
+	maxHistoricalCommitteesEpochs := primitives.Epoch(b.cliCtx.Uint64(flags.MaxHistoricalCommitteesEpochs.Name))
+	maxLivenessEpochs := primitives.Epoch(b.cliCtx.Uint64(flags.MaxLivenessEpochs.Name))
+	proposalSlotLookahead := primitives.Slot(b.cliCtx.Uint64(flags.ProposalSlotLookahead.Name))
+	eventHistorySize := b.cliCtx.Int(flags.EventHistorySize.Name)
+	eventHistoryAge := b.cliCtx.Duration(flags.EventHistoryAge.Name)
 
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
@@ -1009,6 +1014,11 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		OperationNotifier:             b,
 		StateGen:                      b.stateGen,
 		EnableDebugRPCEndpoints:       enableDebugRPCEndpoints,
+		MaxHistoricalCommitteesEpochs: maxHistoricalCommitteesEpochs,
+		MaxLivenessEpochs:             maxLivenessEpochs,
+		ProposalSlotLookahead:         proposalSlotLookahead,
+		EventHistorySize:              eventHistorySize,
+		EventHistoryAge:               eventHistoryAge,
 		MaxMsgSize:                    maxMsgSize,
 		BlockBuilder:                  b.fetchBuilderService(),
 		Router:                        router,
@@ -258,8 +258,8 @@ func TestService_BroadcastAttestationWithDiscoveryAttempts(t *testing.T) {
 		if i == 2 {
 			s.dv5Listener = listener
 			s.metaData = wrapper.WrappedMetadataV0(new(ethpb.MetaDataV0))
-			bitV := bitfield.NewBitvector64()
-			bitV.SetBitAt(subnet, true)
+			bitV, err := AttnetsBitvectorWithSubnets([]uint64{subnet})
+			require.NoError(t, err)
 			s.updateSubnetRecordWithMetadata(bitV)
 		}
 		assert.NoError(t, err, "Could not start discovery for node")
@@ -14,7 +14,6 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
@@ -61,10 +60,10 @@ func (s *Service) RefreshENR() {
 		return
 	}
 
-	bitV := bitfield.NewBitvector64()
-	committees := cache.SubnetIDs.GetAllSubnets()
-	for _, idx := range committees {
-		bitV.SetBitAt(idx, true)
+	bitV, err := AttnetsBitvectorWithSubnets(cache.SubnetIDs.GetAllSubnets())
+	if err != nil {
+		log.WithError(err).Error("Could not build att bitfield")
+		return
 	}
 	currentBitV, err := attBitvector(s.dv5Listener.Self().Record())
 	if err != nil {
@@ -85,10 +84,10 @@ func (s *Service) RefreshENR() {
 	default:
 		// Retrieve sync subnets from application level
 		// cache.
-		bitS := bitfield.Bitvector4{byte(0x00)}
-		committees = cache.SyncSubnetIDs.GetAllSubnets(currEpoch)
-		for _, idx := range committees {
-			bitS.SetBitAt(idx, true)
+		bitS, err := SyncnetsBitvectorWithSubnets(cache.SyncSubnetIDs.GetAllSubnets(currEpoch))
+		if err != nil {
+			log.WithError(err).Error("Could not build sync bitfield")
+			return
 		}
 		currentBitS, err := syncBitvector(s.dv5Listener.Self().Record())
 		if err != nil {
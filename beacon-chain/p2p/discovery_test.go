@@ -467,10 +467,8 @@ func TestRefreshENR_ForkBoundaries(t *testing.T) {
 				subs, err := computeSubscribedSubnets(s.dv5Listener.LocalNode().ID(), currEpoch)
 				assert.NoError(t, err)
 
-				bitV := bitfield.NewBitvector64()
-				for _, idx := range subs {
-					bitV.SetBitAt(idx, true)
-				}
+				bitV, err := AttnetsBitvectorWithSubnets(subs)
+				assert.NoError(t, err)
 				assert.DeepEqual(t, bitV, s.metaData.AttnetsBitfield())
 			},
 		},
@@ -558,10 +556,8 @@ func TestRefreshENR_ForkBoundaries(t *testing.T) {
 				subs, err := computeSubscribedSubnets(s.dv5Listener.LocalNode().ID(), currEpoch)
 				assert.NoError(t, err)
 
-				bitV := bitfield.NewBitvector64()
-				for _, idx := range subs {
-					bitV.SetBitAt(idx, true)
-				}
+				bitV, err := AttnetsBitvectorWithSubnets(subs)
+				assert.NoError(t, err)
 				assert.DeepEqual(t, bitV, s.metaData.AttnetsBitfield())
 			},
 		},
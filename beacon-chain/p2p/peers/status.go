@@ -1063,7 +1063,7 @@ func sameIP(firstAddr, secondAddr ma.Multiaddr) bool {
 
 func indicesFromBitfield(bitV bitfield.Bitvector64) []uint64 {
 	committeeIdxs := make([]uint64, 0, bitV.Count())
-	for i := uint64(0); i < 64; i++ {
+	for i := uint64(0); i < bitV.Len(); i++ {
 		if bitV.BitAt(i) {
 			committeeIdxs = append(committeeIdxs, i)
 		}
@@ -297,15 +297,12 @@ func attSubnets(record *enr.Record) (map[uint64]bool, error) {
 		return nil, err
 	}
 	committeeIdxs := make(map[uint64]bool)
-	// lint:ignore uintcast -- subnet count can be safely cast to int.
-	if len(bitV) != byteCount(int(attestationSubnetCount)) {
-		return committeeIdxs, errors.Errorf("invalid bitvector provided, it has a size of %d", len(bitV))
+	subnets, err := AttnetsSubnets(bitV)
+	if err != nil {
+		return committeeIdxs, err
 	}
-
-	for i := uint64(0); i < attestationSubnetCount; i++ {
-		if bitV.BitAt(i) {
-			committeeIdxs[i] = true
-		}
+	for _, i := range subnets {
+		committeeIdxs[i] = true
 	}
 	return committeeIdxs, nil
 }
@@ -317,17 +314,70 @@ func syncSubnets(record *enr.Record) ([]uint64, error) {
 	if err != nil {
 		return nil, err
 	}
+	return SyncnetsSubnets(bitV)
+}
+
+// AttnetsBitvectorWithSubnets returns the attnets bitvector of a node's metadata and ENR, with
+// the bits of the given attestation subnets set. An error is returned if a subnet isn't below the
+// configured attestation subnet count, or if that count doesn't match the bitvector length.
+func AttnetsBitvectorWithSubnets(indices []uint64) (bitfield.Bitvector64, error) {
+	bitV := bitfield.NewBitvector64()
+	if err := setSubnetBits(bitV, params.BeaconConfig().AttestationSubnetCount, indices); err != nil {
+		return nil, errors.Wrap(err, "could not build attnets bitvector")
+	}
+	return bitV, nil
+}
+
+// SyncnetsBitvectorWithSubnets returns the syncnets bitvector of a node's metadata and ENR, with
+// the bits of the given sync committee subnets set. An error is returned if a subnet isn't below
+// the configured sync committee subnet count, or if that count doesn't match the bitvector length.
+func SyncnetsBitvectorWithSubnets(indices []uint64) (bitfield.Bitvector4, error) {
+	bitV := bitfield.NewBitvector4()
+	if err := setSubnetBits(bitV, params.BeaconConfig().SyncCommitteeSubnetCount, indices); err != nil {
+		return nil, errors.Wrap(err, "could not build syncnets bitvector")
+	}
+	return bitV, nil
+}
+
+// AttnetsSubnets returns the attestation subnets set in an attnets bitvector, in increasing order.
+// An error is returned if the bitvector doesn't have the size of the configured attestation
+// subnet count.
+func AttnetsSubnets(bitV bitfield.Bitvector64) ([]uint64, error) {
+	return subnetsFromBits(bitV, len(bitV), params.BeaconConfig().AttestationSubnetCount)
+}
+
+// SyncnetsSubnets returns the sync committee subnets set in a syncnets bitvector, in increasing
+// order. An error is returned if the bitvector doesn't have the size of the configured sync
+// committee subnet count.
+func SyncnetsSubnets(bitV bitfield.Bitvector4) ([]uint64, error) {
+	return subnetsFromBits(bitV, len(bitV), params.BeaconConfig().SyncCommitteeSubnetCount)
+}
+
+func setSubnetBits(bitV bitfield.Bitfield, subnetCount uint64, indices []uint64) error {
+	if bitV.Len() != subnetCount {
+		return errors.Errorf("bitvector of %d bits can't represent %d subnets", bitV.Len(), subnetCount)
+	}
+	for _, i := range indices {
+		if i >= subnetCount {
+			return errors.Errorf("subnet %d is out of range for %d subnets", i, subnetCount)
+		}
+		bitV.SetBitAt(i, true)
+	}
+	return nil
+}
+
+func subnetsFromBits(bitV bitfield.Bitfield, size int, subnetCount uint64) ([]uint64, error) {
 	// lint:ignore uintcast -- subnet count can be safely cast to int.
-	if len(bitV) != byteCount(int(syncCommsSubnetCount)) {
-		return []uint64{}, errors.Errorf("invalid bitvector provided, it has a size of %d", len(bitV))
+	if bitV.Len() != subnetCount || size != byteCount(int(subnetCount)) {
+		return []uint64{}, errors.Errorf("invalid bitvector provided, it has a size of %d", size)
 	}
-	var committeeIdxs []uint64
-	for i := uint64(0); i < syncCommsSubnetCount; i++ {
+	var subnets []uint64
+	for i := uint64(0); i < subnetCount; i++ {
 		if bitV.BitAt(i) {
-			committeeIdxs = append(committeeIdxs, i)
+			subnets = append(subnets, i)
 		}
 	}
-	return committeeIdxs, nil
+	return subnets, nil
 }
 
 // Parses the attestation subnets ENR entry in a node and extracts its value
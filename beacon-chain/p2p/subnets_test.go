@@ -107,8 +107,8 @@ func TestStartDiscV5_FindPeersWithSubnet(t *testing.T) {
 		service.Start()
 
 		// Set the ENR `attnets`, used by Prysm to filter peers by subnet.
-		bitV := bitfield.NewBitvector64()
-		bitV.SetBitAt(subnet, true)
+		bitV, err := AttnetsBitvectorWithSubnets([]uint64{subnet})
+		require.NoError(t, err)
 		entry := enr.WithEntry(attSubnetEnrKey, &bitV)
 		service.dv5Listener.LocalNode().Set(entry)
 
@@ -249,8 +249,8 @@ func Test_AttSubnets(t *testing.T) {
 		{
 			name: "single subnet",
 			record: func(localNode *enode.LocalNode) *enr.Record {
-				bitV := bitfield.NewBitvector64()
-				bitV.SetBitAt(0, true)
+				bitV, err := AttnetsBitvectorWithSubnets([]uint64{0})
+				require.NoError(t, err)
 				entry := enr.WithEntry(attSubnetEnrKey, bitV.Bytes())
 				localNode.Set(entry)
 				return localNode.Node().Record()
@@ -421,8 +421,8 @@ func Test_SyncSubnets(t *testing.T) {
 				convertedKey, err := ecdsaprysm.ConvertFromInterfacePrivKey(priv)
 				assert.NoError(t, err)
 				localNode := enode.NewLocalNode(db, convertedKey)
-				bitV := bitfield.Bitvector4{byte(0x00)}
-				bitV.SetBitAt(0, true)
+				bitV, err := SyncnetsBitvectorWithSubnets([]uint64{0})
+				require.NoError(t, err)
 				entry := enr.WithEntry(syncCommsSubnetEnrKey, bitV.Bytes())
 				localNode.Set(entry)
 				return localNode.Node().Record()
@@ -495,6 +495,44 @@ func Test_SyncSubnets(t *testing.T) {
 	}
 }
 
+func TestSubnetBitvectors(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	attnets, err := AttnetsBitvectorWithSubnets([]uint64{1, 2, 3, 23})
+	require.NoError(t, err)
+	assert.DeepEqual(t, bitfield.Bitvector64{0xe, 0x0, 0x80, 0x0, 0x0, 0x0, 0x0, 0x0}, attnets)
+	subnets, err := AttnetsSubnets(attnets)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{1, 2, 3, 23}, subnets)
+
+	syncnets, err := SyncnetsBitvectorWithSubnets([]uint64{0, 1})
+	require.NoError(t, err)
+	assert.DeepEqual(t, bitfield.Bitvector4{0x03}, syncnets)
+	subnets, err = SyncnetsSubnets(syncnets)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{0, 1}, subnets)
+
+	_, err = AttnetsBitvectorWithSubnets([]uint64{params.BeaconConfig().AttestationSubnetCount})
+	assert.ErrorContains(t, "subnet 64 is out of range for 64 subnets", err)
+	_, err = SyncnetsBitvectorWithSubnets([]uint64{params.BeaconConfig().SyncCommitteeSubnetCount})
+	assert.ErrorContains(t, "subnet 4 is out of range for 4 subnets", err)
+	_, err = AttnetsSubnets(bitfield.Bitvector64{0x01})
+	assert.ErrorContains(t, "invalid bitvector provided, it has a size of 1", err)
+
+	// A subnet count that no longer matches the bitvector length must not silently truncate bits.
+	cfg := params.BeaconConfig().Copy()
+	cfg.AttestationSubnetCount = 128
+	cfg.SyncCommitteeSubnetCount = 8
+	params.OverrideBeaconConfig(cfg)
+	_, err = AttnetsBitvectorWithSubnets([]uint64{100})
+	assert.ErrorContains(t, "bitvector of 64 bits can't represent 128 subnets", err)
+	_, err = SyncnetsBitvectorWithSubnets([]uint64{5})
+	assert.ErrorContains(t, "bitvector of 4 bits can't represent 8 subnets", err)
+	_, err = AttnetsSubnets(attnets)
+	assert.ErrorContains(t, "invalid bitvector provided", err)
+	_, err = SyncnetsSubnets(syncnets)
+	assert.ErrorContains(t, "invalid bitvector provided", err)
+}
+
 func TestSubnetComputation(t *testing.T) {
 	db, err := enode.OpenDB("")
 	assert.NoError(t, err)
@@ -53,12 +53,21 @@ func (e *AggregateBroadcastFailedError) Error() string {
 	return fmt.Sprintf("could not broadcast signed aggregated attestation: %s", e.err.Error())
 }
 
+// ValidatorPerformance extends the validator performance response with fields
+// that are not part of the v1alpha1 API.
+type ValidatorPerformance struct {
+	*ethpb.ValidatorPerformanceResponse
+	// MaxEffectiveBalances are the effective balance ceilings of the validators,
+	// which depend on their withdrawal credentials. Only populated from Electra.
+	MaxEffectiveBalances []uint64
+}
+
 // ComputeValidatorPerformance reports the validator's latest balance along with other important metrics on
 // rewards and penalties throughout its lifecycle in the beacon chain.
 func (s *Service) ComputeValidatorPerformance(
 	ctx context.Context,
 	req *ethpb.ValidatorPerformanceRequest,
-) (*ethpb.ValidatorPerformanceResponse, *RpcError) {
+) (*ValidatorPerformance, *RpcError) {
 	ctx, span := trace.StartSpan(ctx, "coreService.ComputeValidatorPerformance")
 	defer span.End()
 
@@ -115,7 +124,7 @@ func (s *Service) ComputeValidatorPerformance(
 		}
 		validatorSummary = vp
 	} else {
-		return nil, &RpcError{Err: errors.Wrapf(err, "head state version %d not supported", headState.Version()), Reason: Internal}
+		return nil, &RpcError{Err: errors.Errorf("head state version %s not supported", version.String(headState.Version())), Reason: Internal}
 	}
 
 	responseCap := len(req.Indices) + len(req.PublicKeys)
@@ -163,6 +172,10 @@ func (s *Service) ComputeValidatorPerformance(
 	correctlyVotedTarget := make([]bool, 0, responseCap)
 	correctlyVotedHead := make([]bool, 0, responseCap)
 	inactivityScores := make([]uint64, 0, responseCap)
+	var maxEffectiveBalances []uint64
+	if headState.Version() >= version.Electra {
+		maxEffectiveBalances = make([]uint64, 0, responseCap)
+	}
 	// Append performance summaries.
 	// Also track missing validators using public keys.
 	for _, idx := range validatorIndices {
@@ -196,9 +209,16 @@ func (s *Service) ComputeValidatorPerformance(
 			correctlyVotedSource = append(correctlyVotedSource, summary.IsPrevEpochSourceAttester)
 			inactivityScores = append(inactivityScores, summary.InactivityScore)
 		}
+		if headState.Version() >= version.Electra {
+			maxEffectiveBalance := params.BeaconConfig().MinActivationBalance
+			if helpers.HasCompoundingWithdrawalCredential(val) {
+				maxEffectiveBalance = params.BeaconConfig().MaxEffectiveBalanceElectra
+			}
+			maxEffectiveBalances = append(maxEffectiveBalances, maxEffectiveBalance)
+		}
 	}
 
-	return &ethpb.ValidatorPerformanceResponse{
+	resp := &ethpb.ValidatorPerformanceResponse{
 		PublicKeys:                    pubKeys,
 		CorrectlyVotedSource:          correctlyVotedSource,
 		CorrectlyVotedTarget:          correctlyVotedTarget, // In altair, when this is true then the attestation was definitely included.
@@ -208,6 +228,10 @@ func (s *Service) ComputeValidatorPerformance(
 		BalancesAfterEpochTransition:  afterTransitionBalances,
 		MissingValidators:             missingValidators,
 		InactivityScores:              inactivityScores, // Only populated in Altair
+	}
+	return &ValidatorPerformance{
+		ValidatorPerformanceResponse: resp,
+		MaxEffectiveBalances:         maxEffectiveBalances,
 	}, nil
 }
 
@@ -15,35 +15,40 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	beaconRoutes := map[string][]string{
-		"/eth/v1/beacon/genesis":                                     {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/root":                      {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/fork":                      {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/finality_checkpoints":      {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/validators":                {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/states/{state_id}/validators/{validator_id}": {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/validator_balances":        {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/states/{state_id}/committees":                {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/sync_committees":           {http.MethodGet},
-		"/eth/v1/beacon/states/{state_id}/randao":                    {http.MethodGet},
-		"/eth/v1/beacon/headers":                                     {http.MethodGet},
-		"/eth/v1/beacon/headers/{block_id}":                          {http.MethodGet},
-		"/eth/v1/beacon/blinded_blocks":                              {http.MethodPost},
-		"/eth/v2/beacon/blinded_blocks":                              {http.MethodPost},
-		"/eth/v1/beacon/blocks":                                      {http.MethodPost},
-		"/eth/v2/beacon/blocks":                                      {http.MethodPost},
-		"/eth/v1/beacon/blocks/{block_id}":                           {http.MethodGet},
-		"/eth/v2/beacon/blocks/{block_id}":                           {http.MethodGet},
-		"/eth/v1/beacon/blocks/{block_id}/root":                      {http.MethodGet},
-		"/eth/v1/beacon/blocks/{block_id}/attestations":              {http.MethodGet},
-		"/eth/v1/beacon/blob_sidecars/{block_id}":                    {http.MethodGet},
-		"/eth/v1/beacon/deposit_snapshot":                            {http.MethodGet},
-		"/eth/v1/beacon/blinded_blocks/{block_id}":                   {http.MethodGet},
-		"/eth/v1/beacon/pool/attestations":                           {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/attester_slashings":                     {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/proposer_slashings":                     {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/sync_committees":                        {http.MethodPost},
-		"/eth/v1/beacon/pool/voluntary_exits":                        {http.MethodGet, http.MethodPost},
-		"/eth/v1/beacon/pool/bls_to_execution_changes":               {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/genesis":                                       {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/root":                        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/fork":                        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/finality_checkpoints":        {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/validators":                  {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/states/{state_id}/validators/{validator_id}":   {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/validator_balances":          {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/states/{state_id}/committees":                  {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/sync_committees":             {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/randao":                      {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/pending_deposits":            {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/pending_partial_withdrawals": {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/pending_consolidations":      {http.MethodGet},
+		"/eth/v1/beacon/headers":                                       {http.MethodGet},
+		"/eth/v1/beacon/headers/{block_id}":                            {http.MethodGet},
+		"/eth/v1/beacon/blinded_blocks":                                {http.MethodPost},
+		"/eth/v2/beacon/blinded_blocks":                                {http.MethodPost},
+		"/eth/v1/beacon/blocks":                                        {http.MethodPost},
+		"/eth/v2/beacon/blocks":                                        {http.MethodPost},
+		"/eth/v1/beacon/blocks/{block_id}":                             {http.MethodGet},
+		"/eth/v2/beacon/blocks/{block_id}":                             {http.MethodGet},
+		"/eth/v1/beacon/blocks/{block_id}/root":                        {http.MethodGet},
+		"/eth/v1/beacon/blocks/{block_id}/attestations":                {http.MethodGet},
+		"/eth/v1/beacon/blob_sidecars/{block_id}":                      {http.MethodGet},
+		"/eth/v1/beacon/deposit_snapshot":                              {http.MethodGet},
+		"/eth/v1/beacon/blinded_blocks/{block_id}":                     {http.MethodGet},
+		"/eth/v1/beacon/pool/attestations":                             {http.MethodGet, http.MethodPost},
+		"/eth/v2/beacon/pool/attestations":                             {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/attester_slashings":                       {http.MethodGet, http.MethodPost},
+		"/eth/v2/beacon/pool/attester_slashings":                       {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/proposer_slashings":                       {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/sync_committees":                          {http.MethodPost},
+		"/eth/v1/beacon/pool/voluntary_exits":                          {http.MethodGet, http.MethodPost},
+		"/eth/v1/beacon/pool/bls_to_execution_changes":                 {http.MethodGet, http.MethodPost},
 	}
 
 	lightClientRoutes := map[string][]string{
@@ -123,8 +128,9 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	prysmValidatorRoutes := map[string][]string{
-		"/prysm/validators/performance":    {http.MethodPost},
-		"/prysm/v1/validators/performance": {http.MethodPost},
+		"/prysm/validators/performance":      {http.MethodPost},
+		"/prysm/v1/validators/performance":   {http.MethodPost},
+		"/prysm/v1/validators/registrations": {http.MethodGet},
 	}
 
 	s := &Service{cfg: &Config{}}
@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -39,6 +40,7 @@ import (
 
 const (
 	broadcastValidationQueryParam               = "broadcast_validation"
+	broadcastValidationGossip                   = "gossip"
 	broadcastValidationConsensus                = "consensus"
 	broadcastValidationConsensusAndEquivocation = "consensus_and_equivocation"
 )
@@ -288,10 +290,11 @@ func (s *Server) publishBlindedBlockSSZ(ctx context.Context, w http.ResponseWrit
 	versionHeader := r.Header.Get(api.VersionHeader)
 	if versionRequired && versionHeader == "" {
 		httputil.HandleError(w, api.VersionHeader+" header is required", http.StatusBadRequest)
+		return
 	}
 
 	denebBlock := &eth.SignedBlindedBeaconBlockDeneb{}
-	if err = denebBlock.UnmarshalSSZ(body); err == nil {
+	if err = denebBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Deneb) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_BlindedDeneb{
 				BlindedDeneb: denebBlock,
@@ -314,7 +317,7 @@ func (s *Server) publishBlindedBlockSSZ(ctx context.Context, w http.ResponseWrit
 	}
 
 	capellaBlock := &eth.SignedBlindedBeaconBlockCapella{}
-	if err = capellaBlock.UnmarshalSSZ(body); err == nil {
+	if err = capellaBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Capella) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_BlindedCapella{
 				BlindedCapella: capellaBlock,
@@ -337,7 +340,7 @@ func (s *Server) publishBlindedBlockSSZ(ctx context.Context, w http.ResponseWrit
 	}
 
 	bellatrixBlock := &eth.SignedBlindedBeaconBlockBellatrix{}
-	if err = bellatrixBlock.UnmarshalSSZ(body); err == nil {
+	if err = bellatrixBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Bellatrix) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_BlindedBellatrix{
 				BlindedBellatrix: bellatrixBlock,
@@ -360,7 +363,7 @@ func (s *Server) publishBlindedBlockSSZ(ctx context.Context, w http.ResponseWrit
 	}
 
 	altairBlock := &eth.SignedBeaconBlockAltair{}
-	if err = altairBlock.UnmarshalSSZ(body); err == nil {
+	if err = altairBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Altair) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Altair{
 				Altair: altairBlock,
@@ -383,7 +386,7 @@ func (s *Server) publishBlindedBlockSSZ(ctx context.Context, w http.ResponseWrit
 	}
 
 	phase0Block := &eth.SignedBeaconBlock{}
-	if err = phase0Block.UnmarshalSSZ(body); err == nil {
+	if err = phase0Block.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Phase0) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Phase0{
 				Phase0: phase0Block,
@@ -417,6 +420,7 @@ func (s *Server) publishBlindedBlock(ctx context.Context, w http.ResponseWriter,
 	versionHeader := r.Header.Get(api.VersionHeader)
 	if versionRequired && versionHeader == "" {
 		httputil.HandleError(w, api.VersionHeader+" header is required", http.StatusBadRequest)
+		return
 	}
 
 	var consensusBlock *eth.GenericSignedBeaconBlock
@@ -585,7 +589,7 @@ func (s *Server) publishBlockSSZ(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	denebBlock := &eth.SignedBeaconBlockContentsDeneb{}
-	if err = denebBlock.UnmarshalSSZ(body); err == nil {
+	if err = denebBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Deneb) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Deneb{
 				Deneb: denebBlock,
@@ -618,7 +622,7 @@ func (s *Server) publishBlockSSZ(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	capellaBlock := &eth.SignedBeaconBlockCapella{}
-	if err = capellaBlock.UnmarshalSSZ(body); err == nil {
+	if err = capellaBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Capella) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Capella{
 				Capella: capellaBlock,
@@ -641,7 +645,7 @@ func (s *Server) publishBlockSSZ(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	bellatrixBlock := &eth.SignedBeaconBlockBellatrix{}
-	if err = bellatrixBlock.UnmarshalSSZ(body); err == nil {
+	if err = bellatrixBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Bellatrix) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Bellatrix{
 				Bellatrix: bellatrixBlock,
@@ -664,7 +668,7 @@ func (s *Server) publishBlockSSZ(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	altairBlock := &eth.SignedBeaconBlockAltair{}
-	if err = altairBlock.UnmarshalSSZ(body); err == nil {
+	if err = altairBlock.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Altair) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Altair{
 				Altair: altairBlock,
@@ -687,7 +691,7 @@ func (s *Server) publishBlockSSZ(ctx context.Context, w http.ResponseWriter, r *
 	}
 
 	phase0Block := &eth.SignedBeaconBlock{}
-	if err = phase0Block.UnmarshalSSZ(body); err == nil {
+	if err = phase0Block.UnmarshalSSZ(body); err == nil && versionHeaderMatches(versionHeader, version.Phase0) {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Phase0{
 				Phase0: phase0Block,
@@ -712,6 +716,12 @@ func (s *Server) publishBlockSSZ(ctx context.Context, w http.ResponseWriter, r *
 	httputil.HandleError(w, "Body does not represent a valid block type", http.StatusBadRequest)
 }
 
+// versionHeaderMatches reports whether an SSZ request body may be decoded as a container of the
+// given fork. Without a version header every fork is tried, otherwise only the named one.
+func versionHeaderMatches(versionHeader string, v int) bool {
+	return versionHeader == "" || versionHeader == version.String(v)
+}
+
 func (s *Server) publishBlock(ctx context.Context, w http.ResponseWriter, r *http.Request, versionRequired bool) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -858,29 +868,30 @@ func unmarshalStrict(data []byte, v interface{}) error {
 	return dec.Decode(v)
 }
 
+// validateBroadcast runs the validation requested by the `broadcast_validation` query parameter.
+// Gossip validation, the default, is left to the block's import, while consensus validation also
+// checks that the block passes the state transition, and consensus and equivocation validation
+// additionally checks that the proposer has not proposed another block at the same slot.
 func (s *Server) validateBroadcast(ctx context.Context, r *http.Request, blk *eth.GenericSignedBeaconBlock) error {
-	switch r.URL.Query().Get(broadcastValidationQueryParam) {
-	case broadcastValidationConsensus:
-		b, err := blocks.NewSignedBeaconBlock(blk.Block)
-		if err != nil {
-			return errors.Wrapf(err, "could not create signed beacon block")
-		}
-		if err = s.validateConsensus(ctx, b); err != nil {
-			return errors.Wrap(err, "consensus validation failed")
-		}
-	case broadcastValidationConsensusAndEquivocation:
-		b, err := blocks.NewSignedBeaconBlock(blk.Block)
-		if err != nil {
-			return errors.Wrapf(err, "could not create signed beacon block")
-		}
-		if err = s.validateConsensus(r.Context(), b); err != nil {
-			return errors.Wrap(err, "consensus validation failed")
-		}
-		if err = s.validateEquivocation(b.Block()); err != nil {
+	validation := r.URL.Query().Get(broadcastValidationQueryParam)
+	switch validation {
+	case "", broadcastValidationGossip:
+		return nil
+	case broadcastValidationConsensus, broadcastValidationConsensusAndEquivocation:
+	default:
+		return fmt.Errorf("invalid %s value %s", broadcastValidationQueryParam, validation)
+	}
+	b, err := blocks.NewSignedBeaconBlock(blk.Block)
+	if err != nil {
+		return errors.Wrapf(err, "could not create signed beacon block")
+	}
+	if err = s.validateConsensus(ctx, b); err != nil {
+		return errors.Wrap(err, "consensus validation failed")
+	}
+	if validation == broadcastValidationConsensusAndEquivocation {
+		if err = s.validateEquivocation(ctx, b.Block()); err != nil {
 			return errors.Wrap(err, "equivocation validation failed")
 		}
-	default:
-		return nil
 	}
 	return nil
 }
@@ -908,9 +919,40 @@ func (s *Server) validateConsensus(ctx context.Context, blk interfaces.ReadOnlyS
 	return nil
 }
 
-func (s *Server) validateEquivocation(blk interfaces.ReadOnlyBeaconBlock) error {
-	if s.ForkchoiceFetcher.HighestReceivedBlockSlot() == blk.Slot() {
-		return errors.Wrapf(errEquivocatedBlock, "block for slot %d already exists in fork choice", blk.Slot())
+// validateEquivocation checks that no block other than blk was received from the proposer of blk
+// at its slot. Publishing the same block again is not an equivocation.
+func (s *Server) validateEquivocation(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock) error {
+	if s.ForkchoiceFetcher.HighestReceivedBlockSlot() < blk.Slot() {
+		return nil
+	}
+	root, err := blk.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	_, roots, err := s.BeaconDB.BlockRootsBySlot(ctx, blk.Slot())
+	if err != nil {
+		return errors.Wrapf(err, "could not get block roots for slot %d", blk.Slot())
+	}
+	for _, r := range roots {
+		if r == root {
+			continue
+		}
+		other, err := s.BeaconDB.Block(ctx, r)
+		if err != nil {
+			return errors.Wrapf(err, "could not get block %#x", r)
+		}
+		if err := blocks.BeaconBlockIsNil(other); err != nil {
+			continue
+		}
+		if other.Block().ProposerIndex() == blk.ProposerIndex() {
+			return errors.Wrapf(
+				errEquivocatedBlock,
+				"block %#x from proposer %d already exists for slot %d",
+				r,
+				blk.ProposerIndex(),
+				blk.Slot(),
+			)
+		}
 	}
 	return nil
 }
@@ -1070,6 +1112,9 @@ func (s *Server) GetStateFork(w http.ResponseWriter, r *http.Request) {
 
 // GetCommittees retrieves the committees for the given state at the given epoch.
 // If the requested slot and index are defined, only those committees are returned.
+// The committees of epochs before the previous epoch of the state are computed from
+// the state at the start of the epoch on the chain of the requested state, which is
+// regenerated from archived states.
 func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetCommittees")
 	defer span.End()
@@ -1099,21 +1144,38 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	epoch := slots.ToEpoch(st.Slot())
+	stEpoch := slots.ToEpoch(st.Slot())
+	epoch := stEpoch
 	if rawEpoch != "" {
 		epoch = primitives.Epoch(e)
 	}
-	activeCount, err := corehelpers.ActiveValidatorCount(ctx, st, epoch)
+	startSlot, err := slots.EpochStart(epoch)
 	if err != nil {
-		httputil.HandleError(w, "Could not get active validator count: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "Could not get epoch start slot: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	startSlot, err := slots.EpochStart(epoch)
+	committeesState := st
+	if epoch+1 < stEpoch {
+		if s.MaxHistoricalCommitteesEpochs != 0 && stEpoch-epoch > s.MaxHistoricalCommitteesEpochs {
+			httputil.HandleError(
+				w,
+				fmt.Sprintf("Epoch %d is more than %d epochs before the state epoch %d", epoch, s.MaxHistoricalCommitteesEpochs, stEpoch),
+				http.StatusBadRequest,
+			)
+			return
+		}
+		committeesState, err = s.historicalCommitteesState(ctx, st, startSlot)
+		if err != nil {
+			shared.WriteStateFetchError(w, err)
+			return
+		}
+	}
+	activeCount, err := corehelpers.ActiveValidatorCount(ctx, committeesState, epoch)
 	if err != nil {
-		httputil.HandleError(w, "Could not get epoch start slot: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "Could not get active validator count: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+
 	endSlot, err := slots.EpochEnd(epoch)
 	if err != nil {
 		httputil.HandleError(w, "Could not get epoch end slot: "+err.Error(), http.StatusInternalServerError)
@@ -1129,7 +1191,7 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 			if rawIndex != "" && index != primitives.CommitteeIndex(i) {
 				continue
 			}
-			committee, err := corehelpers.BeaconCommitteeFromState(ctx, st, slot, index)
+			committee, err := corehelpers.BeaconCommitteeFromState(ctx, committeesState, slot, index)
 			if err != nil {
 				httputil.HandleError(w, "Could not get committee: "+err.Error(), http.StatusInternalServerError)
 				return
@@ -1162,6 +1224,24 @@ func (s *Server) GetCommittees(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, &structs.GetCommitteesResponse{Data: committees, ExecutionOptimistic: isOptimistic, Finalized: isFinalized})
 }
 
+// historicalCommitteesState returns the state at the start slot of a historical epoch on the chain of st. It is
+// regenerated from the post-state of the latest block before the start slot, whose root is in the block roots of st.
+// Slots older than the block roots of st are assumed to be finalized, so the canonical state is used for them.
+func (s *Server) historicalCommitteesState(ctx context.Context, st state.BeaconState, startSlot primitives.Slot) (state.BeaconState, error) {
+	if startSlot == 0 || st.Slot() >= startSlot+params.BeaconConfig().SlotsPerHistoricalRoot {
+		return s.Stater.StateBySlot(ctx, startSlot)
+	}
+	root, err := corehelpers.BlockRootAtSlot(st, startSlot-1)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block root")
+	}
+	blockState, err := s.StateGenService.StateByRoot(ctx, bytesutil.ToBytes32(root))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state of block %#x", root)
+	}
+	return transition.ProcessSlots(ctx, blockState.Copy(), startSlot)
+}
+
 // GetBlockHeaders retrieves block headers matching given query. By default it will fetch current head slot blocks.
 func (s *Server) GetBlockHeaders(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetBlockHeaders")
@@ -1178,14 +1258,24 @@ func (s *Server) GetBlockHeaders(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 	var blks []interfaces.ReadOnlySignedBeaconBlock
-	var blkRoots [][32]byte
 
 	if rawParentRoot != "" {
-		blks, blkRoots, err = s.BeaconDB.Blocks(ctx, filters.NewFilter().SetParentRoot(parentRoot))
+		// The parent root index holds every saved child of the parent, including the ones on
+		// non-canonical branches.
+		blks, _, err = s.BeaconDB.Blocks(ctx, filters.NewFilter().SetParentRoot(parentRoot))
 		if err != nil {
 			httputil.HandleError(w, errors.Wrapf(err, "Could not retrieve blocks for parent root %s", parentRoot).Error(), http.StatusInternalServerError)
 			return
 		}
+		if rawSlot != "" {
+			atSlot := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+			for _, b := range blks {
+				if b.Block().Slot() == primitives.Slot(slot) {
+					atSlot = append(atSlot, b)
+				}
+			}
+			blks = atSlot
+		}
 	} else {
 		if rawSlot == "" {
 			slot = uint64(s.ChainInfoFetcher.HeadSlot())
@@ -1195,11 +1285,6 @@ func (s *Server) GetBlockHeaders(w http.ResponseWriter, r *http.Request) {
 			httputil.HandleError(w, errors.Wrapf(err, "Could not retrieve blocks for slot %d", slot).Error(), http.StatusInternalServerError)
 			return
 		}
-		_, blkRoots, err = s.BeaconDB.BlockRootsBySlot(ctx, primitives.Slot(slot))
-		if err != nil {
-			httputil.HandleError(w, errors.Wrapf(err, "Could not retrieve blocks for slot %d", slot).Error(), http.StatusInternalServerError)
-			return
-		}
 	}
 
 	if len(blks) == 0 {
@@ -1216,25 +1301,26 @@ func (s *Server) GetBlockHeaders(w http.ResponseWriter, r *http.Request) {
 			httputil.HandleError(w, errors.Wrapf(err, "Could not get block header from block").Error(), http.StatusInternalServerError)
 			return
 		}
+		// The root of the header is the root of the block.
 		headerRoot, err := v1alpha1Header.Header.HashTreeRoot()
 		if err != nil {
 			httputil.HandleError(w, errors.Wrapf(err, "Could not hash block header").Error(), http.StatusInternalServerError)
 			return
 		}
-		canonical, err := s.ChainInfoFetcher.IsCanonical(ctx, blkRoots[i])
+		canonical, err := s.ChainInfoFetcher.IsCanonical(ctx, headerRoot)
 		if err != nil {
 			httputil.HandleError(w, errors.Wrapf(err, "Could not determine if block root is canonical").Error(), http.StatusInternalServerError)
 			return
 		}
 		if !isOptimistic {
-			isOptimistic, err = s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, blkRoots[i])
+			isOptimistic, err = s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, headerRoot)
 			if err != nil {
 				httputil.HandleError(w, errors.Wrapf(err, "Could not check if block is optimistic").Error(), http.StatusInternalServerError)
 				return
 			}
 		}
 		if isFinalized {
-			isFinalized = s.FinalizationFetcher.IsFinalized(ctx, blkRoots[i])
+			isFinalized = s.FinalizationFetcher.IsFinalized(ctx, headerRoot)
 		}
 		blkHdrs[i] = &structs.SignedBeaconBlockHeaderContainer{
 			Header: &structs.SignedBeaconBlockHeader{
@@ -1323,6 +1409,7 @@ func (s *Server) GetFinalityCheckpoints(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Historical states are regenerated by the stater through stategen.
 	st, err := s.Stater.State(ctx, []byte(stateId))
 	if err != nil {
 		shared.WriteStateFetchError(w, err)
@@ -1333,30 +1420,32 @@ func (s *Server) GetFinalityCheckpoints(w http.ResponseWriter, r *http.Request)
 		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	blockRoot, err := stateBlockRoot(ctx, st)
 	if err != nil {
-		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "Could not get block root of state: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// The state is finalized when its block is finalized and no slot was processed past the
+	// finalized checkpoint, as a state built on top of the finalized block with empty slots is not.
 	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+	if isFinalized {
+		var finalizedEpoch primitives.Epoch
+		if f := s.FinalizationFetcher.FinalizedCheckpt(); f != nil {
+			finalizedEpoch = f.Epoch
+		}
+		finalizedSlot, err := slots.EpochStart(finalizedEpoch)
+		if err != nil {
+			httputil.HandleError(w, "Could not get finalized slot: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		isFinalized = st.Slot() <= finalizedSlot
+	}
 
-	pj := st.PreviousJustifiedCheckpoint()
-	cj := st.CurrentJustifiedCheckpoint()
-	f := st.FinalizedCheckpoint()
 	resp := &structs.GetFinalityCheckpointsResponse{
 		Data: &structs.FinalityCheckpoints{
-			PreviousJustified: &structs.Checkpoint{
-				Epoch: strconv.FormatUint(uint64(pj.Epoch), 10),
-				Root:  hexutil.Encode(pj.Root),
-			},
-			CurrentJustified: &structs.Checkpoint{
-				Epoch: strconv.FormatUint(uint64(cj.Epoch), 10),
-				Root:  hexutil.Encode(cj.Root),
-			},
-			Finalized: &structs.Checkpoint{
-				Epoch: strconv.FormatUint(uint64(f.Epoch), 10),
-				Root:  hexutil.Encode(f.Root),
-			},
+			PreviousJustified: finalityCheckpoint(st.PreviousJustifiedCheckpoint()),
+			CurrentJustified:  finalityCheckpoint(st.CurrentJustifiedCheckpoint()),
+			Finalized:         finalityCheckpoint(st.FinalizedCheckpoint()),
 		},
 		ExecutionOptimistic: isOptimistic,
 		Finalized:           isFinalized,
@@ -1364,6 +1453,39 @@ func (s *Server) GetFinalityCheckpoints(w http.ResponseWriter, r *http.Request)
 	httputil.WriteJson(w, resp)
 }
 
+// stateBlockRoot returns the root of the latest block processed by the state. The state root of
+// the latest block header is only filled in by the slot following the block, so it is filled in
+// with the root of the state when the state is at the slot of the block.
+func stateBlockRoot(ctx context.Context, st state.BeaconState) ([32]byte, error) {
+	header := st.LatestBlockHeader()
+	if header == nil {
+		return [32]byte{}, errors.New("nil latest block header")
+	}
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not compute state root")
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	return header.HashTreeRoot()
+}
+
+// finalityCheckpoint converts a checkpoint of a state, returning the zero checkpoint with epoch 0
+// and ZERO_HASH as root when it is not set, before the chain is justified or finalized.
+func finalityCheckpoint(c *eth.Checkpoint) *structs.Checkpoint {
+	if c == nil || len(c.Root) == 0 {
+		return &structs.Checkpoint{
+			Epoch: "0",
+			Root:  hexutil.Encode(params.BeaconConfig().ZeroHash[:]),
+		}
+	}
+	return &structs.Checkpoint{
+		Epoch: strconv.FormatUint(uint64(c.Epoch), 10),
+		Root:  hexutil.Encode(c.Root),
+	}
+}
+
 // GetGenesis retrieves details of the chain's genesis which can be used to identify chain.
 func (s *Server) GetGenesis(w http.ResponseWriter, r *http.Request) {
 	_, span := trace.StartSpan(r.Context(), "beacon.GetGenesis")
@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
@@ -19,7 +22,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	consensus_types "github.com/prysmaticlabs/prysm/v5/consensus-types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
@@ -47,56 +52,205 @@ func (s *Server) ListAttestations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	attestations := s.AttestationsPool.AggregatedAttestations()
-	unaggAtts, err := s.AttestationsPool.UnaggregatedAttestations()
+	attestations, err := s.poolAttestations()
 	if err != nil {
 		httputil.HandleError(w, "Could not get unaggregated attestations: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	attestations = append(attestations, unaggAtts...)
-	isEmptyReq := rawSlot == "" && rawCommitteeIndex == ""
-	if isEmptyReq {
-		allAtts := make([]*structs.Attestation, len(attestations))
-		for i, att := range attestations {
-			a, ok := att.(*eth.Attestation)
-			if ok {
-				allAtts[i] = structs.AttFromConsensus(a)
-			} else {
-				httputil.HandleError(w, fmt.Sprintf("unable to convert attestations of type %T", att), http.StatusInternalServerError)
-				return
-			}
+	filteredAtts := make([]*structs.Attestation, 0, len(attestations))
+	for _, att := range attestations {
+		if !attestationMatchesQuery(att, rawSlot, slot, rawCommitteeIndex, committeeIndex) {
+			continue
+		}
+		a, ok := att.(*eth.Attestation)
+		if !ok {
+			httputil.HandleError(w, fmt.Sprintf("unable to convert attestations of type %T", att), http.StatusInternalServerError)
+			return
 		}
-		httputil.WriteJson(w, &structs.ListAttestationsResponse{Data: allAtts})
+		filteredAtts = append(filteredAtts, structs.AttFromConsensus(a))
+	}
+	httputil.WriteJson(w, &structs.ListAttestationsResponse{Data: filteredAtts})
+}
+
+// ListAttestationsV2 retrieves attestations known by the node but not necessarily incorporated into any block.
+// Allows filtering by committee index or slot. Only attestations of the fork active at the current slot are
+// returned, and the fork is given in the Eth-Consensus-Version header.
+func (s *Server) ListAttestationsV2(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "beacon.ListAttestationsV2")
+	defer span.End()
+
+	rawSlot, slot, ok := shared.UintFromQuery(w, r, "slot", false)
+	if !ok {
+		return
+	}
+	rawCommitteeIndex, committeeIndex, ok := shared.UintFromQuery(w, r, "committee_index", false)
+	if !ok {
 		return
 	}
 
-	bothDefined := rawSlot != "" && rawCommitteeIndex != ""
-	filteredAtts := make([]*structs.Attestation, 0, len(attestations))
+	v := slots.ToForkVersion(s.TimeFetcher.CurrentSlot())
+	attestations, err := s.poolAttestations()
+	if err != nil {
+		httputil.HandleError(w, "Could not get unaggregated attestations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	filteredAtts := make([]interface{}, 0, len(attestations))
 	for _, att := range attestations {
-		committeeIndexMatch := rawCommitteeIndex != "" && att.GetData().CommitteeIndex == primitives.CommitteeIndex(committeeIndex)
-		slotMatch := rawSlot != "" && att.GetData().Slot == primitives.Slot(slot)
-		shouldAppend := (bothDefined && committeeIndexMatch && slotMatch) || (!bothDefined && (committeeIndexMatch || slotMatch))
-		if shouldAppend {
-			a, ok := att.(*eth.Attestation)
-			if ok {
-				filteredAtts = append(filteredAtts, structs.AttFromConsensus(a))
-			} else {
-				httputil.HandleError(w, fmt.Sprintf("unable to convert attestations of type %T", att), http.StatusInternalServerError)
-				return
-			}
+		// Electra attestations have a different layout, so they are only returned once Electra is active.
+		if (v >= version.Electra) != (att.Version() >= version.Electra) {
+			continue
+		}
+		if !attestationMatchesQuery(att, rawSlot, slot, rawCommitteeIndex, committeeIndex) {
+			continue
+		}
+		switch a := att.(type) {
+		case *eth.Attestation:
+			filteredAtts = append(filteredAtts, structs.AttFromConsensus(a))
+		case *eth.AttestationElectra:
+			filteredAtts = append(filteredAtts, structs.AttElectraFromConsensus(a))
+		default:
+			httputil.HandleError(w, fmt.Sprintf("unable to convert attestations of type %T", att), http.StatusInternalServerError)
+			return
 		}
 	}
-	httputil.WriteJson(w, &structs.ListAttestationsResponse{Data: filteredAtts})
+	data, err := json.Marshal(filteredAtts)
+	if err != nil {
+		httputil.HandleError(w, "Could not marshal attestations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(v))
+	httputil.WriteJson(w, &structs.ListAttestationsV2Response{
+		Version: version.String(v),
+		Data:    data,
+	})
+}
+
+// poolAttestations returns both the aggregated and the unaggregated attestations of the pool.
+func (s *Server) poolAttestations() ([]eth.Att, error) {
+	attestations := s.AttestationsPool.AggregatedAttestations()
+	unaggAtts, err := s.AttestationsPool.UnaggregatedAttestations()
+	if err != nil {
+		return nil, err
+	}
+	return append(attestations, unaggAtts...), nil
+}
+
+// attestationMatchesQuery reports whether an attestation matches the slot and committee index filters of a
+// request, ignoring filters whose raw query value is empty. The committee index of an Electra attestation is
+// given by its committee bits.
+func attestationMatchesQuery(att eth.Att, rawSlot string, slot uint64, rawCommitteeIndex string, committeeIndex uint64) bool {
+	if rawSlot != "" && att.GetData().Slot != primitives.Slot(slot) {
+		return false
+	}
+	if rawCommitteeIndex == "" {
+		return true
+	}
+	if att.Version() >= version.Electra {
+		return att.CommitteeBitsVal().BitAt(committeeIndex)
+	}
+	return att.GetData().CommitteeIndex == primitives.CommitteeIndex(committeeIndex)
 }
 
 // SubmitAttestations submits an attestation object to node. If the attestation passes all validation
 // constraints, node MUST publish the attestation on an appropriate subnet.
+// The attestations are accepted either as JSON or as an SSZ encoded list. Attestations of the Electra
+// fork must be submitted to SubmitAttestationsV2 instead.
 func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.SubmitAttestations")
 	defer span.End()
 
-	var req structs.SubmitAttestationsRequest
-	err := json.NewDecoder(r.Body).Decode(&req.Data)
+	if v := r.Header.Get(api.VersionHeader); v != "" {
+		ver, err := version.FromString(v)
+		if err != nil {
+			httputil.HandleError(w, "Invalid "+api.VersionHeader+" header: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if ver >= version.Electra {
+			httputil.HandleError(
+				w,
+				fmt.Sprintf("%s attestations are not supported by this endpoint, use /eth/v2/beacon/pool/attestations instead", version.String(ver)),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+
+	var sourceAtts []*eth.Attestation
+	var attFailures []*server.IndexedVerificationFailure
+	if httputil.IsRequestSsz(r) {
+		var ok bool
+		sourceAtts, ok = decodeAttestationsSSZ(w, r)
+		if !ok {
+			return
+		}
+	} else {
+		var req structs.SubmitAttestationsRequest
+		err := json.NewDecoder(r.Body).Decode(&req.Data)
+		switch {
+		case err == io.EOF:
+			httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+			return
+		case err != nil:
+			httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Attestations that can't be converted are left nil, so that failures keep the request's indices.
+		sourceAtts = make([]*eth.Attestation, len(req.Data))
+		for i, sourceAtt := range req.Data {
+			att, err := sourceAtt.ToConsensus()
+			if err != nil {
+				attFailures = append(attFailures, &server.IndexedVerificationFailure{
+					Index:   i,
+					Message: "Could not convert request attestation to consensus attestation: " + err.Error(),
+				})
+				continue
+			}
+			sourceAtts[i] = att
+		}
+	}
+	if len(sourceAtts) == 0 {
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	}
+
+	atts := make([]eth.Att, len(sourceAtts))
+	for i, att := range sourceAtts {
+		if att == nil {
+			continue
+		}
+		if slots.ToForkVersion(att.Data.Slot) >= version.Electra {
+			attFailures = append(attFailures, &server.IndexedVerificationFailure{
+				Index:   i,
+				Message: "Attestations of the Electra fork must be submitted to /eth/v2/beacon/pool/attestations",
+			})
+			continue
+		}
+		atts[i] = att
+	}
+	s.submitAttestations(ctx, w, atts, attFailures)
+}
+
+// SubmitAttestationsV2 submits attestation objects of the fork given in the Eth-Consensus-Version header
+// to node. An Electra attestation must be for a single committee of its slot, given by its committee bits.
+// If an attestation passes all validation constraints, node MUST publish the attestation on the subnet of
+// its committee.
+func (s *Server) SubmitAttestationsV2(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.SubmitAttestationsV2")
+	defer span.End()
+
+	versionHeader := r.Header.Get(api.VersionHeader)
+	if versionHeader == "" {
+		httputil.HandleError(w, api.VersionHeader+" header is required", http.StatusBadRequest)
+		return
+	}
+	v, err := version.FromString(versionHeader)
+	if err != nil {
+		httputil.HandleError(w, "Invalid "+api.VersionHeader+" header: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var rawAtts []json.RawMessage
+	err = json.NewDecoder(r.Body).Decode(&rawAtts)
 	switch {
 	case err == io.EOF:
 		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
@@ -105,15 +259,16 @@ func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	if len(req.Data) == 0 {
+	if len(rawAtts) == 0 {
 		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
 		return
 	}
 
-	var validAttestations []*eth.Attestation
+	// Attestations that can't be converted are left nil, so that failures keep the request's indices.
 	var attFailures []*server.IndexedVerificationFailure
-	for i, sourceAtt := range req.Data {
-		att, err := sourceAtt.ToConsensus()
+	atts := make([]eth.Att, len(rawAtts))
+	for i, rawAtt := range rawAtts {
+		att, err := attestationFromJSON(rawAtt, v)
 		if err != nil {
 			attFailures = append(attFailures, &server.IndexedVerificationFailure{
 				Index:   i,
@@ -121,7 +276,54 @@ func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 			})
 			continue
 		}
-		if _, err = bls.SignatureFromBytes(att.Signature); err != nil {
+		if (slots.ToForkVersion(att.GetData().Slot) >= version.Electra) != (v >= version.Electra) {
+			attFailures = append(attFailures, &server.IndexedVerificationFailure{
+				Index:   i,
+				Message: fmt.Sprintf("Attestation slot %d is not a slot of the %s fork", att.GetData().Slot, version.String(v)),
+			})
+			continue
+		}
+		atts[i] = att
+	}
+	s.submitAttestations(ctx, w, atts, attFailures)
+}
+
+// attestationFromJSON converts a JSON attestation of the given fork to a consensus attestation.
+func attestationFromJSON(raw json.RawMessage, v int) (eth.Att, error) {
+	if v >= version.Electra {
+		var att *structs.AttestationElectra
+		if err := json.Unmarshal(raw, &att); err != nil {
+			return nil, err
+		}
+		if att == nil {
+			return nil, errors.New("attestation is null")
+		}
+		return att.ToConsensus()
+	}
+	var att *structs.Attestation
+	if err := json.Unmarshal(raw, &att); err != nil {
+		return nil, err
+	}
+	if att == nil {
+		return nil, errors.New("attestation is null")
+	}
+	return att.ToConsensus()
+}
+
+// submitAttestations broadcasts the attestations that pass validation on the subnets of their committees and
+// saves them to the pool. Nil attestations, which could not be decoded, are skipped. The failures of the
+// attestations, including the given decoding failures, are reported with the indices of the request.
+func (s *Server) submitAttestations(ctx context.Context, w http.ResponseWriter, atts []eth.Att, attFailures []*server.IndexedVerificationFailure) {
+	type validAttestation struct {
+		att    eth.Att
+		subnet uint64
+	}
+	var validAttestations []validAttestation
+	for i, att := range atts {
+		if att == nil {
+			continue
+		}
+		if _, err := bls.SignatureFromBytes(att.GetSignature()); err != nil {
 			attFailures = append(attFailures, &server.IndexedVerificationFailure{
 				Index:   i,
 				Message: "Incorrect attestation signature: " + err.Error(),
@@ -129,6 +331,23 @@ func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
+		// Determine subnet to broadcast attestation to
+		wantedEpoch := slots.ToEpoch(att.GetData().Slot)
+		vals, err := s.HeadFetcher.HeadValidatorsIndices(ctx, wantedEpoch)
+		if err != nil {
+			httputil.HandleError(w, "Could not get head validator indices: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		committeeIndex, err := attestationCommitteeIndex(att, corehelpers.SlotCommitteeCount(uint64(len(vals))))
+		if err != nil {
+			attFailures = append(attFailures, &server.IndexedVerificationFailure{
+				Index:   i,
+				Message: "Invalid attestation committee: " + err.Error(),
+			})
+			continue
+		}
+		subnet := corehelpers.ComputeSubnetFromCommitteeAndSlot(uint64(len(vals)), committeeIndex, att.GetData().Slot)
+
 		// Broadcast the unaggregated attestation on a feed to notify other services in the beacon node
 		// of a received unaggregated attestation.
 		// Note we can't send for aggregated att because we don't have selection proof.
@@ -141,30 +360,21 @@ func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 			})
 		}
 
-		validAttestations = append(validAttestations, att)
+		validAttestations = append(validAttestations, validAttestation{att: att, subnet: subnet})
 	}
 
 	failedBroadcasts := make([]string, 0)
-	for i, att := range validAttestations {
-		// Determine subnet to broadcast attestation to
-		wantedEpoch := slots.ToEpoch(att.Data.Slot)
-		vals, err := s.HeadFetcher.HeadValidatorsIndices(ctx, wantedEpoch)
-		if err != nil {
-			httputil.HandleError(w, "Could not get head validator indices: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		subnet := corehelpers.ComputeSubnetFromCommitteeAndSlot(uint64(len(vals)), att.Data.CommitteeIndex, att.Data.Slot)
-
-		if err = s.Broadcaster.BroadcastAttestation(ctx, subnet, att); err != nil {
+	for i, valid := range validAttestations {
+		if err := s.Broadcaster.BroadcastAttestation(ctx, valid.subnet, valid.att); err != nil {
 			log.WithError(err).Errorf("could not broadcast attestation at index %d", i)
 		}
 
-		if corehelpers.IsAggregated(att) {
-			if err = s.AttestationsPool.SaveAggregatedAttestation(att); err != nil {
+		if corehelpers.IsAggregated(valid.att) {
+			if err := s.AttestationsPool.SaveAggregatedAttestation(valid.att); err != nil {
 				log.WithError(err).Error("could not save aggregated attestation")
 			}
 		} else {
-			if err = s.AttestationsPool.SaveUnaggregatedAttestation(att); err != nil {
+			if err := s.AttestationsPool.SaveUnaggregatedAttestation(valid.att); err != nil {
 				log.WithError(err).Error("could not save unaggregated attestation")
 			}
 		}
@@ -188,6 +398,30 @@ func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// attestationCommitteeIndex returns the index of the committee an attestation is for. The committee of an
+// Electra attestation is given by its committee bits, which must select a single one of the committees of
+// its slot, while the committee index of its data must be 0.
+func attestationCommitteeIndex(att eth.Att, committeesPerSlot uint64) (primitives.CommitteeIndex, error) {
+	if att.Version() < version.Electra {
+		return att.GetData().CommitteeIndex, nil
+	}
+	if att.GetData().CommitteeIndex != 0 {
+		return 0, fmt.Errorf("committee index %d of attestation data is not 0", att.GetData().CommitteeIndex)
+	}
+	bits := att.CommitteeBitsVal()
+	if wantLen := (params.BeaconConfig().MaxCommitteesPerSlot + 7) / 8; uint64(len(bits.Bytes())) != wantLen {
+		return 0, fmt.Errorf("committee bits have length %d instead of %d bytes", len(bits.Bytes()), wantLen)
+	}
+	indices := corehelpers.CommitteeIndices(bits)
+	if len(indices) != 1 {
+		return 0, fmt.Errorf("%d committee bits are set instead of 1", len(indices))
+	}
+	if uint64(indices[0]) >= committeesPerSlot {
+		return 0, fmt.Errorf("committee index %d is out of range, the slot has %d committees", indices[0], committeesPerSlot)
+	}
+	return indices[0], nil
+}
+
 // ListVoluntaryExits retrieves voluntary exits known by the node but
 // not necessarily incorporated into any block.
 func (s *Server) ListVoluntaryExits(w http.ResponseWriter, r *http.Request) {
@@ -267,37 +501,45 @@ func (s *Server) SubmitVoluntaryExit(w http.ResponseWriter, r *http.Request) {
 }
 
 // SubmitSyncCommitteeSignatures submits sync committee signature objects to the node.
+// The messages are accepted either as JSON or as an SSZ encoded list.
 func (s *Server) SubmitSyncCommitteeSignatures(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.SubmitPoolSyncCommitteeSignatures")
 	defer span.End()
 
-	var req structs.SubmitSyncCommitteeSignaturesRequest
-	err := json.NewDecoder(r.Body).Decode(&req.Data)
-	switch {
-	case err == io.EOF:
-		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
-		return
-	case err != nil:
-		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
-		return
-	}
-	if len(req.Data) == 0 {
-		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
-		return
-	}
-
 	var validMessages []*eth.SyncCommitteeMessage
 	var msgFailures []*server.IndexedVerificationFailure
-	for i, sourceMsg := range req.Data {
-		msg, err := sourceMsg.ToConsensus()
-		if err != nil {
-			msgFailures = append(msgFailures, &server.IndexedVerificationFailure{
-				Index:   i,
-				Message: "Could not convert request message to consensus message: " + err.Error(),
-			})
-			continue
+	if httputil.IsRequestSsz(r) {
+		var ok bool
+		validMessages, ok = decodeSyncCommitteeMessagesSSZ(w, r)
+		if !ok {
+			return
+		}
+	} else {
+		var req structs.SubmitSyncCommitteeSignaturesRequest
+		err := json.NewDecoder(r.Body).Decode(&req.Data)
+		switch {
+		case err == io.EOF:
+			httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+			return
+		case err != nil:
+			httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.Data) == 0 {
+			httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+			return
+		}
+		for i, sourceMsg := range req.Data {
+			msg, err := sourceMsg.ToConsensus()
+			if err != nil {
+				msgFailures = append(msgFailures, &server.IndexedVerificationFailure{
+					Index:   i,
+					Message: "Could not convert request message to consensus message: " + err.Error(),
+				})
+				continue
+			}
+			validMessages = append(validMessages, msg)
 		}
-		validMessages = append(validMessages, msg)
 	}
 
 	for _, msg := range validMessages {
@@ -509,21 +751,202 @@ func (s *Server) SubmitAttesterSlashing(w http.ResponseWriter, r *http.Request)
 		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	headState, err = transition.ProcessSlotsIfPossible(ctx, headState, slashing.Attestation_1.Data.Slot)
+	if err := s.submitAttesterSlashing(ctx, headState, slashing); err != nil {
+		var invalidErr *invalidAttesterSlashingError
+		if errors.As(err, &invalidErr) {
+			httputil.HandleError(w, "Invalid attester slashing: "+invalidErr.err.Error(), http.StatusBadRequest)
+			return
+		}
+		httputil.HandleError(w, "Could not submit attester slashing: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetAttesterSlashingsV2 retrieves attester slashings known by the node but not necessarily incorporated
+// into any block. The pool holds slashings of both layouts, which are returned as attester slashings of the
+// current fork, given by the Eth-Consensus-Version header of the response.
+func (s *Server) GetAttesterSlashingsV2(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetAttesterSlashingsV2")
+	defer span.End()
+
+	v := slots.ToForkVersion(s.TimeFetcher.CurrentSlot())
+	headState, err := s.ChainInfoFetcher.HeadStateReadOnly(ctx)
 	if err != nil {
-		httputil.HandleError(w, "Could not process slots: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	err = blocks.VerifyAttesterSlashing(ctx, headState, slashing)
+	sourceSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, headState, true /* return unlimited slashings */)
+	slashings := make([]interface{}, 0, len(sourceSlashings))
+	for _, slashing := range sourceSlashings {
+		// Slashings that can't be included in a block of the current fork are not returned.
+		converted, err := blocks.AttesterSlashingForVersion(slashing, v)
+		if err != nil {
+			continue
+		}
+		switch sl := converted.(type) {
+		case *eth.AttesterSlashing:
+			slashings = append(slashings, structs.AttesterSlashingFromConsensus(sl))
+		case *eth.AttesterSlashingElectra:
+			slashings = append(slashings, structs.AttesterSlashingElectraFromConsensus(sl))
+		default:
+			httputil.HandleError(w, fmt.Sprintf("unable to convert slashing of type %T", converted), http.StatusInternalServerError)
+			return
+		}
+	}
+	data, err := json.Marshal(slashings)
 	if err != nil {
-		httputil.HandleError(w, "Invalid attester slashing: "+err.Error(), http.StatusBadRequest)
+		httputil.HandleError(w, "Could not marshal attester slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(v))
+	httputil.WriteJson(w, &structs.GetAttesterSlashingsV2Response{
+		Version: version.String(v),
+		Data:    data,
+	})
+}
+
+// SubmitAttesterSlashingsV2 submits attester slashings to the node's pool. The slashings are decoded as
+// attester slashings of the fork given by the Eth-Consensus-Version header, either as a single slashing or
+// as a list. Each slashing goes through the same validation as a slashing received through gossip, and is
+// broadcast when it passes. The failures are reported with the index of the slashing in the request.
+func (s *Server) SubmitAttesterSlashingsV2(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.SubmitAttesterSlashingsV2")
+	defer span.End()
+
+	versionHeader := r.Header.Get(api.VersionHeader)
+	if versionHeader == "" {
+		httputil.HandleError(w, api.VersionHeader+" header is required", http.StatusBadRequest)
 		return
 	}
-	err = s.SlashingsPool.InsertAttesterSlashing(ctx, headState, slashing)
+	v, err := version.FromString(versionHeader)
 	if err != nil {
-		httputil.HandleError(w, "Could not insert attester slashing into pool: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "Invalid "+api.VersionHeader+" header: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var raw json.RawMessage
+	err = json.NewDecoder(r.Body).Decode(&raw)
+	switch {
+	case err == io.EOF:
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	var rawSlashings []json.RawMessage
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(raw, &rawSlashings); err != nil {
+			httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		rawSlashings = []json.RawMessage{raw}
+	}
+	if len(rawSlashings) == 0 {
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	}
+
+	headState, err := s.ChainInfoFetcher.HeadState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var failures []*server.IndexedVerificationFailure
+	for i, rawSlashing := range rawSlashings {
+		slashing, err := attesterSlashingFromJSON(rawSlashing, v)
+		if err != nil {
+			failures = append(failures, &server.IndexedVerificationFailure{
+				Index:   i,
+				Message: "Could not convert request slashing to consensus slashing: " + err.Error(),
+			})
+			continue
+		}
+		if err := s.submitAttesterSlashing(ctx, headState.Copy(), slashing); err != nil {
+			failures = append(failures, &server.IndexedVerificationFailure{
+				Index:   i,
+				Message: err.Error(),
+			})
+		}
+	}
+	if len(failures) > 0 {
+		failuresErr := &server.IndexedVerificationFailureError{
+			Code:     http.StatusBadRequest,
+			Message:  "One or more attester slashings failed validation",
+			Failures: failures,
+		}
+		httputil.WriteError(w, failuresErr)
+	}
+}
+
+// attesterSlashingFromJSON converts a JSON attester slashing of the given fork to a consensus attester slashing.
+func attesterSlashingFromJSON(raw json.RawMessage, v int) (eth.AttSlashing, error) {
+	if v >= version.Electra {
+		var slashing *structs.AttesterSlashingElectra
+		if err := json.Unmarshal(raw, &slashing); err != nil {
+			return nil, err
+		}
+		if slashing == nil {
+			return nil, errors.New("attester slashing is null")
+		}
+		return slashing.ToConsensus()
+	}
+	var slashing *structs.AttesterSlashing
+	if err := json.Unmarshal(raw, &slashing); err != nil {
+		return nil, err
+	}
+	if slashing == nil || slashing.Attestation1 == nil || slashing.Attestation2 == nil {
+		return nil, errors.New("attester slashing is null")
+	}
+	return slashing.ToConsensus()
+}
+
+// submitAttesterSlashing validates the attester slashing like the gossip validation does, against the head
+// state processed to the slot of its first attestation, then inserts it into the pool and broadcasts it.
+// The slashing is stored and broadcast as an attester slashing of the fork of the state, so that it can be
+// included in the blocks proposed on top of the head.
+func (s *Server) submitAttesterSlashing(ctx context.Context, headState state.BeaconState, slashing eth.AttSlashing) error {
+	if slashing.FirstAttestation() == nil || slashing.FirstAttestation().GetData() == nil {
+		return &invalidAttesterSlashingError{err: errors.New("nil attestation data")}
+	}
+	st, err := transition.ProcessSlotsIfPossible(ctx, headState, slashing.FirstAttestation().GetData().Slot)
+	if err != nil {
+		return fmt.Errorf("could not process slots: %w", err)
+	}
+	slashing, err = blocks.AttesterSlashingForVersion(slashing, st.Version())
+	if err != nil {
+		return &invalidAttesterSlashingError{err: err}
+	}
+	if err := blocks.VerifyAttesterSlashing(ctx, st, slashing); err != nil {
+		return &invalidAttesterSlashingError{err: err}
+	}
+	isSlashable := false
+	previouslySlashed := false
+	slashedVals := blocks.SlashableAttesterIndices(slashing)
+	for _, idx := range slashedVals {
+		val, err := st.ValidatorAtIndexReadOnly(primitives.ValidatorIndex(idx))
+		if err != nil {
+			return &invalidAttesterSlashingError{err: err}
+		}
+		if val.Slashed() {
+			previouslySlashed = true
+			continue
+		}
+		if corehelpers.IsSlashableValidator(val.ActivationEpoch(), val.WithdrawableEpoch(), val.Slashed(), slots.ToEpoch(st.Slot())) {
+			isSlashable = true
+			break
+		}
+	}
+	if !isSlashable {
+		if previouslySlashed {
+			return &invalidAttesterSlashingError{err: fmt.Errorf("validators were previously slashed: %v", slashedVals)}
+		}
+		return &invalidAttesterSlashingError{err: fmt.Errorf("none of the validators are slashable: %v", slashedVals)}
+	}
+	if err := s.SlashingsPool.InsertAttesterSlashing(ctx, st, slashing); err != nil {
+		return fmt.Errorf("could not insert attester slashing into pool: %w", err)
+	}
 	// notify events
 	s.OperationNotifier.OperationFeed().Send(&feed.Event{
 		Type: operation.AttesterSlashingReceived,
@@ -532,11 +955,24 @@ func (s *Server) SubmitAttesterSlashing(w http.ResponseWriter, r *http.Request)
 		},
 	})
 	if !features.Get().DisableBroadcastSlashings {
-		if err = s.Broadcaster.Broadcast(ctx, slashing); err != nil {
-			httputil.HandleError(w, "Could not broadcast slashing object: "+err.Error(), http.StatusInternalServerError)
-			return
+		if err := s.Broadcaster.Broadcast(ctx, slashing); err != nil {
+			return fmt.Errorf("could not broadcast slashing object: %w", err)
 		}
 	}
+	return nil
+}
+
+// invalidAttesterSlashingError is returned by submitAttesterSlashing when the attester slashing fails validation.
+type invalidAttesterSlashingError struct {
+	err error
+}
+
+func (e *invalidAttesterSlashingError) Error() string {
+	return "invalid attester slashing: " + e.err.Error()
+}
+
+func (e *invalidAttesterSlashingError) Unwrap() error {
+	return e.err
 }
 
 // GetProposerSlashings retrieves proposer slashings known by the node
@@ -615,3 +1051,61 @@ func (s *Server) SubmitProposerSlashing(w http.ResponseWriter, r *http.Request)
 		}
 	}
 }
+
+// decodeAttestationsSSZ decodes the SSZ encoded list of attestations of the request body. The list
+// holds phase0 attestations.
+func decodeAttestationsSSZ(w http.ResponseWriter, r *http.Request) ([]*eth.Attestation, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		httputil.HandleError(w, "Could not read request body: "+err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	// The number of attestations is only bounded by the size of the body.
+	n, err := ssz.DecodeDynamicLength(body, len(body))
+	if err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	atts := make([]*eth.Attestation, n)
+	err = ssz.UnmarshalDynamic(body, n, func(i int, b []byte) error {
+		atts[i] = &eth.Attestation{}
+		return atts[i].UnmarshalSSZ(b)
+	})
+	if err != nil {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return atts, true
+}
+
+// decodeSyncCommitteeMessagesSSZ decodes the SSZ encoded list of sync committee messages of the
+// request body. The messages have a fixed size, so the list is their plain concatenation.
+func decodeSyncCommitteeMessagesSSZ(w http.ResponseWriter, r *http.Request) ([]*eth.SyncCommitteeMessage, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		httputil.HandleError(w, "Could not read request body: "+err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	if len(body) == 0 {
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return nil, false
+	}
+	size := (&eth.SyncCommitteeMessage{}).SizeSSZ()
+	if len(body)%size != 0 {
+		httputil.HandleError(
+			w,
+			fmt.Sprintf("Could not decode request body: size %d is not a multiple of the message size %d", len(body), size),
+			http.StatusBadRequest,
+		)
+		return nil, false
+	}
+	msgs := make([]*eth.SyncCommitteeMessage, len(body)/size)
+	for i := range msgs {
+		msgs[i] = &eth.SyncCommitteeMessage{}
+		if err := msgs[i].UnmarshalSSZ(body[i*size : (i+1)*size]); err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not decode message %d: %v", i, err), http.StatusBadRequest)
+			return nil, false
+		}
+	}
+	return msgs, true
+}
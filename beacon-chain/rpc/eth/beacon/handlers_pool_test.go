@@ -3,6 +3,7 @@ package beacon
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,9 +14,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	blockchainmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	prysmtime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
@@ -37,6 +40,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbv1alpha1 "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
@@ -184,6 +188,162 @@ func TestListAttestations(t *testing.T) {
 			assert.Equal(t, "4", a.Data.CommitteeIndex)
 		}
 	})
+	t.Run("both slot + index request, no match", func(t *testing.T) {
+		url := "http://example.com?slot=2&committee_index=1"
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ListAttestations(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.StringContains(t, `"data":[]`, writer.Body.String())
+	})
+}
+
+// poolAttestationsAcrossCommittees returns an aggregated and an unaggregated attestation for each of the
+// given slots and committees. Electra attestations give their committee in their committee bits.
+func poolAttestationsAcrossCommittees(electra bool, slotCount, committeeCount uint64) (aggregated, unaggregated []ethpbv1alpha1.Att) {
+	for slot := uint64(1); slot <= slotCount; slot++ {
+		for c := uint64(0); c < committeeCount; c++ {
+			for _, bits := range []bitfield.Bitlist{{0b1011}, {0b1001}} {
+				data := &ethpbv1alpha1.AttestationData{
+					Slot:            primitives.Slot(slot),
+					BeaconBlockRoot: bytesutil.PadTo([]byte(fmt.Sprintf("blockroot%d%d", slot, c)), 32),
+				}
+				var att ethpbv1alpha1.Att
+				if electra {
+					committeeBits := primitives.NewAttestationCommitteeBits()
+					committeeBits.SetBitAt(c, true)
+					att = util.HydrateAttestationElectra(&ethpbv1alpha1.AttestationElectra{
+						AggregationBits: bits,
+						Data:            data,
+						CommitteeBits:   committeeBits,
+					})
+				} else {
+					data.CommitteeIndex = primitives.CommitteeIndex(c)
+					att = util.HydrateAttestation(&ethpbv1alpha1.Attestation{
+						AggregationBits: bits,
+						Data:            data,
+					})
+				}
+				if bits.Count() > 1 {
+					aggregated = append(aggregated, att)
+				} else {
+					unaggregated = append(unaggregated, att)
+				}
+			}
+		}
+	}
+	return aggregated, unaggregated
+}
+
+func TestListAttestationsV2(t *testing.T) {
+	listAttestations := func(t *testing.T, s *Server, query string) ([]json.RawMessage, string) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ListAttestationsV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.ListAttestationsV2Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, resp.Version, writer.Header().Get(api.VersionHeader))
+		var data []json.RawMessage
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		require.NotNil(t, data)
+		return data, resp.Version
+	}
+
+	t.Run("pre-electra", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconConfig().Copy()
+		cfg.DenebForkEpoch = 0
+		cfg.ElectraForkEpoch = 100
+		params.OverrideBeaconConfig(cfg)
+
+		slot := primitives.Slot(10)
+		s := &Server{
+			AttestationsPool: attestations.NewPool(),
+			TimeFetcher:      &blockchainmock.ChainService{Slot: &slot},
+		}
+		aggregated, unaggregated := poolAttestationsAcrossCommittees(false, 3, 3)
+		require.NoError(t, s.AttestationsPool.SaveAggregatedAttestations(aggregated))
+		require.NoError(t, s.AttestationsPool.SaveUnaggregatedAttestations(unaggregated))
+		electraAggregated, _ := poolAttestationsAcrossCommittees(true, 1, 1)
+		require.NoError(t, s.AttestationsPool.SaveAggregatedAttestations(electraAggregated))
+
+		tests := []struct {
+			query string
+			count int
+		}{
+			{query: "", count: 18},
+			{query: "?slot=2", count: 6},
+			{query: "?committee_index=1", count: 6},
+			{query: "?slot=2&committee_index=1", count: 2},
+			{query: "?slot=4", count: 0},
+			{query: "?slot=3&committee_index=3", count: 0},
+		}
+		for _, tt := range tests {
+			data, v := listAttestations(t, s, tt.query)
+			assert.Equal(t, "deneb", v)
+			require.Equal(t, tt.count, len(data), "Wrong number of attestations for query %q", tt.query)
+			for _, d := range data {
+				a := &structs.Attestation{}
+				require.NoError(t, json.Unmarshal(d, a))
+				if strings.Contains(tt.query, "slot=2") {
+					assert.Equal(t, "2", a.Data.Slot)
+				}
+				if strings.Contains(tt.query, "committee_index=1") {
+					assert.Equal(t, "1", a.Data.CommitteeIndex)
+				}
+			}
+		}
+	})
+	t.Run("post-electra", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconConfig().Copy()
+		cfg.ElectraForkEpoch = 0
+		params.OverrideBeaconConfig(cfg)
+
+		slot := primitives.Slot(10)
+		s := &Server{
+			AttestationsPool: attestations.NewPool(),
+			TimeFetcher:      &blockchainmock.ChainService{Slot: &slot},
+		}
+		aggregated, unaggregated := poolAttestationsAcrossCommittees(true, 3, 3)
+		require.NoError(t, s.AttestationsPool.SaveAggregatedAttestations(aggregated))
+		require.NoError(t, s.AttestationsPool.SaveUnaggregatedAttestations(unaggregated))
+		phase0Aggregated, _ := poolAttestationsAcrossCommittees(false, 1, 1)
+		require.NoError(t, s.AttestationsPool.SaveAggregatedAttestations(phase0Aggregated))
+
+		tests := []struct {
+			query string
+			count int
+		}{
+			{query: "", count: 18},
+			{query: "?slot=2", count: 6},
+			{query: "?committee_index=1", count: 6},
+			{query: "?slot=2&committee_index=1", count: 2},
+			{query: "?slot=4", count: 0},
+			{query: "?slot=3&committee_index=3", count: 0},
+		}
+		for _, tt := range tests {
+			data, v := listAttestations(t, s, tt.query)
+			assert.Equal(t, "electra", v)
+			require.Equal(t, tt.count, len(data), "Wrong number of attestations for query %q", tt.query)
+			for _, d := range data {
+				a := &structs.AttestationElectra{}
+				require.NoError(t, json.Unmarshal(d, a))
+				assert.Equal(t, "0", a.Data.CommitteeIndex)
+				if strings.Contains(tt.query, "slot=2") {
+					assert.Equal(t, "2", a.Data.Slot)
+				}
+				if strings.Contains(tt.query, "committee_index=1") {
+					assert.Equal(t, hexutil.Encode([]byte{0b10, 0, 0, 0, 0, 0, 0, 0}), a.CommitteeBits)
+				}
+			}
+		}
+	})
 }
 
 func TestSubmitAttestations(t *testing.T) {
@@ -269,6 +429,69 @@ func TestSubmitAttestations(t *testing.T) {
 		assert.Equal(t, 2, broadcaster.NumAttestations())
 		assert.Equal(t, 2, s.AttestationsPool.UnaggregatedAttestationCount())
 	})
+	t.Run("ssz", func(t *testing.T) {
+		broadcaster := &p2pMock.MockBroadcaster{}
+		s.Broadcaster = broadcaster
+		s.AttestationsPool = attestations.NewPool()
+
+		var sourceAtts []*structs.Attestation
+		require.NoError(t, json.Unmarshal([]byte(multipleAtts), &sourceAtts))
+		atts := make([]*ethpbv1alpha1.Attestation, len(sourceAtts))
+		for i, a := range sourceAtts {
+			atts[i], err = a.ToConsensus()
+			require.NoError(t, err)
+		}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(attestationsSSZ(t, atts)))
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
+		request.Header.Set(api.VersionHeader, version.String(version.Phase0))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttestations(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 2, broadcaster.NumAttestations())
+		for i, att := range atts {
+			assert.DeepSSZEqual(t, att, broadcaster.BroadcastAttestations[i])
+		}
+		assert.Equal(t, 2, s.AttestationsPool.UnaggregatedAttestationCount())
+	})
+	t.Run("ssz invalid", func(t *testing.T) {
+		s.Broadcaster = &p2pMock.MockBroadcaster{}
+		s.AttestationsPool = attestations.NewPool()
+		att, err := sourceAttestation(t, singleAtt).ToConsensus()
+		require.NoError(t, err)
+		body := attestationsSSZ(t, []*ethpbv1alpha1.Attestation{att})
+
+		tests := []struct {
+			name    string
+			body    []byte
+			version string
+			wantErr string
+		}{
+			{name: "empty", body: []byte{}, wantErr: "No data submitted"},
+			{name: "truncated", body: body[:len(body)-1], wantErr: "Could not decode request body"},
+			{name: "electra", body: body, version: version.String(version.Electra), wantErr: "electra attestations are not supported by this endpoint"},
+			{name: "unknown version", body: body, version: "foo", wantErr: "Invalid " + api.VersionHeader + " header"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(tt.body))
+				request.Header.Set("Content-Type", api.OctetStreamMediaType)
+				if tt.version != "" {
+					request.Header.Set(api.VersionHeader, tt.version)
+				}
+				writer := httptest.NewRecorder()
+				writer.Body = &bytes.Buffer{}
+
+				s.SubmitAttestations(writer, request)
+				assert.Equal(t, http.StatusBadRequest, writer.Code)
+				e := &httputil.DefaultJsonError{}
+				require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+				assert.StringContains(t, tt.wantErr, e.Message)
+				assert.Equal(t, 0, s.AttestationsPool.UnaggregatedAttestationCount())
+			})
+		}
+	})
 	t.Run("no body", func(t *testing.T) {
 		request := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
 		writer := httptest.NewRecorder()
@@ -353,6 +576,164 @@ func TestListVoluntaryExits(t *testing.T) {
 	assert.Equal(t, "2", resp.Data[1].Message.ValidatorIndex)
 }
 
+func TestSubmitAttestationsV2(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	params.SetupTestConfigCleanup(t)
+	c := params.BeaconConfig().Copy()
+	// Required for correct committee size calculation.
+	c.SlotsPerEpoch = 1
+	c.ElectraForkEpoch = 0
+	params.OverrideBeaconConfig(c)
+
+	_, keys, err := util.DeterministicDepositsAndKeys(1)
+	require.NoError(t, err)
+	validators := []*ethpbv1alpha1.Validator{
+		{
+			PublicKey: keys[0].PublicKey().Marshal(),
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		},
+	}
+	bs, err := util.NewBeaconState(func(state *ethpbv1alpha1.BeaconState) error {
+		state.Validators = validators
+		state.Slot = 1
+		state.PreviousJustifiedCheckpoint = &ethpbv1alpha1.Checkpoint{
+			Epoch: 0,
+			Root:  bytesutil.PadTo([]byte("sourceroot1"), 32),
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	chainService := &blockchainmock.ChainService{State: bs}
+	s := &Server{
+		HeadFetcher:       chainService,
+		ChainInfoFetcher:  chainService,
+		OperationNotifier: &blockchainmock.MockOperationNotifier{},
+	}
+
+	t.Run("electra", func(t *testing.T) {
+		broadcaster := &p2pMock.MockBroadcaster{}
+		s.Broadcaster = broadcaster
+		s.AttestationsPool = attestations.NewPool()
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(multipleAttsElectra))
+		request.Header.Set(api.VersionHeader, version.String(version.Electra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttestationsV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &server.IndexedVerificationFailureError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		require.Equal(t, 1, len(e.Failures))
+		assert.Equal(t, 1, e.Failures[0].Index)
+		assert.StringContains(t, "committee index 1 is out of range, the slot has 1 committees", e.Failures[0].Message)
+
+		require.Equal(t, 1, broadcaster.NumAttestations())
+		att, ok := broadcaster.BroadcastAttestations[0].(*ethpbv1alpha1.AttestationElectra)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "0x0100000000000000", hexutil.Encode(att.CommitteeBits))
+		assert.Equal(t, primitives.CommitteeIndex(0), att.Data.CommitteeIndex)
+		assert.Equal(t, 1, s.AttestationsPool.UnaggregatedAttestationCount())
+	})
+	t.Run("invalid committee bits", func(t *testing.T) {
+		s.Broadcaster = &p2pMock.MockBroadcaster{}
+		s.AttestationsPool = attestations.NewPool()
+
+		tests := []struct {
+			name          string
+			committeeBits string
+			index         string
+			wantErr       string
+		}{
+			{name: "no bit", committeeBits: "0x0000000000000000", index: "0", wantErr: "0 committee bits are set instead of 1"},
+			{name: "two bits", committeeBits: "0x0300000000000000", index: "0", wantErr: "2 committee bits are set instead of 1"},
+			{name: "short", committeeBits: "0x01", index: "0", wantErr: "committee bits have length 1 instead of 8 bytes"},
+			{name: "data index", committeeBits: "0x0100000000000000", index: "1", wantErr: "committee index 1 of attestation data is not 0"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var atts []*structs.AttestationElectra
+				require.NoError(t, json.Unmarshal([]byte(multipleAttsElectra), &atts))
+				atts[0].CommitteeBits = tt.committeeBits
+				atts[0].Data.CommitteeIndex = tt.index
+				body, err := json.Marshal(atts[:1])
+				require.NoError(t, err)
+				request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body))
+				request.Header.Set(api.VersionHeader, version.String(version.Electra))
+				writer := httptest.NewRecorder()
+				writer.Body = &bytes.Buffer{}
+
+				s.SubmitAttestationsV2(writer, request)
+				assert.Equal(t, http.StatusBadRequest, writer.Code)
+				e := &server.IndexedVerificationFailureError{}
+				require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+				require.Equal(t, 1, len(e.Failures))
+				assert.Equal(t, 0, e.Failures[0].Index)
+				assert.StringContains(t, tt.wantErr, e.Failures[0].Message)
+				assert.Equal(t, 0, s.AttestationsPool.UnaggregatedAttestationCount())
+			})
+		}
+	})
+	t.Run("pre-electra attestations of an electra slot", func(t *testing.T) {
+		s.Broadcaster = &p2pMock.MockBroadcaster{}
+		s.AttestationsPool = attestations.NewPool()
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(singleAtt))
+		request.Header.Set(api.VersionHeader, version.String(version.Deneb))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttestationsV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &server.IndexedVerificationFailureError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		require.Equal(t, 1, len(e.Failures))
+		assert.StringContains(t, "Attestation slot 0 is not a slot of the deneb fork", e.Failures[0].Message)
+	})
+	t.Run("v1 rejects electra attestations", func(t *testing.T) {
+		s.Broadcaster = &p2pMock.MockBroadcaster{}
+		s.AttestationsPool = attestations.NewPool()
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(singleAtt))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttestations(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &server.IndexedVerificationFailureError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		require.Equal(t, 1, len(e.Failures))
+		assert.StringContains(t, "must be submitted to /eth/v2/beacon/pool/attestations", e.Failures[0].Message)
+		assert.Equal(t, 0, s.AttestationsPool.UnaggregatedAttestationCount())
+	})
+	t.Run("missing version", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(multipleAttsElectra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttestationsV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, api.VersionHeader+" header is required", e.Message)
+	})
+	t.Run("empty", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("[]"))
+		request.Header.Set(api.VersionHeader, version.String(version.Electra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttestationsV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No data submitted", e.Message)
+	})
+}
+
 func TestSubmitVoluntaryExit(t *testing.T) {
 	transition.SkipSlotCache.Disable()
 	defer transition.SkipSlotCache.Enable()
@@ -569,6 +950,57 @@ func TestSubmitSyncCommitteeSignatures(t *testing.T) {
 		require.Equal(t, 1, len(msgsInPool))
 		assert.Equal(t, true, broadcaster.BroadcastCalled.Load())
 	})
+	t.Run("ssz", func(t *testing.T) {
+		broadcaster := &p2pMock.MockBroadcaster{}
+		s := &Server{
+			CoreService: &core.Service{
+				SyncCommitteePool: synccommittee.NewStore(),
+				P2P:               broadcaster,
+				HeadFetcher: &blockchainmock.ChainService{
+					State:                st,
+					SyncCommitteeIndices: []primitives.CommitteeIndex{0},
+				},
+			},
+		}
+
+		var sourceMsgs []*structs.SyncCommitteeMessage
+		require.NoError(t, json.Unmarshal([]byte(multipleSyncCommitteeMsg), &sourceMsgs))
+		var body []byte
+		msgs := make([]*ethpbv1alpha1.SyncCommitteeMessage, len(sourceMsgs))
+		for i, m := range sourceMsgs {
+			var err error
+			msgs[i], err = m.ToConsensus()
+			require.NoError(t, err)
+			body, err = msgs[i].MarshalSSZTo(body)
+			require.NoError(t, err)
+		}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body))
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitSyncCommitteeSignatures(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		for _, msg := range msgs {
+			msgsInPool, err := s.CoreService.SyncCommitteePool.SyncCommitteeMessages(msg.Slot)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(msgsInPool))
+			assert.DeepSSZEqual(t, msg, msgsInPool[0])
+		}
+		assert.Equal(t, true, broadcaster.BroadcastCalled.Load())
+
+		// A body that is not a whole number of messages is rejected.
+		request = httptest.NewRequest(http.MethodPost, "http://example.com", bytes.NewReader(body[:len(body)-1]))
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitSyncCommitteeSignatures(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "is not a multiple of the message size", e.Message)
+	})
 	t.Run("invalid", func(t *testing.T) {
 		broadcaster := &p2pMock.MockBroadcaster{}
 		s := &Server{
@@ -1076,6 +1508,82 @@ func TestGetAttesterSlashings(t *testing.T) {
 	assert.Equal(t, 2, len(resp.Data))
 }
 
+func TestGetAttesterSlashingsV2(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.ElectraForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	bs, keys := util.DeterministicGenesisState(t, 64)
+	legacySlashing, err := util.GenerateAttesterSlashingForValidator(bs, keys[1], 1)
+	require.NoError(t, err)
+	bsElectra, keysElectra := util.DeterministicGenesisStateElectra(t, 64)
+	electraSlashing, err := util.GenerateAttesterSlashingForValidator(bsElectra, keysElectra[2], 2)
+	require.NoError(t, err)
+
+	t.Run("pre-electra", func(t *testing.T) {
+		slot := primitives.Slot(0)
+		s := &Server{
+			ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
+			TimeFetcher:      &blockchainmock.ChainService{Slot: &slot},
+			SlashingsPool:    &slashingsmock.PoolMock{PendingAttSlashings: []ethpbv1alpha1.AttSlashing{legacySlashing, electraSlashing}},
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v2/beacon/pool/attester_slashings", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetAttesterSlashingsV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, version.String(version.Deneb), writer.Header().Get(api.VersionHeader))
+		resp := &structs.GetAttesterSlashingsV2Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, version.String(version.Deneb), resp.Version)
+		var slashings []*structs.AttesterSlashing
+		require.NoError(t, json.Unmarshal(resp.Data, &slashings))
+		require.Equal(t, 2, len(slashings))
+		got, err := slashings[0].ToConsensus()
+		require.NoError(t, err)
+		assert.DeepEqual(t, legacySlashing, got)
+		got, err = slashings[1].ToConsensus()
+		require.NoError(t, err)
+		want, err := blocks.AttesterSlashingForVersion(electraSlashing, version.Deneb)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, got)
+	})
+	t.Run("electra", func(t *testing.T) {
+		slot := params.BeaconConfig().SlotsPerEpoch
+		s := &Server{
+			ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
+			TimeFetcher:      &blockchainmock.ChainService{Slot: &slot},
+			SlashingsPool:    &slashingsmock.PoolMock{PendingAttSlashings: []ethpbv1alpha1.AttSlashing{legacySlashing, electraSlashing}},
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v2/beacon/pool/attester_slashings", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetAttesterSlashingsV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, version.String(version.Electra), writer.Header().Get(api.VersionHeader))
+		resp := &structs.GetAttesterSlashingsV2Response{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, version.String(version.Electra), resp.Version)
+		var slashings []*structs.AttesterSlashingElectra
+		require.NoError(t, json.Unmarshal(resp.Data, &slashings))
+		require.Equal(t, 2, len(slashings))
+		got, err := slashings[0].ToConsensus()
+		require.NoError(t, err)
+		want, err := blocks.AttesterSlashingForVersion(legacySlashing, version.Electra)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, got)
+		got, err = slashings[1].ToConsensus()
+		require.NoError(t, err)
+		assert.DeepEqual(t, electraSlashing, got)
+	})
+}
+
 func TestGetProposerSlashings(t *testing.T) {
 	bs, err := util.NewBeaconState()
 	require.NoError(t, err)
@@ -1151,7 +1659,9 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 	_, keys, err := util.DeterministicDepositsAndKeys(1)
 	require.NoError(t, err)
 	validator := &ethpbv1alpha1.Validator{
-		PublicKey: keys[0].PublicKey().Marshal(),
+		PublicKey:         keys[0].PublicKey().Marshal(),
+		ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+		WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
 	}
 	bs, err := util.NewBeaconState(func(state *ethpbv1alpha1.BeaconState) error {
 		state.Validators = []*ethpbv1alpha1.Validator{validator}
@@ -1352,6 +1862,110 @@ func TestSubmitAttesterSlashing_InvalidSlashing(t *testing.T) {
 	assert.StringContains(t, "Invalid attester slashing", e.Message)
 }
 
+func TestSubmitAttesterSlashingsV2(t *testing.T) {
+	ctx := context.Background()
+
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	bs, keys := util.DeterministicGenesisStateElectra(t, 64)
+	slashing1, err := util.GenerateAttesterSlashingForValidator(bs, keys[1], 1)
+	require.NoError(t, err)
+	slashing2, err := util.GenerateAttesterSlashingForValidator(bs, keys[2], 2)
+	require.NoError(t, err)
+	electraSlashing1, ok := slashing1.(*ethpbv1alpha1.AttesterSlashingElectra)
+	require.Equal(t, true, ok)
+	electraSlashing2, ok := slashing2.(*ethpbv1alpha1.AttesterSlashingElectra)
+	require.Equal(t, true, ok)
+
+	newServer := func() (*Server, *p2pMock.MockBroadcaster) {
+		broadcaster := &p2pMock.MockBroadcaster{}
+		chainmock := &blockchainmock.ChainService{State: bs}
+		return &Server{
+			ChainInfoFetcher:  chainmock,
+			SlashingsPool:     &slashingsmock.PoolMock{},
+			Broadcaster:       broadcaster,
+			OperationNotifier: chainmock.OperationNotifier(),
+		}, broadcaster
+	}
+
+	t.Run("single", func(t *testing.T) {
+		s, broadcaster := newServer()
+		b, err := json.Marshal(structs.AttesterSlashingElectraFromConsensus(electraSlashing1))
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v2/beacon/pool/attester_slashings", bytes.NewReader(b))
+		request.Header.Set(api.VersionHeader, version.String(version.Electra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingsV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		pendingSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)
+		require.Equal(t, 1, len(pendingSlashings))
+		assert.DeepEqual(t, slashing1, pendingSlashings[0])
+		require.Equal(t, 1, broadcaster.NumMessages())
+		_, ok := broadcaster.BroadcastMessages[0].(*ethpbv1alpha1.AttesterSlashingElectra)
+		assert.Equal(t, true, ok)
+	})
+	t.Run("multiple", func(t *testing.T) {
+		s, broadcaster := newServer()
+		b, err := json.Marshal(structs.AttesterSlashingsElectraFromConsensus([]*ethpbv1alpha1.AttesterSlashingElectra{electraSlashing1, electraSlashing2}))
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v2/beacon/pool/attester_slashings", bytes.NewReader(b))
+		request.Header.Set(api.VersionHeader, version.String(version.Electra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingsV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		pendingSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)
+		require.Equal(t, 2, len(pendingSlashings))
+		assert.DeepEqual(t, slashing1, pendingSlashings[0])
+		assert.DeepEqual(t, slashing2, pendingSlashings[1])
+		assert.Equal(t, 2, broadcaster.NumMessages())
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		s, broadcaster := newServer()
+		invalid := structs.AttesterSlashingElectraFromConsensus(electraSlashing2)
+		invalid.Attestation2.Signature = invalid.Attestation1.Signature
+		b, err := json.Marshal([]*structs.AttesterSlashingElectra{structs.AttesterSlashingElectraFromConsensus(electraSlashing1), invalid})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v2/beacon/pool/attester_slashings", bytes.NewReader(b))
+		request.Header.Set(api.VersionHeader, version.String(version.Electra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingsV2(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &server.IndexedVerificationFailureError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		require.Equal(t, 1, len(e.Failures))
+		assert.Equal(t, 1, e.Failures[0].Index)
+		assert.StringContains(t, "invalid attester slashing", e.Failures[0].Message)
+		pendingSlashings := s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)
+		require.Equal(t, 1, len(pendingSlashings))
+		assert.DeepEqual(t, slashing1, pendingSlashings[0])
+		assert.Equal(t, 1, broadcaster.NumMessages())
+	})
+	t.Run("no version header", func(t *testing.T) {
+		s, broadcaster := newServer()
+		b, err := json.Marshal(structs.AttesterSlashingElectraFromConsensus(electraSlashing1))
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v2/beacon/pool/attester_slashings", bytes.NewReader(b))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SubmitAttesterSlashingsV2(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, api.VersionHeader+" header is required", e.Message)
+		assert.Equal(t, 0, len(s.SlashingsPool.PendingAttesterSlashings(ctx, bs, true)))
+		assert.Equal(t, false, broadcaster.BroadcastCalled.Load())
+	})
+}
+
 func TestSubmitProposerSlashing_Ok(t *testing.T) {
 	ctx := context.Background()
 
@@ -1566,6 +2180,45 @@ var (
       }
     }
   }
+]`
+	// The second attestation is for committee 1, while its slot only has committee 0.
+	multipleAttsElectra = `[
+  {
+    "aggregation_bits": "0x03",
+    "committee_bits": "0x0100000000000000",
+    "signature": "0x8146f4397bfd8fd057ebbcd6a67327bdc7ed5fb650533edcb6377b650dea0b6da64c14ecd60846d5c0a0cd43893d6972092500f82c9d8a955e2b58c5ed3cbe885d84008ace6bd86ba9e23652f58e2ec207cec494c916063257abf285b9b15b15",
+    "data": {
+      "slot": "0",
+      "index": "0",
+      "beacon_block_root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
+      "source": {
+        "epoch": "0",
+        "root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
+      },
+      "target": {
+        "epoch": "0",
+        "root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
+      }
+    }
+  },
+  {
+    "aggregation_bits": "0x03",
+    "committee_bits": "0x0200000000000000",
+    "signature": "0x8146f4397bfd8fd057ebbcd6a67327bdc7ed5fb650533edcb6377b650dea0b6da64c14ecd60846d5c0a0cd43893d6972092500f82c9d8a955e2b58c5ed3cbe885d84008ace6bd86ba9e23652f58e2ec207cec494c916063257abf285b9b15b15",
+    "data": {
+      "slot": "0",
+      "index": "0",
+      "beacon_block_root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
+      "source": {
+        "epoch": "0",
+        "root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
+      },
+      "target": {
+        "epoch": "0",
+        "root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
+      }
+    }
+  }
 ]`
 	multipleAtts = `[
   {
@@ -1757,3 +2410,23 @@ var (
   }
 }`
 )
+
+func sourceAttestation(t *testing.T, raw string) *structs.Attestation {
+	var atts []*structs.Attestation
+	require.NoError(t, json.Unmarshal([]byte(raw), &atts))
+	require.Equal(t, 1, len(atts))
+	return atts[0]
+}
+
+// attestationsSSZ encodes the attestations as an SSZ list of variable size containers, i.e. the
+// offsets of the attestations followed by the attestations themselves.
+func attestationsSSZ(t *testing.T, atts []*ethpbv1alpha1.Attestation) []byte {
+	var offsets, data []byte
+	for _, att := range atts {
+		offsets = binary.LittleEndian.AppendUint32(offsets, uint32(4*len(atts)+len(data)))
+		enc, err := att.MarshalSSZ()
+		require.NoError(t, err)
+		data = append(data, enc...)
+	}
+	return append(offsets, data...)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
@@ -19,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"go.opencensus.io/trace"
 )
@@ -146,6 +148,151 @@ func (s *Server) GetRandao(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
+// GetPendingDeposits returns the pending balance deposits of the state with given 'stateId'.
+// States before Electra have no pending deposits, so an empty list is returned for them.
+func (s *Server) GetPendingDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingDeposits")
+	defer span.End()
+
+	writePendingQueue(ctx, s, w, r, pendingQueue[*ethpbalpha.PendingBalanceDeposit]{
+		name:      "pending deposits",
+		sszFile:   "pending_deposits.ssz",
+		fromState: state.BeaconState.PendingBalanceDeposits,
+		response: func(v string, isOptimistic, isFinalized bool, deposits []*ethpbalpha.PendingBalanceDeposit) interface{} {
+			return &structs.GetPendingDepositsResponse{
+				Version:             v,
+				ExecutionOptimistic: isOptimistic,
+				Finalized:           isFinalized,
+				Data:                structs.PendingBalanceDepositsFromConsensus(deposits),
+			}
+		},
+	})
+}
+
+// GetPendingPartialWithdrawals returns the pending partial withdrawals of the state with given 'stateId'.
+// States before Electra have no pending partial withdrawals, so an empty list is returned for them.
+func (s *Server) GetPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingPartialWithdrawals")
+	defer span.End()
+
+	writePendingQueue(ctx, s, w, r, pendingQueue[*ethpbalpha.PendingPartialWithdrawal]{
+		name:      "pending partial withdrawals",
+		sszFile:   "pending_partial_withdrawals.ssz",
+		fromState: state.BeaconState.PendingPartialWithdrawals,
+		response: func(v string, isOptimistic, isFinalized bool, withdrawals []*ethpbalpha.PendingPartialWithdrawal) interface{} {
+			return &structs.GetPendingPartialWithdrawalsResponse{
+				Version:             v,
+				ExecutionOptimistic: isOptimistic,
+				Finalized:           isFinalized,
+				Data:                structs.PendingPartialWithdrawalsFromConsensus(withdrawals),
+			}
+		},
+	})
+}
+
+// GetPendingConsolidations returns the pending consolidations of the state with given 'stateId'.
+// States before Electra have no pending consolidations, so an empty list is returned for them.
+func (s *Server) GetPendingConsolidations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingConsolidations")
+	defer span.End()
+
+	writePendingQueue(ctx, s, w, r, pendingQueue[*ethpbalpha.PendingConsolidation]{
+		name:      "pending consolidations",
+		sszFile:   "pending_consolidations.ssz",
+		fromState: state.BeaconState.PendingConsolidations,
+		response: func(v string, isOptimistic, isFinalized bool, consolidations []*ethpbalpha.PendingConsolidation) interface{} {
+			return &structs.GetPendingConsolidationsResponse{
+				Version:             v,
+				ExecutionOptimistic: isOptimistic,
+				Finalized:           isFinalized,
+				Data:                structs.PendingConsolidationsFromConsensus(consolidations),
+			}
+		},
+	})
+}
+
+// pendingQueue describes one of the pending queues that Electra added to the state.
+type pendingQueue[T sszMarshaler] struct {
+	// name is used in error messages.
+	name    string
+	sszFile string
+	// fromState reads the queue from an Electra or later state.
+	fromState func(state.BeaconState) ([]T, error)
+	// response builds the JSON response for the queue.
+	response func(version string, isOptimistic, isFinalized bool, items []T) interface{}
+}
+
+// writePendingQueue writes the pending queue of the state with the request's 'stateId', as JSON or
+// SSZ. The queue is empty for states before Electra.
+func writePendingQueue[T sszMarshaler](ctx context.Context, s *Server, w http.ResponseWriter, r *http.Request, q pendingQueue[T]) {
+	stateId := mux.Vars(r)["state_id"]
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	items := make([]T, 0)
+	if st.Version() >= version.Electra {
+		items, err = q.fromState(st)
+		if err != nil {
+			httputil.HandleError(w, "Could not get "+q.name+": "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	if httputil.RespondWithSsz(r) {
+		sszResp, err := marshalSszList(items)
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal "+q.name+": "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszResp, q.sszFile)
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateStatus(ctx, w, stateId, st)
+	if !ok {
+		return
+	}
+	httputil.WriteJson(w, q.response(version.String(st.Version()), isOptimistic, isFinalized, items))
+}
+
+// stateStatus returns whether the state with given 'stateId' is optimistic and whether it is finalized.
+func (s *Server) stateStatus(ctx context.Context, w http.ResponseWriter, stateId string, st state.BeaconState) (bool, bool, bool) {
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return false, false, false
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return false, false, false
+	}
+	return isOptimistic, s.FinalizationFetcher.IsFinalized(ctx, blockRoot), true
+}
+
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+}
+
+// marshalSszList serializes a list of fixed size items, which is the concatenation of their encodings.
+func marshalSszList[T sszMarshaler](items []T) ([]byte, error) {
+	var result []byte
+	for _, item := range items {
+		b, err := item.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b...)
+	}
+	return result, nil
+}
+
 // GetSyncCommittees retrieves the sync committees for the given epoch.
 // If the epoch is not passed in, then the sync committees for the epoch of the state will be obtained.
 func (s *Server) GetSyncCommittees(w http.ResponseWriter, r *http.Request) {
@@ -13,9 +13,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -306,6 +308,276 @@ func TestGetRandao(t *testing.T) {
 	})
 }
 
+func TestGetPendingDeposits(t *testing.T) {
+	deposits := []*ethpbalpha.PendingBalanceDeposit{
+		{Index: 1, Amount: 100},
+		{Index: 2, Amount: 200},
+	}
+	st, err := util.NewBeaconStateElectra(func(s *ethpbalpha.BeaconStateElectra) error {
+		s.PendingBalanceDeposits = deposits
+		return nil
+	})
+	require.NoError(t, err)
+	chainService := &chainMock.ChainService{Optimistic: true}
+	s := &Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              dbTest.SetupDB(t),
+	}
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		resp := &structs.GetPendingDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "electra", resp.Version)
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.Finalized)
+		assert.DeepEqual(t, structs.PendingBalanceDepositsFromConsensus(deposits), resp.Data)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		var want []byte
+		for _, d := range deposits {
+			b, err := d.MarshalSSZ()
+			require.NoError(t, err)
+			want = append(want, b...)
+		}
+		assert.DeepEqual(t, want, writer.Body.Bytes())
+	})
+	t.Run("pre-electra state", func(t *testing.T) {
+		denebSt, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		s := &Server{
+			Stater: &testutil.MockStater{
+				BeaconState: denebSt,
+			},
+			HeadFetcher:           chainService,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+			BeaconDB:              dbTest.SetupDB(t),
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "deneb", resp.Version)
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("finalized", func(t *testing.T) {
+		headerRoot, err := st.LatestBlockHeader().HashTreeRoot()
+		require.NoError(t, err)
+		chainService := &chainMock.ChainService{
+			FinalizedRoots: map[[32]byte]bool{
+				headerRoot: true,
+			},
+			FinalizedCheckPoint: &ethpbalpha.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]},
+		}
+		s := &Server{
+			Stater: &testutil.MockStater{
+				BeaconState: st,
+			},
+			HeadFetcher:           chainService,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+			ChainInfoFetcher:      chainService,
+			BeaconDB:              dbTest.SetupDB(t),
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "finalized"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Finalized)
+	})
+	t.Run("state not found", func(t *testing.T) {
+		s := &Server{
+			Stater: &testutil.MockStater{
+				StateProviderFunc: func(context.Context, []byte) (state.BeaconState, error) {
+					return nil, &lookup.StateNotFoundError{}
+				},
+			},
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestGetPendingPartialWithdrawals(t *testing.T) {
+	withdrawals := []*ethpbalpha.PendingPartialWithdrawal{
+		{Index: 1, Amount: 100, WithdrawableEpoch: 10},
+		{Index: 2, Amount: 200, WithdrawableEpoch: 20},
+	}
+	st, err := util.NewBeaconStateElectra(func(s *ethpbalpha.BeaconStateElectra) error {
+		s.PendingPartialWithdrawals = withdrawals
+		return nil
+	})
+	require.NoError(t, err)
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              dbTest.SetupDB(t),
+	}
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_partial_withdrawals", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingPartialWithdrawals(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingPartialWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "electra", resp.Version)
+		assert.DeepEqual(t, structs.PendingPartialWithdrawalsFromConsensus(withdrawals), resp.Data)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_partial_withdrawals", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingPartialWithdrawals(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		var want []byte
+		for _, w := range withdrawals {
+			b, err := w.MarshalSSZ()
+			require.NoError(t, err)
+			want = append(want, b...)
+		}
+		assert.DeepEqual(t, want, writer.Body.Bytes())
+	})
+	t.Run("pre-electra state", func(t *testing.T) {
+		denebSt, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		s.Stater = &testutil.MockStater{
+			BeaconState: denebSt,
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_partial_withdrawals", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingPartialWithdrawals(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingPartialWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+}
+
+func TestGetPendingConsolidations(t *testing.T) {
+	consolidations := []*ethpbalpha.PendingConsolidation{
+		{SourceIndex: 1, TargetIndex: 2},
+		{SourceIndex: 3, TargetIndex: 4},
+	}
+	st, err := util.NewBeaconStateElectra(func(s *ethpbalpha.BeaconStateElectra) error {
+		s.PendingConsolidations = consolidations
+		return nil
+	})
+	require.NoError(t, err)
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              dbTest.SetupDB(t),
+	}
+
+	t.Run("json", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_consolidations", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingConsolidations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingConsolidationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "electra", resp.Version)
+		assert.DeepEqual(t, structs.PendingConsolidationsFromConsensus(consolidations), resp.Data)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_consolidations", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingConsolidations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		var want []byte
+		for _, c := range consolidations {
+			b, err := c.MarshalSSZ()
+			require.NoError(t, err)
+			want = append(want, b...)
+		}
+		assert.DeepEqual(t, want, writer.Body.Bytes())
+	})
+	t.Run("pre-electra state", func(t *testing.T) {
+		denebSt, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		s.Stater = &testutil.MockStater{
+			BeaconState: denebSt,
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com//eth/v1/beacon/states/{state_id}/pending_consolidations", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPendingConsolidations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingConsolidationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, 0, len(resp.Data))
+	})
+}
+
 func Test_currentCommitteeIndicesFromState(t *testing.T) {
 	st, _ := util.DeterministicGenesisStateAltair(t, params.BeaconConfig().SyncCommitteeSize)
 	vals := st.Validators()
@@ -24,6 +24,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache/depositsnapshot"
+	coreblocks "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	corehelpers "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -32,6 +34,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	mockstategen "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen/mock"
 	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -2464,40 +2468,153 @@ func TestValidateConsensus(t *testing.T) {
 }
 
 func TestValidateEquivocation(t *testing.T) {
-	t.Run("ok", func(t *testing.T) {
-		st, err := util.NewBeaconState()
-		require.NoError(t, err)
-		require.NoError(t, st.SetSlot(10))
-		fc := doublylinkedtree.New()
-		require.NoError(t, fc.InsertNode(context.Background(), st, bytesutil.ToBytes32([]byte("root"))))
-		server := &Server{
-			ForkchoiceFetcher: &chainMock.ChainService{ForkChoiceStore: fc},
-		}
-		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
-		require.NoError(t, err)
-		blk.SetSlot(st.Slot() + 1)
+	ctx := context.Background()
+	st, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	blk, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	sbb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	equivocating := eth.CopySignedBeaconBlock(blk)
+	equivocating.Block.Body.Graffiti = bytesutil.PadTo([]byte("equivocating"), 32)
+	equivocatingSbb, err := blocks.NewSignedBeaconBlock(equivocating)
+	require.NoError(t, err)
+	otherProposer := eth.CopySignedBeaconBlock(equivocating)
+	otherProposer.Block.ProposerIndex++
+	otherProposerSbb, err := blocks.NewSignedBeaconBlock(otherProposer)
+	require.NoError(t, err)
 
-		require.NoError(t, server.validateEquivocation(blk.Block()))
-	})
-	t.Run("block already exists", func(t *testing.T) {
-		st, err := util.NewBeaconState()
+	// newServer returns a server that has received the given blocks at slot 1.
+	newServer := func(t *testing.T, received ...interfaces.ReadOnlySignedBeaconBlock) *Server {
+		beaconDB := dbTest.SetupDB(t)
+		require.NoError(t, beaconDB.SaveBlocks(ctx, received))
+		fcState, err := util.NewBeaconState()
 		require.NoError(t, err)
-		require.NoError(t, st.SetSlot(10))
+		require.NoError(t, fcState.SetSlot(1))
 		fc := doublylinkedtree.New()
-		require.NoError(t, fc.InsertNode(context.Background(), st, bytesutil.ToBytes32([]byte("root"))))
-		server := &Server{
+		require.NoError(t, fc.InsertNode(ctx, fcState, bytesutil.ToBytes32([]byte("root"))))
+		return &Server{
+			BeaconDB:          beaconDB,
 			ForkchoiceFetcher: &chainMock.ChainService{ForkChoiceStore: fc},
 		}
-		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
-		require.NoError(t, err)
-		blk.SetSlot(st.Slot())
+	}
 
-		err = server.validateEquivocation(blk.Block())
-		assert.ErrorContains(t, "already exists", err)
+	t.Run("no block received at the slot", func(t *testing.T) {
+		server := &Server{ForkchoiceFetcher: &chainMock.ChainService{ForkChoiceStore: doublylinkedtree.New()}}
+		require.NoError(t, server.validateEquivocation(ctx, sbb.Block()))
+	})
+	t.Run("same block", func(t *testing.T) {
+		require.NoError(t, newServer(t, sbb).validateEquivocation(ctx, sbb.Block()))
+	})
+	t.Run("block from another proposer", func(t *testing.T) {
+		require.NoError(t, newServer(t, otherProposerSbb).validateEquivocation(ctx, sbb.Block()))
+	})
+	t.Run("equivocating block", func(t *testing.T) {
+		err := newServer(t, sbb, equivocatingSbb).validateEquivocation(ctx, sbb.Block())
+		assert.ErrorContains(t, "already exists for slot 1", err)
 		require.ErrorIs(t, err, errEquivocatedBlock)
 	})
 }
 
+func TestPublishBlockV2_BroadcastValidation(t *testing.T) {
+	ctx := context.Background()
+	parentState, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	parentBlock, err := util.GenerateFullBlock(parentState, privs, util.DefaultBlockGenConfig(), parentState.Slot())
+	require.NoError(t, err)
+	parentSbb, err := blocks.NewSignedBeaconBlock(parentBlock)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, parentState.Copy(), parentSbb)
+	require.NoError(t, err)
+	parentRoot, err := parentSbb.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	valid, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), st.Slot()+1)
+	require.NoError(t, err)
+	validSbb, err := blocks.NewSignedBeaconBlock(valid)
+	require.NoError(t, err)
+	invalid := eth.CopySignedBeaconBlock(valid)
+	invalid.Block.StateRoot = bytesutil.PadTo([]byte("invalid"), 32)
+	// The equivocating block is valid, but its proposer has already proposed another block at its slot.
+	equivocating := eth.CopySignedBeaconBlock(valid)
+	equivocating.Block.Body.Graffiti = bytesutil.PadTo([]byte("equivocating"), 32)
+	sig, err := util.BlockSignature(st, equivocating.Block, privs)
+	require.NoError(t, err)
+	equivocating.Signature = sig.Marshal()
+
+	publishers := map[string]func(s *Server) http.HandlerFunc{
+		"blocks":         func(s *Server) http.HandlerFunc { return s.PublishBlockV2 },
+		"blinded_blocks": func(s *Server) http.HandlerFunc { return s.PublishBlindedBlockV2 },
+	}
+	tests := []struct {
+		name       string
+		validation string
+		block      *eth.SignedBeaconBlock
+		published  bool
+		err        string
+	}{
+		{name: "gossip", validation: "gossip", block: invalid, published: true},
+		{name: "consensus", validation: "consensus", block: valid, published: true},
+		{name: "consensus invalid", validation: "consensus", block: invalid, err: "consensus validation failed"},
+		{name: "consensus equivocating", validation: "consensus", block: equivocating, published: true},
+		{name: "consensus and equivocation", validation: "consensus_and_equivocation", block: valid, published: true},
+		{
+			name:       "consensus and equivocation equivocating",
+			validation: "consensus_and_equivocation",
+			block:      equivocating,
+			err:        "equivocation validation failed",
+		},
+		{name: "unknown validation", validation: "foo", block: valid, err: "invalid broadcast_validation value foo"},
+	}
+	for endpoint, publisher := range publishers {
+		for _, tt := range tests {
+			t.Run(endpoint+"/"+tt.name, func(t *testing.T) {
+				beaconDB := dbTest.SetupDB(t)
+				require.NoError(t, beaconDB.SaveBlock(ctx, validSbb))
+				fc := doublylinkedtree.New()
+				fcState, err := transition.ProcessSlots(ctx, st.Copy(), valid.Block.Slot)
+				require.NoError(t, err)
+				require.NoError(t, fc.InsertNode(ctx, fcState, bytesutil.ToBytes32([]byte("received"))))
+
+				ctrl := gomock.NewController(t)
+				v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+				if tt.published {
+					v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), gomock.Any())
+				}
+				server := &Server{
+					BeaconDB:                beaconDB,
+					V1Alpha1ValidatorServer: v1alpha1Server,
+					SyncChecker:             &mockSync.Sync{IsSyncing: false},
+					ForkchoiceFetcher:       &chainMock.ChainService{ForkChoiceStore: fc},
+					Blocker:                 &testutil.MockBlocker{RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{parentRoot: parentSbb}},
+					Stater: &testutil.MockStater{StatesByRoot: map[[32]byte]state.BeaconState{
+						bytesutil.ToBytes32(parentBlock.Block.StateRoot): st.Copy(),
+					}},
+				}
+
+				body, err := json.Marshal(structs.SignedBeaconBlockPhase0FromConsensus(tt.block))
+				require.NoError(t, err)
+				request := httptest.NewRequest(
+					http.MethodPost,
+					"http://foo.example?broadcast_validation="+tt.validation,
+					bytes.NewReader(body),
+				)
+				request.Header.Set(api.VersionHeader, version.String(version.Phase0))
+				writer := httptest.NewRecorder()
+				writer.Body = &bytes.Buffer{}
+				publisher(server)(writer, request)
+				if tt.err == "" {
+					assert.Equal(t, http.StatusOK, writer.Code)
+					return
+				}
+				assert.Equal(t, http.StatusBadRequest, writer.Code)
+				e := &httputil.DefaultJsonError{}
+				require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+				assert.Equal(t, http.StatusBadRequest, e.Code)
+				assert.StringContains(t, tt.err, e.Message)
+			})
+		}
+	}
+}
+
 func TestServer_GetBlockRoot(t *testing.T) {
 	beaconDB := dbTest.SetupDB(t)
 	ctx := context.Background()
@@ -3023,6 +3140,126 @@ func TestGetCommittees(t *testing.T) {
 	})
 }
 
+func TestGetCommittees_HistoricalEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+	ctx := context.Background()
+	url := "http://example.com/eth/v1/beacon/states/{state_id}/committees"
+
+	genesis, keys := util.DeterministicGenesisState(t, 64)
+	cp, err := util.GenerateFinalizedCheckpoint(ctx, genesis, keys)
+	require.NoError(t, err)
+
+	// Only the genesis state and the blocks are saved, as once the hot states of a finalized chain are pruned,
+	// so the states of historical epochs have to be regenerated.
+	beaconDB := dbTest.SetupDB(t)
+	genesisRoot, err := genesis.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesisBlock := coreblocks.NewGenesisBlock(genesisRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesisBlock)
+	genesisBlockRoot, err := genesisBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, genesis, genesisBlockRoot))
+	for _, b := range cp.Blocks {
+		util.SaveBlock(t, ctx, beaconDB, b)
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, cp.Checkpoint))
+	stateGen := stategen.New(beaconDB, doublylinkedtree.New())
+
+	headRoot, err := cp.Blocks[len(cp.Blocks)-1].Block.HashTreeRoot()
+	require.NoError(t, err)
+	headState, err := stateGen.StateByRoot(ctx, headRoot)
+	require.NoError(t, err)
+	historicalEpoch := primitives.Epoch(1)
+	headEpoch := slots.ToEpoch(headState.Slot())
+	require.Equal(t, true, historicalEpoch+1 < headEpoch)
+	startSlot, err := slots.EpochStart(historicalEpoch)
+	require.NoError(t, err)
+
+	chainService := &chainMock.ChainService{State: headState, Root: headRoot[:], FinalizedCheckPoint: cp.Checkpoint}
+	s := &Server{
+		Stater: &lookup.BeaconDbStater{
+			BeaconDB:           beaconDB,
+			ChainInfoFetcher:   chainService,
+			GenesisTimeFetcher: chainService,
+			StateGenService:    stateGen,
+		},
+		StateGenService:       stateGen,
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              beaconDB,
+	}
+	// The seed of the historical epoch is still in the randao mixes of the head state, so the committees
+	// computed from the head state are the expected ones.
+	expectedCommittee := func(t *testing.T, slot primitives.Slot, index primitives.CommitteeIndex) []primitives.ValidatorIndex {
+		committee, err := corehelpers.BeaconCommitteeFromState(ctx, headState, slot, index)
+		require.NoError(t, err)
+		return committee
+	}
+
+	t.Run("Committees of historical epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, url+fmt.Sprintf("?epoch=%d", historicalEpoch), nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetCommittees(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetCommitteesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(resp.Data))
+		for _, datum := range resp.Data {
+			slot, err := strconv.ParseUint(datum.Slot, 10, 64)
+			require.NoError(t, err)
+			index, err := strconv.ParseUint(datum.Index, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, historicalEpoch, slots.ToEpoch(primitives.Slot(slot)))
+			committee := expectedCommittee(t, primitives.Slot(slot), primitives.CommitteeIndex(index))
+			require.Equal(t, len(committee), len(datum.Validators))
+			for i, v := range committee {
+				assert.Equal(t, strconv.FormatUint(uint64(v), 10), datum.Validators[i])
+			}
+		}
+	})
+	t.Run("Historical epoch with slot and index", func(t *testing.T) {
+		slot := startSlot + 3
+		request := httptest.NewRequest(http.MethodGet, url+fmt.Sprintf("?epoch=%d&slot=%d&index=0", historicalEpoch, slot), nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetCommittees(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetCommitteesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, strconv.FormatUint(uint64(slot), 10), resp.Data[0].Slot)
+		assert.Equal(t, "0", resp.Data[0].Index)
+		committee := expectedCommittee(t, slot, 0)
+		require.Equal(t, len(committee), len(resp.Data[0].Validators))
+		for i, v := range committee {
+			assert.Equal(t, strconv.FormatUint(uint64(v), 10), resp.Data[0].Validators[i])
+		}
+	})
+	t.Run("Epoch beyond limit", func(t *testing.T) {
+		s.MaxHistoricalCommitteesEpochs = 1
+		defer func() { s.MaxHistoricalCommitteesEpochs = 0 }()
+		request := httptest.NewRequest(http.MethodGet, url+fmt.Sprintf("?epoch=%d", historicalEpoch), nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetCommittees(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.StringContains(t, fmt.Sprintf("Epoch %d is more than 1 epochs before the state epoch %d", historicalEpoch, headEpoch), e.Message)
+	})
+}
+
 func TestGetBlockHeaders(t *testing.T) {
 	beaconDB := dbTest.SetupDB(t)
 	ctx := context.Background()
@@ -3104,6 +3341,14 @@ func TestGetBlockHeaders(t *testing.T) {
 					b4,
 				},
 			},
+			{
+				name:       "parent root and slot",
+				slot:       "30",
+				parentRoot: hexutil.Encode(b1.Block.ParentRoot),
+				want: []*eth.SignedBeaconBlock{
+					b1,
+				},
+			},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
@@ -3259,6 +3504,69 @@ func TestGetBlockHeaders(t *testing.T) {
 	})
 }
 
+func TestGetBlockHeaders_SiblingBlocks(t *testing.T) {
+	beaconDB := dbTest.SetupDB(t)
+	ctx := context.Background()
+
+	genesis, privs := util.DeterministicGenesisState(t, 64)
+	canonical, canonicalRoot, err := util.GenerateFullBlockWithRoot(genesis, privs, &util.BlockGenConfig{}, 1)
+	require.NoError(t, err)
+	// The sibling equivocates with the canonical block, with the same parent at the same slot.
+	sibling, err := util.MutateAndResignBlock(genesis, privs, canonical, util.MutateGraffiti([32]byte{'f', 'o', 'r', 'k'}))
+	require.NoError(t, err)
+	siblingRoot, err := sibling.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, canonical))
+	require.NoError(t, beaconDB.SaveBlock(ctx, sibling))
+	parentRoot := canonical.Block().ParentRoot()
+
+	mockChainFetcher := &chainMock.ChainService{
+		DB:                  beaconDB,
+		Block:               canonical,
+		Root:                canonicalRoot[:],
+		CanonicalRoots:      map[[32]byte]bool{canonicalRoot: true},
+		FinalizedCheckPoint: &eth.Checkpoint{Root: make([]byte, 32)},
+		FinalizedRoots:      map[[32]byte]bool{},
+	}
+	bs := &Server{
+		BeaconDB:              beaconDB,
+		ChainInfoFetcher:      mockChainFetcher,
+		OptimisticModeFetcher: mockChainFetcher,
+		FinalizationFetcher:   mockChainFetcher,
+	}
+	url := "http://example.com/eth/v1/beacon/headers"
+
+	for _, slot := range []string{"", "1"} {
+		t.Run("slot "+slot, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?slot=%s&parent_root=%s", url, slot, hexutil.Encode(parentRoot[:])), nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			bs.GetBlockHeaders(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &structs.GetBlockHeadersResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			require.Equal(t, 2, len(resp.Data))
+			canonicalByRoot := make(map[string]bool)
+			for _, h := range resp.Data {
+				canonicalByRoot[h.Root] = h.Canonical
+			}
+			require.DeepEqual(t, map[string]bool{
+				hexutil.Encode(canonicalRoot[:]): true,
+				hexutil.Encode(siblingRoot[:]):   false,
+			}, canonicalByRoot)
+		})
+	}
+	t.Run("slot without children", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?slot=2&parent_root=%s", url, hexutil.Encode(parentRoot[:])), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		bs.GetBlockHeaders(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
 func TestServer_GetBlockHeader(t *testing.T) {
 	b := util.NewBeaconBlock()
 	b.Block.Slot = 123
@@ -3487,11 +3795,11 @@ func TestGetFinalityCheckpoints(t *testing.T) {
 		assert.Equal(t, true, resp.ExecutionOptimistic)
 	})
 	t.Run("finalized", func(t *testing.T) {
-		headerRoot, err := fakeState.LatestBlockHeader().HashTreeRoot()
+		blockRoot, err := stateBlockRoot(context.Background(), fakeState)
 		require.NoError(t, err)
 		chainService := &chainMock.ChainService{
 			FinalizedRoots: map[[32]byte]bool{
-				headerRoot: true,
+				blockRoot: true,
 			},
 		}
 		s := &Server{
@@ -3516,6 +3824,138 @@ func TestGetFinalityCheckpoints(t *testing.T) {
 	})
 }
 
+func TestGetFinalityCheckpoints_HistoricalStates(t *testing.T) {
+	ctx := context.Background()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	genesis, privs := util.DeterministicGenesisState(t, 64)
+	fc, err := util.GenerateFinalizedCheckpoint(ctx, genesis, privs)
+	require.NoError(t, err)
+
+	// The replayer regenerates the post-state of the canonical block at every slot of the chain.
+	replayer := mockstategen.NewReplayerBuilder()
+	replayer.SetMockStateForSlot(genesis, 0)
+	genesisRoot, err := stateBlockRoot(ctx, genesis)
+	require.NoError(t, err)
+	finalizedSlot, err := slots.EpochStart(fc.Checkpoint.Epoch)
+	require.NoError(t, err)
+	finalizedRoots := map[[32]byte]bool{genesisRoot: true}
+	statesBySlot := map[primitives.Slot]state.BeaconState{0: genesis}
+	st := genesis.Copy()
+	for _, b := range fc.Blocks {
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		replayer.SetMockStateForSlot(st.Copy(), b.Block.Slot)
+		statesBySlot[b.Block.Slot] = st.Copy()
+		if b.Block.Slot <= finalizedSlot {
+			root, err := b.Block.HashTreeRoot()
+			require.NoError(t, err)
+			finalizedRoots[root] = true
+		}
+	}
+	head := st
+	headSlot := head.Slot()
+	chainService := &chainMock.ChainService{
+		State:                      head,
+		Slot:                       &headSlot,
+		FinalizedCheckPoint:        head.FinalizedCheckpoint(),
+		CurrentJustifiedCheckPoint: head.CurrentJustifiedCheckpoint(),
+		FinalizedRoots:             finalizedRoots,
+	}
+	s := &Server{
+		Stater: &lookup.BeaconDbStater{
+			ChainInfoFetcher:   chainService,
+			GenesisTimeFetcher: chainService,
+			ReplayerBuilder:    replayer,
+		},
+		HeadFetcher:           chainService,
+		ChainInfoFetcher:      chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	justifiedSlot, err := slots.EpochStart(head.CurrentJustifiedCheckpoint().Epoch)
+	require.NoError(t, err)
+	zero := &eth.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	tests := []struct {
+		stateId   string
+		st        state.BeaconState
+		finalized bool
+	}{
+		{stateId: "genesis", st: genesis, finalized: true},
+		{stateId: "1", st: statesBySlot[1], finalized: true},
+		{stateId: strconv.FormatUint(uint64(params.BeaconConfig().SlotsPerEpoch), 10), st: statesBySlot[params.BeaconConfig().SlotsPerEpoch], finalized: true},
+		{stateId: "finalized", st: statesBySlot[finalizedSlot], finalized: true},
+		{stateId: strconv.FormatUint(uint64(finalizedSlot)+1, 10), st: statesBySlot[finalizedSlot+1], finalized: false},
+		{stateId: "justified", st: statesBySlot[justifiedSlot], finalized: justifiedSlot <= finalizedSlot},
+		{stateId: strconv.FormatUint(uint64(headSlot), 10), st: head, finalized: false},
+		{stateId: "head", st: head, finalized: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stateId, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/states/{state_id}/finality_checkpoints", nil)
+			request = mux.SetURLVars(request, map[string]string{"state_id": tt.stateId})
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetFinalityCheckpoints(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &structs.GetFinalityCheckpointsResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			require.NotNil(t, resp.Data)
+			assert.DeepEqual(t, structs.CheckpointFromConsensus(tt.st.PreviousJustifiedCheckpoint()), resp.Data.PreviousJustified)
+			assert.DeepEqual(t, structs.CheckpointFromConsensus(tt.st.CurrentJustifiedCheckpoint()), resp.Data.CurrentJustified)
+			assert.DeepEqual(t, structs.CheckpointFromConsensus(tt.st.FinalizedCheckpoint()), resp.Data.Finalized)
+			assert.Equal(t, tt.finalized, resp.Finalized)
+			assert.Equal(t, false, resp.ExecutionOptimistic)
+		})
+	}
+
+	t.Run("pre-finality state", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/states/{state_id}/finality_checkpoints", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetFinalityCheckpoints(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetFinalityCheckpointsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.DeepEqual(t, structs.CheckpointFromConsensus(zero), resp.Data.Finalized)
+		assert.DeepEqual(t, structs.CheckpointFromConsensus(zero), resp.Data.CurrentJustified)
+		assert.DeepEqual(t, structs.CheckpointFromConsensus(zero), resp.Data.PreviousJustified)
+	})
+	t.Run("unset checkpoints", func(t *testing.T) {
+		st, err := util.NewBeaconState(func(st *eth.BeaconState) error {
+			st.FinalizedCheckpoint = nil
+			st.CurrentJustifiedCheckpoint = &eth.Checkpoint{}
+			return nil
+		})
+		require.NoError(t, err)
+		s := &Server{
+			Stater:                &testutil.MockStater{BeaconState: st},
+			HeadFetcher:           chainService,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+		}
+		request := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/states/{state_id}/finality_checkpoints", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetFinalityCheckpoints(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetFinalityCheckpointsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.DeepEqual(t, structs.CheckpointFromConsensus(zero), resp.Data.Finalized)
+		assert.DeepEqual(t, structs.CheckpointFromConsensus(zero), resp.Data.CurrentJustified)
+	})
+}
+
 func TestGetGenesis(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	config := params.BeaconConfig().Copy()
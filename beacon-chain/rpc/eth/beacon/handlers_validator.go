@@ -10,11 +10,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api/pagination"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
-	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
@@ -65,17 +66,18 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var statuses []string
+	var rawStatuses []string
 	var rawIds []string
 	if r.Method == http.MethodGet {
 		rawIds = r.URL.Query()["id"]
-		statuses = r.URL.Query()["status"]
+		rawStatuses = r.URL.Query()["status"]
 	} else {
 		rawIds = req.Ids
-		statuses = req.Statuses
+		rawStatuses = req.Statuses
 	}
-	for i, ss := range statuses {
-		statuses[i] = strings.ToLower(ss)
+	statusFilter, ok := subStatusFilter(w, rawStatuses)
+	if !ok {
+		return
 	}
 
 	ids, ok := decodeIds(w, st, rawIds, true /* ignore unknown */)
@@ -93,86 +95,98 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	readOnlyVals, ok := valsFromIds(w, st, ids)
-	if !ok {
-		return
-	}
+	// Validators are classified and filtered in a single pass, and only the matching ones are
+	// converted to containers along with their balance.
 	epoch := slots.ToEpoch(st.Slot())
-
-	// Exit early if no matching validators were found or we don't want to further filter validators by status.
-	if len(readOnlyVals) == 0 || len(statuses) == 0 {
-		containers := make([]*structs.ValidatorContainer, len(readOnlyVals))
-		for i, val := range readOnlyVals {
-			valStatus, err := helpers.ValidatorSubStatus(val, epoch)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
+	var matches []validatorMatch
+	match := func(id primitives.ValidatorIndex, val state.ReadOnlyValidator) error {
+		valSubStatus, err := helpers.ValidatorSubStatus(val, epoch)
+		if err != nil {
+			return err
+		}
+		if statusFilter == nil || statusFilter[valSubStatus] {
+			matches = append(matches, validatorMatch{id: id, val: val, status: valSubStatus})
+		}
+		return nil
+	}
+	if len(ids) == 0 {
+		if statusFilter == nil {
+			matches = make([]validatorMatch, 0, st.NumValidators())
+		}
+		err = st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+			return match(primitives.ValidatorIndex(idx), val)
+		})
+	} else {
+		matches = make([]validatorMatch, 0, len(ids))
+		for _, id := range ids {
+			val, valErr := st.ValidatorAtIndexReadOnly(id)
+			if valErr != nil {
+				httputil.HandleError(w, fmt.Sprintf("Could not get validator at index %d: %s", id, valErr.Error()), http.StatusInternalServerError)
 				return
 			}
-			id := primitives.ValidatorIndex(i)
-			if len(ids) > 0 {
-				id = ids[i]
+			if err = match(id, val); err != nil {
+				break
 			}
-			balance, err := st.BalanceAtIndex(id)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			containers[i] = valContainerFromReadOnlyVal(val, id, balance, valStatus)
 		}
-		resp := &structs.GetValidatorsResponse{
-			Data:                containers,
-			ExecutionOptimistic: isOptimistic,
-			Finalized:           isFinalized,
-		}
-		httputil.WriteJson(w, resp)
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	filteredStatuses := make(map[validator.Status]bool, len(statuses))
-	for _, ss := range statuses {
-		ok, vs := validator.StatusFromString(ss)
-		if !ok {
-			httputil.HandleError(w, "Invalid status "+ss, http.StatusBadRequest)
-			return
-		}
-		filteredStatuses[vs] = true
-	}
-	valContainers := make([]*structs.ValidatorContainer, 0, len(readOnlyVals))
-	for i, val := range readOnlyVals {
-		valStatus, err := helpers.ValidatorStatus(val, epoch)
-		if err != nil {
-			httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		valSubStatus, err := helpers.ValidatorSubStatus(val, epoch)
+	containers := make([]*structs.ValidatorContainer, len(matches))
+	for i, m := range matches {
+		balance, err := st.BalanceAtIndex(m.id)
 		if err != nil {
-			httputil.HandleError(w, "Could not get validator status: "+err.Error(), http.StatusInternalServerError)
+			httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
-		if filteredStatuses[valStatus] || filteredStatuses[valSubStatus] {
-			var container *structs.ValidatorContainer
-			id := primitives.ValidatorIndex(i)
-			if len(ids) > 0 {
-				id = ids[i]
-			}
-			balance, err := st.BalanceAtIndex(id)
-			if err != nil {
-				httputil.HandleError(w, "Could not get validator balance: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			container = valContainerFromReadOnlyVal(val, id, balance, valSubStatus)
-			valContainers = append(valContainers, container)
-		}
+		containers[i] = valContainerFromReadOnlyVal(m.val, m.id, balance, m.status)
 	}
-
 	resp := &structs.GetValidatorsResponse{
-		Data:                valContainers,
+		Data:                containers,
 		ExecutionOptimistic: isOptimistic,
 		Finalized:           isFinalized,
 	}
 	httputil.WriteJson(w, resp)
 }
 
+// validatorMatch is a validator matching the filters of a validators request, along with its index
+// and sub-status.
+type validatorMatch struct {
+	id     primitives.ValidatorIndex
+	val    state.ReadOnlyValidator
+	status validator.Status
+}
+
+// subStatusFilter returns the set of sub-statuses matching the requested statuses, or nil when no
+// status is requested. Each requested status can be a comma-separated list of statuses, and a
+// top-level status matches all of its sub-statuses, so that validators can be filtered by their
+// sub-status alone.
+func subStatusFilter(w http.ResponseWriter, rawStatuses []string) (map[validator.Status]bool, bool) {
+	var filter map[validator.Status]bool
+	for _, raw := range rawStatuses {
+		for _, ss := range strings.Split(raw, ",") {
+			ss = strings.ToLower(strings.TrimSpace(ss))
+			if ss == "" {
+				continue
+			}
+			ok, vs := validator.StatusFromString(ss)
+			if !ok {
+				httputil.HandleError(w, "Invalid status "+ss, http.StatusBadRequest)
+				return nil, false
+			}
+			if filter == nil {
+				filter = make(map[validator.Status]bool)
+			}
+			for _, sub := range helpers.SubStatuses(vs) {
+				filter[sub] = true
+			}
+		}
+	}
+	return filter, true
+}
+
 // GetValidator returns a validator specified by state and id or public key along with status and balance.
 func (s *Server) GetValidator(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetValidator")
@@ -238,7 +252,9 @@ func (s *Server) GetValidator(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
-// GetValidatorBalances returns a filterable list of validator balances.
+// GetValidatorBalances returns a filterable list of validator balances. Validators can be requested
+// by index or public key, and unknown ones are omitted. The balances of all validators can be paged
+// through with the page_token and page_size query parameters.
 func (s *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetValidatorBalances")
 	defer span.End()
@@ -296,34 +312,119 @@ func (s *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bals := st.Balances()
-	var valBalances []*structs.ValidatorBalance
 	if len(ids) == 0 {
-		valBalances = make([]*structs.ValidatorBalance, len(bals))
-		for i, b := range bals {
-			valBalances[i] = &structs.ValidatorBalance{
-				Index:   strconv.FormatUint(uint64(i), 10),
-				Balance: strconv.FormatUint(b, 10),
-			}
+		query := r.URL.Query()
+		if query.Get("page_token") != "" || query.Get("page_size") != "" {
+			writeValidatorBalancesPage(w, r, st, isOptimistic, isFinalized)
+			return
 		}
-	} else {
-		valBalances = make([]*structs.ValidatorBalance, len(ids))
-		for i, id := range ids {
-			valBalances[i] = &structs.ValidatorBalance{
-				Index:   strconv.FormatUint(uint64(id), 10),
-				Balance: strconv.FormatUint(bals[id], 10),
-			}
+		httputil.WriteJson(w, &structs.GetValidatorBalancesResponse{
+			Data:                allBalances(st),
+			ExecutionOptimistic: isOptimistic,
+			Finalized:           isFinalized,
+		})
+		return
+	}
+	valBalances, ok := balancesFromIds(w, st, ids)
+	if !ok {
+		return
+	}
+
+	resp := &structs.GetValidatorBalancesResponse{
+		Data:                valBalances,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// writeValidatorBalancesPage writes the page of balances of all validators requested with the
+// page_token and page_size query parameters. Requesting all balances of a large registry at once
+// results in a response of hundreds of megabytes, which paging allows to avoid.
+func writeValidatorBalancesPage(w http.ResponseWriter, r *http.Request, st state.BeaconState, isOptimistic, isFinalized bool) {
+	query := r.URL.Query()
+	var pageSize uint64
+	if rawPageSize := query.Get("page_size"); rawPageSize != "" {
+		var ok bool
+		pageSize, ok = shared.ValidateUint(w, "page_size", rawPageSize)
+		if !ok {
+			return
 		}
+		if pageSize > uint64(cmd.Get().MaxRPCPageSize) {
+			httputil.HandleError(
+				w,
+				fmt.Sprintf("Requested page size %d can not be greater than max size %d", pageSize, cmd.Get().MaxRPCPageSize),
+				http.StatusBadRequest,
+			)
+			return
+		}
+	}
+	totalSize := st.BalancesLength()
+	if totalSize == 0 {
+		httputil.WriteJson(w, &structs.GetValidatorBalancesResponse{
+			Data:                []*structs.ValidatorBalance{},
+			ExecutionOptimistic: isOptimistic,
+			Finalized:           isFinalized,
+			TotalSize:           "0",
+		})
+		return
+	}
+	start, end, nextPageToken, err := pagination.StartAndEndPage(query.Get("page_token"), int(pageSize), totalSize) // lint:ignore uintcast -- page size is capped above.
+	if err != nil {
+		httputil.HandleError(w, "Could not paginate results: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	ids := make([]primitives.ValidatorIndex, end-start)
+	for i := range ids {
+		ids[i] = primitives.ValidatorIndex(start + i)
+	}
+	valBalances, ok := balancesFromIds(w, st, ids)
+	if !ok {
+		return
 	}
 
 	resp := &structs.GetValidatorBalancesResponse{
 		Data:                valBalances,
 		ExecutionOptimistic: isOptimistic,
 		Finalized:           isFinalized,
+		NextPageToken:       nextPageToken,
+		TotalSize:           strconv.Itoa(totalSize),
 	}
 	httputil.WriteJson(w, resp)
 }
 
+// balancesFromIds returns the balances of the supplied validator indices, without copying
+// the balances of the whole registry.
+func balancesFromIds(w http.ResponseWriter, st state.BeaconState, ids []primitives.ValidatorIndex) ([]*structs.ValidatorBalance, bool) {
+	valBalances := make([]*structs.ValidatorBalance, len(ids))
+	for i, id := range ids {
+		bal, err := st.BalanceAtIndex(id)
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Could not get balance at index %d: %s", id, err.Error()), http.StatusInternalServerError)
+			return nil, false
+		}
+		valBalances[i] = &structs.ValidatorBalance{
+			Index:   strconv.FormatUint(uint64(id), 10),
+			Balance: strconv.FormatUint(bal, 10),
+		}
+	}
+	return valBalances, true
+}
+
+// allBalances returns the balances of all validators. The balances are read at once, as reading them
+// one index at a time would take the state's lock for every validator of the registry.
+func allBalances(st state.BeaconState) []*structs.ValidatorBalance {
+	bals := st.Balances()
+	valBalances := make([]*structs.ValidatorBalance, len(bals))
+	for i, bal := range bals {
+		valBalances[i] = &structs.ValidatorBalance{
+			Index:   strconv.FormatUint(uint64(i), 10),
+			Balance: strconv.FormatUint(bal, 10),
+		}
+	}
+	return valBalances
+}
+
 // decodeIds takes in a list of validator ID strings (as either a pubkey or a validator index)
 // and returns the corresponding validator indices. It can be configured to ignore well-formed but unknown indices.
 func decodeIds(w http.ResponseWriter, st state.BeaconState, rawIds []string, ignoreUnknown bool) ([]primitives.ValidatorIndex, bool) {
@@ -373,18 +474,12 @@ func valsFromIds(w http.ResponseWriter, st state.BeaconState, ids []primitives.V
 	} else {
 		vals = make([]state.ReadOnlyValidator, 0, len(ids))
 		for _, id := range ids {
-			val, err := st.ValidatorAtIndex(id)
+			val, err := st.ValidatorAtIndexReadOnly(id)
 			if err != nil {
 				httputil.HandleError(w, fmt.Sprintf("Could not get validator at index %d: %s", id, err.Error()), http.StatusInternalServerError)
 				return nil, false
 			}
-
-			readOnlyVal, err := statenative.NewValidator(val)
-			if err != nil {
-				httputil.HandleError(w, "Could not convert validator: "+err.Error(), http.StatusInternalServerError)
-				return nil, false
-			}
-			vals = append(vals, readOnlyVal)
+			vals = append(vals, val)
 		}
 	}
 
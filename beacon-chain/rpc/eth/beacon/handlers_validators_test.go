@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -638,6 +640,148 @@ func TestGetValidators_FilterByStatus(t *testing.T) {
 	})
 }
 
+func TestGetValidators_StatusBoundaries(t *testing.T) {
+	const epoch = primitives.Epoch(10)
+	var st state.BeaconState
+	st, _ = util.DeterministicGenesisState(t, 1)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch))))
+
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	maxEB := params.BeaconConfig().MaxEffectiveBalance
+	// Each validator sits right at the epoch threshold separating its sub-status from a neighbouring one.
+	validators := []*eth.Validator{
+		// Pending initialized: not yet eligible for activation.
+		{ActivationEligibilityEpoch: farFutureEpoch, ActivationEpoch: farFutureEpoch, ExitEpoch: farFutureEpoch, WithdrawableEpoch: farFutureEpoch},
+		// Pending queued: eligible, activated at the next epoch.
+		{ActivationEligibilityEpoch: epoch, ActivationEpoch: epoch + 1, ExitEpoch: farFutureEpoch, WithdrawableEpoch: farFutureEpoch},
+		// Active ongoing: activated at the epoch.
+		{ActivationEpoch: epoch, ExitEpoch: farFutureEpoch, WithdrawableEpoch: farFutureEpoch, EffectiveBalance: maxEB},
+		// Active exiting: exits at the next epoch.
+		{ActivationEpoch: 0, ExitEpoch: epoch + 1, WithdrawableEpoch: epoch + 2, EffectiveBalance: maxEB},
+		// Active slashed: exits at the next epoch.
+		{ActivationEpoch: 0, ExitEpoch: epoch + 1, WithdrawableEpoch: epoch + 2, EffectiveBalance: maxEB, Slashed: true},
+		// Exited unslashed: exited at the epoch.
+		{ActivationEpoch: 0, ExitEpoch: epoch, WithdrawableEpoch: epoch + 1, EffectiveBalance: maxEB},
+		// Exited slashed: exited at the epoch.
+		{ActivationEpoch: 0, ExitEpoch: epoch, WithdrawableEpoch: epoch + 1, EffectiveBalance: maxEB, Slashed: true},
+		// Withdrawal possible: withdrawable at the epoch.
+		{ActivationEpoch: 0, ExitEpoch: epoch - 1, WithdrawableEpoch: epoch, EffectiveBalance: maxEB},
+		// Withdrawal done: withdrawable at the epoch, with no balance left.
+		{ActivationEpoch: 0, ExitEpoch: epoch - 1, WithdrawableEpoch: epoch, EffectiveBalance: 0},
+	}
+	for _, val := range validators {
+		require.NoError(t, st.AppendValidator(val))
+		require.NoError(t, st.AppendBalance(val.EffectiveBalance))
+	}
+	// The genesis validator is at index 0.
+	chainService := &chainMock.ChainService{}
+	s := Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+	getValidators := func(t *testing.T, query string) *structs.GetValidatorsResponse {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/validators?"+query, nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidators(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	subStatuses := []string{
+		"pending_initialized",
+		"pending_queued",
+		"active_ongoing",
+		"active_exiting",
+		"active_slashed",
+		"exited_unslashed",
+		"exited_slashed",
+		"withdrawal_possible",
+		"withdrawal_done",
+	}
+	for i, status := range subStatuses {
+		t.Run(status, func(t *testing.T) {
+			resp := getValidators(t, "status="+status)
+			expected := []string{strconv.Itoa(i + 1)}
+			if status == "active_ongoing" {
+				// The genesis validator is active as well.
+				expected = []string{"0", expected[0]}
+			}
+			require.Equal(t, len(expected), len(resp.Data))
+			for j, vc := range resp.Data {
+				assert.Equal(t, expected[j], vc.Index)
+				assert.Equal(t, status, vc.Status)
+			}
+		})
+	}
+	t.Run("comma-separated statuses", func(t *testing.T) {
+		resp := getValidators(t, "status=pending_queued,exited_slashed&status=WITHDRAWAL_DONE")
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, "pending_queued", resp.Data[0].Status)
+		assert.Equal(t, "exited_slashed", resp.Data[1].Status)
+		assert.Equal(t, "withdrawal_done", resp.Data[2].Status)
+	})
+	t.Run("status and ids", func(t *testing.T) {
+		resp := getValidators(t, "status=exited&id=1&id=6&id=7")
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "6", resp.Data[0].Index)
+		assert.Equal(t, "7", resp.Data[1].Index)
+	})
+	t.Run("invalid status", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/validators?status=active,foo", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidators(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid status foo", e.Message)
+	})
+}
+
+func BenchmarkGetValidators_FilterByStatus(b *testing.B) {
+	// A mainnet-sized registry, with a small share of the validators queued for activation.
+	st, err := util.NewBeaconStateWithActiveValidators(1_000_000, 1_000)
+	require.NoError(b, err)
+	chainService := &chainMock.ChainService{}
+	s := Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	for _, query := range []string{"status=pending_queued", "status=pending,exited", "status=active"} {
+		b.Run(query, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/validators?"+query, nil)
+				request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+				writer := httptest.NewRecorder()
+				writer.Body = &bytes.Buffer{}
+
+				s.GetValidators(writer, request)
+				if writer.Code != http.StatusOK {
+					b.Fatalf("unexpected status code %d", writer.Code)
+				}
+			}
+		})
+	}
+}
+
 func TestGetValidator(t *testing.T) {
 	var st state.BeaconState
 	st, _ = util.DeterministicGenesisState(t, 2)
@@ -1178,3 +1322,119 @@ func TestGetValidatorBalances(t *testing.T) {
 		assert.StringContains(t, "Could not decode request body", e.Message)
 	})
 }
+
+func TestGetValidatorBalances_LargeRegistry(t *testing.T) {
+	const count = 10000
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	vals := make([]*eth.Validator, count)
+	balances := make([]uint64, count)
+	for i := range vals {
+		var pubkey [fieldparams.BLSPubkeyLength]byte
+		binary.LittleEndian.PutUint64(pubkey[:], uint64(i))
+		vals[i] = &eth.Validator{
+			PublicKey:             pubkey[:],
+			WithdrawalCredentials: make([]byte, 32),
+		}
+		balances[i] = uint64(i) * 10
+	}
+	require.NoError(t, st.SetValidators(vals))
+	require.NoError(t, st.SetBalances(balances))
+
+	chainService := &chainMock.ChainService{}
+	s := Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+	getBalances := func(t *testing.T, query string) (int, *structs.GetValidatorBalancesResponse, *httputil.DefaultJsonError) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/validator_balances"+query, nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidatorBalances(writer, request)
+		if writer.Code != http.StatusOK {
+			e := &httputil.DefaultJsonError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			return writer.Code, nil, e
+		}
+		resp := &structs.GetValidatorBalancesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return writer.Code, resp, nil
+	}
+
+	t.Run("filter by mixed ids", func(t *testing.T) {
+		unknownPubkey := make([]byte, fieldparams.BLSPubkeyLength)
+		unknownPubkey[fieldparams.BLSPubkeyLength-1] = 1
+		code, resp, _ := getBalances(t, fmt.Sprintf(
+			"?id=%s&id=17&id=%s&id=%d",
+			hexutil.Encode(vals[9876].PublicKey),
+			hexutil.Encode(unknownPubkey),
+			count,
+		))
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "9876", resp.Data[0].Index)
+		assert.Equal(t, "98760", resp.Data[0].Balance)
+		assert.Equal(t, "17", resp.Data[1].Index)
+		assert.Equal(t, "170", resp.Data[1].Balance)
+		assert.Equal(t, "", resp.NextPageToken)
+		assert.Equal(t, "", resp.TotalSize)
+	})
+	t.Run("unpaged", func(t *testing.T) {
+		code, resp, _ := getBalances(t, "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, count, len(resp.Data))
+		assert.Equal(t, "", resp.NextPageToken)
+		assert.Equal(t, "", resp.TotalSize)
+	})
+	t.Run("paged", func(t *testing.T) {
+		pageSize := 200
+		var indices []string
+		token := "0"
+		for pages := 0; token != ""; pages++ {
+			require.Equal(t, true, pages < count/pageSize, "too many pages")
+			code, resp, _ := getBalances(t, fmt.Sprintf("?page_size=%d&page_token=%s", pageSize, token))
+			require.Equal(t, http.StatusOK, code)
+			assert.Equal(t, strconv.Itoa(count), resp.TotalSize)
+			for _, b := range resp.Data {
+				indices = append(indices, b.Index)
+				i, err := strconv.Atoi(b.Index)
+				require.NoError(t, err)
+				assert.Equal(t, strconv.Itoa(i*10), b.Balance)
+			}
+			token = resp.NextPageToken
+		}
+		require.Equal(t, count, len(indices))
+		for i, idx := range indices {
+			require.Equal(t, strconv.Itoa(i), idx)
+		}
+	})
+	t.Run("default page size", func(t *testing.T) {
+		code, resp, _ := getBalances(t, "?page_token=1")
+		require.Equal(t, http.StatusOK, code)
+		pageSize := params.BeaconConfig().DefaultPageSize
+		require.Equal(t, pageSize, len(resp.Data))
+		assert.Equal(t, strconv.Itoa(pageSize), resp.Data[0].Index)
+		assert.Equal(t, "2", resp.NextPageToken)
+	})
+	t.Run("page size too large", func(t *testing.T) {
+		code, _, e := getBalances(t, fmt.Sprintf("?page_size=%d", cmd.Get().MaxRPCPageSize+1))
+		require.Equal(t, http.StatusBadRequest, code)
+		assert.StringContains(t, "can not be greater than max size", e.Message)
+	})
+	t.Run("page out of range", func(t *testing.T) {
+		code, _, e := getBalances(t, "?page_size=200&page_token=50")
+		require.Equal(t, http.StatusBadRequest, code)
+		assert.StringContains(t, "Could not paginate results", e.Message)
+	})
+	t.Run("invalid page size", func(t *testing.T) {
+		code, _, e := getBalances(t, "?page_size=foo")
+		require.Equal(t, http.StatusBadRequest, code)
+		assert.StringContains(t, "page_size is invalid", e.Message)
+	})
+}
@@ -8,8 +8,10 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	field_params "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -35,6 +37,10 @@ func (s *Server) Blobs(w http.ResponseWriter, r *http.Request) {
 		code := core.ErrorReasonToHTTP(rpcErr.Reason)
 		switch code {
 		case http.StatusBadRequest:
+			if errors.Is(rpcErr.Err, lookup.ErrBlobIndexOutOfRange) {
+				httputil.HandleError(w, "Invalid blob indices: "+rpcErr.Err.Error(), code)
+				return
+			}
 			httputil.HandleError(w, "Invalid block ID: "+rpcErr.Err.Error(), code)
 			return
 		case http.StatusNotFound:
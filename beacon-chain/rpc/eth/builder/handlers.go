@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -34,6 +35,13 @@ func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
 		httputil.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
 		return
 	}
+	if st.Version() < version.Capella {
+		httputil.WriteError(w, &httputil.DefaultJsonError{
+			Message: fmt.Sprintf("expected withdrawals are not supported for %s states", version.String(st.Version())),
+			Code:    http.StatusNotImplemented,
+		})
+		return
+	}
 	queryParam := r.URL.Query().Get("proposal_slot")
 	var proposalSlot primitives.Slot
 	if queryParam != "" {
@@ -66,7 +74,7 @@ func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	lookAheadLimit := uint64(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().MaxSeedLookahead)))
+	lookAheadLimit := uint64(s.proposalSlotLookahead())
 	if st.Slot().Add(lookAheadLimit) <= proposalSlot {
 		httputil.WriteError(w, &httputil.DefaultJsonError{
 			Message: fmt.Sprintf("proposal slot cannot be >= %d slots ahead of state slot", lookAheadLimit),
@@ -87,8 +95,8 @@ func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
 	}
 	var blockRoot = [32]byte(root)
 	isFinalized := s.FinalizationFetcher.IsFinalized(r.Context(), blockRoot)
-	// Advance state forward to proposal slot
-	st, err = transition.ProcessSlots(r.Context(), st, proposalSlot)
+	// Advance a copy of the state forward to proposal slot
+	st, err = transition.ProcessSlots(r.Context(), st.Copy(), proposalSlot)
 	if err != nil {
 		httputil.WriteError(w, &httputil.DefaultJsonError{
 			Message: "could not process slots",
@@ -111,6 +119,15 @@ func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// proposalSlotLookahead returns how many slots ahead of the state the proposal slot of expected withdrawals
+// may be, defaulting to MAX_SEED_LOOKAHEAD epochs.
+func (s *Server) proposalSlotLookahead() primitives.Slot {
+	if s.ProposalSlotLookahead != 0 {
+		return s.ProposalSlotLookahead
+	}
+	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().MaxSeedLookahead))
+}
+
 func buildExpectedWithdrawalsData(withdrawals []*enginev1.Withdrawal) []*structs.ExpectedWithdrawal {
 	data := make([]*structs.ExpectedWithdrawal, len(withdrawals))
 	for i, withdrawal := range withdrawals {
@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -209,3 +210,130 @@ func TestExpectedWithdrawals(t *testing.T) {
 		require.DeepEqual(t, expectedWithdrawal3, resp.Data[2])
 	})
 }
+
+func TestExpectedWithdrawals_PreCapella(t *testing.T) {
+	st, err := util.NewBeaconStateBellatrix()
+	require.NoError(t, err)
+	mockChainService := &mock.ChainService{}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+	request := httptest.NewRequest("GET", "/eth/v1/builder/states/{state_id}/expected_withdrawals", nil)
+	request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.ExpectedWithdrawals(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+	e := &httputil.DefaultJsonError{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.Equal(t, http.StatusNotImplemented, e.Code)
+	assert.StringContains(t, "expected withdrawals are not supported for bellatrix states", e.Message)
+}
+
+func TestExpectedWithdrawals_ProposalSlotLookahead(t *testing.T) {
+	st, err := util.NewBeaconStateCapella()
+	require.NoError(t, err)
+	capellaSlot, err := slots.EpochStart(params.BeaconConfig().CapellaForkEpoch)
+	require.NoError(t, err)
+	currentSlot := capellaSlot + 5000
+	require.NoError(t, st.SetSlot(currentSlot))
+	mockChainService := &mock.ChainService{}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+		ProposalSlotLookahead: 8,
+	}
+	request := httptest.NewRequest(
+		"GET", "/eth/v1/builder/states/{state_id}/expected_withdrawals?proposal_slot="+
+			strconv.FormatUint(uint64(currentSlot+8), 10), nil)
+	request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.ExpectedWithdrawals(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	e := &httputil.DefaultJsonError{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.StringContains(t, "proposal slot cannot be >= 8 slots ahead of state slot", e.Message)
+}
+
+func TestExpectedWithdrawals_SweepPosition(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxValidatorsPerWithdrawalsSweep = 16
+	params.OverrideBeaconConfig(cfg)
+
+	st, err := util.NewBeaconStateCapella()
+	require.NoError(t, err)
+	capellaSlot, err := slots.EpochStart(params.BeaconConfig().CapellaForkEpoch)
+	require.NoError(t, err)
+	currentSlot := capellaSlot + 5000
+	require.NoError(t, st.SetSlot(currentSlot))
+
+	valCount := 17
+	validators := make([]*eth.Validator, 0, valCount)
+	balances := make([]uint64, 0, valCount)
+	for i := 0; i < valCount; i++ {
+		blsKey, err := bls.RandKey()
+		require.NoError(t, err)
+		val := &eth.Validator{
+			PublicKey:             blsKey.PublicKey().Marshal(),
+			WithdrawalCredentials: make([]byte, 32),
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+		}
+		val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+		validators = append(validators, val)
+		balances = append(balances, params.BeaconConfig().MaxEffectiveBalance)
+	}
+	// Partially withdrawable, before the start of the sweep
+	balances[2] += params.BeaconConfig().MinDepositAmount
+	// Partially withdrawable, at the start of the sweep
+	balances[16] += params.BeaconConfig().MinDepositAmount
+	// Partially withdrawable, after the end of the sweep
+	balances[15] += params.BeaconConfig().MinDepositAmount
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+
+	// The previous payload had a single withdrawal, so the sweep moves forward by a full bound and
+	// wraps around to start at validator 16.
+	require.NoError(t, util.AdvanceWithdrawalIndices(st, []*enginev1.Withdrawal{{Index: 4, ValidatorIndex: 3}}))
+
+	mockChainService := &mock.ChainService{}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+	request := httptest.NewRequest(
+		"GET", "/eth/v1/builder/states/{state_id}/expected_withdrawals?proposal_slot="+
+			strconv.FormatUint(uint64(currentSlot+1), 10), nil)
+	request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.ExpectedWithdrawals(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.ExpectedWithdrawalsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.DeepEqual(t, &structs.ExpectedWithdrawal{
+		Index:          "5",
+		ValidatorIndex: "16",
+		Address:        hexutil.Encode(validators[16].WithdrawalCredentials[12:]),
+		Amount:         strconv.FormatUint(params.BeaconConfig().MinDepositAmount, 10),
+	}, resp.Data[0])
+	assert.DeepEqual(t, &structs.ExpectedWithdrawal{
+		Index:          "6",
+		ValidatorIndex: "2",
+		Address:        hexutil.Encode(validators[2].WithdrawalCredentials[12:]),
+		Amount:         strconv.FormatUint(params.BeaconConfig().MinDepositAmount, 10),
+	}, resp.Data[1])
+	// The state returned by the stater is left untouched.
+	assert.Equal(t, currentSlot, st.Slot())
+}
@@ -3,10 +3,14 @@ package builder
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 )
 
 type Server struct {
 	FinalizationFetcher   blockchain.FinalizationFetcher
 	OptimisticModeFetcher blockchain.OptimisticModeFetcher
 	Stater                lookup.Stater
+	// ProposalSlotLookahead is the maximum number of slots between a state and the proposal slot of its
+	// expected withdrawals. MAX_SEED_LOOKAHEAD epochs are used when it is zero.
+	ProposalSlotLookahead primitives.Slot
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"runtime"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
@@ -12,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"go.opencensus.io/trace"
@@ -31,7 +33,7 @@ func (s *Server) GetBeaconStateV2(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if httputil.RespondWithSsz(r) {
-		s.getBeaconStateSSZV2(ctx, w, []byte(stateId))
+		s.getBeaconStateSSZV2(ctx, w, r, []byte(stateId))
 	} else {
 		s.getBeaconStateV2(ctx, w, []byte(stateId))
 	}
@@ -111,19 +113,26 @@ func (s *Server) getBeaconStateV2(ctx context.Context, w http.ResponseWriter, id
 }
 
 // getBeaconStateSSZV2 returns the SSZ-serialized version of the full beacon state object for given state ID.
-func (s *Server) getBeaconStateSSZV2(ctx context.Context, w http.ResponseWriter, id []byte) {
+// The state is streamed to the response, as its serialization is too large to be allocated for every request.
+func (s *Server) getBeaconStateSSZV2(ctx context.Context, w http.ResponseWriter, r *http.Request, id []byte) {
 	st, err := s.Stater.State(ctx, id)
 	if err != nil {
 		shared.WriteStateFetchError(w, err)
 		return
 	}
-	sszState, err := st.MarshalSSZ()
-	if err != nil {
-		httputil.HandleError(w, "Could not marshal state into SSZ: "+err.Error(), http.StatusInternalServerError)
+	// The fetched state may be shared with other goroutines. Its copy shares the fields with it, and a shared field
+	// is copied before it is modified, so the fields of the copy can be serialized without copying them again. The
+	// copy must be kept alive while it is serialized, as its shared references are released once it is collected.
+	cp := st.Copy()
+	defer runtime.KeepAlive(cp)
+	pb := cp.ToProtoUnsafe()
+	m, ok := pb.(ssz.Marshaler)
+	if !ok {
+		httputil.HandleError(w, fmt.Sprintf("Could not marshal state into SSZ: unsupported state type %T", pb), http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set(api.VersionHeader, version.String(st.Version()))
-	httputil.WriteSsz(w, sszState, "beacon_state.ssz")
+	httputil.WriteSszStream(w, r, m, "beacon_state.ssz")
 }
 
 // GetForkChoiceHeadsV2 retrieves the leaves of the current fork choice tree.
@@ -179,6 +188,8 @@ func (s *Server) GetForkChoice(w http.ResponseWriter, r *http.Request) {
 				Balance:                  fmt.Sprintf("%d", n.Balance),
 				ExecutionOptimistic:      n.ExecutionOptimistic,
 				TimeStamp:                fmt.Sprintf("%d", n.Timestamp),
+				Timely:                   n.Timely,
+				TargetRoot:               hexutil.Encode(n.TargetRoot),
 			},
 		}
 	}
@@ -2,10 +2,14 @@ package debug
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -17,7 +21,11 @@ import (
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -365,6 +373,81 @@ func TestGetBeaconStateSSZV2(t *testing.T) {
 	})
 }
 
+func TestGetBeaconStateSSZV2_LargeState(t *testing.T) {
+	fakeState, err := util.NewBeaconStateDeneb()
+	require.NoError(t, err)
+	require.NoError(t, fakeState.SetSlot(123))
+	const numVals = 100_000
+	vals := make([]*ethpb.Validator, numVals)
+	balances := make([]uint64, numVals)
+	for i := range vals {
+		pubkey := make([]byte, fieldparams.BLSPubkeyLength)
+		binary.LittleEndian.PutUint64(pubkey, uint64(i))
+		vals[i] = &ethpb.Validator{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch:     params.BeaconConfig().FarFutureEpoch,
+		}
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance + uint64(i)
+	}
+	require.NoError(t, fakeState.SetValidators(vals))
+	require.NoError(t, fakeState.SetBalances(balances))
+	require.NoError(t, fakeState.SetInactivityScores(make([]uint64, numVals)))
+	require.NoError(t, fakeState.SetPreviousParticipationBits(make([]byte, numVals)))
+	require.NoError(t, fakeState.SetCurrentParticipationBits(make([]byte, numVals)))
+	expectedRoot, err := fakeState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+
+	s := &Server{
+		Stater: &testutil.MockStater{
+			BeaconState: fakeState,
+		},
+	}
+	// roundTrip checks that the streamed bytes decode into a state equal to the requested one.
+	roundTrip := func(t *testing.T, sszState []byte) {
+		pb := &ethpb.BeaconStateDeneb{}
+		require.NoError(t, pb.UnmarshalSSZ(sszState))
+		st, err := state_native.InitializeFromProtoDeneb(pb)
+		require.NoError(t, err)
+		root, err := st.HashTreeRoot(context.Background())
+		require.NoError(t, err)
+		assert.DeepEqual(t, expectedRoot, root)
+	}
+
+	t.Run("uncompressed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v2/debug/beacon/states/{state_id}", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBeaconStateV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, version.String(version.Deneb), writer.Header().Get(api.VersionHeader))
+		assert.Equal(t, strconv.Itoa(writer.Body.Len()), writer.Header().Get("Content-Length"))
+		roundTrip(t, writer.Body.Bytes())
+	})
+	t.Run("gzip", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v2/debug/beacon/states/{state_id}", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		request.Header.Set("Accept-Encoding", "gzip")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBeaconStateV2(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "gzip", writer.Header().Get("Content-Encoding"))
+		gz, err := gzip.NewReader(writer.Body)
+		require.NoError(t, err)
+		sszState, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		roundTrip(t, sszState)
+	})
+}
+
 func TestGetForkChoiceHeadsV2(t *testing.T) {
 	expectedSlotsAndRoots := []struct {
 		Slot string
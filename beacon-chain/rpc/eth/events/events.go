@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	time2 "time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -19,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
@@ -57,6 +59,8 @@ const (
 	LightClientFinalityUpdateTopic = "light_client_finality_update"
 	// LightClientOptimisticUpdateTopic represents a new light client optimistic update event topic.
 	LightClientOptimisticUpdateTopic = "light_client_optimistic_update"
+	// BlockGossipTopic represents a new block that passed gossip validation event topic.
+	BlockGossipTopic = "block_gossip"
 )
 
 const topicDataMismatch = "Event data type %T does not correspond to event topic %s"
@@ -78,6 +82,7 @@ var casesHandled = map[string]bool{
 	AttesterSlashingTopic:            true,
 	LightClientFinalityUpdateTopic:   true,
 	LightClientOptimisticUpdateTopic: true,
+	BlockGossipTopic:                 true,
 }
 
 // StreamEvents provides an endpoint to subscribe to the beacon node Server-Sent-Events stream.
@@ -109,11 +114,27 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Subscribe to event feeds from information received in the beacon node runtime.
-	opsChan := make(chan *feed.Event, chanBuffer)
-	opsSub := s.OperationNotifier.OperationFeed().Subscribe(opsChan)
+	// When events are recorded to the history, the recorded events are streamed instead, and
+	// the state feed is only used for payload attributes, which aren't recorded.
+	liveTopics := topicsMap
+	var opsChan chan *feed.Event
+	var historyChan chan *streamEvent
+	if s.EventHistory != nil {
+		s.startRecording()
+		historyChan = make(chan *streamEvent, chanBuffer)
+		historySub := s.EventHistory.feed.Subscribe(historyChan)
+		defer historySub.Unsubscribe()
+		liveTopics = make(map[string]bool)
+		if topicsMap[PayloadAttributesTopic] {
+			liveTopics[PayloadAttributesTopic] = true
+		}
+	} else {
+		opsChan = make(chan *feed.Event, chanBuffer)
+		opsSub := s.OperationNotifier.OperationFeed().Subscribe(opsChan)
+		defer opsSub.Unsubscribe()
+	}
 	stateChan := make(chan *feed.Event, chanBuffer)
 	stateSub := s.StateNotifier.StateFeed().Subscribe(stateChan)
-	defer opsSub.Unsubscribe()
 	defer stateSub.Unsubscribe()
 
 	// Set up SSE response headers
@@ -129,17 +150,52 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// Send the events missed by a reconnecting client before the live ones, which are subscribed
+	// to beforehand so that none is lost in between.
+	var lastSentID uint64
+	if lastEventID := r.Header.Get(api.LastEventIDHeader); s.EventHistory != nil && lastEventID != "" {
+		id, err := s.replayEvents(w, flusher, topicsMap, lastEventID)
+		if err != nil {
+			httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		lastSentID = id
+	}
 	keepaliveTicker := time2.NewTicker(time2.Duration(params.BeaconConfig().SecondsPerSlot) * time2.Second)
+	// Payload attributes are sent at the start of every slot, in addition to head changes and missed
+	// slots. Slots can't be ticked before the genesis time is known.
+	var slotTicks <-chan primitives.Slot
+	if topicsMap[PayloadAttributesTopic] {
+		if genesisTime := s.ChainInfoFetcher.GenesisTime(); !genesisTime.IsZero() {
+			slotTicker := slots.NewSlotTicker(genesisTime, params.BeaconConfig().SecondsPerSlot)
+			defer slotTicker.Done()
+			slotTicks = slotTicker.C()
+		}
+	}
 
+	sink := &streamSink{w: w, flusher: flusher}
 	for {
 		select {
 		case event := <-opsChan:
-			if err := handleBlockOperationEvents(w, flusher, topicsMap, event); err != nil {
+			if err := handleBlockOperationEvents(sink, topicsMap, event); err != nil {
 				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
 		case event := <-stateChan:
-			if err := s.handleStateEvents(ctx, w, flusher, topicsMap, event); err != nil {
+			if err := s.handleStateEvents(ctx, sink, liveTopics, event); err != nil {
+				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		case e := <-historyChan:
+			if e.id <= lastSentID || !topicsMap[e.topic] {
+				continue
+			}
+			if err := sendStreamEvent(w, flusher, e); err != nil {
+				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		case <-slotTicks:
+			if err := s.sendPayloadAttributes(ctx, sink); err != nil {
 				httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
@@ -154,7 +210,106 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func handleBlockOperationEvents(w http.ResponseWriter, flusher http.Flusher, requestedTopics map[string]bool, event *feed.Event) error {
+// replayEvents sends the recorded events of the topics following the event with the ID lastEventID, and
+// returns the ID of the last event sent. If these events are no longer recorded, a stream_start comment
+// is sent instead so that the client knows to resync.
+func (s *Server) replayEvents(w http.ResponseWriter, flusher http.Flusher, topics map[string]bool, lastEventID string) (uint64, error) {
+	id, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return 0, write(w, flusher, ":stream_start\n\n")
+	}
+	events, ok := s.EventHistory.since(topics, id)
+	if !ok {
+		return 0, write(w, flusher, ":stream_start\n\n")
+	}
+	for _, e := range events {
+		if err := sendStreamEvent(w, flusher, e); err != nil {
+			return 0, err
+		}
+		id = e.id
+	}
+	return id, nil
+}
+
+// startRecording subscribes to the event feeds and records their events to the history, once for the
+// lifetime of the server.
+func (s *Server) startRecording() {
+	s.recordOnce.Do(func() {
+		opsChan := make(chan *feed.Event, chanBuffer)
+		opsSub := s.OperationNotifier.OperationFeed().Subscribe(opsChan)
+		stateChan := make(chan *feed.Event, chanBuffer)
+		stateSub := s.StateNotifier.StateFeed().Subscribe(stateChan)
+		go func() {
+			defer opsSub.Unsubscribe()
+			defer stateSub.Unsubscribe()
+			sink := &historySink{history: s.EventHistory}
+			for {
+				select {
+				case event := <-opsChan:
+					if err := handleBlockOperationEvents(sink, recordedTopics, event); err != nil {
+						log.WithError(err).Debug("Could not record operation event")
+					}
+				case event := <-stateChan:
+					if err := s.handleStateEvents(s.Ctx, sink, recordedTopics, event); err != nil {
+						log.WithError(err).Debug("Could not record state event")
+					}
+				case <-s.Ctx.Done():
+					return
+				}
+			}
+		}()
+	})
+}
+
+// recordedTopics are the topics whose events are recorded to the history. Payload attributes only matter
+// until the proposal slot, so they are sent to live streams only.
+var recordedTopics = func() map[string]bool {
+	topics := make(map[string]bool, len(casesHandled))
+	for topic := range casesHandled {
+		if topic != PayloadAttributesTopic {
+			topics[topic] = true
+		}
+	}
+	return topics
+}()
+
+// eventSink receives the events of the event handlers.
+type eventSink interface {
+	// sendEvent sends the JSON encoded data of an event of the topic.
+	sendEvent(topic string, data []byte) error
+	// sendError reports an event that could not be sent.
+	sendError(format string, a ...any) error
+}
+
+// streamSink writes the events to the response of an event stream.
+type streamSink struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func (s *streamSink) sendEvent(topic string, data []byte) error {
+	return write(s.w, s.flusher, "event: %s\ndata: %s\n\n", topic, string(data))
+}
+
+func (s *streamSink) sendError(format string, a ...any) error {
+	return write(s.w, s.flusher, format, a...)
+}
+
+// historySink records the events to the event history, from which they are sent to the event streams.
+type historySink struct {
+	history *EventHistory
+}
+
+func (s *historySink) sendEvent(topic string, data []byte) error {
+	s.history.add(topic, data)
+	return nil
+}
+
+func (s *historySink) sendError(format string, a ...any) error {
+	return errors.Errorf(format, a...)
+}
+
+func handleBlockOperationEvents(sink eventSink, requestedTopics map[string]bool, event *feed.Event) error {
 	switch event.Type {
 	case operation.AggregatedAttReceived:
 		if _, ok := requestedTopics[AttestationTopic]; !ok {
@@ -162,60 +317,60 @@ func handleBlockOperationEvents(w http.ResponseWriter, flusher http.Flusher, req
 		}
 		attData, ok := event.Data.(*operation.AggregatedAttReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, AttestationTopic)
+			return sink.sendError(topicDataMismatch, event.Data, AttestationTopic)
 		}
 		att := structs.AttFromConsensus(attData.Attestation.Aggregate)
-		return send(w, flusher, AttestationTopic, att)
+		return send(sink, AttestationTopic, att)
 	case operation.UnaggregatedAttReceived:
 		if _, ok := requestedTopics[AttestationTopic]; !ok {
 			return nil
 		}
 		attData, ok := event.Data.(*operation.UnAggregatedAttReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, AttestationTopic)
+			return sink.sendError(topicDataMismatch, event.Data, AttestationTopic)
 		}
 		a, ok := attData.Attestation.(*eth.Attestation)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, AttestationTopic)
+			return sink.sendError(topicDataMismatch, event.Data, AttestationTopic)
 		}
 		att := structs.AttFromConsensus(a)
-		return send(w, flusher, AttestationTopic, att)
+		return send(sink, AttestationTopic, att)
 	case operation.ExitReceived:
 		if _, ok := requestedTopics[VoluntaryExitTopic]; !ok {
 			return nil
 		}
 		exitData, ok := event.Data.(*operation.ExitReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, VoluntaryExitTopic)
+			return sink.sendError(topicDataMismatch, event.Data, VoluntaryExitTopic)
 		}
 		exit := structs.SignedExitFromConsensus(exitData.Exit)
-		return send(w, flusher, VoluntaryExitTopic, exit)
+		return send(sink, VoluntaryExitTopic, exit)
 	case operation.SyncCommitteeContributionReceived:
 		if _, ok := requestedTopics[SyncCommitteeContributionTopic]; !ok {
 			return nil
 		}
 		contributionData, ok := event.Data.(*operation.SyncCommitteeContributionReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, SyncCommitteeContributionTopic)
+			return sink.sendError(topicDataMismatch, event.Data, SyncCommitteeContributionTopic)
 		}
 		contribution := structs.SignedContributionAndProofFromConsensus(contributionData.Contribution)
-		return send(w, flusher, SyncCommitteeContributionTopic, contribution)
+		return send(sink, SyncCommitteeContributionTopic, contribution)
 	case operation.BLSToExecutionChangeReceived:
 		if _, ok := requestedTopics[BLSToExecutionChangeTopic]; !ok {
 			return nil
 		}
 		changeData, ok := event.Data.(*operation.BLSToExecutionChangeReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, BLSToExecutionChangeTopic)
+			return sink.sendError(topicDataMismatch, event.Data, BLSToExecutionChangeTopic)
 		}
-		return send(w, flusher, BLSToExecutionChangeTopic, structs.SignedBLSChangeFromConsensus(changeData.Change))
+		return send(sink, BLSToExecutionChangeTopic, structs.SignedBLSChangeFromConsensus(changeData.Change))
 	case operation.BlobSidecarReceived:
 		if _, ok := requestedTopics[BlobSidecarTopic]; !ok {
 			return nil
 		}
 		blobData, ok := event.Data.(*operation.BlobSidecarReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, BlobSidecarTopic)
+			return sink.sendError(topicDataMismatch, event.Data, BlobSidecarTopic)
 		}
 		versionedHash := blockchain.ConvertKzgCommitmentToVersionedHash(blobData.Blob.KzgCommitment)
 		blobEvent := &structs.BlobSidecarEvent{
@@ -225,18 +380,35 @@ func handleBlockOperationEvents(w http.ResponseWriter, flusher http.Flusher, req
 			VersionedHash: versionedHash.String(),
 			KzgCommitment: hexutil.Encode(blobData.Blob.KzgCommitment),
 		}
-		return send(w, flusher, BlobSidecarTopic, blobEvent)
+		return send(sink, BlobSidecarTopic, blobEvent)
+	case operation.BlockGossipReceived:
+		if _, ok := requestedTopics[BlockGossipTopic]; !ok {
+			return nil
+		}
+		blockData, ok := event.Data.(*operation.BlockGossipReceivedData)
+		if !ok {
+			return sink.sendError(topicDataMismatch, event.Data, BlockGossipTopic)
+		}
+		blockRoot, err := blockData.SignedBlock.Block().HashTreeRoot()
+		if err != nil {
+			return sink.sendError("Could not get block root: " + err.Error())
+		}
+		blockEvent := &structs.BlockGossipEvent{
+			Slot:  fmt.Sprintf("%d", blockData.SignedBlock.Block().Slot()),
+			Block: hexutil.Encode(blockRoot[:]),
+		}
+		return send(sink, BlockGossipTopic, blockEvent)
 	case operation.AttesterSlashingReceived:
 		if _, ok := requestedTopics[AttesterSlashingTopic]; !ok {
 			return nil
 		}
 		attesterSlashingData, ok := event.Data.(*operation.AttesterSlashingReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, AttesterSlashingTopic)
+			return sink.sendError(topicDataMismatch, event.Data, AttesterSlashingTopic)
 		}
 		slashing, ok := attesterSlashingData.AttesterSlashing.(*eth.AttesterSlashing)
 		if ok {
-			return send(w, flusher, AttesterSlashingTopic, structs.AttesterSlashingFromConsensus(slashing))
+			return send(sink, AttesterSlashingTopic, structs.AttesterSlashingFromConsensus(slashing))
 		}
 		// TODO: extend to Electra
 	case operation.ProposerSlashingReceived:
@@ -245,20 +417,20 @@ func handleBlockOperationEvents(w http.ResponseWriter, flusher http.Flusher, req
 		}
 		proposerSlashingData, ok := event.Data.(*operation.ProposerSlashingReceivedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, ProposerSlashingTopic)
+			return sink.sendError(topicDataMismatch, event.Data, ProposerSlashingTopic)
 		}
-		return send(w, flusher, ProposerSlashingTopic, structs.ProposerSlashingFromConsensus(proposerSlashingData.ProposerSlashing))
+		return send(sink, ProposerSlashingTopic, structs.ProposerSlashingFromConsensus(proposerSlashingData.ProposerSlashing))
 	}
 	return nil
 }
 
-func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, requestedTopics map[string]bool, event *feed.Event) error {
+func (s *Server) handleStateEvents(ctx context.Context, sink eventSink, requestedTopics map[string]bool, event *feed.Event) error {
 	switch event.Type {
 	case statefeed.NewHead:
 		if _, ok := requestedTopics[HeadTopic]; ok {
 			headData, ok := event.Data.(*ethpb.EventHead)
 			if !ok {
-				return write(w, flusher, topicDataMismatch, event.Data, HeadTopic)
+				return sink.sendError(topicDataMismatch, event.Data, HeadTopic)
 			}
 			head := &structs.HeadEvent{
 				Slot:                      fmt.Sprintf("%d", headData.Slot),
@@ -269,14 +441,17 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 				PreviousDutyDependentRoot: hexutil.Encode(headData.PreviousDutyDependentRoot),
 				CurrentDutyDependentRoot:  hexutil.Encode(headData.CurrentDutyDependentRoot),
 			}
-			return send(w, flusher, HeadTopic, head)
+			if err := send(sink, HeadTopic, head); err != nil {
+				return err
+			}
 		}
+		// A new head changes the parent of the next proposal.
 		if _, ok := requestedTopics[PayloadAttributesTopic]; ok {
-			return s.sendPayloadAttributes(ctx, w, flusher)
+			return s.sendPayloadAttributes(ctx, sink)
 		}
 	case statefeed.MissedSlot:
 		if _, ok := requestedTopics[PayloadAttributesTopic]; ok {
-			return s.sendPayloadAttributes(ctx, w, flusher)
+			return s.sendPayloadAttributes(ctx, sink)
 		}
 	case statefeed.FinalizedCheckpoint:
 		if _, ok := requestedTopics[FinalizedCheckpointTopic]; !ok {
@@ -284,7 +459,7 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 		}
 		checkpointData, ok := event.Data.(*ethpb.EventFinalizedCheckpoint)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, FinalizedCheckpointTopic)
+			return sink.sendError(topicDataMismatch, event.Data, FinalizedCheckpointTopic)
 		}
 		checkpoint := &structs.FinalizedCheckpointEvent{
 			Block:               hexutil.Encode(checkpointData.Block),
@@ -292,14 +467,14 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 			Epoch:               fmt.Sprintf("%d", checkpointData.Epoch),
 			ExecutionOptimistic: checkpointData.ExecutionOptimistic,
 		}
-		return send(w, flusher, FinalizedCheckpointTopic, checkpoint)
+		return send(sink, FinalizedCheckpointTopic, checkpoint)
 	case statefeed.LightClientFinalityUpdate:
 		if _, ok := requestedTopics[LightClientFinalityUpdateTopic]; !ok {
 			return nil
 		}
 		updateData, ok := event.Data.(*ethpbv2.LightClientFinalityUpdateWithVersion)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, LightClientFinalityUpdateTopic)
+			return sink.sendError(topicDataMismatch, event.Data, LightClientFinalityUpdateTopic)
 		}
 
 		var finalityBranch []string
@@ -330,14 +505,14 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 				SignatureSlot: fmt.Sprintf("%d", updateData.Data.SignatureSlot),
 			},
 		}
-		return send(w, flusher, LightClientFinalityUpdateTopic, update)
+		return send(sink, LightClientFinalityUpdateTopic, update)
 	case statefeed.LightClientOptimisticUpdate:
 		if _, ok := requestedTopics[LightClientOptimisticUpdateTopic]; !ok {
 			return nil
 		}
 		updateData, ok := event.Data.(*ethpbv2.LightClientOptimisticUpdateWithVersion)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, LightClientOptimisticUpdateTopic)
+			return sink.sendError(topicDataMismatch, event.Data, LightClientOptimisticUpdateTopic)
 		}
 		update := &structs.LightClientOptimisticUpdateEvent{
 			Version: version.String(int(updateData.Version)),
@@ -356,14 +531,14 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 				SignatureSlot: fmt.Sprintf("%d", updateData.Data.SignatureSlot),
 			},
 		}
-		return send(w, flusher, LightClientOptimisticUpdateTopic, update)
+		return send(sink, LightClientOptimisticUpdateTopic, update)
 	case statefeed.Reorg:
 		if _, ok := requestedTopics[ChainReorgTopic]; !ok {
 			return nil
 		}
 		reorgData, ok := event.Data.(*ethpb.EventChainReorg)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, ChainReorgTopic)
+			return sink.sendError(topicDataMismatch, event.Data, ChainReorgTopic)
 		}
 		reorg := &structs.ChainReorgEvent{
 			Slot:                fmt.Sprintf("%d", reorgData.Slot),
@@ -375,69 +550,74 @@ func (s *Server) handleStateEvents(ctx context.Context, w http.ResponseWriter, f
 			Epoch:               fmt.Sprintf("%d", reorgData.Epoch),
 			ExecutionOptimistic: reorgData.ExecutionOptimistic,
 		}
-		return send(w, flusher, ChainReorgTopic, reorg)
+		return send(sink, ChainReorgTopic, reorg)
 	case statefeed.BlockProcessed:
 		if _, ok := requestedTopics[BlockTopic]; !ok {
 			return nil
 		}
 		blkData, ok := event.Data.(*statefeed.BlockProcessedData)
 		if !ok {
-			return write(w, flusher, topicDataMismatch, event.Data, BlockTopic)
+			return sink.sendError(topicDataMismatch, event.Data, BlockTopic)
 		}
 		blockRoot, err := blkData.SignedBlock.Block().HashTreeRoot()
 		if err != nil {
-			return write(w, flusher, "Could not get block root: "+err.Error())
+			return sink.sendError("Could not get block root: " + err.Error())
 		}
 		blk := &structs.BlockEvent{
 			Slot:                fmt.Sprintf("%d", blkData.Slot),
 			Block:               hexutil.Encode(blockRoot[:]),
 			ExecutionOptimistic: blkData.Optimistic,
 		}
-		return send(w, flusher, BlockTopic, blk)
+		return send(sink, BlockTopic, blk)
 	}
 	return nil
 }
 
 // This event stream is intended to be used by builders and relays.
 // Parent fields are based on state at N_{current_slot}, while the rest of fields are based on state of N_{current_slot + 1}
-func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWriter, flusher http.Flusher) error {
+func (s *Server) sendPayloadAttributes(ctx context.Context, sink eventSink) error {
 	headRoot, err := s.HeadFetcher.HeadRoot(ctx)
 	if err != nil {
-		return write(w, flusher, "Could not get head root: "+err.Error())
+		return sink.sendError("Could not get head root: " + err.Error())
 	}
 	st, err := s.HeadFetcher.HeadState(ctx)
 	if err != nil {
-		return write(w, flusher, "Could not get head state: "+err.Error())
+		return sink.sendError("Could not get head state: " + err.Error())
 	}
 	// advance the head state
 	headState, err := transition.ProcessSlotsIfPossible(ctx, st, s.ChainInfoFetcher.CurrentSlot()+1)
 	if err != nil {
-		return write(w, flusher, "Could not advance head state: "+err.Error())
+		return sink.sendError("Could not advance head state: " + err.Error())
+	}
+	// Payloads are only built from Bellatrix on. The state is advanced to the proposal slot, so the
+	// attributes are those of the fork of the proposal.
+	if headState.Version() < version.Bellatrix {
+		return nil
 	}
 
 	headBlock, err := s.HeadFetcher.HeadBlock(ctx)
 	if err != nil {
-		return write(w, flusher, "Could not get head block: "+err.Error())
+		return sink.sendError("Could not get head block: " + err.Error())
 	}
 
 	headPayload, err := headBlock.Block().Body().Execution()
 	if err != nil {
-		return write(w, flusher, "Could not get execution payload: "+err.Error())
+		return sink.sendError("Could not get execution payload: " + err.Error())
 	}
 
 	t, err := slots.ToTime(headState.GenesisTime(), headState.Slot())
 	if err != nil {
-		return write(w, flusher, "Could not get head state slot time: "+err.Error())
+		return sink.sendError("Could not get head state slot time: " + err.Error())
 	}
 
 	prevRando, err := helpers.RandaoMix(headState, time.CurrentEpoch(headState))
 	if err != nil {
-		return write(w, flusher, "Could not get head state randao mix: "+err.Error())
+		return sink.sendError("Could not get head state randao mix: " + err.Error())
 	}
 
 	proposerIndex, err := helpers.BeaconProposerIndex(ctx, headState)
 	if err != nil {
-		return write(w, flusher, "Could not get head state proposer index: "+err.Error())
+		return sink.sendError("Could not get head state proposer index: " + err.Error())
 	}
 
 	var attributes interface{}
@@ -451,7 +631,7 @@ func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWrite
 	case version.Capella:
 		withdrawals, _, err := headState.ExpectedWithdrawals()
 		if err != nil {
-			return write(w, flusher, "Could not get head state expected withdrawals: "+err.Error())
+			return sink.sendError("Could not get head state expected withdrawals: " + err.Error())
 		}
 		attributes = &structs.PayloadAttributesV2{
 			Timestamp:             fmt.Sprintf("%d", t.Unix()),
@@ -459,14 +639,14 @@ func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWrite
 			SuggestedFeeRecipient: hexutil.Encode(headPayload.FeeRecipient()),
 			Withdrawals:           structs.WithdrawalsFromConsensus(withdrawals),
 		}
-	case version.Deneb:
+	case version.Deneb, version.Electra:
 		withdrawals, _, err := headState.ExpectedWithdrawals()
 		if err != nil {
-			return write(w, flusher, "Could not get head state expected withdrawals: "+err.Error())
+			return sink.sendError("Could not get head state expected withdrawals: " + err.Error())
 		}
 		parentRoot, err := headBlock.Block().HashTreeRoot()
 		if err != nil {
-			return write(w, flusher, "Could not get head block root: "+err.Error())
+			return sink.sendError("Could not get head block root: " + err.Error())
 		}
 		attributes = &structs.PayloadAttributesV3{
 			Timestamp:             fmt.Sprintf("%d", t.Unix()),
@@ -476,12 +656,12 @@ func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWrite
 			ParentBeaconBlockRoot: hexutil.Encode(parentRoot[:]),
 		}
 	default:
-		return write(w, flusher, "Payload version %s is not supported", version.String(headState.Version()))
+		return sink.sendError("Payload version %s is not supported", version.String(headState.Version()))
 	}
 
 	attributesBytes, err := json.Marshal(attributes)
 	if err != nil {
-		return write(w, flusher, err.Error())
+		return sink.sendError(err.Error())
 	}
 	eventData := structs.PayloadAttributesEventData{
 		ProposerIndex:     fmt.Sprintf("%d", proposerIndex),
@@ -493,20 +673,24 @@ func (s *Server) sendPayloadAttributes(ctx context.Context, w http.ResponseWrite
 	}
 	eventDataBytes, err := json.Marshal(eventData)
 	if err != nil {
-		return write(w, flusher, err.Error())
+		return sink.sendError(err.Error())
 	}
-	return send(w, flusher, PayloadAttributesTopic, &structs.PayloadAttributesEvent{
+	return send(sink, PayloadAttributesTopic, &structs.PayloadAttributesEvent{
 		Version: version.String(headState.Version()),
 		Data:    eventDataBytes,
 	})
 }
 
-func send(w http.ResponseWriter, flusher http.Flusher, name string, data interface{}) error {
+func send(sink eventSink, name string, data interface{}) error {
 	j, err := json.Marshal(data)
 	if err != nil {
-		return write(w, flusher, "Could not marshal event to JSON: "+err.Error())
+		return sink.sendError("Could not marshal event to JSON: " + err.Error())
 	}
-	return write(w, flusher, "event: %s\ndata: %s\n\n", name, string(j))
+	return sink.sendEvent(name, j)
+}
+
+func sendStreamEvent(w http.ResponseWriter, flusher http.Flusher, e *streamEvent) error {
+	return write(w, flusher, "id: %d\nevent: %s\ndata: %s\n\n", e.id, e.topic, string(e.data))
 }
 
 func sendKeepalive(w http.ResponseWriter, flusher http.Flusher) error {
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"math"
@@ -10,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prysmaticlabs/prysm/v5/api"
 	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
@@ -50,6 +53,7 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			BlobSidecarTopic,
 			AttesterSlashingTopic,
 			ProposerSlashingTopic,
+			BlockGossipTopic,
 		}
 		for i, topic := range topics {
 			topics[i] = "topics=" + topic
@@ -192,6 +196,17 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			},
 		})
 
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 5
+		signedBlock, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		s.OperationNotifier.OperationFeed().Send(&feed.Event{
+			Type: operation.BlockGossipReceived,
+			Data: &operation.BlockGossipReceivedData{
+				SignedBlock: signedBlock,
+			},
+		})
+
 		time.Sleep(1 * time.Second)
 		request.Context().Done()
 
@@ -328,6 +343,35 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 				},
 				expected: payloadAttributesDenebResult,
 			},
+			{
+				name: "electra",
+				getState: func() state.BeaconState {
+					st, err := util.NewBeaconStateElectra()
+					require.NoError(t, err)
+					return st
+				},
+				getBlock: func() interfaces.SignedBeaconBlock {
+					b, err := blocks.NewSignedBeaconBlock(util.HydrateSignedBeaconBlockElectra(&eth.SignedBeaconBlockElectra{}))
+					require.NoError(t, err)
+					return b
+				},
+				expected: payloadAttributesElectraResult,
+			},
+			{
+				name: "altair",
+				getState: func() state.BeaconState {
+					st, err := util.NewBeaconStateAltair()
+					require.NoError(t, err)
+					return st
+				},
+				getBlock: func() interfaces.SignedBeaconBlock {
+					b, err := blocks.NewSignedBeaconBlock(util.HydrateSignedBeaconBlockAltair(&eth.SignedBeaconBlockAltair{}))
+					require.NoError(t, err)
+					return b
+				},
+				// There are no payload attributes before Bellatrix.
+				expected: ":\n\n",
+			},
 		}
 		for _, tc := range testCases {
 			st := tc.getState()
@@ -375,6 +419,185 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 	})
 }
 
+func TestStreamEvents_PayloadAttributes(t *testing.T) {
+	newServer := func(t *testing.T, genesis time.Time) *Server {
+		st, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		v := &eth.Validator{ExitEpoch: math.MaxUint64}
+		require.NoError(t, st.SetValidators([]*eth.Validator{v}))
+		currentSlot := primitives.Slot(0)
+		// to avoid slot processing
+		require.NoError(t, st.SetSlot(currentSlot+1))
+		b, err := blocks.NewSignedBeaconBlock(util.HydrateSignedBeaconBlockDeneb(&eth.SignedBeaconBlockDeneb{}))
+		require.NoError(t, err)
+		mockChainService := &mockChain.ChainService{
+			Root:    make([]byte, 32),
+			State:   st,
+			Block:   b,
+			Slot:    &currentSlot,
+			Genesis: genesis,
+		}
+		return &Server{
+			StateNotifier:     &mockChain.MockStateNotifier{},
+			OperationNotifier: &mockChain.MockOperationNotifier{},
+			HeadFetcher:       mockChainService,
+			ChainInfoFetcher:  mockChainService,
+		}
+	}
+
+	t.Run("head update", func(t *testing.T) {
+		s := newServer(t, time.Time{})
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://example.com/eth/v1/events?topics=%s&topics=%s", HeadTopic, PayloadAttributesTopic), nil)
+		w := &flushableResponseRecorder{
+			ResponseRecorder: httptest.NewRecorder(),
+		}
+
+		go func() {
+			s.StreamEvents(w, request)
+		}()
+		// wait for initiation of StreamEvents
+		time.Sleep(100 * time.Millisecond)
+		s.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.NewHead,
+			Data: &ethpb.EventHead{
+				Slot:                      0,
+				Block:                     make([]byte, 32),
+				State:                     make([]byte, 32),
+				EpochTransition:           true,
+				PreviousDutyDependentRoot: make([]byte, 32),
+				CurrentDutyDependentRoot:  make([]byte, 32),
+				ExecutionOptimistic:       false,
+			},
+		})
+
+		// wait for feed
+		time.Sleep(1 * time.Second)
+		request.Context().Done()
+
+		resp := w.Result()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NotNil(t, body)
+		assert.Equal(t, headAndPayloadAttributesResult, string(body))
+	})
+	t.Run("slot start", func(t *testing.T) {
+		// The first slot tick is at genesis, which has already passed.
+		s := newServer(t, time.Now().Add(-time.Second))
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://example.com/eth/v1/events?topics=%s", PayloadAttributesTopic), nil)
+		w := &flushableResponseRecorder{
+			ResponseRecorder: httptest.NewRecorder(),
+		}
+
+		go func() {
+			s.StreamEvents(w, request)
+		}()
+		// wait for the slot tick
+		time.Sleep(1 * time.Second)
+		request.Context().Done()
+
+		resp := w.Result()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NotNil(t, body)
+		assert.Equal(t, payloadAttributesDenebResult, string(body))
+	})
+}
+
+func TestStreamEvents_ResumeWithLastEventID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Server{
+		Ctx:               ctx,
+		StateNotifier:     &mockChain.MockStateNotifier{},
+		OperationNotifier: &mockChain.MockOperationNotifier{},
+		EventHistory:      NewEventHistory(2, time.Minute),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(s.StreamEvents))
+	defer srv.Close()
+
+	// connect opens a stream of head and finalized checkpoint events and waits for its first keepalive.
+	connect := func(t *testing.T, lastEventID string) (*bufio.Reader, io.Closer) {
+		reqCtx, reqCancel := context.WithTimeout(ctx, 10*time.Second)
+		t.Cleanup(reqCancel)
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, srv.URL+"/eth/v1/events?topics=head&topics=finalized_checkpoint", nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set(api.LastEventIDHeader, lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		reader := bufio.NewReader(resp.Body)
+		require.Equal(t, ":", readFrame(t, reader))
+		return reader, resp.Body
+	}
+	sendHead := func(slot primitives.Slot) {
+		s.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.NewHead,
+			Data: &ethpb.EventHead{
+				Slot:                      slot,
+				Block:                     make([]byte, 32),
+				State:                     make([]byte, 32),
+				PreviousDutyDependentRoot: make([]byte, 32),
+				CurrentDutyDependentRoot:  make([]byte, 32),
+			},
+		})
+	}
+	headFrame := func(id uint64, slot primitives.Slot) string {
+		zero := "0x0000000000000000000000000000000000000000000000000000000000000000"
+		return fmt.Sprintf(
+			"id: %d\nevent: head\ndata: {\"slot\":\"%d\",\"block\":\"%s\",\"state\":\"%s\",\"epoch_transition\":false,\"execution_optimistic\":false,\"previous_duty_dependent_root\":\"%s\",\"current_duty_dependent_root\":\"%s\"}",
+			id, slot, zero, zero, zero, zero,
+		)
+	}
+
+	reader, body := connect(t, "")
+	sendHead(1)
+	require.Equal(t, headFrame(1, 1), readFrame(t, reader))
+	require.NoError(t, body.Close())
+
+	// Events are still recorded while the client is disconnected.
+	sendHead(2)
+	s.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.FinalizedCheckpoint,
+		Data: &ethpb.EventFinalizedCheckpoint{
+			Block: make([]byte, 32),
+			State: make([]byte, 32),
+			Epoch: 1,
+		},
+	})
+
+	reader, body = connect(t, "1")
+	require.Equal(t, headFrame(2, 2), readFrame(t, reader))
+	require.Equal(t, `id: 3
+event: finalized_checkpoint
+data: {"block":"0x0000000000000000000000000000000000000000000000000000000000000000","state":"0x0000000000000000000000000000000000000000000000000000000000000000","epoch":"1","execution_optimistic":false}`, readFrame(t, reader))
+	sendHead(3)
+	require.Equal(t, headFrame(4, 3), readFrame(t, reader))
+	require.NoError(t, body.Close())
+
+	// Only the 2 latest head events are kept, so the first one has aged out.
+	reader, body = connect(t, "0")
+	require.Equal(t, ":stream_start", readFrame(t, reader))
+	sendHead(4)
+	require.Equal(t, headFrame(5, 4), readFrame(t, reader))
+	require.NoError(t, body.Close())
+}
+
+// readFrame reads the lines of the event stream up to the next blank line.
+func readFrame(t *testing.T, reader *bufio.Reader) string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return strings.Join(lines, "\n")
+		}
+		lines = append(lines, line)
+	}
+}
+
 const operationsResult = `:
 
 event: attestation
@@ -401,6 +624,9 @@ data: {"attestation_1":{"attesting_indices":["0","1"],"data":{"slot":"0","index"
 event: proposer_slashing
 data: {"signed_header_1":{"message":{"slot":"0","proposer_index":"0","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},"signed_header_2":{"message":{"slot":"0","proposer_index":"0","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}}
 
+event: block_gossip
+data: {"slot":"5","block":"0xa1ded04d6dbd85f7a514bb9b0e6bef04168b2aabf9113755a0b0d219183444c0"}
+
 `
 
 const stateResult = `:
@@ -439,3 +665,20 @@ event: payload_attributes
 data: {"version":"deneb","data":{"proposer_index":"0","proposal_slot":"1","parent_block_number":"0","parent_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","parent_block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000","payload_attributes":{"timestamp":"12","prev_randao":"0x0000000000000000000000000000000000000000000000000000000000000000","suggested_fee_recipient":"0x0000000000000000000000000000000000000000","withdrawals":[],"parent_beacon_block_root":"0xbef96cb938fd48b2403d3e662664325abb0102ed12737cbb80d717520e50cf4a"}}}
 
 `
+
+const payloadAttributesElectraResult = `:
+
+event: payload_attributes
+data: {"version":"electra","data":{"proposer_index":"0","proposal_slot":"1","parent_block_number":"0","parent_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","parent_block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000","payload_attributes":{"timestamp":"12","prev_randao":"0x0000000000000000000000000000000000000000000000000000000000000000","suggested_fee_recipient":"0x0000000000000000000000000000000000000000","withdrawals":[],"parent_beacon_block_root":"0xf8bd69e8c0caa3ea928168ea4dc707f37eebae94b9f3078089cfc1ef3958bf3c"}}}
+
+`
+
+const headAndPayloadAttributesResult = `:
+
+event: head
+data: {"slot":"0","block":"0x0000000000000000000000000000000000000000000000000000000000000000","state":"0x0000000000000000000000000000000000000000000000000000000000000000","epoch_transition":true,"execution_optimistic":false,"previous_duty_dependent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","current_duty_dependent_root":"0x0000000000000000000000000000000000000000000000000000000000000000"}
+
+event: payload_attributes
+data: {"version":"deneb","data":{"proposer_index":"0","proposal_slot":"1","parent_block_number":"0","parent_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","parent_block_hash":"0x0000000000000000000000000000000000000000000000000000000000000000","payload_attributes":{"timestamp":"12","prev_randao":"0x0000000000000000000000000000000000000000000000000000000000000000","suggested_fee_recipient":"0x0000000000000000000000000000000000000000","withdrawals":[],"parent_beacon_block_root":"0xbef96cb938fd48b2403d3e662664325abb0102ed12737cbb80d717520e50cf4a"}}}
+
+`
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+)
+
+// streamEvent is an event sent to the event stream, along with the ID identifying it in the stream.
+type streamEvent struct {
+	id       uint64
+	topic    string
+	data     []byte
+	recorded time.Time
+}
+
+// eventRing is a ring buffer of the recent events of a topic.
+type eventRing struct {
+	events []*streamEvent
+	start  int
+	count  int
+	// evicted is the ID of the latest event dropped from the buffer.
+	evicted uint64
+}
+
+func (r *eventRing) push(e *streamEvent) {
+	if r.count == len(r.events) {
+		r.evicted = r.events[r.start].id
+		r.events[r.start] = e
+		r.start = (r.start + 1) % len(r.events)
+		return
+	}
+	r.events[(r.start+r.count)%len(r.events)] = e
+	r.count++
+}
+
+// pruneBefore drops the events recorded before t.
+func (r *eventRing) pruneBefore(t time.Time) {
+	for r.count > 0 && r.events[r.start].recorded.Before(t) {
+		r.evicted = r.events[r.start].id
+		r.events[r.start] = nil
+		r.start = (r.start + 1) % len(r.events)
+		r.count--
+	}
+}
+
+func (r *eventRing) at(i int) *streamEvent {
+	return r.events[(r.start+i)%len(r.events)]
+}
+
+// EventHistory keeps the recent events of each topic sent to the event stream, so that clients reconnecting
+// with the Last-Event-ID header can be sent the events they missed. Event IDs are shared by all topics and
+// increase monotonically, so the events of a topic are always sent with increasing IDs.
+type EventHistory struct {
+	maxEvents int
+	maxAge    time.Duration
+	lock      sync.Mutex
+	lastID    uint64
+	topics    map[string]*eventRing
+	feed      event.Feed
+}
+
+// NewEventHistory creates an event history keeping up to maxEvents events of each topic for at most maxAge.
+func NewEventHistory(maxEvents int, maxAge time.Duration) *EventHistory {
+	if maxEvents < 1 {
+		maxEvents = 1
+	}
+	return &EventHistory{
+		maxEvents: maxEvents,
+		maxAge:    maxAge,
+		topics:    make(map[string]*eventRing),
+	}
+}
+
+// add assigns the next ID to the event of the topic, keeps it and sends it to the live streams.
+func (h *EventHistory) add(topic string, data []byte) {
+	h.lock.Lock()
+	h.lastID++
+	e := &streamEvent{
+		id:       h.lastID,
+		topic:    topic,
+		data:     data,
+		recorded: time.Now(),
+	}
+	ring, ok := h.topics[topic]
+	if !ok {
+		ring = &eventRing{events: make([]*streamEvent, h.maxEvents)}
+		h.topics[topic] = ring
+	}
+	ring.pruneBefore(e.recorded.Add(-h.maxAge))
+	ring.push(e)
+	h.lock.Unlock()
+
+	h.feed.Send(e)
+}
+
+// since returns the kept events of the topics with an ID greater than lastID, ordered by ID. It returns false
+// if some of these events are no longer kept, or if lastID was never assigned.
+func (h *EventHistory) since(topics map[string]bool, lastID uint64) ([]*streamEvent, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if lastID > h.lastID {
+		return nil, false
+	}
+	cutoff := time.Now().Add(-h.maxAge)
+	var events []*streamEvent
+	for topic := range topics {
+		ring, ok := h.topics[topic]
+		if !ok {
+			continue
+		}
+		ring.pruneBefore(cutoff)
+		if ring.evicted > lastID {
+			return nil, false
+		}
+		for i := 0; i < ring.count; i++ {
+			if e := ring.at(i); e.id > lastID {
+				events = append(events, e)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].id < events[j].id
+	})
+	return events, true
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestEventHistory_Since(t *testing.T) {
+	h := NewEventHistory(2, time.Minute)
+	h.add(HeadTopic, []byte("1"))
+	h.add(BlockTopic, []byte("2"))
+	h.add(HeadTopic, []byte("3"))
+	h.add(HeadTopic, []byte("4"))
+	h.add(BlockTopic, []byte("5"))
+
+	t.Run("ordered by id", func(t *testing.T) {
+		events, ok := h.since(map[string]bool{HeadTopic: true, BlockTopic: true}, 2)
+		require.Equal(t, true, ok)
+		require.Equal(t, 3, len(events))
+		for i, id := range []uint64{3, 4, 5} {
+			assert.Equal(t, id, events[i].id)
+		}
+	})
+	t.Run("nothing missed", func(t *testing.T) {
+		events, ok := h.since(map[string]bool{HeadTopic: true}, 5)
+		require.Equal(t, true, ok)
+		assert.Equal(t, 0, len(events))
+	})
+	t.Run("evicted", func(t *testing.T) {
+		_, ok := h.since(map[string]bool{HeadTopic: true}, 0)
+		assert.Equal(t, false, ok)
+		// The block events are still all kept.
+		events, ok := h.since(map[string]bool{BlockTopic: true}, 0)
+		require.Equal(t, true, ok)
+		assert.Equal(t, 2, len(events))
+	})
+	t.Run("unknown id", func(t *testing.T) {
+		_, ok := h.since(map[string]bool{HeadTopic: true}, 6)
+		assert.Equal(t, false, ok)
+	})
+	t.Run("aged out", func(t *testing.T) {
+		h := NewEventHistory(2, time.Millisecond)
+		h.add(HeadTopic, []byte("1"))
+		time.Sleep(10 * time.Millisecond)
+		_, ok := h.since(map[string]bool{HeadTopic: true}, 0)
+		assert.Equal(t, false, ok)
+		events, ok := h.since(map[string]bool{HeadTopic: true}, 1)
+		require.Equal(t, true, ok)
+		assert.Equal(t, 0, len(events))
+	})
+}
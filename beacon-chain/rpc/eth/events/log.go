@@ -0,0 +1,5 @@
+package events
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "rpc/events")
@@ -4,6 +4,9 @@
 package events
 
 import (
+	"context"
+	"sync"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
@@ -12,8 +15,13 @@ import (
 // Server defines a server implementation of the gRPC events service,
 // providing RPC endpoints to subscribe to events from the beacon node.
 type Server struct {
+	Ctx               context.Context
 	StateNotifier     statefeed.Notifier
 	OperationNotifier opfeed.Notifier
 	HeadFetcher       blockchain.HeadFetcher
 	ChainInfoFetcher  blockchain.ChainInfoFetcher
+	// EventHistory is optional. When set, the events are recorded to it for the lifetime of Ctx
+	// from the first stream on, and clients can resume their streams with the Last-Event-ID header.
+	EventHistory *EventHistory
+	recordOnce   sync.Once
 }
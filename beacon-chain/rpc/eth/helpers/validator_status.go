@@ -27,6 +27,22 @@ func ValidatorStatus(val state.ReadOnlyValidator, epoch primitives.Epoch) (valid
 	return 0, errors.New("invalid validator state")
 }
 
+// SubStatuses returns the sub-statuses a validator with the given status can have. A sub-status is
+// its own only sub-status.
+func SubStatuses(status validator.Status) []validator.Status {
+	switch status {
+	case validator.Pending:
+		return []validator.Status{validator.PendingInitialized, validator.PendingQueued}
+	case validator.Active:
+		return []validator.Status{validator.ActiveOngoing, validator.ActiveSlashed, validator.ActiveExiting}
+	case validator.Exited:
+		return []validator.Status{validator.ExitedUnslashed, validator.ExitedSlashed}
+	case validator.Withdrawal:
+		return []validator.Status{validator.WithdrawalPossible, validator.WithdrawalDone}
+	}
+	return []validator.Status{status}
+}
+
 // ValidatorSubStatus returns a validator's sub-status at the given epoch.
 func ValidatorSubStatus(val state.ReadOnlyValidator, epoch primitives.Epoch) (validator.Status, error) {
 	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
@@ -301,3 +301,11 @@ func TestNumberOfStatuses(t *testing.T) {
 	x = ethpb.ValidatorStatus(lastValidEnumValue + 1)
 	assert.Equal(t, strconv.Itoa(lastValidEnumValue+1), x.String())
 }
+
+func TestSubStatuses(t *testing.T) {
+	assert.DeepEqual(t, []validator.Status{validator.PendingInitialized, validator.PendingQueued}, SubStatuses(validator.Pending))
+	assert.DeepEqual(t, []validator.Status{validator.ActiveOngoing, validator.ActiveSlashed, validator.ActiveExiting}, SubStatuses(validator.Active))
+	assert.DeepEqual(t, []validator.Status{validator.ExitedUnslashed, validator.ExitedSlashed}, SubStatuses(validator.Exited))
+	assert.DeepEqual(t, []validator.Status{validator.WithdrawalPossible, validator.WithdrawalDone}, SubStatuses(validator.Withdrawal))
+	assert.DeepEqual(t, []validator.Status{validator.ExitedSlashed}, SubStatuses(validator.ExitedSlashed))
+}
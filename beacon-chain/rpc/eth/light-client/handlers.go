@@ -8,11 +8,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"go.opencensus.io/trace"
 
 	"github.com/wealdtech/go-bytesutil"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -20,6 +22,7 @@ import (
 	types "github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 // GetLightClientBootstrap - implements https://github.com/ethereum/beacon-APIs/blob/263f4ed6c263c967f13279c7a9f5629b51c5fc55/apis/beacon/light_client/bootstrap.yaml
@@ -40,6 +43,10 @@ func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, req *http.Reques
 	if !shared.WriteBlockFetchError(w, blk, err) {
 		return
 	}
+	if blk.Version() < version.Altair {
+		httputil.HandleError(w, "light client bootstrap is not available for pre-Altair blocks", http.StatusNotFound)
+		return
+	}
 
 	// Get the state
 	state, err := s.Stater.StateBySlot(ctx, blk.Block().Slot())
@@ -54,9 +61,28 @@ func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, req *http.Reques
 		return
 	}
 
+	w.Header().Set(api.VersionHeader, version.String(blk.Version()))
+	if httputil.RespondWithSsz(req) {
+		if !sszSupported(w, blk.Version()) {
+			return
+		}
+		execution, err := newLightClientExecution(blk)
+		if err != nil {
+			httputil.HandleError(w, "could not get light client header execution: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sszResp, err := marshalLightClientBootstrapSSZ(blk.Version(), bootstrap, execution)
+		if err != nil {
+			httputil.HandleError(w, "could not marshal light client bootstrap into SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszResp, "light_client_bootstrap.ssz")
+		return
+	}
+
 	response := &structs.LightClientBootstrapResponse{
 		Version: version.String(blk.Version()),
-		Data:    bootstrap,
+		Data:    newLightClientBootstrapToJSON(bootstrap),
 	}
 
 	httputil.WriteJson(w, response)
@@ -118,110 +144,170 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 		endPeriod = maxSlot / slotsPerPeriod
 	}
 
+	// Updates of periods before the finalized checkpoint's period can no longer change
+	var finalizedPeriod uint64
+	if finalizedCheckpoint := headState.FinalizedCheckpoint(); finalizedCheckpoint != nil {
+		finalizedPeriod = slots.SyncCommitteePeriod(finalizedCheckpoint.Epoch)
+	}
+
 	// Populate updates
-	var updates []*structs.LightClientUpdateWithVersion
+	var updates []*periodUpdate
 	for period := startPeriod; period <= endPeriod; period++ {
-		// Get the last known state of the period,
-		//    1. We wish the block has a parent in the same period if possible
-		//	  2. We wish the block has a state in the same period
-		lastSlotInPeriod := period*slotsPerPeriod + slotsPerPeriod - 1
-		if lastSlotInPeriod > maxSlot {
-			lastSlotInPeriod = maxSlot
+		update, ok := s.updates.get(period)
+		if !ok {
+			update = s.lightClientUpdateForPeriod(ctx, period, maxSlot)
+			if update == nil {
+				// No valid block found for the period
+				continue
+			}
+			if period < finalizedPeriod {
+				s.updates.add(period, update)
+			}
 		}
-		firstSlotInPeriod := period * slotsPerPeriod
+		updates = append(updates, update)
+	}
 
-		// Let's not use the first slot in the period, otherwise the attested header will be in previous period
-		firstSlotInPeriod++
+	if len(updates) == 0 {
+		httputil.HandleError(w, "no updates found", http.StatusNotFound)
+		return
+	}
 
-		var state state.BeaconState
-		var block interfaces.ReadOnlySignedBeaconBlock
-		for slot := lastSlotInPeriod; slot >= firstSlotInPeriod; slot-- {
-			state, err = s.Stater.StateBySlot(ctx, types.Slot(slot))
-			if err != nil {
-				continue
+	if httputil.RespondWithSsz(req) {
+		for _, u := range updates {
+			if !sszSupported(w, u.version) {
+				return
 			}
+		}
+		sszResp, err := marshalLightClientUpdatesSSZ(updates, headState.GenesisValidatorsRoot())
+		if err != nil {
+			httputil.HandleError(w, "could not marshal light client updates into SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszResp, "light_client_updates.ssz")
+		return
+	}
 
-			// Get the block
-			latestBlockHeader := state.LatestBlockHeader()
-			latestStateRoot, err := state.HashTreeRoot(ctx)
-			if err != nil {
-				continue
-			}
-			latestBlockHeader.StateRoot = latestStateRoot[:]
-			blockRoot, err := latestBlockHeader.HashTreeRoot()
-			if err != nil {
-				continue
-			}
+	response := make([]*structs.LightClientUpdateWithVersion, len(updates))
+	for i, u := range updates {
+		response[i] = &structs.LightClientUpdateWithVersion{
+			Version: version.String(u.version),
+			Data:    newLightClientUpdateToJSON(u.update),
+		}
+	}
 
-			block, err = s.Blocker.Block(ctx, blockRoot[:])
-			if err != nil || block == nil {
-				continue
-			}
+	httputil.WriteJson(w, response)
+}
 
-			syncAggregate, err := block.Block().Body().SyncAggregate()
-			if err != nil || syncAggregate == nil {
-				continue
-			}
+// lightClientUpdateForPeriod returns the light client update of the given sync committee period, built from the
+// latest block of the period signed by a supermajority of the sync committee, or nil if there is no such block.
+func (s *Server) lightClientUpdateForPeriod(ctx context.Context, period, maxSlot uint64) *periodUpdate {
+	config := params.BeaconConfig()
+	slotsPerPeriod := uint64(config.EpochsPerSyncCommitteePeriod) * uint64(config.SlotsPerEpoch)
 
-			if syncAggregate.SyncCommitteeBits.Count()*3 < config.SyncCommitteeSize*2 {
-				// Not enough votes
-				continue
-			}
+	// Get the last known state of the period,
+	//    1. We wish the block has a parent in the same period if possible
+	//	  2. We wish the block has a state in the same period
+	lastSlotInPeriod := period*slotsPerPeriod + slotsPerPeriod - 1
+	if lastSlotInPeriod > maxSlot {
+		lastSlotInPeriod = maxSlot
+	}
+	firstSlotInPeriod := period * slotsPerPeriod
+
+	// Let's not use the first slot in the period, otherwise the attested header will be in previous period
+	firstSlotInPeriod++
 
-			break
+	var state state.BeaconState
+	var block interfaces.ReadOnlySignedBeaconBlock
+	for slot := lastSlotInPeriod; slot >= firstSlotInPeriod; slot-- {
+		slotState, err := s.Stater.StateBySlot(ctx, types.Slot(slot))
+		if err != nil || slotState == nil {
+			continue
 		}
 
-		if block == nil {
-			// No valid block found for the period
+		// Get the block
+		latestBlockHeader := slotState.LatestBlockHeader()
+		latestStateRoot, err := slotState.HashTreeRoot(ctx)
+		if err != nil {
+			continue
+		}
+		latestBlockHeader.StateRoot = latestStateRoot[:]
+		blockRoot, err := latestBlockHeader.HashTreeRoot()
+		if err != nil {
 			continue
 		}
 
-		// Get attested state
-		attestedRoot := block.Block().ParentRoot()
-		attestedBlock, err := s.Blocker.Block(ctx, attestedRoot[:])
-		if err != nil || attestedBlock == nil {
+		slotBlock, err := s.Blocker.Block(ctx, blockRoot[:])
+		if err != nil || slotBlock == nil {
 			continue
 		}
 
-		attestedSlot := attestedBlock.Block().Slot()
-		attestedState, err := s.Stater.StateBySlot(ctx, attestedSlot)
-		if err != nil {
+		syncAggregate, err := slotBlock.Block().Body().SyncAggregate()
+		if err != nil || syncAggregate == nil {
 			continue
 		}
 
-		// Get finalized block
-		var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
-		finalizedCheckPoint := attestedState.FinalizedCheckpoint()
-		if finalizedCheckPoint != nil {
-			finalizedRoot := bytesutil.ToBytes32(finalizedCheckPoint.Root)
-			finalizedBlock, err = s.Blocker.Block(ctx, finalizedRoot[:])
-			if err != nil {
-				finalizedBlock = nil
-			}
+		if syncAggregate.SyncCommitteeBits.Count()*3 < config.SyncCommitteeSize*2 {
+			// Not enough votes
+			continue
 		}
 
-		update, err := createLightClientUpdate(
-			ctx,
-			state,
-			block,
-			attestedState,
-			finalizedBlock,
-		)
+		state, block = slotState, slotBlock
+		break
+	}
 
-		if err == nil {
-			updates = append(updates, &structs.LightClientUpdateWithVersion{
-				Version: version.String(attestedState.Version()),
-				Data:    update,
-			})
+	if block == nil {
+		return nil
+	}
+
+	// Get attested state
+	attestedRoot := block.Block().ParentRoot()
+	attestedBlock, err := s.Blocker.Block(ctx, attestedRoot[:])
+	if err != nil || attestedBlock == nil {
+		return nil
+	}
+
+	attestedSlot := attestedBlock.Block().Slot()
+	attestedState, err := s.Stater.StateBySlot(ctx, attestedSlot)
+	if err != nil || attestedState == nil {
+		return nil
+	}
+
+	// Get finalized block
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	finalizedCheckPoint := attestedState.FinalizedCheckpoint()
+	if finalizedCheckPoint != nil {
+		finalizedRoot := bytesutil.ToBytes32(finalizedCheckPoint.Root)
+		finalizedBlock, err = s.Blocker.Block(ctx, finalizedRoot[:])
+		if err != nil {
+			finalizedBlock = nil
 		}
 	}
 
-	if len(updates) == 0 {
-		httputil.HandleError(w, "no updates found", http.StatusNotFound)
-		return
+	update, err := createLightClientUpdate(
+		ctx,
+		state,
+		block,
+		attestedState,
+		finalizedBlock,
+	)
+	if err != nil {
+		return nil
+	}
+	attestedExecution, err := newLightClientExecution(attestedBlock)
+	if err != nil {
+		return nil
+	}
+	finalizedExecution, err := newLightClientExecution(finalizedBlock)
+	if err != nil {
+		return nil
 	}
 
-	httputil.WriteJson(w, updates)
+	return &periodUpdate{
+		version:            attestedState.Version(),
+		update:             update,
+		attestedExecution:  attestedExecution,
+		finalizedExecution: finalizedExecution,
+	}
 }
 
 // GetLightClientFinalityUpdate - implements https://github.com/ethereum/beacon-APIs/blob/263f4ed6c263c967f13279c7a9f5629b51c5fc55/apis/beacon/light_client/finality_update.yaml
@@ -271,7 +357,7 @@ func (s *Server) GetLightClientFinalityUpdate(w http.ResponseWriter, req *http.R
 		}
 	}
 
-	update, err := newLightClientFinalityUpdateFromBeaconState(
+	update, err := blockchain.NewLightClientFinalityUpdateFromBeaconState(
 		ctx,
 		state,
 		block,
@@ -283,9 +369,38 @@ func (s *Server) GetLightClientFinalityUpdate(w http.ResponseWriter, req *http.R
 		return
 	}
 
+	w.Header().Set(api.VersionHeader, version.String(attestedState.Version()))
+	if httputil.RespondWithSsz(req) {
+		if !sszSupported(w, attestedState.Version()) {
+			return
+		}
+		attestedExecution, err := newLightClientExecution(attestedBlock)
+		if err != nil {
+			httputil.HandleError(w, "could not get light client header execution: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		finalizedExecution, err := newLightClientExecution(finalizedBlock)
+		if err != nil {
+			httputil.HandleError(w, "could not get light client header execution: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sszResp, err := marshalLightClientFinalityUpdateSSZ(
+			attestedState.Version(),
+			blockchain.CreateLightClientFinalityUpdate(update),
+			attestedExecution,
+			finalizedExecution,
+		)
+		if err != nil {
+			httputil.HandleError(w, "could not marshal light client finality update into SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszResp, "light_client_finality_update.ssz")
+		return
+	}
+
 	response := &structs.LightClientUpdateWithVersion{
 		Version: version.String(attestedState.Version()),
-		Data:    update,
+		Data:    newLightClientUpdateToJSON(update),
 	}
 
 	httputil.WriteJson(w, response)
@@ -329,7 +444,7 @@ func (s *Server) GetLightClientOptimisticUpdate(w http.ResponseWriter, req *http
 		return
 	}
 
-	update, err := newLightClientOptimisticUpdateFromBeaconState(
+	update, err := blockchain.NewLightClientOptimisticUpdateFromBeaconState(
 		ctx,
 		state,
 		block,
@@ -340,9 +455,28 @@ func (s *Server) GetLightClientOptimisticUpdate(w http.ResponseWriter, req *http
 		return
 	}
 
+	w.Header().Set(api.VersionHeader, version.String(attestedState.Version()))
+	if httputil.RespondWithSsz(req) {
+		if !sszSupported(w, attestedState.Version()) {
+			return
+		}
+		attestedExecution, err := newLightClientExecution(attestedBlock)
+		if err != nil {
+			httputil.HandleError(w, "could not get light client header execution: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sszResp, err := marshalLightClientOptimisticUpdateSSZ(attestedState.Version(), blockchain.CreateLightClientOptimisticUpdate(update), attestedExecution)
+		if err != nil {
+			httputil.HandleError(w, "could not marshal light client optimistic update into SSZ: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		httputil.WriteSsz(w, sszResp, "light_client_optimistic_update.ssz")
+		return
+	}
+
 	response := &structs.LightClientUpdateWithVersion{
 		Version: version.String(attestedState.Version()),
-		Data:    update,
+		Data:    newLightClientUpdateToJSON(update),
 	}
 
 	httputil.WriteJson(w, response)
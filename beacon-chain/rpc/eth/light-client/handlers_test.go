@@ -3,6 +3,7 @@ package lightclient
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
@@ -21,10 +23,15 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 func TestLightClientHandler_GetLightClientBootstrap(t *testing.T) {
@@ -1008,3 +1015,294 @@ func TestLightClientHandler_GetLightClientEventBlock_NeedFetchParent(t *testing.
 	require.Equal(t, true, syncAggregate.SyncCommitteeBits.Count() >= minSignaturesRequired)
 	require.Equal(t, slot-1, eventBlock.Block().Slot())
 }
+
+// altairLightClient returns a light client fixture with Altair states and blocks, whose attested block is at
+// the slot before the block.
+func altairLightClient(t *testing.T, participants uint64) *util.TestLightClient {
+	return util.NewTestLightClient(t).SetupTestWithConfig(util.TestLightClientConfig{
+		Version:                 version.Altair,
+		Participants:            participants,
+		AttestedSlotBeforeBlock: true,
+	})
+}
+
+func TestLightClientHandler_GetLightClientBootstrap_SSZ(t *testing.T) {
+	helpers.ClearCache()
+	l := altairLightClient(t, params.BeaconConfig().MinSyncCommitteeParticipants)
+	slot := l.State.Slot()
+
+	s := &Server{
+		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+			slot: l.State,
+		}},
+		Blocker:     &testutil.MockBlocker{BlockToReturn: l.Block},
+		HeadFetcher: &mock.ChainService{Slot: &slot},
+	}
+	root, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	request := httptest.NewRequest("GET", "http://foo.com/", nil)
+	request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+	request.Header.Set("Accept", api.OctetStreamMediaType)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientBootstrap(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.Equal(t, "altair", writer.Header().Get(api.VersionHeader))
+	// header + current_sync_committee + current_sync_committee_branch
+	require.Equal(t, 112+24624+5*32, writer.Body.Len())
+	header := &ethpb.BeaconBlockHeader{}
+	require.NoError(t, header.UnmarshalSSZ(writer.Body.Bytes()[:112]))
+	headerRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, root, headerRoot)
+}
+
+func TestLightClientHandler_GetLightClientBootstrap_SSZCapella(t *testing.T) {
+	helpers.ClearCache()
+	l := util.NewTestLightClient(t).SetupTest()
+	slot := l.State.Slot()
+
+	s := &Server{
+		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+			slot: l.State,
+		}},
+		Blocker:     &testutil.MockBlocker{BlockToReturn: l.Block},
+		HeadFetcher: &mock.ChainService{Slot: &slot},
+	}
+	root, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	request := httptest.NewRequest("GET", "http://foo.com/", nil)
+	request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+	request.Header.Set("Accept", api.OctetStreamMediaType)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientBootstrap(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.Equal(t, "capella", writer.Header().Get(api.VersionHeader))
+	resp := writer.Body.Bytes()
+	// header offset + current_sync_committee + current_sync_committee_branch
+	fixedSize := 4 + 24624 + 5*32
+	require.Equal(t, uint32(fixedSize), binary.LittleEndian.Uint32(resp[:4]))
+	header := resp[fixedSize:]
+	// beacon + execution offset + execution_branch
+	require.Equal(t, uint32(112+4+4*32), binary.LittleEndian.Uint32(header[112:116]))
+
+	beacon := &ethpb.BeaconBlockHeader{}
+	require.NoError(t, beacon.UnmarshalSSZ(header[:112]))
+	beaconRoot, err := beacon.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, root, beaconRoot)
+
+	// The execution payload header is proven by the execution branch against the body root.
+	execution := &enginev1.ExecutionPayloadHeaderCapella{}
+	require.NoError(t, execution.UnmarshalSSZ(header[112+4+4*32:]))
+	executionRoot, err := execution.HashTreeRoot()
+	require.NoError(t, err)
+	payload, err := l.Block.Block().Body().Execution()
+	require.NoError(t, err)
+	payloadRoot, err := payload.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, payloadRoot, executionRoot)
+	branch := make([][]byte, 4)
+	for i := range branch {
+		branch[i] = bytesutil.SafeCopyBytes(header[116+32*i : 116+32*(i+1)])
+	}
+	require.Equal(t, true, trie.VerifyMerkleProof(beacon.BodyRoot, executionRoot[:], 9, branch))
+}
+
+func TestLightClientHandler_GetLightClientBootstrap_PreAltair(t *testing.T) {
+	helpers.ClearCache()
+	b := util.NewBeaconBlock()
+	signedBlock, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	r, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	s := &Server{
+		Stater:      &testutil.MockStater{},
+		Blocker:     &testutil.MockBlocker{BlockToReturn: signedBlock},
+		HeadFetcher: &mock.ChainService{},
+	}
+	request := httptest.NewRequest("GET", "http://foo.com/", nil)
+	request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(r[:])})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientBootstrap(writer, request)
+	require.Equal(t, http.StatusNotFound, writer.Code)
+	require.StringContains(t, "not available for pre-Altair blocks", writer.Body.String())
+}
+
+// lightClientUpdatesServer returns a server serving the updates of the light client fixture, whose
+// head state has its finalized checkpoint at the given epoch.
+func lightClientUpdatesServer(t *testing.T, l *util.TestLightClient, finalizedEpoch primitives.Epoch) *Server {
+	slot := l.State.Slot()
+	headState := l.State.Copy()
+	require.NoError(t, headState.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: finalizedEpoch, Root: make([]byte, 32)}))
+	root, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	attestedRoot, err := l.AttestedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	return &Server{
+		Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+			slot.Sub(1): l.AttestedState,
+			slot:        l.State,
+		}},
+		Blocker: &testutil.MockBlocker{
+			RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{
+				attestedRoot: l.AttestedBlock,
+				root:         l.Block,
+			},
+		},
+		HeadFetcher: &mock.ChainService{Slot: &slot, State: headState},
+	}
+}
+
+func TestLightClientHandler_GetLightClientUpdatesByRange_SSZ(t *testing.T) {
+	helpers.ClearCache()
+	config := params.BeaconConfig()
+	l := altairLightClient(t, config.SyncCommitteeSize)
+	s := lightClientUpdatesServer(t, l, 0)
+
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(l.State.Slot()))
+	url := fmt.Sprintf("http://foo.com/?count=1&start_period=%d", period)
+	request := httptest.NewRequest("GET", url, nil)
+	request.Header.Set("Accept", api.OctetStreamMediaType)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientUpdatesByRange(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := writer.Body.Bytes()
+	// attested_header + next_sync_committee + next_sync_committee_branch + finalized_header + finality_branch + sync_aggregate + signature_slot
+	updateSize := 112 + 24624 + 5*32 + 112 + 6*32 + 160 + 8
+	require.Equal(t, 8+4+updateSize, len(resp))
+	require.Equal(t, uint64(4+updateSize), binary.LittleEndian.Uint64(resp[:8]))
+	digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(l.AttestedHeader.Slot), make([]byte, 32))
+	require.NoError(t, err)
+	require.DeepEqual(t, digest[:], resp[8:12])
+	require.Equal(t, uint64(l.AttestedHeader.Slot), binary.LittleEndian.Uint64(resp[12:20]))
+	require.Equal(t, uint64(l.Block.Block().Slot()), binary.LittleEndian.Uint64(resp[len(resp)-8:]))
+}
+
+func TestLightClientHandler_GetLightClientUpdatesByRange_SSZCapella(t *testing.T) {
+	helpers.ClearCache()
+	config := params.BeaconConfig()
+	l := util.NewTestLightClient(t).SetupTestWithConfig(util.TestLightClientConfig{
+		Version:                 version.Capella,
+		Participants:            config.SyncCommitteeSize,
+		AttestedSlotBeforeBlock: true,
+	})
+	s := lightClientUpdatesServer(t, l, 0)
+
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(l.State.Slot()))
+	url := fmt.Sprintf("http://foo.com/?count=1&start_period=%d", period)
+	request := httptest.NewRequest("GET", url, nil)
+	request.Header.Set("Accept", api.OctetStreamMediaType)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientUpdatesByRange(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := writer.Body.Bytes()
+	update := resp[12:]
+	require.Equal(t, uint64(4+len(update)), binary.LittleEndian.Uint64(resp[:8]))
+	// attested_header offset + next_sync_committee + next_sync_committee_branch + finalized_header offset +
+	// finality_branch + sync_aggregate + signature_slot
+	fixedSize := 4 + 24624 + 5*32 + 4 + 6*32 + 160 + 8
+	attestedOffset := binary.LittleEndian.Uint32(update[:4])
+	finalizedOffset := binary.LittleEndian.Uint32(update[4+24624+5*32:])
+	require.Equal(t, uint32(fixedSize), attestedOffset)
+	// The light client header is made of the beacon header, the offset of the execution payload header, the
+	// execution branch and the execution payload header.
+	require.Equal(t, uint32(112+4+4*32), binary.LittleEndian.Uint32(update[attestedOffset+112:]))
+	require.Equal(t, uint64(l.AttestedHeader.Slot), binary.LittleEndian.Uint64(update[attestedOffset:]))
+	require.Equal(t, true, finalizedOffset > attestedOffset)
+	require.Equal(t, len(update), int(finalizedOffset)+112+4+4*32+(&enginev1.ExecutionPayloadHeaderCapella{}).SizeSSZ())
+	require.Equal(t, uint64(l.Block.Block().Slot()), binary.LittleEndian.Uint64(update[fixedSize-8:]))
+}
+
+func TestLightClientHandler_GetLightClientUpdatesByRange_Cache(t *testing.T) {
+	config := params.BeaconConfig()
+	period := slots.SyncCommitteePeriod(config.AltairForkEpoch)
+	url := fmt.Sprintf("http://foo.com/?count=1&start_period=%d", period)
+
+	t.Run("finalized period is cached", func(t *testing.T) {
+		helpers.ClearCache()
+		l := altairLightClient(t, config.SyncCommitteeSize)
+		s := lightClientUpdatesServer(t, l, primitives.Epoch(period+1)*config.EpochsPerSyncCommitteePeriod)
+
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientUpdatesByRange(writer, httptest.NewRequest("GET", url, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		expected := writer.Body.String()
+
+		// The update is served from the cache, without looking up states and blocks.
+		s.Stater = &testutil.MockStater{}
+		s.Blocker = &testutil.MockBlocker{}
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientUpdatesByRange(writer, httptest.NewRequest("GET", url, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, expected, writer.Body.String())
+	})
+	t.Run("unfinalized period is not cached", func(t *testing.T) {
+		helpers.ClearCache()
+		l := altairLightClient(t, config.SyncCommitteeSize)
+		s := lightClientUpdatesServer(t, l, config.AltairForkEpoch)
+
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientUpdatesByRange(writer, httptest.NewRequest("GET", url, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+
+		s.Stater = &testutil.MockStater{}
+		s.Blocker = &testutil.MockBlocker{}
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientUpdatesByRange(writer, httptest.NewRequest("GET", url, nil))
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestLightClientHandler_GetLightClientFinalityUpdate_SSZ(t *testing.T) {
+	helpers.ClearCache()
+	l := altairLightClient(t, params.BeaconConfig().MinSyncCommitteeParticipants)
+	s := lightClientUpdatesServer(t, l, 0)
+	s.HeadFetcher = &mock.ChainService{State: l.State}
+
+	request := httptest.NewRequest("GET", "http://foo.com", nil)
+	request.Header.Set("Accept", api.OctetStreamMediaType)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientFinalityUpdate(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.Equal(t, "altair", writer.Header().Get(api.VersionHeader))
+	// attested_header + finalized_header + finality_branch + sync_aggregate + signature_slot
+	require.Equal(t, 112+112+6*32+160+8, writer.Body.Len())
+	require.Equal(t, uint64(l.AttestedHeader.Slot), binary.LittleEndian.Uint64(writer.Body.Bytes()[:8]))
+}
+
+func TestLightClientHandler_GetLightClientOptimisticUpdate_SSZ(t *testing.T) {
+	helpers.ClearCache()
+	l := altairLightClient(t, params.BeaconConfig().MinSyncCommitteeParticipants)
+	s := lightClientUpdatesServer(t, l, 0)
+	s.HeadFetcher = &mock.ChainService{State: l.State}
+
+	request := httptest.NewRequest("GET", "http://foo.com", nil)
+	request.Header.Set("Accept", api.OctetStreamMediaType)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetLightClientOptimisticUpdate(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.Equal(t, "altair", writer.Header().Get(api.VersionHeader))
+	// attested_header + sync_aggregate + signature_slot
+	require.Equal(t, 112+160+8, writer.Body.Len())
+	require.Equal(t, uint64(l.AttestedHeader.Slot), binary.LittleEndian.Uint64(writer.Body.Bytes()[:8]))
+}
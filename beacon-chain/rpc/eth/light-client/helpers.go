@@ -2,19 +2,28 @@ package lightclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	v2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -35,7 +44,7 @@ import (
 //	    current_sync_committee=state.current_sync_committee,
 //	    current_sync_committee_branch=compute_merkle_proof_for_state(state, CURRENT_SYNC_COMMITTEE_INDEX)
 //	)
-func createLightClientBootstrap(ctx context.Context, state state.BeaconState) (*structs.LightClientBootstrap, error) {
+func createLightClientBootstrap(ctx context.Context, state state.BeaconState) (*v2.LightClientBootstrap, error) {
 	// assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
 	if slots.ToEpoch(state.Slot()) < params.BeaconConfig().AltairForkEpoch {
 		return nil, fmt.Errorf("light client bootstrap is not supported before Altair, invalid slot %d", state.Slot())
@@ -53,35 +62,28 @@ func createLightClientBootstrap(ctx context.Context, state state.BeaconState) (*
 		return nil, fmt.Errorf("could not get current sync committee: %s", err.Error())
 	}
 
-	committee := structs.SyncCommitteeFromConsensus(currentSyncCommittee)
-
 	currentSyncCommitteeProof, err := state.CurrentSyncCommitteeProof(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not get current sync committee proof: %s", err.Error())
 	}
 
-	branch := make([]string, fieldparams.NextSyncCommitteeBranchDepth)
-	for i, proof := range currentSyncCommitteeProof {
-		branch[i] = hexutil.Encode(proof)
-	}
-
-	header := structs.BeaconBlockHeaderFromConsensus(latestBlockHeader)
+	header := migration.V1Alpha1HeaderToV1(latestBlockHeader)
 	if header == nil {
 		return nil, fmt.Errorf("could not get beacon block header")
 	}
 
-	// Above shared util function won't calculate state root, so we need to do it manually
+	// The latest block header's state root is only filled in by the next slot, so we need to do it manually
 	stateRoot, err := state.HashTreeRoot(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not get state root: %s", err.Error())
 	}
-	header.StateRoot = hexutil.Encode(stateRoot[:])
+	header.StateRoot = stateRoot[:]
 
 	// Return result
-	result := &structs.LightClientBootstrap{
+	result := &v2.LightClientBootstrap{
 		Header:                     header,
-		CurrentSyncCommittee:       committee,
-		CurrentSyncCommitteeBranch: branch,
+		CurrentSyncCommittee:       migration.V1Alpha1SyncCommitteeToV2(currentSyncCommittee),
+		CurrentSyncCommitteeBranch: currentSyncCommitteeProof,
 	}
 
 	return result, nil
@@ -150,7 +152,7 @@ func createLightClientUpdate(
 	state state.BeaconState,
 	block interfaces.ReadOnlySignedBeaconBlock,
 	attestedState state.BeaconState,
-	finalizedBlock interfaces.ReadOnlySignedBeaconBlock) (*structs.LightClientUpdate, error) {
+	finalizedBlock interfaces.ReadOnlySignedBeaconBlock) (*v2.LightClientUpdate, error) {
 	result, err := blockchain.NewLightClientFinalityUpdateFromBeaconState(ctx, state, block, attestedState, finalizedBlock)
 	if err != nil {
 		return nil, err
@@ -161,10 +163,10 @@ func createLightClientUpdate(
 	var nextSyncCommitteeBranch [][]byte
 
 	// update_signature_period = compute_sync_committee_period(compute_epoch_at_slot(block.message.slot))
-	updateSignaturePeriod := slots.ToEpoch(block.Block().Slot())
+	updateSignaturePeriod := slots.SyncCommitteePeriod(slots.ToEpoch(block.Block().Slot()))
 
 	// update_attested_period = compute_sync_committee_period(compute_epoch_at_slot(attested_header.slot))
-	updateAttestedPeriod := slots.ToEpoch(result.AttestedHeader.Slot)
+	updateAttestedPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(result.AttestedHeader.Slot))
 
 	if updateAttestedPeriod == updateSignaturePeriod {
 		tempNextSyncCommittee, err := attestedState.NextSyncCommittee()
@@ -192,42 +194,15 @@ func createLightClientUpdate(
 			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
 		}
 
-		nextSyncCommitteeBranch = make([][]byte, fieldparams.NextSyncCommitteeBranchDepth)
-		for i := 0; i < fieldparams.NextSyncCommitteeBranchDepth; i++ {
+		nextSyncCommitteeBranch = make([][]byte, syncCommitteeBranchDepth(attestedState.Version()))
+		for i := range nextSyncCommitteeBranch {
 			nextSyncCommitteeBranch[i] = make([]byte, fieldparams.RootLength)
 		}
 	}
 
 	result.NextSyncCommittee = nextSyncCommittee
 	result.NextSyncCommitteeBranch = nextSyncCommitteeBranch
-	return newLightClientUpdateToJSON(result), nil
-}
-
-func newLightClientFinalityUpdateFromBeaconState(
-	ctx context.Context,
-	state state.BeaconState,
-	block interfaces.ReadOnlySignedBeaconBlock,
-	attestedState state.BeaconState,
-	finalizedBlock interfaces.ReadOnlySignedBeaconBlock) (*structs.LightClientUpdate, error) {
-	result, err := blockchain.NewLightClientFinalityUpdateFromBeaconState(ctx, state, block, attestedState, finalizedBlock)
-	if err != nil {
-		return nil, err
-	}
-
-	return newLightClientUpdateToJSON(result), nil
-}
-
-func newLightClientOptimisticUpdateFromBeaconState(
-	ctx context.Context,
-	state state.BeaconState,
-	block interfaces.ReadOnlySignedBeaconBlock,
-	attestedState state.BeaconState) (*structs.LightClientUpdate, error) {
-	result, err := blockchain.NewLightClientOptimisticUpdateFromBeaconState(ctx, state, block, attestedState)
-	if err != nil {
-		return nil, err
-	}
-
-	return newLightClientUpdateToJSON(result), nil
+	return result, nil
 }
 
 func NewLightClientBootstrapFromJSON(bootstrapJSON *structs.LightClientBootstrap) (*v2.LightClientBootstrap, error) {
@@ -253,6 +228,18 @@ func NewLightClientBootstrapFromJSON(bootstrapJSON *structs.LightClientBootstrap
 	return bootstrap, nil
 }
 
+func newLightClientBootstrapToJSON(input *v2.LightClientBootstrap) *structs.LightClientBootstrap {
+	if input == nil {
+		return nil
+	}
+
+	return &structs.LightClientBootstrap{
+		Header:                     structs.BeaconBlockHeaderFromConsensus(migration.V1HeaderToV1Alpha1(input.Header)),
+		CurrentSyncCommittee:       structs.SyncCommitteeFromConsensus(migration.V2SyncCommitteeToV1Alpha1(input.CurrentSyncCommittee)),
+		CurrentSyncCommitteeBranch: branchToJSON(input.CurrentSyncCommitteeBranch),
+	}
+}
+
 func branchFromJSON(branch []string) ([][]byte, error) {
 	var branchBytes [][]byte
 	for _, root := range branch {
@@ -311,3 +298,392 @@ func newLightClientUpdateToJSON(input *v2.LightClientUpdate) *structs.LightClien
 		SignatureSlot:           strconv.FormatUint(uint64(input.SignatureSlot), 10),
 	}
 }
+
+// sszSupported returns whether light client objects of the given version can be served as SSZ, writing
+// an error when they cannot.
+func sszSupported(w http.ResponseWriter, v int) bool {
+	if v < version.Altair || v > version.Electra {
+		httputil.HandleError(w, fmt.Sprintf("SSZ encoding of %s light client objects is not supported", version.String(v)), http.StatusNotAcceptable)
+		return false
+	}
+	return true
+}
+
+// syncCommitteeBranchDepth is floorlog2(CURRENT_SYNC_COMMITTEE_INDEX) of the states of the given version, which is
+// also floorlog2(NEXT_SYNC_COMMITTEE_INDEX).
+func syncCommitteeBranchDepth(v int) int {
+	if v >= version.Electra {
+		return fieldparams.NextSyncCommitteeBranchDepth + 1
+	}
+	return fieldparams.NextSyncCommitteeBranchDepth
+}
+
+// finalityBranchDepth is floorlog2(FINALIZED_ROOT_INDEX) of the states of the given version.
+func finalityBranchDepth(v int) int {
+	if v >= version.Electra {
+		return 7
+	}
+	return 6
+}
+
+// executionBranchDepth is floorlog2(EXECUTION_PAYLOAD_INDEX).
+const executionBranchDepth = 4
+
+// lightClientExecution is the execution part of a light client header of Capella or later: the header of the
+// execution payload of the block, and the proof of the payload in the block body.
+type lightClientExecution struct {
+	header *enginev1.ExecutionPayloadHeaderElectra
+	branch [][]byte
+}
+
+// newLightClientExecution implements the execution part of block_to_light_client_header. The execution of blocks
+// before Capella is empty, as is the execution of a missing or genesis block, whose light client header is empty.
+func newLightClientExecution(block interfaces.ReadOnlySignedBeaconBlock) (*lightClientExecution, error) {
+	if block == nil || block.IsNil() || block.Block().Slot() == 0 || block.Version() < version.Capella {
+		branch := make([][]byte, executionBranchDepth)
+		for i := range branch {
+			branch[i] = make([]byte, fieldparams.RootLength)
+		}
+		return &lightClientExecution{header: emptyExecutionPayloadHeader(), branch: branch}, nil
+	}
+	body := block.Block().Body()
+	payload, err := body.Execution()
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution payload: %s", err.Error())
+	}
+	header, err := executionPayloadHeader(payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution payload header: %s", err.Error())
+	}
+	branch, err := blocks.PayloadProof(body)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution payload proof: %s", err.Error())
+	}
+	return &lightClientExecution{header: header, branch: branch}, nil
+}
+
+// executionPayloadHeader returns the header of the payload, holding the fields of all forks. The fields the
+// payload's fork doesn't have are left empty.
+func executionPayloadHeader(payload interfaces.ExecutionData) (*enginev1.ExecutionPayloadHeaderElectra, error) {
+	if p, ok := payload.(interfaces.ExecutionDataElectra); ok {
+		return blocks.PayloadToHeaderElectra(p)
+	}
+	header := emptyExecutionPayloadHeader()
+	if payload.IsBlinded() {
+		if p, ok := payload.Proto().(*enginev1.ExecutionPayloadHeaderElectra); ok {
+			return p, nil
+		}
+		txRoot, err := payload.TransactionsRoot()
+		if err != nil {
+			return nil, err
+		}
+		withdrawalsRoot, err := payload.WithdrawalsRoot()
+		if err != nil {
+			return nil, err
+		}
+		header.TransactionsRoot, header.WithdrawalsRoot = txRoot, withdrawalsRoot
+	} else {
+		capellaHeader, err := blocks.PayloadToHeaderCapella(payload)
+		if err != nil {
+			return nil, err
+		}
+		header.TransactionsRoot, header.WithdrawalsRoot = capellaHeader.TransactionsRoot, capellaHeader.WithdrawalsRoot
+	}
+	header.ParentHash = payload.ParentHash()
+	header.FeeRecipient = payload.FeeRecipient()
+	header.StateRoot = payload.StateRoot()
+	header.ReceiptsRoot = payload.ReceiptsRoot()
+	header.LogsBloom = payload.LogsBloom()
+	header.PrevRandao = payload.PrevRandao()
+	header.BlockNumber = payload.BlockNumber()
+	header.GasLimit = payload.GasLimit()
+	header.GasUsed = payload.GasUsed()
+	header.Timestamp = payload.Timestamp()
+	header.ExtraData = payload.ExtraData()
+	header.BaseFeePerGas = payload.BaseFeePerGas()
+	header.BlockHash = payload.BlockHash()
+	// Capella payloads don't have blob gas fields, which are zero in the later headers.
+	if blobGasUsed, err := payload.BlobGasUsed(); err == nil {
+		header.BlobGasUsed = blobGasUsed
+	}
+	if excessBlobGas, err := payload.ExcessBlobGas(); err == nil {
+		header.ExcessBlobGas = excessBlobGas
+	}
+	return header, nil
+}
+
+func emptyExecutionPayloadHeader() *enginev1.ExecutionPayloadHeaderElectra {
+	return &enginev1.ExecutionPayloadHeaderElectra{
+		ParentHash:             make([]byte, fieldparams.RootLength),
+		FeeRecipient:           make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:              make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:           make([]byte, fieldparams.RootLength),
+		LogsBloom:              make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:             make([]byte, fieldparams.RootLength),
+		ExtraData:              make([]byte, 0),
+		BaseFeePerGas:          make([]byte, fieldparams.RootLength),
+		BlockHash:              make([]byte, fieldparams.RootLength),
+		TransactionsRoot:       make([]byte, fieldparams.RootLength),
+		WithdrawalsRoot:        make([]byte, fieldparams.RootLength),
+		DepositRequestsRoot:    make([]byte, fieldparams.RootLength),
+		WithdrawalRequestsRoot: make([]byte, fieldparams.RootLength),
+	}
+}
+
+// marshalSSZ encodes the header as the ExecutionPayloadHeader of the given version.
+func (e *lightClientExecution) marshalSSZ(v int) ([]byte, error) {
+	h := e.header
+	switch {
+	case v >= version.Electra:
+		return h.MarshalSSZ()
+	case v == version.Deneb:
+		return (&enginev1.ExecutionPayloadHeaderDeneb{
+			ParentHash:       h.ParentHash,
+			FeeRecipient:     h.FeeRecipient,
+			StateRoot:        h.StateRoot,
+			ReceiptsRoot:     h.ReceiptsRoot,
+			LogsBloom:        h.LogsBloom,
+			PrevRandao:       h.PrevRandao,
+			BlockNumber:      h.BlockNumber,
+			GasLimit:         h.GasLimit,
+			GasUsed:          h.GasUsed,
+			Timestamp:        h.Timestamp,
+			ExtraData:        h.ExtraData,
+			BaseFeePerGas:    h.BaseFeePerGas,
+			BlockHash:        h.BlockHash,
+			TransactionsRoot: h.TransactionsRoot,
+			WithdrawalsRoot:  h.WithdrawalsRoot,
+			BlobGasUsed:      h.BlobGasUsed,
+			ExcessBlobGas:    h.ExcessBlobGas,
+		}).MarshalSSZ()
+	default:
+		return (&enginev1.ExecutionPayloadHeaderCapella{
+			ParentHash:       h.ParentHash,
+			FeeRecipient:     h.FeeRecipient,
+			StateRoot:        h.StateRoot,
+			ReceiptsRoot:     h.ReceiptsRoot,
+			LogsBloom:        h.LogsBloom,
+			PrevRandao:       h.PrevRandao,
+			BlockNumber:      h.BlockNumber,
+			GasLimit:         h.GasLimit,
+			GasUsed:          h.GasUsed,
+			Timestamp:        h.Timestamp,
+			ExtraData:        h.ExtraData,
+			BaseFeePerGas:    h.BaseFeePerGas,
+			BlockHash:        h.BlockHash,
+			TransactionsRoot: h.TransactionsRoot,
+			WithdrawalsRoot:  h.WithdrawalsRoot,
+		}).MarshalSSZ()
+	}
+}
+
+// sszField is a field of an SSZ container. Variable-size fields are encoded after the fixed-size part of the
+// container, which holds their offsets.
+type sszField struct {
+	ssz      []byte
+	variable bool
+}
+
+func marshalContainerSSZ(fields ...sszField) []byte {
+	fixedSize, size := 0, 0
+	for _, f := range fields {
+		if f.variable {
+			fixedSize += 4
+		} else {
+			fixedSize += len(f.ssz)
+		}
+		size += len(f.ssz)
+	}
+	dst := make([]byte, 0, fixedSize+size)
+	offset := fixedSize
+	for _, f := range fields {
+		if !f.variable {
+			dst = append(dst, f.ssz...)
+			continue
+		}
+		dst = ssz.MarshalUint32(dst, uint32(offset))
+		offset += len(f.ssz)
+	}
+	for _, f := range fields {
+		if f.variable {
+			dst = append(dst, f.ssz...)
+		}
+	}
+	return dst
+}
+
+// marshalLightClientHeaderSSZ encodes the LightClientHeader of the given version. Before Capella, the light client
+// header is the beacon block header, later headers also hold the execution part.
+func marshalLightClientHeaderSSZ(v int, header *v1.BeaconBlockHeader, execution *lightClientExecution) (sszField, error) {
+	beacon, err := marshalHeaderSSZ(nil, header)
+	if err != nil {
+		return sszField{}, err
+	}
+	if v < version.Capella {
+		return sszField{ssz: beacon}, nil
+	}
+	if execution == nil {
+		return sszField{}, errors.New("nil execution")
+	}
+	executionHeader, err := execution.marshalSSZ(v)
+	if err != nil {
+		return sszField{}, err
+	}
+	branch, err := marshalBranchSSZ(nil, execution.branch, executionBranchDepth)
+	if err != nil {
+		return sszField{}, err
+	}
+	return sszField{
+		ssz:      marshalContainerSSZ(sszField{ssz: beacon}, sszField{ssz: executionHeader, variable: true}, sszField{ssz: branch}),
+		variable: true,
+	}, nil
+}
+
+func marshalLightClientBootstrapSSZ(v int, bootstrap *v2.LightClientBootstrap, execution *lightClientExecution) ([]byte, error) {
+	header, err := marshalLightClientHeaderSSZ(v, bootstrap.Header, execution)
+	if err != nil {
+		return nil, err
+	}
+	if bootstrap.CurrentSyncCommittee == nil {
+		return nil, errors.New("nil current sync committee")
+	}
+	syncCommittee, err := bootstrap.CurrentSyncCommittee.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	branch, err := marshalBranchSSZ(nil, bootstrap.CurrentSyncCommitteeBranch, syncCommitteeBranchDepth(v))
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainerSSZ(header, sszField{ssz: syncCommittee}, sszField{ssz: branch}), nil
+}
+
+func marshalLightClientUpdateSSZ(u *periodUpdate) ([]byte, error) {
+	update := u.update
+	attestedHeader, err := marshalLightClientHeaderSSZ(u.version, update.AttestedHeader, u.attestedExecution)
+	if err != nil {
+		return nil, err
+	}
+	if update.NextSyncCommittee == nil {
+		return nil, errors.New("nil next sync committee")
+	}
+	nextSyncCommittee, err := update.NextSyncCommittee.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	nextSyncCommitteeBranch, err := marshalBranchSSZ(nil, update.NextSyncCommitteeBranch, syncCommitteeBranchDepth(u.version))
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := marshalLightClientHeaderSSZ(u.version, update.FinalizedHeader, u.finalizedExecution)
+	if err != nil {
+		return nil, err
+	}
+	finalityBranch, err := marshalBranchSSZ(nil, update.FinalityBranch, finalityBranchDepth(u.version))
+	if err != nil {
+		return nil, err
+	}
+	syncAggregateAndSlot, err := marshalSyncAggregateAndSlotSSZ(nil, update.SyncAggregate, update.SignatureSlot)
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainerSSZ(
+		attestedHeader,
+		sszField{ssz: nextSyncCommittee},
+		sszField{ssz: nextSyncCommitteeBranch},
+		finalizedHeader,
+		sszField{ssz: finalityBranch},
+		sszField{ssz: syncAggregateAndSlot},
+	), nil
+}
+
+func marshalLightClientFinalityUpdateSSZ(
+	v int,
+	update *v2.LightClientFinalityUpdate,
+	attestedExecution, finalizedExecution *lightClientExecution,
+) ([]byte, error) {
+	attestedHeader, err := marshalLightClientHeaderSSZ(v, update.AttestedHeader, attestedExecution)
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := marshalLightClientHeaderSSZ(v, update.FinalizedHeader, finalizedExecution)
+	if err != nil {
+		return nil, err
+	}
+	finalityBranch, err := marshalBranchSSZ(nil, update.FinalityBranch, finalityBranchDepth(v))
+	if err != nil {
+		return nil, err
+	}
+	syncAggregateAndSlot, err := marshalSyncAggregateAndSlotSSZ(nil, update.SyncAggregate, update.SignatureSlot)
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainerSSZ(attestedHeader, finalizedHeader, sszField{ssz: finalityBranch}, sszField{ssz: syncAggregateAndSlot}), nil
+}
+
+func marshalLightClientOptimisticUpdateSSZ(v int, update *v2.LightClientOptimisticUpdate, attestedExecution *lightClientExecution) ([]byte, error) {
+	attestedHeader, err := marshalLightClientHeaderSSZ(v, update.AttestedHeader, attestedExecution)
+	if err != nil {
+		return nil, err
+	}
+	syncAggregateAndSlot, err := marshalSyncAggregateAndSlotSSZ(nil, update.SyncAggregate, update.SignatureSlot)
+	if err != nil {
+		return nil, err
+	}
+	return marshalContainerSSZ(attestedHeader, sszField{ssz: syncAggregateAndSlot}), nil
+}
+
+func marshalHeaderSSZ(dst []byte, header *v1.BeaconBlockHeader) ([]byte, error) {
+	if header == nil {
+		return nil, errors.New("nil header")
+	}
+	return header.MarshalSSZTo(dst)
+}
+
+func marshalBranchSSZ(dst []byte, branch [][]byte, depth int) ([]byte, error) {
+	if len(branch) != depth {
+		return nil, fmt.Errorf("branch has %d leaves, expected %d", len(branch), depth)
+	}
+	for _, leaf := range branch {
+		if len(leaf) != fieldparams.RootLength {
+			return nil, fmt.Errorf("branch leaf has length %d, expected %d", len(leaf), fieldparams.RootLength)
+		}
+		dst = append(dst, leaf...)
+	}
+	return dst, nil
+}
+
+func marshalSyncAggregateAndSlotSSZ(dst []byte, syncAggregate *v1.SyncAggregate, signatureSlot primitives.Slot) ([]byte, error) {
+	if syncAggregate == nil {
+		return nil, errors.New("nil sync aggregate")
+	}
+	dst, err := syncAggregate.MarshalSSZTo(dst)
+	if err != nil {
+		return nil, err
+	}
+	return ssz.MarshalUint64(dst, uint64(signatureSlot)), nil
+}
+
+// marshalLightClientUpdatesSSZ encodes the updates as a sequence of response chunks. Each chunk is made of the
+// length of the rest of the chunk as a little-endian uint64, the fork digest of the update's attested header
+// epoch, and the SSZ encoded update.
+func marshalLightClientUpdatesSSZ(updates []*periodUpdate, genesisValidatorsRoot []byte) ([]byte, error) {
+	var resp []byte
+	for _, u := range updates {
+		if u.update.AttestedHeader == nil {
+			return nil, errors.New("nil attested header")
+		}
+		digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(u.update.AttestedHeader.Slot), genesisValidatorsRoot)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute fork digest: %s", err.Error())
+		}
+		payload, err := marshalLightClientUpdateSSZ(u)
+		if err != nil {
+			return nil, err
+		}
+		resp = ssz.MarshalUint64(resp, uint64(len(digest)+len(payload)))
+		resp = append(resp, digest[:]...)
+		resp = append(resp, payload...)
+	}
+	return resp, nil
+}
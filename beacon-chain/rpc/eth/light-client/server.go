@@ -1,12 +1,49 @@
 package lightclient
 
 import (
+	"sync"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	v2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 )
 
 type Server struct {
 	Blocker     lookup.Blocker
 	Stater      lookup.Stater
 	HeadFetcher blockchain.HeadFetcher
+
+	updates updatesCache
+}
+
+// periodUpdate is the light client update of a sync committee period, along with the version of its attested state
+// and the execution parts of its headers.
+type periodUpdate struct {
+	version            int
+	update             *v2.LightClientUpdate
+	attestedExecution  *lightClientExecution
+	finalizedExecution *lightClientExecution
+}
+
+// updatesCache caches light client updates by sync committee period. Only updates of finalized
+// periods are cached, as they are expensive to recompute but can no longer change.
+type updatesCache struct {
+	sync.RWMutex
+	updates map[uint64]*periodUpdate
+}
+
+func (c *updatesCache) get(period uint64) (*periodUpdate, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	u, ok := c.updates[period]
+	return u, ok
+}
+
+func (c *updatesCache) add(period uint64, u *periodUpdate) {
+	c.Lock()
+	defer c.Unlock()
+	if c.updates == nil {
+		c.updates = make(map[uint64]*periodUpdate)
+	}
+	c.updates[period] = u
 }
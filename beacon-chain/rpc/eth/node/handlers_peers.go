@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
@@ -13,7 +14,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
-	"github.com/prysmaticlabs/prysm/v5/proto/migration"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"go.opencensus.io/trace"
 )
@@ -35,56 +35,17 @@ func (s *Server) GetPeer(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Invalid peer ID: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	enr, err := peerStatus.ENR(id)
-	if err != nil {
-		if errors.Is(err, peerdata.ErrPeerUnknown) {
-			httputil.HandleError(w, "Peer not found: "+err.Error(), http.StatusNotFound)
-			return
-		}
-		httputil.HandleError(w, "Could not obtain ENR: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	serializedEnr, err := p2p.SerializeENR(enr)
+	p, err := s.peerInfo(peerStatus, id)
 	if err != nil {
-		httputil.HandleError(w, "Could not obtain ENR: "+err.Error(), http.StatusInternalServerError)
+		httputil.HandleError(w, "Could not get peer info: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	p2pAddress, err := peerStatus.Address(id)
-	if err != nil {
-		httputil.HandleError(w, "Could not obtain address: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	state, err := peerStatus.ConnectionState(id)
-	if err != nil {
-		httputil.HandleError(w, "Could not obtain connection state: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	direction, err := peerStatus.Direction(id)
-	if err != nil {
-		httputil.HandleError(w, "Could not obtain direction: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	if eth.PeerDirection(direction) == eth.PeerDirection_UNKNOWN {
+	if p == nil {
 		httputil.HandleError(w, "Peer not found", http.StatusNotFound)
 		return
 	}
 
-	v1ConnState := migration.V1Alpha1ConnectionStateToV1(eth.ConnectionState(state))
-	v1PeerDirection, err := migration.V1Alpha1PeerDirectionToV1(eth.PeerDirection(direction))
-	if err != nil {
-		httputil.HandleError(w, "Could not handle peer direction: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	resp := &structs.GetPeerResponse{
-		Data: &structs.Peer{
-			PeerId:             rawId,
-			Enr:                "enr:" + serializedEnr,
-			LastSeenP2PAddress: p2pAddress.String(),
-			State:              strings.ToLower(v1ConnState.String()),
-			Direction:          strings.ToLower(v1PeerDirection.String()),
-		},
-	}
+	resp := &structs.GetPeerResponse{Data: p}
 	httputil.WriteJson(w, resp)
 }
 
@@ -93,81 +54,14 @@ func (s *Server) GetPeers(w http.ResponseWriter, r *http.Request) {
 	_, span := trace.StartSpan(r.Context(), "node.GetPeers")
 	defer span.End()
 
-	states := r.URL.Query()["state"]
-	directions := r.URL.Query()["direction"]
+	stateFilter := peerFilter(r.URL.Query()["state"], stateConnecting, stateConnected, stateDisconnecting, stateDisconnected)
+	directionFilter := peerFilter(r.URL.Query()["direction"], directionInbound, directionOutbound)
 
 	peerStatus := s.PeersFetcher.Peers()
-	emptyStateFilter, emptyDirectionFilter := handleEmptyFilters(states, directions)
-
-	if emptyStateFilter && emptyDirectionFilter {
-		allIds := peerStatus.All()
-		allPeers := make([]*structs.Peer, 0, len(allIds))
-		for _, id := range allIds {
-			p, err := peerInfo(peerStatus, id)
-			if err != nil {
-				httputil.HandleError(w, "Could not get peer info: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			if p == nil {
-				continue
-			}
-			allPeers = append(allPeers, p)
-		}
-		resp := &structs.GetPeersResponse{Data: allPeers}
-		httputil.WriteJson(w, resp)
-		return
-	}
-
-	var stateIds []peer.ID
-	if emptyStateFilter {
-		stateIds = peerStatus.All()
-	} else {
-		for _, stateFilter := range states {
-			switch strings.ToUpper(stateFilter) {
-			case stateConnecting:
-				ids := peerStatus.Connecting()
-				stateIds = append(stateIds, ids...)
-			case stateConnected:
-				ids := peerStatus.Connected()
-				stateIds = append(stateIds, ids...)
-			case stateDisconnecting:
-				ids := peerStatus.Disconnecting()
-				stateIds = append(stateIds, ids...)
-			case stateDisconnected:
-				ids := peerStatus.Disconnected()
-				stateIds = append(stateIds, ids...)
-			}
-		}
-	}
-
-	var directionIds []peer.ID
-	if emptyDirectionFilter {
-		directionIds = peerStatus.All()
-	} else {
-		for _, directionFilter := range directions {
-			switch strings.ToUpper(directionFilter) {
-			case directionInbound:
-				ids := peerStatus.Inbound()
-				directionIds = append(directionIds, ids...)
-			case directionOutbound:
-				ids := peerStatus.Outbound()
-				directionIds = append(directionIds, ids...)
-			}
-		}
-	}
-
-	var filteredIds []peer.ID
-	for _, stateId := range stateIds {
-		for _, directionId := range directionIds {
-			if stateId.String() == directionId.String() {
-				filteredIds = append(filteredIds, stateId)
-				break
-			}
-		}
-	}
-	filteredPeers := make([]*structs.Peer, 0, len(filteredIds))
-	for _, id := range filteredIds {
-		p, err := peerInfo(peerStatus, id)
+	allIds := peerStatus.All()
+	filteredPeers := make([]*structs.Peer, 0, len(allIds))
+	for _, id := range allIds {
+		p, err := s.peerInfo(peerStatus, id)
 		if err != nil {
 			httputil.HandleError(w, "Could not get peer info: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -175,6 +69,12 @@ func (s *Server) GetPeers(w http.ResponseWriter, r *http.Request) {
 		if p == nil {
 			continue
 		}
+		if stateFilter != nil && !stateFilter[strings.ToUpper(p.State)] {
+			continue
+		}
+		if directionFilter != nil && !directionFilter[strings.ToUpper(p.Direction)] {
+			continue
+		}
 		filteredPeers = append(filteredPeers, p)
 	}
 
@@ -200,32 +100,32 @@ func (s *Server) GetPeerCount(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
-func handleEmptyFilters(states []string, directions []string) (emptyState, emptyDirection bool) {
-	emptyState = true
-	for _, stateFilter := range states {
-		normalized := strings.ToUpper(stateFilter)
-		filterValid := normalized == stateConnecting || normalized == stateConnected ||
-			normalized == stateDisconnecting || normalized == stateDisconnected
-		if filterValid {
-			emptyState = false
-			break
-		}
-	}
-
-	emptyDirection = true
-	for _, directionFilter := range directions {
-		normalized := strings.ToUpper(directionFilter)
-		filterValid := normalized == directionInbound || normalized == directionOutbound
-		if filterValid {
-			emptyDirection = false
-			break
+// peerFilter returns the set of the valid values requested for a filter, which can be repeated or
+// comma-separated. Unknown values are ignored, and nil is returned when no valid value is requested
+// so that the filter matches all peers.
+func peerFilter(requested []string, valid ...string) map[string]bool {
+	var filter map[string]bool
+	for _, r := range requested {
+		for _, value := range strings.Split(r, ",") {
+			normalized := strings.ToUpper(strings.TrimSpace(value))
+			for _, v := range valid {
+				if normalized != v {
+					continue
+				}
+				if filter == nil {
+					filter = make(map[string]bool, len(valid))
+				}
+				filter[normalized] = true
+			}
 		}
 	}
-
-	return emptyState, emptyDirection
+	return filter
 }
 
-func peerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
+// peerInfo returns the data of a peer known to the peer status store, or nil if the peer is unknown
+// or its direction can't be determined. When a connection to the peer is open, the peer is reported
+// with the address we are connected on and the direction the connection was opened in.
+func (s *Server) peerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
 	enr, err := peerStatus.ENR(id)
 	if err != nil {
 		if errors.Is(err, peerdata.ErrPeerUnknown) {
@@ -261,6 +161,12 @@ func peerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
 		}
 		return nil, errors.Wrap(err, "could not obtain direction")
 	}
+	if conn := s.openConnection(id); conn != nil {
+		address = conn.RemoteMultiaddr()
+		if direction == network.DirUnknown {
+			direction = conn.Stat().Direction
+		}
+	}
 	if eth.PeerDirection(direction) == eth.PeerDirection_UNKNOWN {
 		return nil, nil
 	}
@@ -278,3 +184,15 @@ func peerInfo(peerStatus *peers.Status, id peer.ID) (*structs.Peer, error) {
 
 	return p, nil
 }
+
+// openConnection returns a connection open with the given peer, or nil if there is none.
+func (s *Server) openConnection(id peer.ID) network.Conn {
+	if s.PeerManager == nil || s.PeerManager.Host() == nil {
+		return nil
+	}
+	conns := s.PeerManager.Host().Network().ConnsToPeer(id)
+	if len(conns) == 0 {
+		return nil
+	}
+	return conns[0]
+}
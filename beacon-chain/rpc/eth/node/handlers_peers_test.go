@@ -196,6 +196,12 @@ func TestGetPeers(t *testing.T) {
 			directions: []string{"inbound", "outbound"},
 			wantIds:    []peer.ID{ids[0], ids[1], ids[4], ids[5]},
 		},
+		{
+			name:       "Comma-separated states and directions",
+			states:     []string{"connecting,DISCONNECTING"},
+			directions: []string{"inbound, outbound"},
+			wantIds:    []peer.ID{ids[0], ids[1], ids[4], ids[5]},
+		},
 		{
 			name:       "Unknown filter is ignored",
 			states:     []string{"connected", "foo"},
@@ -247,6 +253,39 @@ func TestGetPeers(t *testing.T) {
 	}
 }
 
+func TestGetPeers_OpenConnection(t *testing.T) {
+	local := mockp2p.NewTestP2P(t)
+	remote := mockp2p.NewTestP2P(t)
+	local.Connect(remote)
+
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	peerStatus := peerFetcher.Peers()
+	// The peer is not yet known to be inbound nor to have an address.
+	peerStatus.Add(nil, remote.PeerID(), nil, network.DirUnknown)
+	peerStatus.SetConnectionState(remote.PeerID(), peers.PeerConnected)
+	s := Server{
+		PeersFetcher: peerFetcher,
+		PeerManager:  &mockp2p.MockPeerManager{BHost: local.BHost},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/node/peers?direction=outbound", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetPeers(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetPeersResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	conns := local.BHost.Network().ConnsToPeer(remote.PeerID())
+	require.NotEqual(t, 0, len(conns))
+	assert.Equal(t, remote.PeerID().String(), resp.Data[0].PeerId)
+	assert.Equal(t, conns[0].RemoteMultiaddr().String(), resp.Data[0].LastSeenP2PAddress)
+	assert.Equal(t, "connected", resp.Data[0].State)
+	assert.Equal(t, "outbound", resp.Data[0].Direction)
+}
+
 func TestGetPeers_NoPeersReturnsEmptyArray(t *testing.T) {
 	peerFetcher := &mockp2p.MockPeersProvider{}
 	peerFetcher.ClearPeers()
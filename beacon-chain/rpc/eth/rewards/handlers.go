@@ -45,16 +45,20 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
-	if err != nil {
-		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
 	blkRoot, err := blk.Block().HashTreeRoot()
 	if err != nil {
 		httputil.HandleError(w, "Could not get block root: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// Blocks without an execution payload can't be optimistic.
+	optimistic := false
+	if blk.Version() >= version.Bellatrix {
+		optimistic, err = s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, blkRoot)
+		if err != nil {
+			httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	blockRewards, httpError := s.BlockRewardFetcher.GetBlockRewardsData(ctx, blk.Block())
 	if httpError != nil {
 		httputil.WriteError(w, httpError)
@@ -88,16 +92,19 @@ func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
-	if err != nil {
-		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
 	blkRoot, err := st.LatestBlockHeader().HashTreeRoot()
 	if err != nil {
 		httputil.HandleError(w, "Could not get block root: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	optimistic := false
+	if st.Version() >= version.Bellatrix {
+		optimistic, err = s.OptimisticModeFetcher.IsOptimisticForRoot(r.Context(), blkRoot)
+		if err != nil {
+			httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	resp := &structs.AttestationRewardsResponse{
 		Data: structs.AttestationRewards{
@@ -165,22 +172,26 @@ func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
 			httputil.HandleError(w, "Could not get validator's balance: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
-		rewards[i] = int(bal - preProcessBals[i]) // lint:ignore uintcast
+		// Members absent from the sync aggregate are penalized, which results in a negative reward.
+		rewards[i] = int(bal) - int(preProcessBals[i]) // lint:ignore uintcast
 		if valIdx == proposerIndex {
 			rewards[i] = rewards[i] - int(proposerReward) // lint:ignore uintcast
 		}
 	}
 
-	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
-	if err != nil {
-		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
 	blkRoot, err := blk.Block().HashTreeRoot()
 	if err != nil {
 		httputil.HandleError(w, "Could not get block root: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	optimistic := false
+	if blk.Version() >= version.Bellatrix {
+		optimistic, err = s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, blkRoot)
+		if err != nil {
+			httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	scRewards := make([]structs.SyncCommitteeReward, len(valIndices))
 	for i, valIdx := range valIndices {
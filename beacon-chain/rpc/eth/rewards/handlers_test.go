@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	dbutil "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -250,14 +251,16 @@ func TestBlockRewards(t *testing.T) {
 		assert.Equal(t, "48", resp.Data.SyncAggregate)
 		assert.Equal(t, "62500000", resp.Data.AttesterSlashings)
 		assert.Equal(t, "62500000", resp.Data.ProposerSlashings)
-		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.ExecutionOptimistic)
 		assert.Equal(t, false, resp.Finalized)
 	})
 	t.Run("bellatrix", func(t *testing.T) {
 		st, sbb, err := BlockRewardTestSetup(t, "bellatrix")
 		require.NoError(t, err)
+		blkRoot, err := sbb.Block().HashTreeRoot()
+		require.NoError(t, err)
 
-		mockChainService := &mock.ChainService{Optimistic: true}
+		mockChainService := &mock.ChainService{OptimisticRoots: map[[32]byte]bool{blkRoot: true}}
 		s := &Server{
 			Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
 				0: phase0block,
@@ -292,8 +295,10 @@ func TestBlockRewards(t *testing.T) {
 	t.Run("capella", func(t *testing.T) {
 		st, sbb, err := BlockRewardTestSetup(t, "capella")
 		require.NoError(t, err)
+		blkRoot, err := sbb.Block().HashTreeRoot()
+		require.NoError(t, err)
 
-		mockChainService := &mock.ChainService{Optimistic: true}
+		mockChainService := &mock.ChainService{OptimisticRoots: map[[32]byte]bool{blkRoot: true}}
 		s := &Server{
 			Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
 				0: phase0block,
@@ -328,8 +333,13 @@ func TestBlockRewards(t *testing.T) {
 	t.Run("deneb", func(t *testing.T) {
 		st, sbb, err := BlockRewardTestSetup(t, "deneb")
 		require.NoError(t, err)
+		blkRoot, err := sbb.Block().HashTreeRoot()
+		require.NoError(t, err)
 
-		mockChainService := &mock.ChainService{Optimistic: true}
+		mockChainService := &mock.ChainService{
+			OptimisticRoots: map[[32]byte]bool{blkRoot: true},
+			FinalizedRoots:  map[[32]byte]bool{blkRoot: true},
+		}
 		s := &Server{
 			Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
 				0: phase0block,
@@ -359,10 +369,74 @@ func TestBlockRewards(t *testing.T) {
 		assert.Equal(t, "62500000", resp.Data.AttesterSlashings)
 		assert.Equal(t, "62500000", resp.Data.ProposerSlashings)
 		assert.Equal(t, true, resp.ExecutionOptimistic)
-		assert.Equal(t, false, resp.Finalized)
+		assert.Equal(t, true, resp.Finalized)
 	})
 }
 
+func TestBlockRewards_GeneratedBlock(t *testing.T) {
+	helpers.ClearCache()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	ctx := context.Background()
+
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	sc, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(sc))
+	require.NoError(t, genesis.SetNextSyncCommittee(sc))
+	conf := &util.BlockGenConfig{
+		NumAttestations:      2,
+		NumProposerSlashings: 1,
+		NumAttesterSlashings: 1,
+		FullSyncAggregate:    true,
+	}
+	b, err := util.GenerateFullBlockAltair(genesis, keys, conf, 1)
+	require.NoError(t, err)
+	sbb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	preState, err := transition.ProcessSlots(ctx, genesis.Copy(), 1)
+	require.NoError(t, err)
+
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			1: sbb,
+		}},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		BlockRewardFetcher: &BlockRewardService{
+			Replayer: mockstategen.NewReplayerBuilder(mockstategen.WithMockState(preState)),
+			DB:       dbutil.SetupDB(t),
+		},
+	}
+
+	url := "http://only.the.slot.number.at.the.end.is.important/1"
+	request := httptest.NewRequest("GET", url, nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.BlockRewards(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.BlockRewardsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, strconv.FormatUint(uint64(sbb.Block().ProposerIndex()), 10), resp.Data.ProposerIndex)
+	assert.Equal(t, "344990", resp.Data.Attestations)
+	// Each slashed validator has the maximum effective balance, of which the proposer gets
+	// 1/WHISTLEBLOWER_REWARD_QUOTIENT.
+	slashingReward := params.BeaconConfig().MaxEffectiveBalance / params.BeaconConfig().WhistleBlowerRewardQuotient
+	assert.Equal(t, strconv.FormatUint(slashingReward, 10), resp.Data.AttesterSlashings)
+	assert.Equal(t, strconv.FormatUint(slashingReward, 10), resp.Data.ProposerSlashings)
+	// The proposer is rewarded for every participant of the full sync aggregate.
+	activeBalance, err := helpers.TotalActiveBalance(preState)
+	require.NoError(t, err)
+	proposerReward, _, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatUint(proposerReward*params.BeaconConfig().SyncCommitteeSize, 10), resp.Data.SyncAggregate)
+	assert.Equal(t, strconv.FormatUint(344990+2*slashingReward+proposerReward*params.BeaconConfig().SyncCommitteeSize, 10), resp.Data.Total)
+	assert.Equal(t, false, resp.ExecutionOptimistic)
+	assert.Equal(t, false, resp.Finalized)
+}
+
 func TestAttestationRewards(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	cfg := params.BeaconConfig()
@@ -485,6 +559,145 @@ func TestAttestationRewards(t *testing.T) {
 			sum += hr + sr + tr
 		}
 		assert.Equal(t, uint64(54221955), sum)
+		assert.Equal(t, false, resp.ExecutionOptimistic)
+	})
+	t.Run("optimistic", func(t *testing.T) {
+		blkRoot, err := st.LatestBlockHeader().HashTreeRoot()
+		require.NoError(t, err)
+		mockChainService := &mock.ChainService{Slot: &currentSlot, OptimisticRoots: map[[32]byte]bool{blkRoot: true}}
+		s := &Server{
+			Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+				params.BeaconConfig().SlotsPerEpoch*3 - 1: st,
+			}},
+			TimeFetcher:           mockChainService,
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+		}
+
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+	})
+	t.Run("partial participation", func(t *testing.T) {
+		ctx := context.Background()
+		helpers.ClearCache()
+		// The state is upgraded to Altair at the start of epoch 1.
+		st, privs := util.DeterministicGenesisState(t, uint64(valCount))
+		var err error
+		// Half of every committee of epoch 1 attests, and the attestations are included at the next slot.
+		slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+		st, err = transition.ProcessSlots(ctx, st, slotsPerEpoch)
+		require.NoError(t, err)
+		for slot := slotsPerEpoch; slot < 2*slotsPerEpoch; slot++ {
+			atts, err := util.GenerateAttestations(st, privs, 1, slot, false, util.WithParticipationRate(0.5))
+			require.NoError(t, err)
+			st, err = transition.ProcessSlots(ctx, st, slot+1)
+			require.NoError(t, err)
+			blk := util.NewBeaconBlockAltair()
+			blk.Block.Slot = slot + 1
+			for _, att := range atts {
+				blk.Block.Body.Attestations = append(blk.Block.Body.Attestations, att.(*eth.Attestation))
+			}
+			rb, err := blocks.NewBeaconBlock(blk.Block)
+			require.NoError(t, err)
+			st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, rb)
+			require.NoError(t, err)
+		}
+		st, err = transition.ProcessSlots(ctx, st, 3*slotsPerEpoch-1)
+		require.NoError(t, err)
+		participation, err := st.PreviousEpochParticipation()
+		require.NoError(t, err)
+
+		s := &Server{
+			Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+				3*slotsPerEpoch - 1: st,
+			}},
+			TimeFetcher:           mockChainService,
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+		}
+
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		// All validators have the maximum effective balance.
+		require.Equal(t, 1, len(resp.Data.IdealRewards))
+		ideal := resp.Data.IdealRewards[0]
+		require.Equal(t, valCount, len(resp.Data.TotalRewards))
+		attested := 0
+		for i, r := range resp.Data.TotalRewards {
+			if participation[i] == 0 {
+				// The missed source and target votes are penalized, the missed head vote is not.
+				assert.Equal(t, "0", r.Head)
+				assert.Equal(t, true, strings.HasPrefix(r.Source, "-"))
+				assert.Equal(t, true, strings.HasPrefix(r.Target, "-"))
+				continue
+			}
+			attested++
+			// The attestations were included at the next slot, so every vote was timely and earns the ideal reward.
+			assert.Equal(t, ideal.Head, r.Head)
+			assert.Equal(t, ideal.Source, r.Source)
+			assert.Equal(t, ideal.Target, r.Target)
+		}
+		assert.Equal(t, valCount/2, attested)
+	})
+	t.Run("inactive validator", func(t *testing.T) {
+		st := st.Copy()
+		farFuture := params.BeaconConfig().FarFutureEpoch
+		pending := util.ValidatorEpochs{
+			ActivationEligibilityEpoch: farFuture,
+			ActivationEpoch:            farFuture,
+			ExitEpoch:                  farFuture,
+			WithdrawableEpoch:          farFuture,
+		}
+		require.NoError(t, util.SetValidatorEpochs(st, 62, pending))
+		participation, err := st.PreviousEpochParticipation()
+		require.NoError(t, err)
+		participation[62] = 0
+		require.NoError(t, st.SetPreviousParticipationBits(participation))
+
+		s := &Server{
+			Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+				params.BeaconConfig().SlotsPerEpoch*3 - 1: st,
+			}},
+			TimeFetcher:           mockChainService,
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+		}
+
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		var body bytes.Buffer
+		valIds, err := json.Marshal([]string{"62"})
+		require.NoError(t, err)
+		_, err = body.Write(valIds)
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data.TotalRewards))
+		// A validator that was not active in the epoch is neither rewarded nor penalized.
+		assert.Equal(t, "0", resp.Data.TotalRewards[0].Head)
+		assert.Equal(t, "0", resp.Data.TotalRewards[0].Source)
+		assert.Equal(t, "0", resp.Data.TotalRewards[0].Target)
+		assert.Equal(t, "0", resp.Data.TotalRewards[0].Inactivity)
 	})
 	t.Run("penalty", func(t *testing.T) {
 		st := st.Copy()
@@ -720,8 +933,11 @@ func TestSyncCommiteeRewards(t *testing.T) {
 	phase0block, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
 	require.NoError(t, err)
 
+	blkRoot, err := sbb.Block().HashTreeRoot()
+	require.NoError(t, err)
+
 	currentSlot := params.BeaconConfig().SlotsPerEpoch
-	mockChainService := &mock.ChainService{Optimistic: true, Slot: &currentSlot}
+	mockChainService := &mock.ChainService{OptimisticRoots: map[[32]byte]bool{blkRoot: true}, Slot: &currentSlot}
 	s := &Server{
 		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
 			0:  phase0block,
@@ -949,3 +1165,96 @@ func TestSyncCommiteeRewards(t *testing.T) {
 		assert.Equal(t, "Sync committee rewards are not supported for Phase 0", e.Message)
 	})
 }
+
+func TestSyncCommitteeRewards_GeneratedBlock(t *testing.T) {
+	helpers.ClearCache()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	ctx := context.Background()
+
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	sc, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(sc))
+	require.NoError(t, genesis.SetNextSyncCommittee(sc))
+	// Only the first half of the sync committee positions participate.
+	b, err := util.GenerateFullBlockAltair(genesis, keys, &util.BlockGenConfig{SyncParticipation: 0.5}, 1)
+	require.NoError(t, err)
+	sbb, err := util.WrapSignedBlock(b)
+	require.NoError(t, err)
+	preState, err := transition.ProcessSlots(ctx, genesis.Copy(), 1)
+	require.NoError(t, err)
+
+	// Every position of a validator in the sync committee is rewarded if it participated, and
+	// penalized by the same amount otherwise.
+	activeBalance, err := helpers.TotalActiveBalance(preState)
+	require.NoError(t, err)
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+	expected := make(map[string]int)
+	for i, pk := range sc.Pubkeys {
+		idx, ok := preState.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		require.Equal(t, true, ok)
+		key := strconv.FormatUint(uint64(idx), 10)
+		if uint64(i) < params.BeaconConfig().SyncCommitteeSize/2 {
+			expected[key] += int(participantReward)
+		} else {
+			expected[key] -= int(participantReward)
+		}
+	}
+
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			1: sbb,
+		}},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		BlockRewardFetcher: &BlockRewardService{
+			Replayer: mockstategen.NewReplayerBuilder(mockstategen.WithMockState(preState)),
+			DB:       dbutil.SetupDB(t),
+		},
+	}
+
+	t.Run("all members", func(t *testing.T) {
+		url := "http://only.the.slot.number.at.the.end.is.important/1"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, len(expected), len(resp.Data))
+		for _, r := range resp.Data {
+			assert.Equal(t, strconv.Itoa(expected[r.ValidatorIndex]), r.Reward, "validator %s", r.ValidatorIndex)
+		}
+		assert.Equal(t, false, resp.ExecutionOptimistic)
+	})
+	t.Run("filtered members", func(t *testing.T) {
+		// The first and last positions of the committee are a participant and an absent member.
+		first, ok := preState.ValidatorIndexByPubkey(bytesutil.ToBytes48(sc.Pubkeys[0]))
+		require.Equal(t, true, ok)
+		last, ok := preState.ValidatorIndexByPubkey(bytesutil.ToBytes48(sc.Pubkeys[len(sc.Pubkeys)-1]))
+		require.Equal(t, true, ok)
+		url := "http://only.the.slot.number.at.the.end.is.important/1"
+		var body bytes.Buffer
+		valIds, err := json.Marshal([]string{strconv.FormatUint(uint64(first), 10), strconv.FormatUint(uint64(last), 10)})
+		require.NoError(t, err)
+		_, err = body.Write(valIds)
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", url, &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		for _, r := range resp.Data {
+			assert.Equal(t, strconv.Itoa(expected[r.ValidatorIndex]), r.Reward, "validator %s", r.ValidatorIndex)
+		}
+	})
+}
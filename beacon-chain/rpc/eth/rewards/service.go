@@ -128,19 +128,18 @@ func (rs *BlockRewardService) GetStateForRewards(ctx context.Context, blk interf
 	// This will allow us to calculate proposer rewards for each operation (atts, slashings etc).
 	// To do this, we replay the state up to the block's slot, but before processing the block.
 
-	// Try getting the state from the next slot cache first.
-	_, prevSlotRoots, err := rs.DB.BlockRootsBySlot(ctx, slots.PrevSlot(blk.Slot()))
-	if err != nil {
-		return nil, &httputil.DefaultJsonError{
-			Message: "Could not get roots for previous slot: " + err.Error(),
-			Code:    http.StatusInternalServerError,
-		}
-	}
-	for _, r := range prevSlotRoots {
-		s := transition.NextSlotState(r[:], blk.Slot())
-		if s != nil {
-			return s, nil
+	// Try getting the state from the next slot cache first. It holds the state of the block's parent
+	// advanced by one slot, which may still have to be advanced to the block's slot if slots were skipped.
+	parentRoot := blk.ParentRoot()
+	if st := transition.NextSlotState(parentRoot[:], blk.Slot()); st != nil {
+		st, err := transition.ProcessSlotsIfPossible(ctx, st, blk.Slot())
+		if err != nil {
+			return nil, &httputil.DefaultJsonError{
+				Message: "Could not process slots: " + err.Error(),
+				Code:    http.StatusInternalServerError,
+			}
 		}
+		return st, nil
 	}
 
 	st, err := rs.Replayer.ReplayerForSlot(slots.PrevSlot(blk.Slot())).ReplayToSlot(ctx, blk.Slot())
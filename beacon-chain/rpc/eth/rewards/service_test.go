@@ -35,6 +35,7 @@ func TestGetStateForRewards_NextSlotCacheHit(t *testing.T) {
 	sbb, err := blocks.NewSignedBeaconBlock(b)
 	require.NoError(t, err)
 	sbb.SetSlot(parent.Block().Slot() + 1)
+	sbb.SetParentRoot(r[:])
 	result, err := s.GetStateForRewards(ctx, sbb.Block())
 	require.NoError(t, err)
 	_, lcs := transition.LastCachedState()
@@ -44,3 +45,32 @@ func TestGetStateForRewards_NextSlotCacheHit(t *testing.T) {
 	require.NoError(t, err)
 	assert.DeepEqual(t, expected, actual)
 }
+
+func TestGetStateForRewards_NextSlotCacheHitWithSkippedSlots(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.SetupDB(t)
+
+	st, err := util.NewBeaconStateDeneb()
+	require.NoError(t, err)
+	b := util.HydrateSignedBeaconBlockDeneb(util.NewBeaconBlockDeneb())
+	parent, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, parent))
+
+	r, err := parent.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, transition.UpdateNextSlotCache(ctx, r[:], st))
+
+	s := &BlockRewardService{
+		Replayer: nil, // setting to nil because replayer must not be invoked
+		DB:       db,
+	}
+	b = util.HydrateSignedBeaconBlockDeneb(util.NewBeaconBlockDeneb())
+	sbb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	sbb.SetSlot(parent.Block().Slot() + 3)
+	sbb.SetParentRoot(r[:])
+	result, err := s.GetStateForRewards(ctx, sbb.Block())
+	require.NoError(t, err)
+	assert.Equal(t, sbb.Block().Slot(), result.Slot())
+}
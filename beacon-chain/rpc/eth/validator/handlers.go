@@ -30,6 +30,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -658,7 +659,15 @@ func (s *Server) GetAttesterDuties(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	st, err := s.Stater.StateBySlot(ctx, startSlot)
+	dependentSlot, err := attestationDependentSlot(requestedEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get dependent slot: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	key := cache.DutiesKey{Kind: cache.AttesterDuties, Epoch: requestedEpoch, Slot: startSlot}
+	st, err := s.dutiesState(ctx, key, currentEpoch, dependentSlot, func() (state.BeaconState, error) {
+		return s.Stater.StateBySlot(ctx, startSlot)
+	})
 	if err != nil {
 		httputil.HandleError(w, "Could not get state: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -774,33 +783,45 @@ func (s *Server) GetProposerDuties(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, fmt.Sprintf("Could not get start slot of epoch %d: %v", requestedEpoch, err), http.StatusInternalServerError)
 		return
 	}
-	var st state.BeaconState
-	// if the requested epoch is new, use the head state and the next slot cache
-	if requestedEpoch < currentEpoch {
-		st, err = s.Stater.StateBySlot(ctx, epochStartSlot)
-		if err != nil {
-			httputil.HandleError(w, fmt.Sprintf("Could not get state for slot %d: %v ", epochStartSlot, err), http.StatusInternalServerError)
-			return
+	dependentEpoch := requestedEpoch
+	if nextEpochLookahead {
+		dependentEpoch = nextEpoch
+	}
+	dependentSlot, err := proposalDependentSlot(dependentEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get dependent slot: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	key := cache.DutiesKey{Kind: cache.ProposerDuties, Epoch: dependentEpoch, Slot: epochStartSlot}
+	st, err := s.dutiesState(ctx, key, currentEpoch, dependentSlot, func() (state.BeaconState, error) {
+		// if the requested epoch is new, use the head state and the next slot cache
+		if requestedEpoch < currentEpoch {
+			st, err := s.Stater.StateBySlot(ctx, epochStartSlot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get state for slot %d", epochStartSlot)
+			}
+			return st, nil
 		}
-	} else {
-		st, err = s.HeadFetcher.HeadState(ctx)
+		st, err := s.HeadFetcher.HeadState(ctx)
 		if err != nil {
-			httputil.HandleError(w, fmt.Sprintf("Could not get head state: %v ", err), http.StatusInternalServerError)
-			return
+			return nil, errors.Wrap(err, "could not get head state")
 		}
 		// Advance state with empty transitions up to the requested epoch start slot.
 		if st.Slot() < epochStartSlot {
 			headRoot, err := s.HeadFetcher.HeadRoot(ctx)
 			if err != nil {
-				httputil.HandleError(w, fmt.Sprintf("Could not get head root: %v ", err), http.StatusInternalServerError)
-				return
+				return nil, errors.Wrap(err, "could not get head root")
 			}
 			st, err = transition.ProcessSlotsUsingNextSlotCache(ctx, st, headRoot, epochStartSlot)
 			if err != nil {
-				httputil.HandleError(w, fmt.Sprintf("Could not process slots up to %d: %v ", epochStartSlot, err), http.StatusInternalServerError)
-				return
+				return nil, errors.Wrapf(err, "could not process slots up to %d", epochStartSlot)
 			}
 		}
+		return st, nil
+	})
+	if err != nil {
+		httputil.HandleError(w, "Could not get state: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	var assignments map[primitives.ValidatorIndex][]primitives.Slot
@@ -833,7 +854,15 @@ func (s *Server) GetProposerDuties(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var dependentRoot []byte
-	if requestedEpoch == 0 {
+	if nextEpochLookahead {
+		// The dependent block of the next epoch's duties is the latest block before the epoch, which
+		// is the head block as no block of the next epoch can exist yet.
+		dependentRoot, err = s.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			httputil.HandleError(w, "Could not get head root: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else if requestedEpoch == 0 {
 		r, err := s.BeaconDB.GenesisBlockRoot(ctx)
 		if err != nil {
 			httputil.HandleError(w, "Could not get genesis block root: "+err.Error(), http.StatusInternalServerError)
@@ -1001,10 +1030,14 @@ func (s *Server) GetSyncCommitteeDuties(w http.ResponseWriter, r *http.Request)
 	httputil.WriteJson(w, resp)
 }
 
+// livenessBatchSize is the number of validator indices GetLiveness processes between checks of the request's context.
+const livenessBatchSize = 1024
+
 // GetLiveness requests the beacon node to indicate if a validator has been observed to be live in a given epoch.
 // The beacon node might detect liveness by observing messages from the validator on the network,
 // in the beacon chain, from its API or from any other source.
 // A beacon node SHOULD support the current and previous epoch, however it MAY support earlier epoch.
+// Earlier epochs are answered from the state at the end of the epoch, up to LivenessEpochsLimit epochs back when the limit is set.
 // It is important to note that the values returned by the beacon node are not canonical;
 // they are best-effort and based upon a subjective view of the network.
 // A beacon node that was recently started or suffered a network partition may indicate that a validator is not live when it actually is.
@@ -1054,6 +1087,14 @@ func (s *Server) GetLiveness(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Requested epoch cannot be in the future", http.StatusBadRequest)
 		return
 	}
+	if s.LivenessEpochsLimit != 0 && requestedEpoch+s.LivenessEpochsLimit < currEpoch {
+		httputil.HandleError(
+			w,
+			fmt.Sprintf("Requested epoch %d is more than %d epochs before the current epoch %d", requestedEpoch, s.LivenessEpochsLimit, currEpoch),
+			http.StatusBadRequest,
+		)
+		return
+	}
 
 	var st state.BeaconState
 	var participation []byte
@@ -1082,6 +1123,14 @@ func (s *Server) GetLiveness(w http.ResponseWriter, r *http.Request) {
 			httputil.HandleError(w, "Could not get slot for requested epoch: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
+		if st == nil || st.IsNil() {
+			httputil.HandleError(w, fmt.Sprintf("Could not find state at the end of epoch %d", requestedEpoch), http.StatusNotFound)
+			return
+		}
+		if st.Version() < version.Altair {
+			httputil.HandleError(w, "Liveness is not supported for epochs before Altair", http.StatusBadRequest)
+			return
+		}
 		participation, err = st.CurrentEpochParticipation()
 		if err != nil {
 			httputil.HandleError(w, "Could not get current epoch participation: "+err.Error(), http.StatusInternalServerError)
@@ -1092,14 +1141,23 @@ func (s *Server) GetLiveness(w http.ResponseWriter, r *http.Request) {
 	resp := &structs.GetLivenessResponse{
 		Data: make([]*structs.Liveness, len(requestedValIndices)),
 	}
-	for i, vi := range requestedValIndices {
-		if vi >= primitives.ValidatorIndex(len(participation)) {
-			httputil.HandleError(w, fmt.Sprintf("Validator index %d is invalid", vi), http.StatusBadRequest)
+	// Large index lists are processed in batches, so that a cancelled request stops early.
+	for start := 0; start < len(requestedValIndices); start += livenessBatchSize {
+		if err := ctx.Err(); err != nil {
+			httputil.HandleError(w, "Could not get liveness: "+err.Error(), http.StatusServiceUnavailable)
 			return
 		}
-		resp.Data[i] = &structs.Liveness{
-			Index:  strconv.FormatUint(uint64(vi), 10),
-			IsLive: participation[vi] != 0,
+		end := min(start+livenessBatchSize, len(requestedValIndices))
+		for i := start; i < end; i++ {
+			vi := requestedValIndices[i]
+			if vi >= primitives.ValidatorIndex(len(participation)) {
+				httputil.HandleError(w, fmt.Sprintf("Validator index %d is invalid", vi), http.StatusBadRequest)
+				return
+			}
+			resp.Data[i] = &structs.Liveness{
+				Index:  strconv.FormatUint(uint64(vi), 10),
+				IsLive: participation[vi] != 0,
+			}
 		}
 	}
 
@@ -1118,18 +1176,69 @@ func (s *Server) SyncCommitteeSelections(w http.ResponseWriter, _ *http.Request)
 	httputil.HandleError(w, "Endpoint not implemented", 501)
 }
 
+// dutiesState returns the state the duties of the key's epoch are computed from. The states of the duties of the
+// current and next epochs are cached with the dependent root of the duties, which is the root of the latest block
+// at the dependent slot on the chain of the head. A state is fetched again once a reorg replaces this block, so that
+// duties are recomputed from the new chain. States of older epochs are not cached.
+func (s *Server) dutiesState(
+	ctx context.Context,
+	key cache.DutiesKey,
+	currentEpoch primitives.Epoch,
+	dependentSlot primitives.Slot,
+	fetch func() (state.BeaconState, error),
+) (state.BeaconState, error) {
+	if s.DutiesCache == nil || key.Epoch < currentEpoch {
+		return fetch()
+	}
+	root, ok, err := s.dependentRootFromHead(ctx, dependentSlot)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return fetch()
+	}
+	key.DependentRoot = root
+	if st, ok := s.DutiesCache.State(key); ok {
+		return st, nil
+	}
+	st, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	s.DutiesCache.Set(key, st)
+	return st, nil
+}
+
+// dependentRootFromHead returns the root of the latest block at or before the given slot on the chain of the head.
+// It returns false when the root is too old to be read from the head state.
+func (s *Server) dependentRootFromHead(ctx context.Context, slot primitives.Slot) ([32]byte, bool, error) {
+	if slot >= s.HeadFetcher.HeadSlot() {
+		headRoot, err := s.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			return [32]byte{}, false, errors.Wrap(err, "could not get head root")
+		}
+		return bytesutil.ToBytes32(headRoot), true, nil
+	}
+	headState, err := s.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return [32]byte{}, false, errors.Wrap(err, "could not get head state")
+	}
+	if slot >= headState.Slot() || slot+params.BeaconConfig().SlotsPerHistoricalRoot <= headState.Slot() {
+		return [32]byte{}, false, nil
+	}
+	root, err := helpers.BlockRootAtSlot(headState, slot)
+	if err != nil {
+		return [32]byte{}, false, errors.Wrap(err, "could not get block root")
+	}
+	return bytesutil.ToBytes32(root), true, nil
+}
+
 // attestationDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch - 1) - 1)
 // or the genesis block root in the case of underflow.
 func attestationDependentRoot(s state.BeaconState, epoch primitives.Epoch) ([]byte, error) {
-	var dependentRootSlot primitives.Slot
-	if epoch <= 1 {
-		dependentRootSlot = 0
-	} else {
-		prevEpochStartSlot, err := slots.EpochStart(epoch.Sub(1))
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not obtain epoch's start slot: %v", err)
-		}
-		dependentRootSlot = prevEpochStartSlot.Sub(1)
+	dependentRootSlot, err := attestationDependentSlot(epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not obtain epoch's start slot: %v", err)
 	}
 	root, err := helpers.BlockRootAtSlot(s, dependentRootSlot)
 	if err != nil {
@@ -1141,15 +1250,9 @@ func attestationDependentRoot(s state.BeaconState, epoch primitives.Epoch) ([]by
 // proposalDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch) - 1)
 // or the genesis block root in the case of underflow.
 func proposalDependentRoot(s state.BeaconState, epoch primitives.Epoch) ([]byte, error) {
-	var dependentRootSlot primitives.Slot
-	if epoch == 0 {
-		dependentRootSlot = 0
-	} else {
-		epochStartSlot, err := slots.EpochStart(epoch)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not obtain epoch's start slot: %v", err)
-		}
-		dependentRootSlot = epochStartSlot.Sub(1)
+	dependentRootSlot, err := proposalDependentSlot(epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not obtain epoch's start slot: %v", err)
 	}
 	root, err := helpers.BlockRootAtSlot(s, dependentRootSlot)
 	if err != nil {
@@ -1158,6 +1261,30 @@ func proposalDependentRoot(s state.BeaconState, epoch primitives.Epoch) ([]byte,
 	return root, nil
 }
 
+// attestationDependentSlot is compute_start_slot_at_epoch(epoch - 1) - 1, or the genesis slot in the case of underflow.
+func attestationDependentSlot(epoch primitives.Epoch) (primitives.Slot, error) {
+	if epoch <= 1 {
+		return 0, nil
+	}
+	prevEpochStartSlot, err := slots.EpochStart(epoch.Sub(1))
+	if err != nil {
+		return 0, err
+	}
+	return prevEpochStartSlot.Sub(1), nil
+}
+
+// proposalDependentSlot is compute_start_slot_at_epoch(epoch) - 1, or the genesis slot in the case of underflow.
+func proposalDependentSlot(epoch primitives.Epoch) (primitives.Slot, error) {
+	if epoch == 0 {
+		return 0, nil
+	}
+	epochStartSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return 0, err
+	}
+	return epochStartSlot.Sub(1), nil
+}
+
 func syncCommitteeDutiesLastValidEpoch(currentEpoch primitives.Epoch) primitives.Epoch {
 	currentSyncPeriodIndex := currentEpoch / params.BeaconConfig().EpochsPerSyncCommitteePeriod
 	// Return the last epoch of the next sync committee.
@@ -33,6 +33,7 @@ import (
 	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -1676,6 +1677,105 @@ func TestGetAttesterDuties(t *testing.T) {
 	})
 }
 
+func TestGetAttesterDuties_Reorg(t *testing.T) {
+	helpers.ClearCache()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	ctx := context.Background()
+
+	// Chain A has a block at every slot up to the start of epoch 1. Chain B forks off at slot 30, skips
+	// the last slot of epoch 0 and has a sibling block at the start of epoch 1, so the randao mix of
+	// epoch 0, which the committees of epoch 2 are shuffled with, differs between the chains.
+	st, privs := util.DeterministicGenesisState(t, 64)
+	startSlot := params.BeaconConfig().SlotsPerEpoch
+	var forkState state.BeaconState
+	var headRootA, dependentRootA, parentRoot [32]byte
+	for slot := primitives.Slot(1); slot <= startSlot; slot++ {
+		if slot == startSlot-1 {
+			forkState = st.Copy()
+		}
+		blk, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		switch slot {
+		case startSlot - 2:
+			parentRoot = root
+		case startSlot - 1:
+			dependentRootA = root
+		case startSlot:
+			headRootA = root
+		}
+	}
+	stateA := st
+	blk, err := util.GenerateFullBlock(forkState, privs, util.DefaultBlockGenConfig(), startSlot)
+	require.NoError(t, err)
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	stateB, err := transition.ExecuteStateTransition(ctx, forkState, wsb)
+	require.NoError(t, err)
+	headRootB, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	chainSlot := startSlot + 1
+	chain := &mockChain.ChainService{State: stateA, Root: headRootA[:], Slot: &chainSlot}
+	stater := &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{startSlot: stateA}}
+	s := &Server{
+		Stater:                stater,
+		HeadFetcher:           chain,
+		TimeFetcher:           chain,
+		SyncChecker:           &mockSync.Sync{IsSyncing: false},
+		OptimisticModeFetcher: chain,
+		DutiesCache:           cache.NewDutiesCache(),
+	}
+	indices := make([]string, 64)
+	for i := range indices {
+		indices[i] = strconv.Itoa(i)
+	}
+	body, err := json.Marshal(indices)
+	require.NoError(t, err)
+	getDuties := func() *structs.GetAttesterDutiesResponse {
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/validator/duties/attester/{epoch}", bytes.NewReader(body))
+		request = mux.SetURLVars(request, map[string]string{"epoch": "2"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetAttesterDuties(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttesterDutiesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	respA := getDuties()
+	assert.Equal(t, hexutil.Encode(dependentRootA[:]), respA.DependentRoot)
+	require.Equal(t, 64, len(respA.Data))
+
+	// The state is not fetched again while the head stays on chain A.
+	delete(stater.StatesBySlot, startSlot)
+	assert.DeepEqual(t, respA, getDuties())
+
+	// Reorg the head to chain B across the epoch boundary.
+	chain.State = stateB
+	chain.Root = headRootB[:]
+	stater.StatesBySlot[startSlot] = stateB
+	respB := getDuties()
+	assert.Equal(t, hexutil.Encode(parentRoot[:]), respB.DependentRoot)
+	require.Equal(t, 64, len(respB.Data))
+	changed := false
+	for i, duty := range respB.Data {
+		if duty.Slot != respA.Data[i].Slot || duty.CommitteeIndex != respA.Data[i].CommitteeIndex {
+			changed = true
+			break
+		}
+	}
+	assert.Equal(t, true, changed, "Duties did not change after the reorg")
+}
+
 func TestGetProposerDuties(t *testing.T) {
 	helpers.ClearCache()
 
@@ -1879,6 +1979,67 @@ func TestGetProposerDuties(t *testing.T) {
 	})
 }
 
+func TestGetProposerDuties_NextEpochAcrossEpochBoundary(t *testing.T) {
+	helpers.ClearCache()
+
+	// The head state is at the last slot of epoch 1, before the transition to epoch 2.
+	bs, _ := util.DeterministicGenesisStateElectra(t, 64)
+	headSlot := 2*params.BeaconConfig().SlotsPerEpoch - 1
+	require.NoError(t, bs.SetSlot(headSlot))
+	roots := make([][]byte, fieldparams.BlockRootsLength)
+	for i := range roots {
+		roots[i] = bytesutil.PadTo([]byte(fmt.Sprintf("root%d", i)), 32)
+	}
+	require.NoError(t, bs.SetBlockRoots(roots))
+	chain := &mockChain.ChainService{
+		State: bs, Root: roots[headSlot], Slot: &headSlot,
+	}
+	s := &Server{
+		HeadFetcher:            chain,
+		TimeFetcher:            chain,
+		OptimisticModeFetcher:  chain,
+		SyncChecker:            &mockSync.Sync{IsSyncing: false},
+		PayloadIDCache:         cache.NewPayloadIDCache(),
+		TrackedValidatorsCache: cache.NewTrackedValidatorsCache(),
+	}
+	// The proposers of each slot of epoch 2, computed from the state advanced across the epoch boundary.
+	schedule, err := util.ProposerScheduleForEpoch(bs, 2)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/validator/duties/proposer/{epoch}", nil)
+	request = mux.SetURLVars(request, map[string]string{"epoch": "2"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetProposerDuties(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetProposerDutiesResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	// Duties of epoch 2 depend on the block at the last slot of epoch 1, which is the head block.
+	assert.Equal(t, hexutil.Encode(roots[headSlot]), resp.DependentRoot)
+	require.Equal(t, len(schedule), len(resp.Data))
+	for _, duty := range resp.Data {
+		slot, err := strconv.ParseUint(duty.Slot, 10, 64)
+		require.NoError(t, err)
+		idx, ok := schedule[primitives.Slot(slot)]
+		require.Equal(t, true, ok, "Unexpected duty for slot %d", slot)
+		assert.Equal(t, strconv.FormatUint(uint64(idx), 10), duty.ValidatorIndex, "Wrong proposer for slot %d", slot)
+		pubkey := bs.PubkeyAtIndex(idx)
+		assert.Equal(t, hexutil.Encode(pubkey[:]), duty.Pubkey)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "http://www.example.com/eth/v1/validator/duties/proposer/{epoch}", nil)
+	request = mux.SetURLVars(request, map[string]string{"epoch": "3"})
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetProposerDuties(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	e := &httputil.DefaultJsonError{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.StringContains(t, "Request epoch 3 can not be greater than next epoch 2", e.Message)
+}
+
 func TestGetSyncCommitteeDuties(t *testing.T) {
 	helpers.ClearCache()
 	params.SetupTestConfigCleanup(t)
@@ -2912,3 +3073,108 @@ var (
     "signature": "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"
   }]`
 )
+
+func TestGetLiveness_HistoricalEpochs(t *testing.T) {
+	// More validators than fit in a single batch of indices.
+	numVals := 2*livenessBatchSize + 100
+	// Validator 7 skipped epoch 1 and validator 2000 skipped epoch 2. Every other validator
+	// participated in every epoch.
+	skipped := map[primitives.Epoch]primitives.ValidatorIndex{1: 7, 2: 2000}
+	statesBySlot := make(map[primitives.Slot]state.BeaconState)
+	for epoch := primitives.Epoch(0); epoch < 4; epoch++ {
+		st, err := util.NewBeaconStateAltair()
+		require.NoError(t, err)
+		epochEnd, err := slots.EpochEnd(epoch)
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(epochEnd))
+		bits := make([]byte, numVals)
+		for i := range bits {
+			bits[i] = 1
+		}
+		if idx, ok := skipped[epoch]; ok {
+			bits[idx] = 0
+		}
+		require.NoError(t, st.SetCurrentParticipationBits(bits))
+		statesBySlot[epochEnd] = st
+	}
+	headSt, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, headSt.SetSlot(params.BeaconConfig().SlotsPerEpoch*6))
+	require.NoError(t, headSt.SetPreviousParticipationBits(make([]byte, numVals)))
+	require.NoError(t, headSt.SetCurrentParticipationBits(make([]byte, numVals)))
+
+	s := &Server{
+		HeadFetcher: &mockChain.ChainService{State: headSt},
+		Stater:      &testutil.MockStater{StatesBySlot: statesBySlot},
+	}
+	getLiveness := func(t *testing.T, s *Server, epoch primitives.Epoch, indices []string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(indices)
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/liveness/{epoch}", bytes.NewReader(body))
+		request = mux.SetURLVars(request, map[string]string{"epoch": strconv.FormatUint(uint64(epoch), 10)})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLiveness(writer, request)
+		return writer
+	}
+	allIndices := make([]string, numVals)
+	for i := range allIndices {
+		allIndices[i] = strconv.Itoa(i)
+	}
+
+	t.Run("skipped epochs", func(t *testing.T) {
+		for epoch := primitives.Epoch(0); epoch < 4; epoch++ {
+			writer := getLiveness(t, s, epoch, allIndices)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &structs.GetLivenessResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			require.Equal(t, numVals, len(resp.Data))
+			for i, l := range resp.Data {
+				assert.Equal(t, strconv.Itoa(i), l.Index)
+				idx, ok := skipped[epoch]
+				assert.Equal(t, !ok || idx != primitives.ValidatorIndex(i), l.IsLive, "Wrong liveness of validator %d in epoch %d", i, epoch)
+			}
+		}
+	})
+	t.Run("epochs limit", func(t *testing.T) {
+		limited := &Server{
+			HeadFetcher:         s.HeadFetcher,
+			Stater:              s.Stater,
+			LivenessEpochsLimit: 4,
+		}
+		writer := getLiveness(t, limited, 1, []string{"7"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Requested epoch 1 is more than 4 epochs before the current epoch 6", e.Message)
+
+		writer = getLiveness(t, limited, 2, []string{"2000"})
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetLivenessResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, false, resp.Data[0].IsLive)
+	})
+	t.Run("missing state", func(t *testing.T) {
+		writer := getLiveness(t, s, 4, []string{"7"})
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Could not find state at the end of epoch 4", e.Message)
+	})
+	t.Run("pre-altair epoch", func(t *testing.T) {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		epochEnd, err := slots.EpochEnd(0)
+		require.NoError(t, err)
+		phase0 := &Server{
+			HeadFetcher: s.HeadFetcher,
+			Stater:      &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{epochEnd: st}},
+		}
+		writer := getLiveness(t, phase0, 0, []string{"7"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Liveness is not supported for epochs before Altair", e.Message)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -37,4 +38,9 @@ type Server struct {
 	BlockRewardFetcher     rewards.BlockRewardsFetcher
 	TrackedValidatorsCache *cache.TrackedValidatorsCache
 	PayloadIDCache         *cache.PayloadIDCache
+	// DutiesCache keeps the states duties are computed from. Duties are always recomputed when it is nil.
+	DutiesCache *cache.DutiesCache
+	// LivenessEpochsLimit is how many epochs before the current epoch liveness can be requested for.
+	// Any epoch whose state can be regenerated is supported when it is zero.
+	LivenessEpochsLimit primitives.Epoch
 }
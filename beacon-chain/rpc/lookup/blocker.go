@@ -39,6 +39,10 @@ func (e BlockIdParseError) Error() string {
 	return e.message
 }
 
+// ErrBlobIndexOutOfRange is returned when a requested blob index is not below the number of blob
+// commitments of the block.
+var ErrBlobIndexOutOfRange = errors.New("blob index out of range")
+
 // Blocker is responsible for retrieving blocks.
 type Blocker interface {
 	Block(ctx context.Context, id []byte) (interfaces.ReadOnlySignedBeaconBlock, error)
@@ -146,6 +150,7 @@ func (p *BeaconDbBlocker) Block(ctx context.Context, id []byte) (interfaces.Read
 //   - block exists, no commitment, 200 w/ empty list
 //   - block exists, has commitments, inside retention period (greater of protocol- or user-specified) serve then w/ 200 unless we hit an error reading them.
 //     we are technically not supposed to import a block to forkchoice unless we have the blobs, so the nuance here is if we can't find the file and we are inside the protocol-defined retention period, then it's actually a 500.
+//   - block exists, has commitments, a requested index is not below the number of commitments, 400
 //   - block exists, has commitments, outside retention period (greater of protocol- or user-specified) - ie just like block exists, no commitment
 func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64) ([]*blocks.VerifiedROBlob, *core.RpcError) {
 	var root []byte
@@ -234,6 +239,14 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 	if len(commitments) == 0 {
 		return make([]*blocks.VerifiedROBlob, 0), nil
 	}
+	for _, index := range indices {
+		if index >= uint64(len(commitments)) {
+			return nil, &core.RpcError{
+				Err:    errors.Wrapf(ErrBlobIndexOutOfRange, "block has %d blob commitments, requested index %d", len(commitments), index),
+				Reason: core.BadRequest,
+			}
+		}
+	}
 	if len(indices) == 0 {
 		m, err := p.BlobStorage.Indices(bytesutil.ToBytes32(root))
 		if err != nil {
@@ -664,7 +664,7 @@ func (bs *Server) GetValidatorPerformance(
 	if err != nil {
 		return nil, status.Errorf(core.ErrorReasonToGRPC(err.Reason), "Could not compute validator performance: %v", err.Err)
 	}
-	return response, nil
+	return response.ValidatorPerformanceResponse, nil
 }
 
 // GetIndividualVotes retrieves individual voting status of validators.
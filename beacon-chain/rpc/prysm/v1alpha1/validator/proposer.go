@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +44,8 @@ var eth1DataNotification bool
 const (
 	eth1dataTimeout           = 2 * time.Second
 	defaultBuilderBoostFactor = primitives.Gwei(100)
+	// maxBuilderBoostFactor is the builder boost factor with which the builder payload is always used.
+	maxBuilderBoostFactor = primitives.Gwei(math.MaxUint64)
 )
 
 // GetBeaconBlock is called by a proposer during its assigned slot to request a block to sign
@@ -233,9 +236,10 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 			return nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
 		}
 
-		// There's no reason to try to get a builder bid if local override is true.
+		// There's no reason to try to get a builder bid if local override is true or if the builder boost
+		// factor is 0, as the local payload is then always used.
 		var builderBid builderapi.Bid
-		if !(local.OverrideBuilder || skipMevBoost) {
+		if !(local.OverrideBuilder || skipMevBoost || builderBoostFactor == 0) {
 			builderBid, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
 			if err != nil {
 				builderGetPayloadMissCount.Inc()
@@ -96,10 +96,8 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		// Compare payload values between local and builder. Default to the local value if it is higher.
 		localValueGwei := primitives.WeiToGwei(local.Bid)
 		builderValueGwei := primitives.WeiToGwei(bid.Value())
-		// Use builder payload if the following in true:
-		// builder_bid_value * builderBoostFactor(default 100) > local_block_value * (local-block-value-boost + 100)
 		boost := primitives.Gwei(params.BeaconConfig().LocalBlockValueBoost)
-		higherValueBuilder := builderValueGwei*builderBoostFactor > localValueGwei*(100+boost)
+		higherValueBuilder := builderValueIsHigher(builderValueGwei, localValueGwei, builderBoostFactor, boost)
 		if boost > 0 && builderBoostFactor != defaultBuilderBoostFactor {
 			log.WithFields(logrus.Fields{
 				"localGweiValue":       localValueGwei,
@@ -137,6 +135,9 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		)
 		return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
 	default: // Bellatrix case.
+		if builderBoostFactor == 0 {
+			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
+		}
 		if err := setBuilderExecution(blk, builderPayload, builderKzgCommitments); err != nil {
 			log.WithError(err).Warn("Proposer: failed to set builder payload")
 			return local.Bid, local.BlobsBundle, setLocalExecution(blk, local)
@@ -146,6 +147,22 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	}
 }
 
+// builderValueIsHigher returns true if the builder payload should be used over the local one, which is the case if
+// builder_bid_value * builderBoostFactor(default 100) > local_block_value * (local-block-value-boost + 100).
+// A builder boost factor of 0 always uses the local payload and the max factor always uses the builder payload.
+func builderValueIsHigher(builderValueGwei, localValueGwei, builderBoostFactor, localBoost primitives.Gwei) bool {
+	switch builderBoostFactor {
+	case 0:
+		return false
+	case maxBuilderBoostFactor:
+		return true
+	}
+	// The scaled values are compared as big integers since the products can overflow uint64.
+	builderValue := new(big.Int).Mul(new(big.Int).SetUint64(uint64(builderValueGwei)), new(big.Int).SetUint64(uint64(builderBoostFactor)))
+	localValue := new(big.Int).Mul(new(big.Int).SetUint64(uint64(localValueGwei)), new(big.Int).SetUint64(uint64(localBoost)+100))
+	return builderValue.Cmp(localValue) > 0
+}
+
 // This function retrieves the payload header and kzg commitments given the slot number and the validator index.
 // It's a no-op if the latest head block is not versioned bellatrix.
 func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (builder.Bid, error) {
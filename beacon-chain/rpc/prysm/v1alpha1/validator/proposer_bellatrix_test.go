@@ -606,6 +606,92 @@ func TestServer_setExecutionData(t *testing.T) {
 	})
 }
 
+func TestServer_setExecutionData_BuilderBoostFactor(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.LocalBlockValueBoost = 0
+	params.OverrideBeaconConfig(cfg)
+
+	withdrawals := []*v1.Withdrawal{{
+		Index:          1,
+		ValidatorIndex: 2,
+		Address:        make([]byte, fieldparams.FeeRecipientLength),
+		Amount:         3,
+	}}
+	wr, err := ssz.WithdrawalSliceRoot(withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+	require.NoError(t, err)
+	localBid := primitives.Uint64ToWei(10 * 1e9)
+	newLocal := func(t *testing.T) *blocks.GetPayloadResponse {
+		ed, err := blocks.NewWrappedExecutionData(&v1.ExecutionPayloadCapella{BlockNumber: 1, Withdrawals: withdrawals})
+		require.NoError(t, err)
+		return &blocks.GetPayloadResponse{ExecutionData: ed, Bid: localBid}
+	}
+	newBid := func(t *testing.T, valueGwei uint64) builder.Bid {
+		value := bytesutil.ReverseByteOrder(new(big.Int).Mul(new(big.Int).SetUint64(valueGwei), big.NewInt(1e9)).Bytes())
+		bid, err := builder.WrappedBuilderBidCapella(&ethpb.BuilderBidCapella{
+			Header: &v1.ExecutionPayloadHeaderCapella{
+				ParentHash:       params.BeaconConfig().ZeroHash[:],
+				FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+				StateRoot:        make([]byte, fieldparams.RootLength),
+				ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+				LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+				PrevRandao:       make([]byte, fieldparams.RootLength),
+				BlockNumber:      2,
+				ExtraData:        make([]byte, 0),
+				BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+				BlockHash:        make([]byte, fieldparams.RootLength),
+				TransactionsRoot: bytesutil.PadTo([]byte{1}, fieldparams.RootLength),
+				WithdrawalsRoot:  wr[:],
+			},
+			Pubkey: make([]byte, fieldparams.BLSPubkeyLength),
+			Value:  bytesutil.PadTo(value, 32),
+		})
+		require.NoError(t, err)
+		return bid
+	}
+
+	tests := []struct {
+		name         string
+		builderGwei  uint64
+		factor       primitives.Gwei
+		wantsBuilder bool
+	}{
+		{name: "factor 0 with higher builder value", builderGwei: 15, factor: 0, wantsBuilder: false},
+		{name: "factor 50 with higher builder value", builderGwei: 15, factor: 50, wantsBuilder: false},
+		{name: "factor 100 with higher builder value", builderGwei: 15, factor: 100, wantsBuilder: true},
+		{name: "factor 200 with higher builder value", builderGwei: 15, factor: 200, wantsBuilder: true},
+		{name: "max factor with higher builder value", builderGwei: 15, factor: math.MaxUint64, wantsBuilder: true},
+		{name: "factor 0 with lower builder value", builderGwei: 8, factor: 0, wantsBuilder: false},
+		{name: "factor 50 with lower builder value", builderGwei: 8, factor: 50, wantsBuilder: false},
+		{name: "factor 100 with lower builder value", builderGwei: 8, factor: 100, wantsBuilder: false},
+		{name: "factor 200 with lower builder value", builderGwei: 8, factor: 200, wantsBuilder: true},
+		{name: "max factor with lower builder value", builderGwei: 8, factor: math.MaxUint64, wantsBuilder: true},
+		{name: "factor overflowing uint64 with lower builder value", builderGwei: 8, factor: math.MaxUint64 - 1, wantsBuilder: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blk, err := blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockCapella{Block: &ethpb.BeaconBlockCapella{Body: &ethpb.BeaconBlockBodyCapella{}}})
+			require.NoError(t, err)
+			bid := newBid(t, tt.builderGwei)
+			value, _, err := setExecutionData(context.Background(), blk, newLocal(t), bid, tt.factor)
+			require.NoError(t, err)
+			e, err := blk.Block().Body().Execution()
+			require.NoError(t, err)
+			if tt.wantsBuilder {
+				require.Equal(t, true, blk.IsBlinded())
+				require.Equal(t, uint64(2), e.BlockNumber())
+				require.Equal(t, 0, bid.Value().Cmp(value))
+			} else {
+				require.Equal(t, false, blk.IsBlinded())
+				require.Equal(t, uint64(1), e.BlockNumber())
+				require.Equal(t, 0, localBid.Cmp(value))
+			}
+		})
+	}
+}
+
 func TestServer_getPayloadHeader(t *testing.T) {
 	genesis := time.Now().Add(-time.Duration(params.BeaconConfig().SlotsPerEpoch) * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
 	params.SetupTestConfigCleanup(t)
@@ -23,7 +23,13 @@ func (vs *Server) getSlashings(ctx context.Context, head state.BeaconState) ([]*
 	attSlashings := vs.SlashingsPool.PendingAttesterSlashings(ctx, head, false /*noLimit*/)
 	validAttSlashings := make([]ethpb.AttSlashing, 0, len(attSlashings))
 	for _, slashing := range attSlashings {
-		_, err := blocks.ProcessAttesterSlashing(ctx, head, slashing, v.SlashValidator)
+		// The pool holds attester slashings of both layouts, the block takes those of the fork of the head.
+		slashing, err := blocks.AttesterSlashingForVersion(slashing, head.Version())
+		if err != nil {
+			log.WithError(err).Warn("Could not convert attester slashing for block inclusion")
+			continue
+		}
+		_, err = blocks.ProcessAttesterSlashing(ctx, head, slashing, v.SlashValidator)
 		if err != nil {
 			log.WithError(err).Warn("Could not validate attester slashing for block inclusion")
 			continue
@@ -4,10 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
@@ -47,3 +50,29 @@ func TestServer_getSlashings(t *testing.T) {
 	require.DeepEqual(t, p, proposerSlashings)
 	require.DeepEqual(t, a, attSlashings)
 }
+
+func TestServer_getSlashings_ConvertsAttesterSlashingsToHeadFork(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privKeys := util.DeterministicGenesisStateElectra(t, 64)
+	proposerServer := &Server{
+		SlashingsPool: slashings.NewPool(),
+	}
+
+	// The pool holds an attester slashing submitted with the previous layout and an Electra one.
+	electraSlashing, err := util.GenerateAttesterSlashingForValidator(beaconState, privKeys[1], 1)
+	require.NoError(t, err)
+	legacySlashing, err := blocks.AttesterSlashingForVersion(electraSlashing, version.Deneb)
+	require.NoError(t, err)
+	require.NoError(t, proposerServer.SlashingsPool.InsertAttesterSlashing(ctx, beaconState, legacySlashing))
+	otherSlashing, err := util.GenerateAttesterSlashingForValidator(beaconState, privKeys[2], 2)
+	require.NoError(t, err)
+	require.NoError(t, proposerServer.SlashingsPool.InsertAttesterSlashing(ctx, beaconState, otherSlashing))
+
+	_, a := proposerServer.getSlashings(ctx, beaconState)
+	require.Equal(t, 2, len(a))
+	require.DeepEqual(t, electraSlashing, a[0])
+	require.DeepEqual(t, otherSlashing, a[1])
+	blk, err := consensusblocks.NewSignedBeaconBlock(util.NewBeaconBlockElectra())
+	require.NoError(t, err)
+	require.NoError(t, blk.SetAttesterSlashings(a))
+}
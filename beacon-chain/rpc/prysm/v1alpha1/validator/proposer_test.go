@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	builderTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/testing"
@@ -765,11 +766,36 @@ func injectSlashings(t *testing.T, st state.BeaconState, keys []bls.SecretKey, s
 }
 
 func TestProposer_ProposeBlock_OK(t *testing.T) {
+	require.NoError(t, kzg.Start())
+	blobs, err := util.GenerateBlobs(1, 1)
+	require.NoError(t, err)
+	otherBlobs, err := util.GenerateBlobs(1, 2)
+	require.NoError(t, err)
+	blobTxs := [][]byte{blobTransaction(t, blobs.VersionedHashes)}
+	payloadWithBlobs := emptyPayloadDeneb()
+	payloadWithBlobs.Transactions = blobTxs
+	blindedDenebWithBlobs := func(parent [32]byte, txs [][]byte) *ethpb.GenericSignedBeaconBlock {
+		blockToPropose := util.NewBlindedBeaconBlockDeneb()
+		blockToPropose.Message.Slot = 5
+		blockToPropose.Message.ParentRoot = parent[:]
+		txRoot, err := ssz.TransactionsRoot(txs)
+		require.NoError(t, err)
+		withdrawalsRoot, err := ssz.WithdrawalSliceRoot([]*enginev1.Withdrawal{}, fieldparams.MaxWithdrawalsPerPayload)
+		require.NoError(t, err)
+		blockToPropose.Message.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
+		blockToPropose.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot = withdrawalsRoot[:]
+		blockToPropose.Message.Body.BlobKzgCommitments = blobs.Commitments
+		blk := &ethpb.GenericSignedBeaconBlock_BlindedDeneb{BlindedDeneb: blockToPropose}
+		return &ethpb.GenericSignedBeaconBlock{Block: blk}
+	}
+
 	tests := []struct {
-		name       string
-		block      func([32]byte) *ethpb.GenericSignedBeaconBlock
-		err        string
-		useBuilder bool
+		name         string
+		block        func([32]byte) *ethpb.GenericSignedBeaconBlock
+		err          string
+		useBuilder   bool
+		payloadDeneb *enginev1.ExecutionPayloadDeneb
+		bundle       *enginev1.BlobsBundle
 	}{
 		{
 			name: "phase0",
@@ -911,20 +937,40 @@ func TestProposer_ProposeBlock_OK(t *testing.T) {
 		{
 			name: "blind deneb block some blobs",
 			block: func(parent [32]byte) *ethpb.GenericSignedBeaconBlock {
-				blockToPropose := util.NewBlindedBeaconBlockDeneb()
-				blockToPropose.Message.Slot = 5
-				blockToPropose.Message.ParentRoot = parent[:]
-				txRoot, err := ssz.TransactionsRoot([][]byte{})
-				require.NoError(t, err)
-				withdrawalsRoot, err := ssz.WithdrawalSliceRoot([]*enginev1.Withdrawal{}, fieldparams.MaxWithdrawalsPerPayload)
-				require.NoError(t, err)
-				blockToPropose.Message.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
-				blockToPropose.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot = withdrawalsRoot[:]
-				blockToPropose.Message.Body.BlobKzgCommitments = [][]byte{bytesutil.PadTo([]byte{0x01}, 48)}
-				blk := &ethpb.GenericSignedBeaconBlock_BlindedDeneb{BlindedDeneb: blockToPropose}
-				return &ethpb.GenericSignedBeaconBlock{Block: blk}
+				return blindedDenebWithBlobs(parent, blobTxs)
+			},
+			useBuilder:   true,
+			payloadDeneb: payloadWithBlobs,
+			bundle:       &enginev1.BlobsBundle{KzgCommitments: blobs.Commitments, Proofs: blobs.Proofs, Blobs: blobs.Blobs},
+		},
+		{
+			name: "blind deneb block some blobs (bundle missing a blob)",
+			block: func(parent [32]byte) *ethpb.GenericSignedBeaconBlock {
+				return blindedDenebWithBlobs(parent, blobTxs)
+			},
+			useBuilder:   true,
+			payloadDeneb: payloadWithBlobs,
+			bundle:       &enginev1.BlobsBundle{KzgCommitments: blobs.Commitments, Proofs: blobs.Proofs},
+			err:          "unblind sidecars failed: bundle has 1 commitments for 0 blobs: blob count mismatch",
+		},
+		{
+			name: "blind deneb block some blobs (blob does not match proof)",
+			block: func(parent [32]byte) *ethpb.GenericSignedBeaconBlock {
+				return blindedDenebWithBlobs(parent, blobTxs)
+			},
+			useBuilder:   true,
+			payloadDeneb: payloadWithBlobs,
+			bundle:       &enginev1.BlobsBundle{KzgCommitments: blobs.Commitments, Proofs: blobs.Proofs, Blobs: otherBlobs.Blobs},
+			err:          "blob KZG proof verification failed",
+		},
+		{
+			name: "blind deneb block some blobs (payload versioned hashes do not match)",
+			block: func(parent [32]byte) *ethpb.GenericSignedBeaconBlock {
+				return blindedDenebWithBlobs(parent, [][]byte{})
 			},
 			useBuilder: true,
+			bundle:     &enginev1.BlobsBundle{KzgCommitments: blobs.Commitments, Proofs: blobs.Proofs, Blobs: blobs.Blobs},
+			err:        "payload has 0 versioned hashes, block has 1 commitments: blob versioned hash mismatch",
 		},
 		{
 			name: "blind deneb block some blobs (commitment value does not match blob)",
@@ -958,12 +1004,20 @@ func TestProposer_ProposeBlock_OK(t *testing.T) {
 
 			c := &mock.ChainService{Root: bsRoot[:], State: beaconState}
 			db := dbutil.SetupDB(t)
+			payloadDeneb := emptyPayloadDeneb()
+			if tt.payloadDeneb != nil {
+				payloadDeneb = tt.payloadDeneb
+			}
+			bundle := &enginev1.BlobsBundle{KzgCommitments: [][]byte{bytesutil.PadTo([]byte{0x01}, 48)}, Proofs: [][]byte{{0x02}}, Blobs: [][]byte{{0x03}}}
+			if tt.bundle != nil {
+				bundle = tt.bundle
+			}
 			proposerServer := &Server{
 				BlockReceiver: c,
 				BlockNotifier: c.BlockNotifier(),
 				P2P:           mockp2p.NewTestP2P(t),
-				BlockBuilder: &builderTest.MockBuilderService{HasConfigured: tt.useBuilder, PayloadCapella: emptyPayloadCapella(), PayloadDeneb: emptyPayloadDeneb(),
-					BlobBundle: &enginev1.BlobsBundle{KzgCommitments: [][]byte{bytesutil.PadTo([]byte{0x01}, 48)}, Proofs: [][]byte{{0x02}}, Blobs: [][]byte{{0x03}}}},
+				BlockBuilder: &builderTest.MockBuilderService{HasConfigured: tt.useBuilder, PayloadCapella: emptyPayloadCapella(), PayloadDeneb: payloadDeneb,
+					BlobBundle: bundle},
 				BeaconDB:          db,
 				BlobReceiver:      c,
 				OperationNotifier: c.OperationNotifier(),
@@ -3,7 +3,11 @@ package validator
 import (
 	"bytes"
 
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -12,6 +16,17 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
+var (
+	errBlobCountMismatch     = errors.New("blob count mismatch")
+	errBlobProofInvalid      = errors.New("blob KZG proof verification failed")
+	errVersionedHashMismatch = errors.New("blob versioned hash mismatch")
+)
+
+// unblindBlobsSidecars builds the blob sidecars of an unblinded block from the blobs bundle returned
+// by the builder. The bundle is verified against the block before any sidecar is returned: it must
+// hold a blob, commitment and proof for every commitment of the block, the blob versioned hashes of
+// the payload's transactions must be the ones of the block's commitments, and every blob must match
+// its commitment and proof.
 func unblindBlobsSidecars(block interfaces.SignedBeaconBlock, bundle *enginev1.BlobsBundle) ([]*ethpb.BlobSidecar, error) {
 	if block.Version() < version.Deneb {
 		return nil, nil
@@ -35,22 +50,30 @@ func unblindBlobsSidecars(block interfaces.SignedBeaconBlock, bundle *enginev1.B
 
 	// Ensure there are equal counts of blobs/commitments/proofs.
 	if len(bundle.KzgCommitments) != len(bundle.Blobs) {
-		return nil, errors.New("mismatch commitments count")
+		return nil, errors.Wrapf(errBlobCountMismatch, "bundle has %d commitments for %d blobs", len(bundle.KzgCommitments), len(bundle.Blobs))
 	}
 	if len(bundle.Proofs) != len(bundle.Blobs) {
-		return nil, errors.New("mismatch proofs count")
+		return nil, errors.Wrapf(errBlobCountMismatch, "bundle has %d proofs for %d blobs", len(bundle.Proofs), len(bundle.Blobs))
 	}
 
 	// Verify that commitments in the bundle match the block.
 	if len(bundle.KzgCommitments) != len(blockCommitments) {
-		return nil, errors.New("commitment count doesn't match block")
+		return nil, errors.Wrapf(errBlobCountMismatch, "bundle has %d commitments, block has %d", len(bundle.KzgCommitments), len(blockCommitments))
 	}
 	for i, commitment := range blockCommitments {
 		if !bytes.Equal(bundle.KzgCommitments[i], commitment) {
-			return nil, errors.New("commitment value doesn't match block")
+			return nil, errors.Errorf("commitment value doesn't match block at index %d", i)
 		}
 	}
 
+	payload, err := body.Execution()
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlobVersionedHashes(payload, blockCommitments); err != nil {
+		return nil, err
+	}
+
 	sidecars := make([]*ethpb.BlobSidecar, len(bundle.Blobs))
 	for i, b := range bundle.Blobs {
 		proof, err := consensusblocks.MerkleProofKZGCommitment(body, i)
@@ -66,5 +89,45 @@ func unblindBlobsSidecars(block interfaces.SignedBeaconBlock, bundle *enginev1.B
 			CommitmentInclusionProof: proof,
 		}
 	}
+
+	roBlobs := make([]consensusblocks.ROBlob, len(sidecars))
+	for i, sc := range sidecars {
+		roBlobs[i], err = consensusblocks.NewROBlob(sc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := kzg.Verify(roBlobs...); err != nil {
+		return nil, errors.Wrap(errBlobProofInvalid, err.Error())
+	}
 	return sidecars, nil
 }
+
+// verifyBlobVersionedHashes checks that the blob versioned hashes referenced by the blob transactions
+// of the payload are, in order, the versioned hashes of the block's commitments.
+func verifyBlobVersionedHashes(payload interfaces.ExecutionData, commitments [][]byte) error {
+	txs, err := payload.Transactions()
+	if err != nil {
+		return err
+	}
+	var hashes []common.Hash
+	for i, raw := range txs {
+		if len(raw) == 0 || raw[0] != gethtypes.BlobTxType {
+			continue
+		}
+		tx := &gethtypes.Transaction{}
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return errors.Wrapf(err, "could not decode blob transaction %d", i)
+		}
+		hashes = append(hashes, tx.BlobHashes()...)
+	}
+	if len(hashes) != len(commitments) {
+		return errors.Wrapf(errVersionedHashMismatch, "payload has %d versioned hashes, block has %d commitments", len(hashes), len(commitments))
+	}
+	for i, commitment := range commitments {
+		if hashes[i] != blockchain.ConvertKzgCommitmentToVersionedHash(commitment) {
+			return errors.Wrapf(errVersionedHashMismatch, "versioned hash %d doesn't match block commitment", i)
+		}
+	}
+	return nil
+}
@@ -3,9 +3,17 @@ package validator
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
 
 func TestUnblinder_UnblindBlobSidecars_InvalidBundle(t *testing.T) {
@@ -32,3 +40,114 @@ func TestUnblinder_UnblindBlobSidecars_InvalidBundle(t *testing.T) {
 	assert.ErrorContains(t, "no valid bundle provided", err)
 
 }
+
+func TestUnblinder_UnblindBlobSidecars_VerifiesBundle(t *testing.T) {
+	require.NoError(t, kzg.Start())
+	blobs, err := util.GenerateBlobs(2, 1)
+	require.NoError(t, err)
+	other, err := util.GenerateBlobs(1, 2)
+	require.NoError(t, err)
+	bundle := func() *enginev1.BlobsBundle {
+		return &enginev1.BlobsBundle{
+			KzgCommitments: [][]byte{blobs.Commitments[0], blobs.Commitments[1]},
+			Proofs:         [][]byte{blobs.Proofs[0], blobs.Proofs[1]},
+			Blobs:          [][]byte{blobs.Blobs[0], blobs.Blobs[1]},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		txs    [][]byte
+		bundle func() *enginev1.BlobsBundle
+		err    error
+	}{
+		{
+			name:   "valid bundle",
+			txs:    [][]byte{blobTransaction(t, blobs.VersionedHashes)},
+			bundle: bundle,
+		},
+		{
+			name: "missing blob",
+			txs:  [][]byte{blobTransaction(t, blobs.VersionedHashes)},
+			bundle: func() *enginev1.BlobsBundle {
+				b := bundle()
+				b.Blobs = b.Blobs[:1]
+				return b
+			},
+			err: errBlobCountMismatch,
+		},
+		{
+			name: "missing commitment and proof",
+			txs:  [][]byte{blobTransaction(t, blobs.VersionedHashes)},
+			bundle: func() *enginev1.BlobsBundle {
+				b := bundle()
+				b.KzgCommitments, b.Proofs, b.Blobs = b.KzgCommitments[:1], b.Proofs[:1], b.Blobs[:1]
+				return b
+			},
+			err: errBlobCountMismatch,
+		},
+		{
+			name: "blob not matching its proof",
+			txs:  [][]byte{blobTransaction(t, blobs.VersionedHashes)},
+			bundle: func() *enginev1.BlobsBundle {
+				b := bundle()
+				b.Blobs[1] = other.Blobs[0]
+				return b
+			},
+			err: errBlobProofInvalid,
+		},
+		{
+			name:   "payload versioned hashes out of order",
+			txs:    [][]byte{blobTransaction(t, []common.Hash{blobs.VersionedHashes[1], blobs.VersionedHashes[0]})},
+			bundle: bundle,
+			err:    errVersionedHashMismatch,
+		},
+		{
+			name:   "payload without blob transaction",
+			bundle: bundle,
+			err:    errVersionedHashMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blk := unblindedBlockDeneb(t, blobs.Commitments, tt.txs)
+			sidecars, err := unblindBlobsSidecars(blk, tt.bundle())
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 2, len(sidecars))
+			for i, sc := range sidecars {
+				require.Equal(t, uint64(i), sc.Index)
+				require.DeepEqual(t, blobs.Blobs[i], sc.Blob)
+				require.DeepEqual(t, blobs.Commitments[i], sc.KzgCommitment)
+				require.DeepEqual(t, blobs.Proofs[i], sc.KzgProof)
+			}
+		})
+	}
+}
+
+func unblindedBlockDeneb(t *testing.T, commitments [][]byte, txs [][]byte) interfaces.SignedBeaconBlock {
+	b := util.NewBeaconBlockDeneb()
+	b.Block.Body.BlobKzgCommitments = commitments
+	b.Block.Body.ExecutionPayload.Transactions = txs
+	wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return wsb
+}
+
+func blobTransaction(t *testing.T, hashes []common.Hash) []byte {
+	tx := gethtypes.NewTx(&gethtypes.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(1),
+		Gas:        21000,
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: hashes,
+	})
+	enc, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return enc
+}
@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"go.opencensus.io/trace"
+)
+
+// GetValidatorRegistrations returns the registrations of the validators submitted to the builder relay, along with
+// the time of the latest submission accepted by the relay and the error of the latest submission to each relay that
+// rejected it. The validators can be filtered with the pubkeys query parameter.
+func (s *Server) GetValidatorRegistrations(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.GetValidatorRegistrations")
+	defer span.End()
+
+	if s.BlockBuilder == nil || !s.BlockBuilder.Configured() {
+		handleHTTPError(w, fmt.Sprintf("Could not get validator registrations: %v", builder.ErrNoBuilder), http.StatusBadRequest)
+		return
+	}
+
+	rawPubkeys := r.URL.Query()["pubkeys"]
+	pubkeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(rawPubkeys))
+	for _, rawPubkey := range rawPubkeys {
+		pubkey, err := bytesutil.DecodeHexWithLength(rawPubkey, fieldparams.BLSPubkeyLength)
+		if err != nil {
+			handleHTTPError(w, fmt.Sprintf("Invalid pubkey %s: %v", rawPubkey, err), http.StatusBadRequest)
+			return
+		}
+		pubkeys = append(pubkeys, bytesutil.ToBytes48(pubkey))
+	}
+
+	statuses, err := s.BlockBuilder.RegistrationStatuses(pubkeys)
+	if err != nil {
+		handleHTTPError(w, "Could not get validator registrations: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(pubkeys) == 0 {
+		for pubkey := range statuses {
+			pubkeys = append(pubkeys, pubkey)
+		}
+		sort.Slice(pubkeys, func(i, j int) bool {
+			return bytes.Compare(pubkeys[i][:], pubkeys[j][:]) < 0
+		})
+	}
+
+	data := make([]*structs.ValidatorRegistrationStatus, 0, len(statuses))
+	seen := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(statuses))
+	for _, pubkey := range pubkeys {
+		status, ok := statuses[pubkey]
+		// A public key requested more than once is only returned once.
+		if !ok || seen[pubkey] {
+			continue
+		}
+		seen[pubkey] = true
+		data = append(data, validatorRegistrationStatus(pubkey, status))
+	}
+	httputil.WriteJson(w, &structs.GetValidatorRegistrationsResponse{Data: data})
+}
+
+func validatorRegistrationStatus(pubkey [fieldparams.BLSPubkeyLength]byte, status *cache.RegistrationStatus) *structs.ValidatorRegistrationStatus {
+	relays := make([]string, 0, len(status.RelayErrors))
+	for relay := range status.RelayErrors {
+		relays = append(relays, relay)
+	}
+	sort.Strings(relays)
+	relayErrors := make([]*structs.RelayError, len(relays))
+	for i, relay := range relays {
+		relayErrors[i] = &structs.RelayError{
+			Relay: relay,
+			Error: status.RelayErrors[relay],
+		}
+	}
+
+	var registration *structs.SignedValidatorRegistration
+	lastSubmitted := "0"
+	if status.Registration != nil {
+		registration = structs.SignedValidatorRegistrationFromConsensus(status.Registration)
+	}
+	if !status.LastSubmitted.IsZero() {
+		lastSubmitted = strconv.FormatInt(status.LastSubmitted.Unix(), 10)
+	}
+	return &structs.ValidatorRegistrationStatus{
+		Pubkey:        hexutil.Encode(pubkey[:]),
+		Registration:  registration,
+		LastSubmitted: lastSubmitted,
+		RelayErrors:   relayErrors,
+	}
+}
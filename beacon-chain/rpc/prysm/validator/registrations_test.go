@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	builderTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestGetValidatorRegistrations(t *testing.T) {
+	regs := make([]*ethpb.SignedValidatorRegistrationV1, 3)
+	for i := range regs {
+		pubkey := bytesutil.ToBytes48([]byte{byte(3 - i)})
+		regs[i] = &ethpb.SignedValidatorRegistrationV1{
+			Message: &ethpb.ValidatorRegistrationV1{
+				Pubkey:       pubkey[:],
+				FeeRecipient: bytesutil.PadTo([]byte{byte(i)}, 20),
+				GasLimit:     30000000,
+				Timestamp:    uint64(1000 + i),
+			},
+			Signature: make([]byte, 96),
+		}
+	}
+	submitted := time.Unix(1700000000, 0)
+	regCache := cache.NewRegistrationCache()
+	regCache.UpdateRegistrationStatus("http://relay-a", regs[0], submitted, nil)
+	regCache.UpdateRegistrationStatus("http://relay-a", regs[1], submitted, errors.New("relay-a failure"))
+	regCache.UpdateRegistrationStatus("http://relay-a", regs[2], submitted, nil)
+	regCache.UpdateRegistrationStatus("http://relay-b", regs[2], submitted.Add(time.Second), errors.New("relay-b failure"))
+	s := &Server{
+		BlockBuilder: &builderTest.MockBuilderService{
+			HasConfigured:     true,
+			RegistrationCache: regCache,
+		},
+	}
+
+	t.Run("all", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/registrations", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidatorRegistrations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorRegistrationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		// The statuses are sorted by public key.
+		assert.Equal(t, hexutil.Encode(regs[2].Message.Pubkey), resp.Data[0].Pubkey)
+		assert.Equal(t, hexutil.Encode(regs[1].Message.Pubkey), resp.Data[1].Pubkey)
+		assert.Equal(t, hexutil.Encode(regs[0].Message.Pubkey), resp.Data[2].Pubkey)
+
+		assert.DeepEqual(t, structs.SignedValidatorRegistrationFromConsensus(regs[2]), resp.Data[0].Registration)
+		assert.Equal(t, strconv.FormatInt(submitted.Unix(), 10), resp.Data[0].LastSubmitted)
+		require.Equal(t, 1, len(resp.Data[0].RelayErrors))
+		assert.Equal(t, "http://relay-b", resp.Data[0].RelayErrors[0].Relay)
+		assert.Equal(t, "relay-b failure", resp.Data[0].RelayErrors[0].Error)
+
+		assert.Equal(t, (*structs.SignedValidatorRegistration)(nil), resp.Data[1].Registration)
+		assert.Equal(t, "0", resp.Data[1].LastSubmitted)
+		require.Equal(t, 1, len(resp.Data[1].RelayErrors))
+		assert.Equal(t, "http://relay-a", resp.Data[1].RelayErrors[0].Relay)
+		assert.Equal(t, "relay-a failure", resp.Data[1].RelayErrors[0].Error)
+
+		assert.DeepEqual(t, structs.SignedValidatorRegistrationFromConsensus(regs[0]), resp.Data[2].Registration)
+		assert.Equal(t, 0, len(resp.Data[2].RelayErrors))
+	})
+	t.Run("filtered by pubkeys", func(t *testing.T) {
+		unknown := bytesutil.ToBytes48([]byte("unknown"))
+		request := httptest.NewRequest(
+			http.MethodGet,
+			"http://example.com/prysm/v1/validators/registrations?pubkeys="+hexutil.Encode(regs[0].Message.Pubkey)+
+				"&pubkeys="+hexutil.Encode(unknown[:])+
+				"&pubkeys="+hexutil.Encode(regs[1].Message.Pubkey)+
+				"&pubkeys="+hexutil.Encode(regs[0].Message.Pubkey),
+			nil,
+		)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidatorRegistrations(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorRegistrationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, hexutil.Encode(regs[0].Message.Pubkey), resp.Data[0].Pubkey)
+		assert.Equal(t, hexutil.Encode(regs[1].Message.Pubkey), resp.Data[1].Pubkey)
+	})
+	t.Run("invalid pubkey", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/registrations?pubkeys=0x1234", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidatorRegistrations(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid pubkey 0x1234", e.Message)
+	})
+	t.Run("builder not configured", func(t *testing.T) {
+		s := &Server{BlockBuilder: &builderTest.MockBuilderService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/registrations", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetValidatorRegistrations(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "builder endpoint not configured", e.Message)
+	})
+}
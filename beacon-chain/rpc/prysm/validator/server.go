@@ -1,9 +1,11 @@
 package validator
 
 import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 )
 
 type Server struct {
-	CoreService *core.Service
+	CoreService  *core.Service
+	BlockBuilder builder.BlockBuilder
 }
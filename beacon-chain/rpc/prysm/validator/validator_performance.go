@@ -43,7 +43,8 @@ func (s *Server) GetValidatorPerformance(w http.ResponseWriter, r *http.Request)
 		BalancesBeforeEpochTransition: computed.BalancesBeforeEpochTransition,
 		BalancesAfterEpochTransition:  computed.BalancesAfterEpochTransition,
 		MissingValidators:             computed.MissingValidators,
-		InactivityScores:              computed.InactivityScores, // Only populated in Altair
+		InactivityScores:              computed.InactivityScores,     // Only populated in Altair
+		MaxEffectiveBalances:          computed.MaxEffectiveBalances, // Only populated in Electra
 	}
 	httputil.WriteJson(w, response)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
@@ -415,6 +416,94 @@ func TestServer_GetValidatorPerformance(t *testing.T) {
 		body, err := io.ReadAll(rawResp.Body)
 		require.NoError(t, err)
 
+		response := &structs.GetValidatorPerformanceResponse{}
+		require.NoError(t, json.Unmarshal(body, response))
+		require.DeepEqual(t, want, response)
+	})
+	t.Run("Electra OK", func(t *testing.T) {
+		helpers.ClearCache()
+		params.SetupTestConfigCleanup(t)
+		params.OverrideBeaconConfig(params.MinimalSpecConfig())
+		ctx := context.Background()
+		cfg := params.BeaconConfig()
+
+		publicKeys := [][48]byte{
+			bytesutil.ToBytes48([]byte{1}),
+			bytesutil.ToBytes48([]byte{2}),
+			bytesutil.ToBytes48([]byte{3}),
+		}
+		epoch := primitives.Epoch(1)
+		headState, _ := util.DeterministicGenesisStateElectra(t, 32)
+		require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch+1))))
+		headState = setHeadState(t, headState, publicKeys)
+
+		// The last validator is compounding and holds more than the legacy effective balance ceiling.
+		compoundingBal := 2 * cfg.MinActivationBalance
+		val, err := headState.ValidatorAtIndex(2)
+		require.NoError(t, err)
+		val.WithdrawalCredentials = append([]byte{cfg.CompoundingWithdrawalPrefixByte}, make([]byte, 31)...)
+		val.EffectiveBalance = compoundingBal
+		require.NoError(t, headState.UpdateValidatorAtIndex(2, val))
+		require.NoError(t, headState.SetBalances([]uint64{100, cfg.MinActivationBalance, compoundingBal + cfg.GweiPerEth}))
+		require.NoError(t, headState.SetInactivityScores([]uint64{0, 4, 8}))
+		// The legacy validator attested timely in the previous epoch, the compounding validator only got the source right.
+		timely := uint8(1<<cfg.TimelySourceFlagIndex | 1<<cfg.TimelyTargetFlagIndex | 1<<cfg.TimelyHeadFlagIndex)
+		require.NoError(t, headState.SetPreviousParticipationBits([]byte{0, timely, 1 << cfg.TimelySourceFlagIndex}))
+		require.NoError(t, headState.SetCurrentParticipationBits([]byte{0, 0, 0}))
+
+		c := headState.Copy()
+		vp, bp, err := altair.InitializePrecomputeValidators(ctx, c)
+		require.NoError(t, err)
+		vp, bp, err = altair.ProcessEpochParticipation(ctx, c, bp, vp)
+		require.NoError(t, err)
+		c, vp, err = altair.ProcessInactivityScores(ctx, c, vp)
+		require.NoError(t, err)
+		_, err = altair.ProcessRewardsAndPenaltiesPrecompute(c, bp, vp)
+		require.NoError(t, err)
+		require.Equal(t, true, vp[2].AfterEpochTransitionBalance < compoundingBal+cfg.GweiPerEth)
+
+		offset := int64(headState.Slot().Mul(params.BeaconConfig().SecondsPerSlot))
+		vs := &Server{
+			CoreService: &core.Service{
+				HeadFetcher: &mock.ChainService{
+					State: headState,
+				},
+				GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now().Add(time.Duration(-1*offset) * time.Second)},
+				SyncChecker:        &mockSync.Sync{IsSyncing: false},
+			},
+		}
+		want := &structs.GetValidatorPerformanceResponse{
+			PublicKeys:                    [][]byte{publicKeys[1][:], publicKeys[2][:]},
+			CurrentEffectiveBalances:      []uint64{params.BeaconConfig().MaxEffectiveBalance, compoundingBal},
+			CorrectlyVotedSource:          []bool{true, true},
+			CorrectlyVotedTarget:          []bool{true, false},
+			CorrectlyVotedHead:            []bool{true, false},
+			BalancesBeforeEpochTransition: []uint64{cfg.MinActivationBalance, compoundingBal + cfg.GweiPerEth},
+			BalancesAfterEpochTransition:  []uint64{vp[1].AfterEpochTransitionBalance, vp[2].AfterEpochTransitionBalance},
+			MissingValidators:             [][]byte{publicKeys[0][:]},
+			InactivityScores:              []uint64{vp[1].InactivityScore, vp[2].InactivityScore},
+			MaxEffectiveBalances:          []uint64{cfg.MinActivationBalance, cfg.MaxEffectiveBalanceElectra},
+		}
+		request := &structs.GetValidatorPerformanceRequest{
+			PublicKeys: [][]byte{publicKeys[0][:], publicKeys[2][:], publicKeys[1][:]},
+		}
+		var buf bytes.Buffer
+		err = json.NewEncoder(&buf).Encode(request)
+		require.NoError(t, err)
+
+		srv := httptest.NewServer(http.HandlerFunc(vs.GetValidatorPerformance))
+		req := httptest.NewRequest("POST", "/foo", &buf)
+		client := &http.Client{}
+		rawResp, err := client.Post(srv.URL, "application/json", req.Body)
+		require.NoError(t, err)
+		defer func() {
+			if err := rawResp.Body.Close(); err != nil {
+				t.Fatal(err)
+			}
+		}()
+		body, err := io.ReadAll(rawResp.Body)
+		require.NoError(t, err)
+
 		response := &structs.GetValidatorPerformanceResponse{}
 		require.NoError(t, json.Unmarshal(body, response))
 		require.DeepEqual(t, want, response)
@@ -6,6 +6,7 @@ import (
 	"context"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -43,6 +44,7 @@ import (
 	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/io/logs"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
 	ethpbv1alpha1 "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -114,6 +116,11 @@ type Config struct {
 	GenesisFetcher                blockchain.GenesisFetcher
 	MockEth1Votes                 bool
 	EnableDebugRPCEndpoints       bool
+	MaxHistoricalCommitteesEpochs primitives.Epoch
+	MaxLivenessEpochs             primitives.Epoch
+	ProposalSlotLookahead         primitives.Slot
+	EventHistorySize              int
+	EventHistoryAge               time.Duration
 	AttestationsPool              attestations.Pool
 	ExitPool                      voluntaryexits.PoolManager
 	SlashingsPool                 slashings.PoolManager
@@ -183,6 +183,18 @@ func (b *BeaconState) ExpectedWithdrawals() ([]*enginev1.Withdrawal, uint64, err
 	return withdrawals, partialWithdrawalsCount, nil
 }
 
+// PendingPartialWithdrawals is a non-mutating call to the beacon state which returns a deep copy of
+// the pending partial withdrawals slice. This method requires access to the RLock on the state and
+// only applies in electra or later.
+func (b *BeaconState) PendingPartialWithdrawals() ([]*ethpb.PendingPartialWithdrawal, error) {
+	if b.version < version.Electra {
+		return nil, errNotSupported("PendingPartialWithdrawals", b.version)
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.pendingPartialWithdrawalsVal(), nil
+}
+
 func (b *BeaconState) pendingPartialWithdrawalsVal() []*ethpb.PendingPartialWithdrawal {
 	return ethpb.CopyPendingPartialWithdrawals(b.pendingPartialWithdrawals)
 }
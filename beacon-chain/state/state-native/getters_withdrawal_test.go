@@ -350,3 +350,34 @@ func TestExpectedWithdrawals(t *testing.T) {
 		require.Equal(t, uint64(8), partialWithdrawalsCount)
 	})
 }
+
+func TestPendingPartialWithdrawals(t *testing.T) {
+	t.Run("electra returns expected value", func(t *testing.T) {
+		want := []*ethpb.PendingPartialWithdrawal{
+			{
+				Index:             1,
+				Amount:            2,
+				WithdrawableEpoch: 3,
+			},
+			{
+				Index:             4,
+				Amount:            5,
+				WithdrawableEpoch: 6,
+			},
+		}
+		st, err := state_native.InitializeFromProtoElectra(&ethpb.BeaconStateElectra{
+			PendingPartialWithdrawals: want,
+		})
+		require.NoError(t, err)
+		got, err := st.PendingPartialWithdrawals()
+		require.NoError(t, err)
+		require.DeepEqual(t, want, got)
+	})
+
+	t.Run("earlier than electra returns error", func(t *testing.T) {
+		st, err := state_native.InitializeFromProtoDeneb(&ethpb.BeaconStateDeneb{})
+		require.NoError(t, err)
+		_, err = st.PendingPartialWithdrawals()
+		require.ErrorContains(t, "is not supported", err)
+	})
+}
@@ -39,7 +39,7 @@ func (b *BeaconState) ExitEpochAndUpdateChurn(exitBalance primitives.Gwei) (prim
 	}
 
 	// This helper requires access to the RLock and cannot be called from within the write Lock.
-	activeBal, err := helpers.TotalActiveBalance(b)
+	perEpochChurn, err := helpers.ActivationExitChurnLimitFromState(b) // Guaranteed to be non-zero.
 	if err != nil {
 		return 0, err
 	}
@@ -48,7 +48,6 @@ func (b *BeaconState) ExitEpochAndUpdateChurn(exitBalance primitives.Gwei) (prim
 	defer b.lock.Unlock()
 
 	earliestExitEpoch := max(b.earliestExitEpoch, helpers.ActivationExitEpoch(slots.ToEpoch(b.slot)))
-	perEpochChurn := helpers.ActivationExitChurnLimit(primitives.Gwei(activeBal)) // Guaranteed to be non-zero.
 
 	// New epoch for exits
 	var exitBalanceToConsume primitives.Gwei
@@ -0,0 +1,31 @@
+package sync
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+)
+
+// gossipEventsLimit is the number of gossip validation events buffered for the operation feed.
+const gossipEventsLimit = 256
+
+// A routine that runs in the background to send the events of messages passing gossip
+// validation to the operation feed, so that slow consumers of the feed don't delay validation.
+func (s *Service) gossipEventsRoutine() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case event := <-s.gossipEvents:
+			s.cfg.operationNotifier.OperationFeed().Send(event)
+		}
+	}
+}
+
+// notifyGossipValidated queues the event of a message passing gossip validation without blocking.
+// The event is dropped if the buffer is full.
+func (s *Service) notifyGossipValidated(event *feed.Event) {
+	select {
+	case s.gossipEvents <- event:
+	default:
+		droppedGossipEventsCounter.Inc()
+	}
+}
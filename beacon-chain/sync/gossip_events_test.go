@@ -0,0 +1,18 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestNotifyGossipValidated_DropsWhenFull(t *testing.T) {
+	s := &Service{gossipEvents: make(chan *feed.Event, 2)}
+	for i := 0; i < 3; i++ {
+		// Notifying never blocks, even without a routine draining the buffer.
+		s.notifyGossipValidated(&feed.Event{Type: opfeed.BlockGossipReceived})
+	}
+	require.Equal(t, 2, len(s.gossipEvents))
+}
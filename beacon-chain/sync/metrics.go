@@ -101,6 +101,11 @@ var (
 		Help: "Captures blocks propagation time. Blocks arrival in milliseconds",
 	})
 
+	droppedGossipEventsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gossip_validation_events_dropped_total",
+		Help: "Count the number of gossip validation events dropped because the operation feed is behind.",
+	})
+
 	// Attestation processing granular error tracking.
 	attBadBlockCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "gossip_attestation_bad_block_total",
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
-	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"google.golang.org/protobuf/proto"
 )
@@ -22,12 +20,5 @@ func (s *Service) blobSubscriber(ctx context.Context, msg proto.Message) error {
 		return err
 	}
 
-	s.cfg.operationNotifier.OperationFeed().Send(&feed.Event{
-		Type: opfeed.BlobSidecarReceived,
-		Data: &opfeed.BlobSidecarReceivedData{
-			Blob: &b,
-		},
-	})
-
 	return nil
 }
@@ -10,6 +10,8 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -137,6 +139,13 @@ func (s *Service) validateBlob(ctx context.Context, pid peer.ID, msg *pubsub.Mes
 	}
 	msg.ValidatorData = vBlobData
 
+	s.notifyGossipValidated(&feed.Event{
+		Type: opfeed.BlobSidecarReceived,
+		Data: &opfeed.BlobSidecarReceivedData{
+			Blob: &vBlobData,
+		},
+	})
+
 	return pubsub.ValidationAccept, nil
 }
 
@@ -11,6 +11,8 @@ import (
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/pkg/errors"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
@@ -285,6 +287,76 @@ func TestValidateBlob_ErrorPathsWithMock(t *testing.T) {
 	}
 }
 
+func TestValidateBlob_NotifiesSidecar(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := p2ptest.NewTestP2P(t)
+	chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0)}
+	s := &Service{
+		ctx:               ctx,
+		seenBlobCache:     lruwrpr.New(10),
+		seenPendingBlocks: make(map[[32]byte]bool),
+		gossipEvents:      make(chan *feed.Event, gossipEventsLimit),
+		cfg: &config{
+			chain:             chainService,
+			p2p:               p,
+			initialSync:       &mockSync.Sync{},
+			clock:             startup.NewClock(chainService.Genesis, chainService.ValidatorsRoot),
+			operationNotifier: chainService.OperationNotifier(),
+		}}
+	s.newBlobVerifier = func(b blocks.ROBlob, reqs []verification.Requirement) verification.BlobVerifier {
+		return &passingBlobVerifier{blob: b}
+	}
+	go s.gossipEventsRoutine()
+
+	_, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, chainService.CurrentSlot()+1, 1)
+	msg := scs[0].BlobSidecar
+	buf := new(bytes.Buffer)
+	_, err := p.Encoding().EncodeGossip(buf, msg)
+	require.NoError(t, err)
+
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(msg)]
+	digest, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic = s.addDigestAndIndexToTopic(topic, digest, 0)
+
+	// Subscribe to operation notifications.
+	opChannel := make(chan *feed.Event, 1)
+	opSub := s.cfg.operationNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+
+	result, err := s.validateBlob(ctx, "", &pubsub.Message{
+		Message: &pb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		}})
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationAccept, result)
+
+	select {
+	case event := <-opChannel:
+		require.Equal(t, feed.EventType(opfeed.BlobSidecarReceived), event.Type)
+		data, ok := event.Data.(*opfeed.BlobSidecarReceivedData)
+		require.Equal(t, true, ok, "Entity is not of type *opfeed.BlobSidecarReceivedData")
+		require.Equal(t, scs[0].BlockRoot(), data.Blob.BlockRoot())
+		require.Equal(t, scs[0].Index, data.Blob.Index)
+	case <-opSub.Err():
+		t.Error("Subscription to operation notifier failed")
+	case <-time.After(10 * time.Second): // Timeout to prevent hanging tests
+		t.Error("Timeout waiting for blob sidecar notification")
+	}
+}
+
+// passingBlobVerifier is a blob verifier for which all checks pass.
+type passingBlobVerifier struct {
+	verification.MockBlobVerifier
+	blob blocks.ROBlob
+}
+
+func (v *passingBlobVerifier) VerifiedROBlob() (blocks.VerifiedROBlob, error) {
+	return blocks.NewVerifiedROBlob(v.blob), nil
+}
+
 func testNewBlobVerifier() verification.NewBlobVerifier {
 	return func(b blocks.ROBlob, reqs []verification.Requirement) verification.BlobVerifier {
 		return &verification.MockBlobVerifier{}
@@ -3,6 +3,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/urfave/cli/v2"
@@ -179,6 +181,35 @@ var (
 		Name:  "disable-debug-rpc-endpoints",
 		Usage: "Disables the debug Beacon API namespace.",
 	}
+	// MaxHistoricalCommitteesEpochs defines how far back committees are served by regenerating historical states.
+	MaxHistoricalCommitteesEpochs = &cli.Uint64Flag{
+		Name:  "max-historical-committees-epochs",
+		Usage: "Sets how many epochs before the epoch of the requested state the committees endpoint regenerates historical states for. Older epochs are rejected.",
+		Value: 1024,
+	}
+	// MaxLivenessEpochs defines how far back validator liveness is served.
+	MaxLivenessEpochs = &cli.Uint64Flag{
+		Name:  "max-liveness-epochs",
+		Usage: "Sets how many epochs before the current epoch the validator liveness endpoint answers for. Any epoch whose state can be regenerated is served when 0, which is the default.",
+		Value: 0,
+	}
+	// EventHistorySize defines how many recent events of each topic are kept to resume event streams.
+	EventHistorySize = &cli.IntFlag{
+		Name:  "event-history-size",
+		Usage: "Sets how many recent events of each topic are kept for clients resuming their event stream with the Last-Event-ID header. Streams can't be resumed when 0, which is the default.",
+		Value: 0,
+	}
+	// EventHistoryAge defines for how long events are kept to resume event streams.
+	EventHistoryAge = &cli.DurationFlag{
+		Name:  "event-history-age",
+		Usage: "Sets for how long events are kept for clients resuming their event stream with the Last-Event-ID header.",
+		Value: 5 * time.Minute,
+	}
+	// ProposalSlotLookahead defines how far ahead of a state expected withdrawals may be requested.
+	ProposalSlotLookahead = &cli.Uint64Flag{
+		Name:  "proposal-slot-lookahead",
+		Usage: "Sets how many slots ahead of the requested state the expected withdrawals endpoint accepts proposal slots for. Defaults to MAX_SEED_LOOKAHEAD epochs when 0.",
+	}
 	// SubscribeToAllSubnets defines a flag to specify whether to subscribe to all possible attestation/sync subnets or not.
 	SubscribeToAllSubnets = &cli.BoolFlag{
 		Name:  "subscribe-all-subnets",
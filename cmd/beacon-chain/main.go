@@ -65,6 +65,11 @@ var appFlags = []cli.Flag{
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
 	flags.DisableDebugRPCEndpoints,
+	flags.MaxHistoricalCommitteesEpochs,
+	flags.MaxLivenessEpochs,
+	flags.ProposalSlotLookahead,
+	flags.EventHistorySize,
+	flags.EventHistoryAge,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
@@ -117,6 +117,11 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BlobBatchLimit,
 			flags.BlobBatchLimitBurstFactor,
 			flags.DisableDebugRPCEndpoints,
+			flags.MaxHistoricalCommitteesEpochs,
+			flags.MaxLivenessEpochs,
+			flags.ProposalSlotLookahead,
+			flags.EventHistorySize,
+			flags.EventHistoryAge,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
@@ -328,7 +328,7 @@ var (
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
 		Name:  "graffiti-file",
-		Usage: "Path to a YAML file with graffiti values.",
+		Usage: "Path to a YAML file with graffiti values. The file is re-read for every proposal, so it can be changed without restarting.",
 	}
 	// ProposerSettingsFlag defines the path or URL to a file with proposer config.
 	ProposerSettingsFlag = &cli.StringFlag{
@@ -1,7 +1,13 @@
 package params
 
 import (
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
 // SetupTestConfigCleanup preserves configurations allowing to modify them within tests without any
@@ -38,3 +44,120 @@ func SetActiveTestCleanup(t *testing.T, cfg *BeaconChainConfig) {
 		}
 	})
 }
+
+// LoadChainConfigFileForTest loads the chain config from the yaml file at the given path and sets it
+// as the active config, and adds a test cleanup hook to revert to the previous config after the test
+// completes. Values missing from the file are taken from the mainnet config, or from the minimal
+// config for files based on the minimal preset. Activating the config registers its fork version
+// schedule, so the fork versions of the file must not collide with those of a known config.
+func LoadChainConfigFileForTest(t testing.TB, path string) *BeaconChainConfig {
+	t.Helper()
+	cfg, err := UnmarshalConfigFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	undo, err := SetActiveWithUndo(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := undo(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return cfg
+}
+
+var (
+	forkScheduleLock sync.Mutex
+	forkSchedules    []*forkScheduleInstall
+)
+
+// forkScheduleInstall is a fork schedule installed by SetupTestConfigWithForkSchedule, shared by
+// all parallel tests that asked for the same schedule.
+type forkScheduleInstall struct {
+	test     string
+	schedule []primitives.Epoch
+	users    int
+	undo     func() error
+}
+
+// SetupTestConfigWithForkSchedule installs a copy of the active config with the given fork epochs,
+// keyed by the fork versions of the runtime/version package, and restores the previous config after
+// the test. Forks missing from the map keep their epochs, and the resulting schedule must not go
+// back in time from one fork to the next. Activating the copy registers its fork version schedule.
+// The test fails if a parallel test is running with a different fork schedule, since each would
+// otherwise observe the config of the other.
+func SetupTestConfigWithForkSchedule(t testing.TB, epochs map[int]primitives.Epoch) {
+	t.Helper()
+	cfg := configs.getActive().Copy()
+	for v, e := range epochs {
+		field := forkEpochField(cfg, v)
+		if field == nil {
+			t.Fatalf("fork epoch of %s can't be scheduled", version.String(v))
+		}
+		*field = e
+	}
+	schedule := make([]primitives.Epoch, 0, version.Electra)
+	for v := version.Altair; v <= version.Electra; v++ {
+		e := *forkEpochField(cfg, v)
+		if len(schedule) > 0 && e < schedule[len(schedule)-1] {
+			t.Fatalf("%s fork epoch %d is before %s fork epoch %d", version.String(v), e, version.String(v-1), schedule[len(schedule)-1])
+		}
+		schedule = append(schedule, e)
+	}
+
+	forkScheduleLock.Lock()
+	defer forkScheduleLock.Unlock()
+	for _, other := range forkSchedules {
+		// Subtests may override the schedule of their parent test.
+		if other.test == t.Name() || strings.HasPrefix(t.Name(), other.test+"/") {
+			continue
+		}
+		if !slices.Equal(other.schedule, schedule) {
+			t.Fatalf("test %s installs fork schedule %v while test %s is running with fork schedule %v", t.Name(), schedule, other.test, other.schedule)
+		}
+		other.users++
+		t.Cleanup(func() { releaseForkSchedule(t, other) })
+		return
+	}
+	undo, err := SetActiveWithUndo(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	install := &forkScheduleInstall{test: t.Name(), schedule: schedule, users: 1, undo: undo}
+	forkSchedules = append(forkSchedules, install)
+	t.Cleanup(func() { releaseForkSchedule(t, install) })
+}
+
+// releaseForkSchedule restores the config that was active before the fork schedule was installed,
+// once the last test using it is done.
+func releaseForkSchedule(t testing.TB, install *forkScheduleInstall) {
+	forkScheduleLock.Lock()
+	defer forkScheduleLock.Unlock()
+	install.users--
+	if install.users > 0 {
+		return
+	}
+	forkSchedules = slices.DeleteFunc(forkSchedules, func(i *forkScheduleInstall) bool { return i == install })
+	if err := install.undo(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func forkEpochField(cfg *BeaconChainConfig, v int) *primitives.Epoch {
+	switch v {
+	case version.Altair:
+		return &cfg.AltairForkEpoch
+	case version.Bellatrix:
+		return &cfg.BellatrixForkEpoch
+	case version.Capella:
+		return &cfg.CapellaForkEpoch
+	case version.Deneb:
+		return &cfg.DenebForkEpoch
+	case version.Electra:
+		return &cfg.ElectraForkEpoch
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+package params_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestSetupTestConfigWithForkSchedule(t *testing.T) {
+	prev := params.BeaconConfig().Copy()
+	t.Run("installs and restores", func(t *testing.T) {
+		params.SetupTestConfigWithForkSchedule(t, map[int]primitives.Epoch{
+			version.Altair:    0,
+			version.Bellatrix: 0,
+			version.Capella:   2,
+			version.Deneb:     4,
+		})
+		cfg := params.BeaconConfig()
+		assert.Equal(t, primitives.Epoch(2), cfg.CapellaForkEpoch)
+		assert.Equal(t, primitives.Epoch(4), cfg.DenebForkEpoch)
+		assert.Equal(t, prev.ElectraForkEpoch, cfg.ElectraForkEpoch)
+		assert.Equal(t, primitives.Epoch(4), cfg.ForkVersionSchedule[bytesutil.ToBytes4(cfg.DenebForkVersion)])
+
+		t.Run("subtests may override", func(t *testing.T) {
+			params.SetupTestConfigWithForkSchedule(t, map[int]primitives.Epoch{version.Deneb: 5})
+			assert.Equal(t, primitives.Epoch(5), params.BeaconConfig().DenebForkEpoch)
+		})
+		assert.Equal(t, primitives.Epoch(4), params.BeaconConfig().DenebForkEpoch)
+	})
+	cfg := params.BeaconConfig()
+	assert.Equal(t, prev.CapellaForkEpoch, cfg.CapellaForkEpoch)
+	assert.Equal(t, prev.DenebForkEpoch, cfg.DenebForkEpoch)
+	assert.Equal(t, prev.DenebForkEpoch, cfg.ForkVersionSchedule[bytesutil.ToBytes4(cfg.DenebForkVersion)])
+}
+
+func TestSetupTestConfigWithForkSchedule_Invalid(t *testing.T) {
+	tb := &fakeTB{TB: t, name: "invalid"}
+	tb.run(func() {
+		params.SetupTestConfigWithForkSchedule(tb, map[int]primitives.Epoch{
+			version.Altair:    0,
+			version.Bellatrix: 0,
+			version.Capella:   2,
+			version.Deneb:     1,
+		})
+	})
+	assert.StringContains(t, "deneb fork epoch 1 is before capella fork epoch 2", tb.failure)
+
+	tb = &fakeTB{TB: t, name: "phase0"}
+	tb.run(func() {
+		params.SetupTestConfigWithForkSchedule(tb, map[int]primitives.Epoch{version.Phase0: 1})
+	})
+	assert.StringContains(t, "fork epoch of phase0 can't be scheduled", tb.failure)
+	tb.cleanup()
+}
+
+func TestSetupTestConfigWithForkSchedule_Parallel(t *testing.T) {
+	prev := params.BeaconConfig().Copy()
+	schedule := map[int]primitives.Epoch{version.Altair: 1}
+	first := &fakeTB{TB: t, name: "first"}
+	first.run(func() { params.SetupTestConfigWithForkSchedule(first, schedule) })
+	require.Equal(t, "", first.failure)
+
+	conflicting := &fakeTB{TB: t, name: "conflicting"}
+	conflicting.run(func() {
+		params.SetupTestConfigWithForkSchedule(conflicting, map[int]primitives.Epoch{version.Altair: 2})
+	})
+	assert.StringContains(t, "while test first is running", conflicting.failure)
+	conflicting.cleanup()
+
+	// A parallel test with the same schedule shares the config, which stays installed until both are done.
+	same := &fakeTB{TB: t, name: "same"}
+	same.run(func() { params.SetupTestConfigWithForkSchedule(same, schedule) })
+	require.Equal(t, "", same.failure)
+	first.cleanup()
+	assert.Equal(t, primitives.Epoch(1), params.BeaconConfig().AltairForkEpoch)
+	same.cleanup()
+	assert.Equal(t, prev.AltairForkEpoch, params.BeaconConfig().AltairForkEpoch)
+}
+
+func TestLoadChainConfigFileForTest(t *testing.T) {
+	prev := params.BeaconConfig().Copy()
+	t.Run("loads", func(t *testing.T) {
+		cfg := params.LoadChainConfigFileForTest(t, "testdata/devnet_config.yaml")
+		assert.Equal(t, cfg, params.BeaconConfig())
+		assert.Equal(t, "test-devnet", cfg.ConfigName)
+		assert.Equal(t, uint64(6), cfg.SecondsPerSlot)
+		assert.Equal(t, uint64(32), cfg.SyncCommitteeSize)
+		// Values missing from the file are taken from mainnet.
+		assert.Equal(t, params.MainnetConfig().SlotsPerEpoch, cfg.SlotsPerEpoch)
+		assert.Equal(t, params.MainnetConfig().MaxEffectiveBalance, cfg.MaxEffectiveBalance)
+
+		byVersion, err := params.ByVersion(bytesutil.ToBytes4(cfg.BellatrixForkVersion))
+		require.NoError(t, err)
+		assert.Equal(t, "test-devnet", byVersion.ConfigName)
+		assert.Equal(t, primitives.Epoch(0), cfg.ForkVersionSchedule[bytesutil.ToBytes4(cfg.BellatrixForkVersion)])
+	})
+	assert.Equal(t, prev.ConfigName, params.BeaconConfig().ConfigName)
+	assert.Equal(t, prev.SecondsPerSlot, params.BeaconConfig().SecondsPerSlot)
+	_, err := params.ByName("test-devnet")
+	require.ErrorContains(t, "unable to find requested BeaconChainConfig", err)
+}
+
+// fakeTB records the failure and the cleanups of a test, so that they can be asserted on and run
+// at a chosen time.
+type fakeTB struct {
+	testing.TB
+	name     string
+	failure  string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Name() string { return f.name }
+
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeTB) Fatal(args ...interface{}) {
+	f.failure = fmt.Sprint(args...)
+	runtime.Goexit()
+}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failure = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// run calls fn in its own goroutine, as failing the test exits the calling goroutine.
+func (f *fakeTB) run(fn func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	<-done
+}
+
+func (f *fakeTB) cleanup() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+	f.cleanups = nil
+}
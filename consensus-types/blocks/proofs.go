@@ -0,0 +1,136 @@
+package blocks
+
+import (
+	"encoding/binary"
+
+	field_params "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// payloadPosition is the index of the execution payload in the Body
+const payloadPosition = 9
+
+// PayloadProof constructs the Merkle proof of the execution payload of the
+// Beacon Block with the given `body`, as used by the light client headers.
+func PayloadProof(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	if body.Version() < version.Capella {
+		return nil, errUnsupportedBeaconBlockBody
+	}
+	roots, err := bodyFieldRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	sparse, err := trie.GenerateTrieFromItems(roots, logBodyLength)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := sparse.MerkleProof(payloadPosition)
+	if err != nil {
+		return nil, err
+	}
+	// sparse.MerkleProof always includes the length of the slice, which is
+	// not part of the proof in the Body
+	return proof[:len(proof)-1], nil
+}
+
+// bodyFieldRoots computes the roots of all the elements of a Capella or later
+// BeaconBlockBody
+func bodyFieldRoots(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	cfg := params.BeaconConfig()
+	maxAttesterSlashings, maxAttestations := cfg.MaxAttesterSlashings, cfg.MaxAttestations
+	if body.Version() >= version.Electra {
+		maxAttesterSlashings, maxAttestations = cfg.MaxAttesterSlashingsElectra, cfg.MaxAttestationsElectra
+	}
+
+	roots := make([][32]byte, 0, 13)
+	randao := body.RandaoReveal()
+	root, err := ssz.MerkleizeByteSliceSSZ(randao[:])
+	if err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	if root, err = body.Eth1Data().HashTreeRoot(); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root, body.Graffiti())
+	if root, err = ssz.MerkleizeListSSZ(body.ProposerSlashings(), cfg.MaxProposerSlashings); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	if root, err = ssz.MerkleizeListSSZ(body.AttesterSlashings(), maxAttesterSlashings); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	if root, err = ssz.MerkleizeListSSZ(body.Attestations(), maxAttestations); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	if root, err = ssz.MerkleizeListSSZ(body.Deposits(), cfg.MaxDeposits); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	if root, err = ssz.MerkleizeListSSZ(body.VoluntaryExits(), cfg.MaxVoluntaryExits); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	sa, err := body.SyncAggregate()
+	if err != nil {
+		return nil, err
+	}
+	if root, err = sa.HashTreeRoot(); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	ep, err := body.Execution()
+	if err != nil {
+		return nil, err
+	}
+	if root, err = ep.HashTreeRoot(); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+	bls, err := body.BLSToExecutionChanges()
+	if err != nil {
+		return nil, err
+	}
+	if root, err = ssz.MerkleizeListSSZ(bls, cfg.MaxBlsToExecutionChanges); err != nil {
+		return nil, err
+	}
+	roots = append(roots, root)
+
+	if body.Version() >= version.Deneb {
+		commitments, err := body.BlobKzgCommitments()
+		if err != nil {
+			return nil, err
+		}
+		leaves := leavesFromCommitments(commitments)
+		chunks := make([][32]byte, len(leaves))
+		for i := range leaves {
+			copy(chunks[i][:], leaves[i])
+		}
+		length := make([]byte, 32)
+		binary.LittleEndian.PutUint64(length, uint64(len(commitments)))
+		root = ssz.MixInLength(ssz.MerkleizeVector(chunks, field_params.MaxBlobCommitmentsPerBlock), length)
+		roots = append(roots, root)
+	}
+	if body.Version() >= version.Electra {
+		electraBody, err := interfaces.AsROBlockBodyElectra(body)
+		if err != nil {
+			return nil, err
+		}
+		if root, err = ssz.MerkleizeListSSZ(electraBody.Consolidations(), cfg.MaxConsolidations); err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+
+	layer := make([][]byte, len(roots))
+	for i := range roots {
+		layer[i] = roots[i][:]
+	}
+	return layer, nil
+}
@@ -0,0 +1,116 @@
+package blocks
+
+import (
+	"crypto/rand"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func Test_PayloadProof(t *testing.T) {
+	syncAggregate := &ethpb.SyncAggregate{
+		SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+		SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+	}
+	eth1Data := &ethpb.Eth1Data{
+		DepositRoot: make([]byte, fieldparams.RootLength),
+		BlockHash:   make([]byte, fieldparams.RootLength),
+	}
+	blockHash := make([]byte, fieldparams.RootLength)
+	_, err := rand.Read(blockHash)
+	require.NoError(t, err)
+	kzgs := make([][]byte, 2)
+	for i := range kzgs {
+		kzgs[i] = make([]byte, 48)
+		_, err = rand.Read(kzgs[i])
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		body interface{}
+	}{
+		{
+			name: "capella",
+			body: &ethpb.BeaconBlockBodyCapella{
+				Eth1Data:      eth1Data,
+				SyncAggregate: syncAggregate,
+				ExecutionPayload: &enginev1.ExecutionPayloadCapella{
+					ParentHash:    make([]byte, fieldparams.RootLength),
+					FeeRecipient:  make([]byte, 20),
+					StateRoot:     make([]byte, fieldparams.RootLength),
+					ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, fieldparams.RootLength),
+					BaseFeePerGas: make([]byte, fieldparams.RootLength),
+					BlockHash:     blockHash,
+				},
+			},
+		},
+		{
+			name: "deneb",
+			body: &ethpb.BeaconBlockBodyDeneb{
+				Eth1Data:      eth1Data,
+				SyncAggregate: syncAggregate,
+				ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+					ParentHash:    make([]byte, fieldparams.RootLength),
+					FeeRecipient:  make([]byte, 20),
+					StateRoot:     make([]byte, fieldparams.RootLength),
+					ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, fieldparams.RootLength),
+					BaseFeePerGas: make([]byte, fieldparams.RootLength),
+					BlockHash:     blockHash,
+					BlobGasUsed:   1,
+				},
+				BlobKzgCommitments: kzgs,
+			},
+		},
+		{
+			name: "electra",
+			body: &ethpb.BeaconBlockBodyElectra{
+				Eth1Data:      eth1Data,
+				SyncAggregate: syncAggregate,
+				ExecutionPayload: &enginev1.ExecutionPayloadElectra{
+					ParentHash:    make([]byte, fieldparams.RootLength),
+					FeeRecipient:  make([]byte, 20),
+					StateRoot:     make([]byte, fieldparams.RootLength),
+					ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, fieldparams.RootLength),
+					BaseFeePerGas: make([]byte, fieldparams.RootLength),
+					BlockHash:     blockHash,
+				},
+				BlobKzgCommitments: kzgs,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := NewBeaconBlockBody(tt.body)
+			require.NoError(t, err)
+			proof, err := PayloadProof(body)
+			require.NoError(t, err)
+			require.Equal(t, logBodyLength, len(proof))
+
+			root, err := body.HashTreeRoot()
+			require.NoError(t, err)
+			payload, err := body.Execution()
+			require.NoError(t, err)
+			payloadRoot, err := payload.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, true, trie.VerifyMerkleProof(root[:], payloadRoot[:], payloadPosition, proof))
+		})
+	}
+
+	t.Run("altair", func(t *testing.T) {
+		body, err := NewBeaconBlockBody(&ethpb.BeaconBlockBodyAltair{})
+		require.NoError(t, err)
+		_, err = PayloadProof(body)
+		require.ErrorIs(t, errUnsupportedBeaconBlockBody, err)
+	})
+}
@@ -48,7 +48,9 @@ type Node struct {
 	Balance                  uint64
 	Weight                   uint64
 	Timestamp                uint64
+	Timely                   bool
 	BlockRoot                []byte
 	ParentRoot               []byte
+	TargetRoot               []byte
 	ExecutionBlockHash       []byte
 }
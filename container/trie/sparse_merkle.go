@@ -86,10 +86,19 @@ func GenerateTrieFromItems(items [][]byte, depth uint64) (*SparseMerkleTrie, err
 		if len(layers[i])%2 == 1 {
 			layers[i] = append(layers[i], ZeroHashes[i][:])
 		}
-		updatedValues := make([][]byte, 0)
-		for j := 0; j < len(layers[i]); j += 2 {
-			concat := hash.Hash(append(layers[i][j], layers[i][j+1]...))
-			updatedValues = append(updatedValues, concat[:])
+		numPairs := len(layers[i]) / 2
+		buf := make([]byte, 64*numPairs)
+		pairs := make([][]byte, numPairs)
+		for j := range pairs {
+			pair := buf[64*j : 64*(j+1)]
+			copy(pair[:32], layers[i][2*j])
+			copy(pair[32:], layers[i][2*j+1])
+			pairs[j] = pair
+		}
+		hashes := hash.HashMany(pairs)
+		updatedValues := make([][]byte, numPairs)
+		for j := range hashes {
+			updatedValues[j] = hashes[j][:]
 		}
 		layers[i+1] = updatedValues
 	}
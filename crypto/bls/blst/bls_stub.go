@@ -0,0 +1,361 @@
+//go:build bls_stub
+
+package blst
+
+// This file replaces BLS with a non-cryptographic scheme for fast unit tests. It is only compiled
+// with the bls_stub build tag and refuses to run outside of a test binary.
+//
+// Keys and signatures are elements of the ring of integers modulo 2^384. A public key is the
+// secret key multiplied by a fixed odd generator, and a signature is the public key multiplied by
+// an odd digest of the message, so that aggregating keys and signatures by addition keeps every
+// verification rule of the real scheme. Anyone can compute a valid signature from a public key,
+// so this scheme must never protect anything.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+)
+
+const stubElementLength = fieldparams.BLSPubkeyLength
+
+var (
+	stubModulus   = new(big.Int).Lsh(big.NewInt(1), 8*stubElementLength)
+	stubGenerator = stubDigest([]byte("bls stub generator"))
+)
+
+func init() {
+	if !testing.Testing() {
+		panic("the bls_stub build tag must only be used in tests")
+	}
+}
+
+// stubDigest maps a message to an odd element of the ring, so that multiplying a non-zero element
+// by it never gives zero.
+func stubDigest(msg []byte) *big.Int {
+	var out [stubElementLength]byte
+	h1 := sha256.Sum256(append([]byte{0}, msg...))
+	h2 := sha256.Sum256(append([]byte{1}, msg...))
+	copy(out[:], h1[:])
+	copy(out[len(h1):], h2[:])
+	out[stubElementLength-1] |= 1
+	return new(big.Int).SetBytes(out[:])
+}
+
+func stubMul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Mod(r, stubModulus)
+}
+
+func stubAdd(a, b *big.Int) *big.Int {
+	r := new(big.Int).Add(a, b)
+	return r.Mod(r, stubModulus)
+}
+
+func stubElementBytes(v *big.Int) []byte {
+	out := make([]byte, stubElementLength)
+	return v.FillBytes(out)
+}
+
+// bls12SecretKey used in the stub signature scheme.
+type bls12SecretKey struct {
+	k []byte
+}
+
+// RandKey creates a new private key from random bytes.
+func RandKey() (common.SecretKey, error) {
+	k := make([]byte, params.BeaconConfig().BLSSecretKeyLength)
+	if _, err := rand.NewGenerator().Read(k); err != nil {
+		return nil, err
+	}
+	if IsZero(k) {
+		return nil, common.ErrZeroKey
+	}
+	return &bls12SecretKey{k: k}, nil
+}
+
+// SecretKeyFromBytes creates a private key from a BigEndian byte slice.
+func SecretKeyFromBytes(privKey []byte) (common.SecretKey, error) {
+	if len(privKey) != params.BeaconConfig().BLSSecretKeyLength {
+		return nil, fmt.Errorf("secret key must be %d bytes", params.BeaconConfig().BLSSecretKeyLength)
+	}
+	// Like blst, a zero key cannot be deserialized.
+	if IsZero(privKey) {
+		return nil, common.ErrSecretUnmarshal
+	}
+	return &bls12SecretKey{k: bytes.Clone(privKey)}, nil
+}
+
+// IsZero checks if the secret key is a zero key.
+func IsZero(sKey []byte) bool {
+	for _, b := range sKey {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicKey obtains the public key corresponding to the secret key.
+func (s *bls12SecretKey) PublicKey() common.PublicKey {
+	return &PublicKey{v: stubMul(new(big.Int).SetBytes(s.k), stubGenerator)}
+}
+
+// Sign a message with the secret key.
+func (s *bls12SecretKey) Sign(msg []byte) common.Signature {
+	pub := s.PublicKey().(*PublicKey)
+	return &Signature{v: stubMul(pub.v, stubDigest(msg))}
+}
+
+// Marshal a secret key into a BigEndian byte slice.
+func (s *bls12SecretKey) Marshal() []byte {
+	return bytes.Clone(s.k)
+}
+
+// PublicKey used in the stub signature scheme.
+type PublicKey struct {
+	v *big.Int
+}
+
+// PublicKeyFromBytes creates a public key from a BigEndian byte slice.
+func PublicKeyFromBytes(pubKey []byte) (common.PublicKey, error) {
+	if len(pubKey) != fieldparams.BLSPubkeyLength {
+		return nil, fmt.Errorf("public key must be %d bytes", fieldparams.BLSPubkeyLength)
+	}
+	if bytes.Equal(pubKey, common.InfinitePublicKey[:]) {
+		return nil, common.ErrInfinitePubKey
+	}
+	v := new(big.Int).SetBytes(pubKey)
+	if v.Sign() == 0 {
+		return nil, errors.New("could not unmarshal bytes into public key")
+	}
+	return &PublicKey{v: v}, nil
+}
+
+// AggregatePublicKeys aggregates the provided raw public keys into a single key.
+func AggregatePublicKeys(pubs [][]byte) (common.PublicKey, error) {
+	if len(pubs) == 0 {
+		return nil, errors.New("nil or empty public keys")
+	}
+	agg := new(big.Int)
+	for _, pub := range pubs {
+		p, err := PublicKeyFromBytes(pub)
+		if err != nil {
+			return nil, err
+		}
+		agg = stubAdd(agg, p.(*PublicKey).v)
+	}
+	return &PublicKey{v: agg}, nil
+}
+
+// AggregateMultiplePubkeys aggregates the provided decompressed keys into a single key.
+func AggregateMultiplePubkeys(pubkeys []common.PublicKey) common.PublicKey {
+	agg := new(big.Int)
+	for _, p := range pubkeys {
+		agg = stubAdd(agg, p.(*PublicKey).v)
+	}
+	return &PublicKey{v: agg}
+}
+
+// Marshal a public key into a BigEndian byte slice.
+func (p *PublicKey) Marshal() []byte {
+	if p.v.Sign() == 0 {
+		return bytes.Clone(common.InfinitePublicKey[:])
+	}
+	return stubElementBytes(p.v)
+}
+
+// Copy the public key to a new pointer reference.
+func (p *PublicKey) Copy() common.PublicKey {
+	return &PublicKey{v: new(big.Int).Set(p.v)}
+}
+
+// IsInfinite checks if the public key is infinite.
+func (p *PublicKey) IsInfinite() bool {
+	return p.v.Sign() == 0
+}
+
+// Equals checks if the provided public key is equal to the current one.
+func (p *PublicKey) Equals(p2 common.PublicKey) bool {
+	return p.v.Cmp(p2.(*PublicKey).v) == 0
+}
+
+// Aggregate two public keys.
+func (p *PublicKey) Aggregate(p2 common.PublicKey) common.PublicKey {
+	p.v = stubAdd(p.v, p2.(*PublicKey).v)
+	return p
+}
+
+// Signature used in the stub signature scheme. It is marshaled as the element twice, or as the
+// infinite signature for zero.
+type Signature struct {
+	v *big.Int
+}
+
+func signatureFromBytes(sig []byte) (*Signature, error) {
+	if len(sig) != fieldparams.BLSSignatureLength {
+		return nil, fmt.Errorf("signature must be %d bytes", fieldparams.BLSSignatureLength)
+	}
+	if bytes.Equal(sig, common.InfiniteSignature[:]) {
+		return &Signature{v: new(big.Int)}, nil
+	}
+	v := new(big.Int).SetBytes(sig[:stubElementLength])
+	if v.Sign() == 0 || !bytes.Equal(sig[:stubElementLength], sig[stubElementLength:]) {
+		return nil, errors.New("could not unmarshal bytes into signature")
+	}
+	return &Signature{v: v}, nil
+}
+
+// SignatureFromBytesNoValidation creates a signature from a byte slice.
+func SignatureFromBytesNoValidation(sig []byte) (common.Signature, error) {
+	s, err := signatureFromBytes(sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create signature from byte slice")
+	}
+	return s, nil
+}
+
+// SignatureFromBytes creates a signature from a byte slice.
+func SignatureFromBytes(sig []byte) (common.Signature, error) {
+	return SignatureFromBytesNoValidation(sig)
+}
+
+// AggregateCompressedSignatures converts a list of compressed signatures into a single, aggregated sig.
+func AggregateCompressedSignatures(multiSigs [][]byte) (common.Signature, error) {
+	sigs, err := MultipleSignaturesFromBytes(multiSigs)
+	if err != nil {
+		return nil, err
+	}
+	return AggregateSignatures(sigs), nil
+}
+
+// MultipleSignaturesFromBytes creates a group of signatures from a 2d-byte slice.
+func MultipleSignaturesFromBytes(multiSigs [][]byte) ([]common.Signature, error) {
+	if len(multiSigs) == 0 {
+		return nil, fmt.Errorf("0 signatures provided to the method")
+	}
+	sigs := make([]common.Signature, len(multiSigs))
+	for i, s := range multiSigs {
+		sig, err := signatureFromBytes(s)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig
+	}
+	return sigs, nil
+}
+
+// Verify a signature given a public key and a message.
+func (s *Signature) Verify(pubKey common.PublicKey, msg []byte) bool {
+	return s.v.Cmp(stubMul(pubKey.(*PublicKey).v, stubDigest(msg))) == 0
+}
+
+// AggregateVerify verifies each public key against its respective message.
+//
+// Deprecated: Use FastAggregateVerify or use this method in spectests only.
+func (s *Signature) AggregateVerify(pubKeys []common.PublicKey, msgs [][32]byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+	expected := new(big.Int)
+	for i := range pubKeys {
+		expected = stubAdd(expected, stubMul(pubKeys[i].(*PublicKey).v, stubDigest(msgs[i][:])))
+	}
+	return s.v.Cmp(expected) == 0
+}
+
+// FastAggregateVerify verifies all the provided public keys with their aggregated signature.
+func (s *Signature) FastAggregateVerify(pubKeys []common.PublicKey, msg [32]byte) bool {
+	if len(pubKeys) == 0 {
+		return false
+	}
+	return s.Verify(AggregateMultiplePubkeys(pubKeys), msg[:])
+}
+
+// Eth2FastAggregateVerify works like FastAggregateVerify, but accepts the infinite signature when
+// pubkeys are empty.
+func (s *Signature) Eth2FastAggregateVerify(pubKeys []common.PublicKey, msg [32]byte) bool {
+	if len(pubKeys) == 0 && s.v.Sign() == 0 {
+		return true
+	}
+	return s.FastAggregateVerify(pubKeys, msg)
+}
+
+// NewAggregateSignature creates a blank aggregate signature.
+func NewAggregateSignature() common.Signature {
+	return &Signature{v: stubDigest([]byte{'m', 'o', 'c', 'k'})}
+}
+
+// AggregateSignatures converts a list of signatures into a single, aggregated sig.
+func AggregateSignatures(sigs []common.Signature) common.Signature {
+	if len(sigs) == 0 {
+		return nil
+	}
+	agg := new(big.Int)
+	for _, s := range sigs {
+		agg = stubAdd(agg, s.(*Signature).v)
+	}
+	return &Signature{v: agg}
+}
+
+// VerifySignature verifies a single signature using public key and message.
+func VerifySignature(sig []byte, msg [32]byte, pubKey common.PublicKey) (bool, error) {
+	s, err := signatureFromBytes(sig)
+	if err != nil {
+		return false, err
+	}
+	return s.Verify(pubKey, msg[:]), nil
+}
+
+// VerifyMultipleSignatures verifies a non-singular set of signatures and its respective pubkeys and messages.
+func VerifyMultipleSignatures(sigs [][]byte, msgs [][32]byte, pubKeys []common.PublicKey) (bool, error) {
+	if len(sigs) == 0 || len(pubKeys) == 0 {
+		return false, nil
+	}
+	if len(sigs) != len(pubKeys) || len(sigs) != len(msgs) {
+		return false, errors.Errorf("provided signatures, pubkeys and messages have differing lengths. S: %d, P: %d,M %d",
+			len(sigs), len(pubKeys), len(msgs))
+	}
+	for i := range sigs {
+		s, err := signatureFromBytes(sigs[i])
+		if err != nil || !s.Verify(pubKeys[i], msgs[i][:]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Marshal a signature into a byte slice.
+func (s *Signature) Marshal() []byte {
+	if s.v.Sign() == 0 {
+		return bytes.Clone(common.InfiniteSignature[:])
+	}
+	e := stubElementBytes(s.v)
+	return append(e, e...)
+}
+
+// Copy returns a full deep copy of a signature.
+func (s *Signature) Copy() common.Signature {
+	return &Signature{v: new(big.Int).Set(s.v)}
+}
+
+// VerifyCompressed verifies that the compressed signature and pubkey are valid from the message provided.
+func VerifyCompressed(signature, pub, msg []byte) bool {
+	s, err := signatureFromBytes(signature)
+	if err != nil {
+		return false
+	}
+	p, err := PublicKeyFromBytes(pub)
+	if err != nil {
+		return false
+	}
+	return s.Verify(p, msg)
+}
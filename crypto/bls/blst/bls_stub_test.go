@@ -0,0 +1,69 @@
+//go:build bls_stub
+
+package blst_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/blst"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStub_SignAndAggregate(t *testing.T) {
+	msg := [32]byte{'s', 't', 'u', 'b'}
+	other := [32]byte{'o', 't', 'h', 'e', 'r'}
+	var pubs []common.PublicKey
+	var sigs []common.Signature
+	var otherSigs []common.Signature
+	for i := 0; i < 4; i++ {
+		sk, err := blst.RandKey()
+		require.NoError(t, err)
+		pubs = append(pubs, sk.PublicKey())
+		sigs = append(sigs, sk.Sign(msg[:]))
+		otherSigs = append(otherSigs, sk.Sign(other[:]))
+	}
+
+	assert.Equal(t, true, sigs[0].Verify(pubs[0], msg[:]))
+	assert.Equal(t, false, sigs[0].Verify(pubs[1], msg[:]))
+	assert.Equal(t, false, sigs[0].Verify(pubs[0], other[:]))
+
+	agg := blst.AggregateSignatures(sigs)
+	assert.Equal(t, true, agg.FastAggregateVerify(pubs, msg))
+	assert.Equal(t, false, agg.FastAggregateVerify(pubs[1:], msg))
+	assert.Equal(t, true, agg.Verify(blst.AggregateMultiplePubkeys(pubs), msg[:]))
+
+	mixed := blst.AggregateSignatures([]common.Signature{sigs[0], otherSigs[1]})
+	assert.Equal(t, true, mixed.AggregateVerify(pubs[:2], [][32]byte{msg, other}))
+	assert.Equal(t, false, mixed.AggregateVerify(pubs[:2], [][32]byte{other, msg}))
+
+	decoded, err := blst.SignatureFromBytes(agg.Marshal())
+	require.NoError(t, err)
+	assert.DeepEqual(t, agg.Marshal(), decoded.Marshal())
+	pub, err := blst.PublicKeyFromBytes(pubs[0].Marshal())
+	require.NoError(t, err)
+	assert.Equal(t, true, pub.Equals(pubs[0]))
+
+	valid, err := blst.VerifyMultipleSignatures(
+		[][]byte{sigs[0].Marshal(), otherSigs[1].Marshal()},
+		[][32]byte{msg, other},
+		pubs[:2],
+	)
+	require.NoError(t, err)
+	assert.Equal(t, true, valid)
+}
+
+func TestStub_Encoding(t *testing.T) {
+	_, err := blst.SignatureFromBytes(make([]byte, 96))
+	require.ErrorContains(t, "could not unmarshal bytes into signature", err)
+	_, err = blst.PublicKeyFromBytes(common.InfinitePublicKey[:])
+	require.ErrorIs(t, err, common.ErrInfinitePubKey)
+	_, err = blst.SecretKeyFromBytes(make([]byte, 32))
+	require.ErrorIs(t, err, common.ErrSecretUnmarshal)
+
+	infinite, err := blst.SignatureFromBytes(common.InfiniteSignature[:])
+	require.NoError(t, err)
+	assert.Equal(t, true, infinite.Eth2FastAggregateVerify(nil, [32]byte{}))
+	assert.DeepEqual(t, common.InfiniteSignature[:], infinite.Marshal())
+}
@@ -3,4 +3,7 @@
 // verifying and aggregating BLS signatures used by Ethereum.
 //
 // This implementation uses the library written by Supranational, blst.
+//
+// Building with the bls_stub tag replaces it with a fast, non-cryptographic scheme for unit tests
+// that only care about the structure of signatures. That scheme refuses to run outside of tests.
 package blst
@@ -1,3 +1,5 @@
+//go:build ((linux && amd64) || (linux && arm64) || (darwin && amd64) || (darwin && arm64) || (windows && amd64)) && !blst_disabled && !bls_stub
+
 package blst
 
 // Note: These functions are for tests to access private globals, such as pubkeyCache.
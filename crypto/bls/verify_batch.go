@@ -0,0 +1,65 @@
+package bls
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// verifyBatchChunkSize is the number of signatures checked together by VerifyBatch in a single
+// multi-pairing. Chunks are verified concurrently, so keeping them small lets large batches use
+// all available cores while still amortizing the final exponentiation.
+const verifyBatchChunkSize = 64
+
+// VerifyBatch verifies each signature against its respective message and public key. Signatures
+// are checked with random linear combinations in chunks that are verified in parallel. When a
+// chunk fails, it is bisected until the invalid signatures are isolated, and their indices are
+// returned in ascending order. A nil result means every signature in the batch is valid.
+func VerifyBatch(sigs [][]byte, msgs [][32]byte, pubKeys []PublicKey) ([]int, error) {
+	if len(sigs) != len(pubKeys) || len(sigs) != len(msgs) {
+		return nil, errors.Errorf("provided signatures, pubkeys and messages have differing lengths. S: %d, P: %d, M: %d",
+			len(sigs), len(pubKeys), len(msgs))
+	}
+	numChunks := (len(sigs) + verifyBatchChunkSize - 1) / verifyBatchChunkSize
+	failed := make([][]int, numChunks)
+	limit := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := 0; i < numChunks; i++ {
+		start := i * verifyBatchChunkSize
+		end := min(start+verifyBatchChunkSize, len(sigs))
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i, start, end int) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			failed[i] = bisectInvalidSignatures(sigs, msgs, pubKeys, start, end)
+		}(i, start, end)
+	}
+	wg.Wait()
+
+	var invalid []int
+	for _, f := range failed {
+		invalid = append(invalid, f...)
+	}
+	return invalid, nil
+}
+
+// bisectInvalidSignatures returns the indices in [start, end) of the signatures that do not verify.
+func bisectInvalidSignatures(sigs [][]byte, msgs [][32]byte, pubKeys []PublicKey, start, end int) []int {
+	if end-start == 1 {
+		valid, err := VerifySignature(sigs[start], msgs[start], pubKeys[start])
+		if err != nil || !valid {
+			return []int{start}
+		}
+		return nil
+	}
+	valid, err := VerifyMultipleSignatures(sigs[start:end], msgs[start:end], pubKeys[start:end])
+	if err == nil && valid {
+		return nil
+	}
+	mid := start + (end-start)/2
+	return append(bisectInvalidSignatures(sigs, msgs, pubKeys, start, mid), bisectInvalidSignatures(sigs, msgs, pubKeys, mid, end)...)
+}
@@ -0,0 +1,76 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	t.Run("all valid", func(t *testing.T) {
+		sigs, msgs, pubs := signedBatch(t, 130)
+		invalid, err := VerifyBatch(sigs, msgs, pubs)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(invalid))
+	})
+	t.Run("reports invalid indices", func(t *testing.T) {
+		sigs, msgs, pubs := signedBatch(t, 130)
+		// A signature over the wrong message, one from the wrong key and one that cannot be decoded.
+		sigs[3] = sigs[4]
+		pubs[70] = pubs[71]
+		sigs[129] = make([]byte, 96)
+		invalid, err := VerifyBatch(sigs, msgs, pubs)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []int{3, 70, 129}, invalid)
+	})
+	t.Run("empty", func(t *testing.T) {
+		invalid, err := VerifyBatch(nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(invalid))
+	})
+	t.Run("differing lengths", func(t *testing.T) {
+		sigs, msgs, pubs := signedBatch(t, 2)
+		_, err := VerifyBatch(sigs, msgs[:1], pubs)
+		require.ErrorContains(t, "differing lengths", err)
+	})
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	for _, n := range []int{128, 1024} {
+		sigs, msgs, pubs := signedBatch(b, n)
+		b.Run(fmt.Sprintf("single_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range sigs {
+					valid, err := VerifySignature(sigs[j], msgs[j], pubs[j])
+					if err != nil || !valid {
+						b.Fatal("could not verify signature")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batch_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				invalid, err := VerifyBatch(sigs, msgs, pubs)
+				if err != nil || len(invalid) > 0 {
+					b.Fatal("could not verify batch")
+				}
+			}
+		})
+	}
+}
+
+func signedBatch(tb testing.TB, num int) ([][]byte, [][32]byte, []PublicKey) {
+	sigs := make([][]byte, num)
+	msgs := make([][32]byte, num)
+	pubs := make([]PublicKey, num)
+	for i := 0; i < num; i++ {
+		priv, err := RandKey()
+		require.NoError(tb, err)
+		msgs[i] = messageBytes(fmt.Sprintf("batch%d", i))
+		sigs[i] = priv.Sign(msgs[i][:]).Marshal()
+		pubs[i] = priv.PublicKey()
+	}
+	return sigs, msgs, pubs
+}
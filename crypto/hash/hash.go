@@ -5,6 +5,7 @@ import (
 	"errors"
 	"hash"
 	"reflect"
+	"runtime"
 	"sync"
 
 	"github.com/minio/highwayhash"
@@ -75,6 +76,67 @@ func CustomSHA256Hasher() func([]byte) [32]byte {
 	}
 }
 
+// HashInto writes the sha256 checksum of data into dst. Hot loops can use it
+// to hash into preallocated memory instead of copying the returned array.
+func HashInto(dst *[32]byte, data []byte) {
+	h, ok := sha256Pool.Get().(hash.Hash)
+	if !ok {
+		h = sha256.New()
+	}
+	defer sha256Pool.Put(h)
+	h.Reset()
+
+	// #nosec G104
+	h.Write(data)
+	h.Sum(dst[:0])
+}
+
+// hashManyParallelThreshold is the number of inputs from which HashMany
+// splits the work across goroutines.
+const hashManyParallelThreshold = 1024
+
+// HashMany returns the sha256 checksums of the inputs, in the same order.
+// Pooled hashers are reused for all inputs, and large batches are hashed in
+// parallel.
+func HashMany(inputs [][]byte) [][32]byte {
+	out := make([][32]byte, len(inputs))
+	workers := runtime.GOMAXPROCS(0)
+	if len(inputs) < hashManyParallelThreshold || workers < 2 {
+		hashRange(out, inputs)
+		return out
+	}
+	chunk := (len(inputs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(inputs); start += chunk {
+		end := start + chunk
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			hashRange(out[start:end], inputs[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+	return out
+}
+
+// hashRange hashes each input into the respective output with a single pooled hasher.
+func hashRange(out [][32]byte, inputs [][]byte) {
+	h, ok := sha256Pool.Get().(hash.Hash)
+	if !ok {
+		h = sha256.New()
+	}
+	defer sha256Pool.Put(h)
+	for i, data := range inputs {
+		h.Reset()
+		// #nosec G104
+		h.Write(data)
+		h.Sum(out[i][:0])
+	}
+}
+
 var keccak256Pool = sync.Pool{New: func() interface{} {
 	return sha3.NewLegacyKeccak256()
 }}
@@ -31,6 +31,49 @@ func BenchmarkHash(b *testing.B) {
 	}
 }
 
+func TestHashInto(t *testing.T) {
+	var dst [32]byte
+	hash.HashInto(&dst, []byte{1})
+	assert.Equal(t, hash.Hash([]byte{1}), dst)
+}
+
+func TestHashMany(t *testing.T) {
+	for _, n := range []int{0, 3, 5000} {
+		inputs := make([][]byte, n)
+		for i := range inputs {
+			inputs[i] = bytesutil.Bytes8(uint64(i))
+		}
+		out := hash.HashMany(inputs)
+		require.Equal(t, n, len(out))
+		for i := range inputs {
+			require.Equal(t, hash.Hash(inputs[i]), out[i])
+		}
+	}
+}
+
+func BenchmarkHashMany(b *testing.B) {
+	inputs := make([][]byte, 4096)
+	for i := range inputs {
+		inputs[i] = make([]byte, 64)
+		inputs[i][0] = byte(i)
+	}
+	b.Run("Hash", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			out := make([][32]byte, len(inputs))
+			for j := range inputs {
+				out[j] = hash.Hash(inputs[j])
+			}
+		}
+	})
+	b.Run("HashMany", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			hash.HashMany(inputs)
+		}
+	})
+}
+
 func TestHashKeccak256(t *testing.T) {
 	hashOf0 := [32]byte{188, 54, 120, 158, 122, 30, 40, 20, 54, 70, 66, 41, 130, 143, 129, 125, 102, 18, 247, 180, 119, 214, 101, 145, 255, 150, 169, 224, 100, 188, 201, 138}
 	h := hash.Keccak256([]byte{0})
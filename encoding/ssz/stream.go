@@ -0,0 +1,376 @@
+package ssz
+
+import (
+	"encoding/binary"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// streamChunkSize is the number of bytes a StreamEncoder serializes before writing them to its writer.
+const streamChunkSize = 1 << 16
+
+// Marshaler is an SSZ container that can serialize itself, as implemented by the generated SSZ types.
+type Marshaler interface {
+	SizeSSZ() int
+	MarshalSSZTo(dst []byte) ([]byte, error)
+}
+
+var marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+
+type streamFieldKind int
+
+const (
+	boolField streamFieldKind = iota
+	uintField
+	bytesField
+	bytesListField
+	variableBytesListField
+	uintListField
+	containerField
+	containerListField
+	variableContainerListField
+)
+
+// streamField is a field of the container serialized by a StreamEncoder.
+type streamField struct {
+	index int
+	kind  streamFieldKind
+	fixed bool
+	// width is the number of bytes of the field's integers.
+	width int
+}
+
+// StreamEncoder writes the SSZ serialization of a container to a writer field by field, so that large containers
+// such as beacon states are written without allocating their whole serialization. The fields are serialized into
+// a buffer of bounded size, and lists are serialized element by element. Nested containers are serialized with
+// their own SSZ methods, as they are small compared to the lists holding them.
+type StreamEncoder struct {
+	container reflect.Value
+	fields    []streamField
+	sizes     []int
+	size      int
+}
+
+// NewStreamEncoder returns a StreamEncoder of the given container, which must be a pointer to a struct of the
+// generated SSZ types. An error is returned when the container has a field that can't be serialized, or when the
+// fields don't have the sizes their SSZ types require.
+func NewStreamEncoder(m Marshaler) (*StreamEncoder, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("unsupported container type %T", m)
+	}
+	v = v.Elem()
+	e := &StreamEncoder{container: v}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		sf, err := newStreamField(i, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s", f.Name)
+		}
+		size, err := sf.sizeOf(v.Field(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s", f.Name)
+		}
+		e.fields = append(e.fields, sf)
+		e.sizes = append(e.sizes, size)
+		if sf.fixed {
+			e.size += size
+		} else {
+			e.size += 4 + size
+		}
+	}
+	if want := m.SizeSSZ(); e.size != want {
+		return nil, errors.Errorf("serialization size %d does not match the SSZ size %d of %T", e.size, want, m)
+	}
+	return e, nil
+}
+
+// Size returns the number of bytes of the serialization.
+func (e *StreamEncoder) Size() int {
+	return e.size
+}
+
+// WriteTo writes the serialization to the writer.
+func (e *StreamEncoder) WriteTo(w io.Writer) (int64, error) {
+	sw := &streamWriter{w: w, buf: make([]byte, 0, streamChunkSize)}
+	offset := 0
+	for i, f := range e.fields {
+		if f.fixed {
+			offset += e.sizes[i]
+		} else {
+			offset += 4
+		}
+	}
+	for i, f := range e.fields {
+		if f.fixed {
+			if err := f.write(sw, e.container.Field(f.index)); err != nil {
+				return sw.n, err
+			}
+			continue
+		}
+		sw.writeOffset(offset)
+		offset += e.sizes[i]
+	}
+	for _, f := range e.fields {
+		if f.fixed {
+			continue
+		}
+		if err := f.write(sw, e.container.Field(f.index)); err != nil {
+			return sw.n, err
+		}
+	}
+	if err := sw.flush(); err != nil {
+		return sw.n, err
+	}
+	return sw.n, sw.err
+}
+
+func newStreamField(index int, f reflect.StructField) (streamField, error) {
+	sf := streamField{index: index, fixed: isFixedField(f.Type, f.Tag)}
+	t := f.Type
+	switch t.Kind() {
+	case reflect.Bool:
+		sf.kind = boolField
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sf.kind = uintField
+		sf.width = int(t.Size())
+	case reflect.Ptr:
+		if !isContainer(t) {
+			return sf, errors.Errorf("unsupported type %s", t)
+		}
+		sf.kind = containerField
+	case reflect.Slice:
+		switch et := t.Elem(); et.Kind() {
+		case reflect.Uint8:
+			sf.kind = bytesField
+		case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			sf.kind = uintListField
+			sf.width = int(et.Size())
+		case reflect.Slice:
+			if et.Elem().Kind() != reflect.Uint8 {
+				return sf, errors.Errorf("unsupported type %s", t)
+			}
+			if hasFixedSizeElements(f.Tag) {
+				sf.kind = bytesListField
+			} else {
+				sf.kind = variableBytesListField
+			}
+		case reflect.Ptr:
+			if !isContainer(et) {
+				return sf, errors.Errorf("unsupported type %s", t)
+			}
+			if isFixedContainer(et.Elem()) {
+				sf.kind = containerListField
+			} else {
+				sf.kind = variableContainerListField
+			}
+		default:
+			return sf, errors.Errorf("unsupported type %s", t)
+		}
+	default:
+		return sf, errors.Errorf("unsupported type %s", t)
+	}
+	return sf, nil
+}
+
+// sizeOf returns the number of bytes of the field's serialization, without the offset of variable-size fields.
+func (f streamField) sizeOf(v reflect.Value) (int, error) {
+	switch f.kind {
+	case boolField:
+		return 1, nil
+	case uintField:
+		return f.width, nil
+	case bytesField:
+		return v.Len(), nil
+	case bytesListField, variableBytesListField:
+		size := 0
+		for i := 0; i < v.Len(); i++ {
+			size += v.Index(i).Len()
+		}
+		if f.kind == variableBytesListField {
+			size += 4 * v.Len()
+		}
+		return size, nil
+	case uintListField:
+		return f.width * v.Len(), nil
+	case containerField:
+		return containerValue(v).SizeSSZ(), nil
+	case containerListField, variableContainerListField:
+		size := 0
+		for i := 0; i < v.Len(); i++ {
+			if v.Index(i).IsNil() {
+				return 0, errors.Errorf("nil element at index %d", i)
+			}
+			size += containerValue(v.Index(i)).SizeSSZ()
+		}
+		if f.kind == variableContainerListField {
+			size += 4 * v.Len()
+		}
+		return size, nil
+	default:
+		return 0, errors.Errorf("unsupported field kind %d", f.kind)
+	}
+}
+
+func (f streamField) write(sw *streamWriter, v reflect.Value) error {
+	switch f.kind {
+	case boolField:
+		if v.Bool() {
+			sw.write([]byte{1})
+		} else {
+			sw.write([]byte{0})
+		}
+	case uintField:
+		sw.writeUint(v.Uint(), f.width)
+	case bytesField:
+		sw.write(v.Bytes())
+	case bytesListField:
+		for i := 0; i < v.Len(); i++ {
+			sw.write(v.Index(i).Bytes())
+		}
+	case variableBytesListField:
+		offset := 4 * v.Len()
+		for i := 0; i < v.Len(); i++ {
+			sw.writeOffset(offset)
+			offset += v.Index(i).Len()
+		}
+		for i := 0; i < v.Len(); i++ {
+			sw.write(v.Index(i).Bytes())
+		}
+	case uintListField:
+		for i := 0; i < v.Len(); i++ {
+			sw.writeUint(v.Index(i).Uint(), f.width)
+		}
+	case containerField:
+		return sw.writeContainer(containerValue(v))
+	case containerListField:
+		for i := 0; i < v.Len(); i++ {
+			if err := sw.writeContainer(containerValue(v.Index(i))); err != nil {
+				return errors.Wrapf(err, "element at index %d", i)
+			}
+		}
+	case variableContainerListField:
+		offset := 4 * v.Len()
+		for i := 0; i < v.Len(); i++ {
+			sw.writeOffset(offset)
+			offset += containerValue(v.Index(i)).SizeSSZ()
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := sw.writeContainer(containerValue(v.Index(i))); err != nil {
+				return errors.Wrapf(err, "element at index %d", i)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported field kind %d", f.kind)
+	}
+	return sw.err
+}
+
+// containerValue returns the container held by the value. As in the generated SSZ methods, a nil container is
+// serialized as the zero container.
+func containerValue(v reflect.Value) Marshaler {
+	if v.IsNil() {
+		return reflect.New(v.Type().Elem()).Interface().(Marshaler)
+	}
+	return v.Interface().(Marshaler)
+}
+
+// isContainer checks whether the type is a pointer to a struct implementing the SSZ methods.
+func isContainer(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && t.Implements(marshalerType)
+}
+
+// isFixedContainer checks whether all the fields of the struct have a fixed size.
+func isFixedContainer(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && !isFixedField(f.Type, f.Tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFixedField checks whether a field of the given type and tag has a fixed size. Lists, which are the fields with
+// a maximum size, have a variable size, as do the containers and vectors holding variable-size values.
+func isFixedField(t reflect.Type, tag reflect.StructTag) bool {
+	if _, ok := tag.Lookup("ssz-max"); ok {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return t.Elem().Kind() != reflect.Struct || isFixedContainer(t.Elem())
+	case reflect.Slice:
+		switch et := t.Elem(); et.Kind() {
+		case reflect.Ptr:
+			return et.Elem().Kind() != reflect.Struct || isFixedContainer(et.Elem())
+		case reflect.Slice:
+			return hasFixedSizeElements(tag)
+		}
+	}
+	return true
+}
+
+// hasFixedSizeElements checks whether the byte slices of a two-dimensional field have a fixed size.
+func hasFixedSizeElements(tag reflect.StructTag) bool {
+	dims := strings.Split(tag.Get("ssz-size"), ",")
+	return len(dims) == 2 && dims[1] != "?"
+}
+
+// streamWriter buffers the serialization up to streamChunkSize bytes before writing it. The first error of the
+// writer is kept, and nothing is written after it.
+type streamWriter struct {
+	w       io.Writer
+	buf     []byte
+	scratch []byte
+	n       int64
+	err     error
+}
+
+func (sw *streamWriter) write(p []byte) {
+	for len(p) > 0 && sw.err == nil {
+		l := min(cap(sw.buf)-len(sw.buf), len(p))
+		sw.buf = append(sw.buf, p[:l]...)
+		p = p[l:]
+		if len(sw.buf) == cap(sw.buf) {
+			sw.err = sw.flush()
+		}
+	}
+}
+
+func (sw *streamWriter) writeUint(v uint64, width int) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	sw.write(b[:width])
+}
+
+func (sw *streamWriter) writeOffset(offset int) {
+	sw.writeUint(uint64(offset), 4)
+}
+
+func (sw *streamWriter) writeContainer(m Marshaler) error {
+	var err error
+	sw.scratch, err = m.MarshalSSZTo(sw.scratch[:0])
+	if err != nil {
+		return err
+	}
+	sw.write(sw.scratch)
+	return sw.err
+}
+
+func (sw *streamWriter) flush() error {
+	if sw.err != nil {
+		return sw.err
+	}
+	n, err := sw.w.Write(sw.buf)
+	sw.n += int64(n)
+	sw.buf = sw.buf[:0]
+	return err
+}
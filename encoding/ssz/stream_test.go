@@ -0,0 +1,91 @@
+package ssz_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// writeRecorder records the size of the largest write.
+type writeRecorder struct {
+	bytes.Buffer
+	maxWrite int
+}
+
+func (w *writeRecorder) Write(p []byte) (int, error) {
+	w.maxWrite = max(w.maxWrite, len(p))
+	return w.Buffer.Write(p)
+}
+
+type unsupportedContainer struct {
+	Name string
+}
+
+func (*unsupportedContainer) SizeSSZ() int {
+	return 0
+}
+
+func (*unsupportedContainer) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return dst, nil
+}
+
+func TestStreamEncoder(t *testing.T) {
+	phase0, _ := util.DeterministicGenesisState(t, 64)
+	phase0Proto, ok := phase0.ToProto().(*ethpb.BeaconState)
+	require.Equal(t, true, ok)
+	// Pending attestations are variable-size elements of a list.
+	phase0Proto.PreviousEpochAttestations = []*ethpb.PendingAttestation{
+		{AggregationBits: bitfield.Bitlist{0b1101}, Data: util.HydrateAttestationData(&ethpb.AttestationData{}), InclusionDelay: 1},
+		{AggregationBits: bitfield.Bitlist{0b1, 0b11}, Data: util.HydrateAttestationData(&ethpb.AttestationData{Slot: 3}), ProposerIndex: 2},
+	}
+	altair, _ := util.DeterministicGenesisStateAltair(t, 64)
+	electra, _ := util.DeterministicGenesisStateElectra(t, 64)
+	electraProto, ok := electra.ToProto().(*ethpb.BeaconStateElectra)
+	require.Equal(t, true, ok)
+	electraProto.PendingBalanceDeposits = []*ethpb.PendingBalanceDeposit{{Index: 1, Amount: 2}}
+
+	tests := []struct {
+		name      string
+		container interface {
+			ssz.Marshaler
+			MarshalSSZ() ([]byte, error)
+		}
+	}{
+		{name: "phase0", container: phase0Proto},
+		{name: "altair", container: altair.ToProto().(*ethpb.BeaconStateAltair)},
+		{name: "electra", container: electraProto},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tt.container.MarshalSSZ()
+			require.NoError(t, err)
+			e, err := ssz.NewStreamEncoder(tt.container)
+			require.NoError(t, err)
+			assert.Equal(t, len(want), e.Size())
+
+			w := &writeRecorder{}
+			n, err := e.WriteTo(w)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(want)), n)
+			assert.DeepEqual(t, want, w.Bytes())
+			// The serialization is larger than the bounded chunks it is written in.
+			assert.Equal(t, true, w.maxWrite < len(want))
+			assert.Equal(t, true, w.maxWrite <= 1<<16)
+		})
+	}
+}
+
+func TestStreamEncoder_Errors(t *testing.T) {
+	_, err := ssz.NewStreamEncoder(&unsupportedContainer{})
+	require.ErrorContains(t, "field Name: unsupported type string", err)
+
+	// The fork versions have a fixed size, which empty versions don't have.
+	_, err = ssz.NewStreamEncoder(&ethpb.Fork{})
+	require.ErrorContains(t, "serialization size 8 does not match the SSZ size 16", err)
+}
@@ -2,13 +2,16 @@ package httputil
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -49,6 +52,89 @@ func WriteSsz(w http.ResponseWriter, respSsz []byte, fileName string) {
 	}
 }
 
+// WriteSszStream writes the SSZ serialization of the container to the response. The container is serialized
+// field by field in chunks of bounded size, each of which is flushed to the client, so that large containers such
+// as beacon states are streamed without allocating their whole serialization. The response is gzip compressed if
+// the request accepts it, otherwise its Content-Length is set to the size of the serialization.
+func WriteSszStream(w http.ResponseWriter, r *http.Request, m ssz.Marshaler, fileName string) {
+	e, err := ssz.NewStreamEncoder(m)
+	if err != nil {
+		HandleError(w, "Could not marshal into SSZ: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", api.OctetStreamMediaType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+fileName)
+	w.Header().Add("Vary", "Accept-Encoding")
+	out := &flushWriter{w: w}
+	out.flusher, _ = w.(http.Flusher)
+	if acceptsGzip(r) {
+		w.Header().Set("Content-Encoding", "gzip")
+		out.gz = gzip.NewWriter(w)
+	} else {
+		w.Header().Set("Content-Length", strconv.Itoa(e.Size()))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := e.WriteTo(out); err != nil {
+		log.WithError(err).Error("Could not write response message")
+		return
+	}
+	if out.gz != nil {
+		if err := out.gz.Close(); err != nil {
+			log.WithError(err).Error("Could not write response message")
+		}
+	}
+}
+
+// flushWriter writes to the response, optionally through a gzip writer, and flushes every write to the client.
+type flushWriter struct {
+	w       io.Writer
+	gz      *gzip.Writer
+	flusher http.Flusher
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	var n int
+	var err error
+	if f.gz != nil {
+		if n, err = f.gz.Write(p); err == nil {
+			err = f.gz.Flush()
+		}
+	} else {
+		n, err = f.w.Write(p)
+	}
+	if err == nil && f.flusher != nil {
+		f.flusher.Flush()
+	}
+	return n, err
+}
+
+// acceptsGzip checks whether the request accepts a gzip encoded response.
+func acceptsGzip(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for _, enc := range strings.Split(header, ",") {
+			values := strings.Split(enc, ";")
+			name := strings.TrimSpace(values[0])
+			if name != "gzip" && name != "*" {
+				continue
+			}
+			if len(values) == 1 {
+				return true
+			}
+			match := priorityRegex.FindStringSubmatch(values[1])
+			if len(match) != 2 {
+				return true
+			}
+			priority, err := strconv.ParseFloat(match[1], 32)
+			if err == nil && priority > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // WriteError writes the error by manipulating headers and the body of the final response.
 func WriteError(w http.ResponseWriter, errJson HasStatusCode) {
 	j, err := json.Marshal(errJson)
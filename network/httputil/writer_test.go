@@ -0,0 +1,96 @@
+package httputil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestWriteSszStream(t *testing.T) {
+	// The transactions span several chunks, with a partial last chunk.
+	txs := make([][]byte, 3)
+	for i := range txs {
+		txs[i] = make([]byte, 1<<16+17*i)
+		for j := range txs[i] {
+			txs[i][j] = byte(j % 251)
+		}
+	}
+	m := &enginev1.ExecutionPayload{
+		ParentHash:    make([]byte, 32),
+		FeeRecipient:  make([]byte, 20),
+		StateRoot:     make([]byte, 32),
+		ReceiptsRoot:  make([]byte, 32),
+		LogsBloom:     make([]byte, 256),
+		PrevRandao:    make([]byte, 32),
+		BlockNumber:   1,
+		ExtraData:     []byte("extra"),
+		BaseFeePerGas: make([]byte, 32),
+		BlockHash:     make([]byte, 32),
+		Transactions:  txs,
+	}
+	data, err := m.MarshalSSZ()
+	require.NoError(t, err)
+
+	t.Run("uncompressed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		WriteSszStream(writer, request, m, "test.ssz")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, api.OctetStreamMediaType, writer.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=test.ssz", writer.Header().Get("Content-Disposition"))
+		assert.Equal(t, strconv.Itoa(len(data)), writer.Header().Get("Content-Length"))
+		assert.Equal(t, "", writer.Header().Get("Content-Encoding"))
+		assert.Equal(t, true, writer.Flushed)
+		assert.DeepEqual(t, data, writer.Body.Bytes())
+	})
+	t.Run("gzip", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		request.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		WriteSszStream(writer, request, m, "test.ssz")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "gzip", writer.Header().Get("Content-Encoding"))
+		assert.Equal(t, "", writer.Header().Get("Content-Length"))
+		gz, err := gzip.NewReader(writer.Body)
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.DeepEqual(t, data, decompressed)
+	})
+	t.Run("gzip not acceptable", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		request.Header.Set("Accept-Encoding", "gzip;q=0")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		WriteSszStream(writer, request, m, "test.ssz")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "", writer.Header().Get("Content-Encoding"))
+		assert.DeepEqual(t, data, writer.Body.Bytes())
+	})
+	t.Run("marshal error", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		WriteSszStream(writer, request, &enginev1.ExecutionPayload{}, "test.ssz")
+		require.Equal(t, http.StatusInternalServerError, writer.Code)
+		e := &DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Could not marshal into SSZ", e.Message)
+	})
+}
@@ -1,24 +1,21 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"testing"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
-	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -274,43 +271,13 @@ func NewBeaconBlockAltair() *ethpb.SignedBeaconBlockAltair {
 }
 
 // BlockSignatureAltair calculates the post-state root of the block and returns the signature.
+// It is kept for the callers predating BlockSignature, which signs blocks of every fork.
 func BlockSignatureAltair(
 	bState state.BeaconState,
 	block *ethpb.BeaconBlockAltair,
 	privKeys []bls.SecretKey,
 ) (bls.Signature, error) {
-	var err error
-	wsb, err := consensusblocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockAltair{Block: block})
-	if err != nil {
-		return nil, err
-	}
-	s, err := transition.CalculateStateRoot(context.Background(), bState, wsb)
-	if err != nil {
-		return nil, err
-	}
-	block.StateRoot = s[:]
-	domain, err := signing.Domain(bState.Fork(), time.CurrentEpoch(bState), params.BeaconConfig().DomainBeaconProposer, bState.GenesisValidatorsRoot())
-	if err != nil {
-		return nil, err
-	}
-	blockRoot, err := signing.ComputeSigningRoot(block, domain)
-	if err != nil {
-		return nil, err
-	}
-	// Temporarily increasing the beacon state slot here since BeaconProposerIndex is a
-	// function deterministic on beacon state slot.
-	currentSlot := bState.Slot()
-	if err := bState.SetSlot(block.Slot); err != nil {
-		return nil, err
-	}
-	proposerIdx, err := helpers.BeaconProposerIndex(context.Background(), bState)
-	if err != nil {
-		return nil, err
-	}
-	if err := bState.SetSlot(currentSlot); err != nil {
-		return nil, err
-	}
-	return privKeys[proposerIdx].Sign(blockRoot[:]), nil
+	return BlockSignature(bState, block, privKeys)
 }
 
 // GenerateFullBlockAltair generates a fully valid Altair block with the requested parameters.
@@ -331,12 +298,16 @@ func GenerateFullBlockAltair(
 	if conf == nil {
 		conf = &BlockGenConfig{}
 	}
+	if conf.DepositRequests {
+		return nil, errors.New("deposit requests are only supported in Electra blocks")
+	}
 
 	var err error
+	slashed := make(map[primitives.ValidatorIndex]bool)
 	var pSlashings []*ethpb.ProposerSlashing
 	numToGen := conf.NumProposerSlashings
 	if numToGen > 0 {
-		pSlashings, err = generateProposerSlashings(bState, privs, numToGen)
+		pSlashings, err = generateProposerSlashings(bState, privs, numToGen, slot, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d proposer slashings:", numToGen)
 		}
@@ -345,7 +316,7 @@ func GenerateFullBlockAltair(
 	numToGen = conf.NumAttesterSlashings
 	var aSlashings []*ethpb.AttesterSlashing
 	if numToGen > 0 {
-		generated, err := generateAttesterSlashings(bState, privs, numToGen)
+		generated, err := generateAttesterSlashings(bState, privs, numToGen, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attester slashings:", numToGen)
 		}
@@ -362,25 +333,17 @@ func GenerateFullBlockAltair(
 	numToGen = conf.NumAttestations
 	var atts []*ethpb.Attestation
 	if numToGen > 0 {
-		generatedAtts, err := GenerateAttestations(bState, privs, numToGen, slot, false)
+		atts, err = generatePhase0Attestations(bState, privs, numToGen, slot)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attestations:", numToGen)
 		}
-		atts = make([]*ethpb.Attestation, len(generatedAtts))
-		var ok bool
-		for i, a := range generatedAtts {
-			atts[i], ok = a.(*ethpb.Attestation)
-			if !ok {
-				return nil, fmt.Errorf("attestation has the wrong type (expected %T, got %T)", &ethpb.Attestation{}, a)
-			}
-		}
 	}
 
 	numToGen = conf.NumDeposits
 	var newDeposits []*ethpb.Deposit
 	eth1Data := bState.Eth1Data()
 	if numToGen > 0 {
-		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen)
+		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen, conf.InvalidDepositSignatures)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d deposits:", numToGen)
 		}
@@ -396,54 +359,40 @@ func GenerateFullBlockAltair(
 	}
 
 	newHeader := bState.LatestBlockHeader()
-	prevStateRoot, err := bState.HashTreeRoot(ctx)
-	if err != nil {
-		return nil, err
+	// The header's state root is filled in by the next slot transition, so it is only
+	// still empty if the state has not been advanced past the header's slot.
+	if bytes.Equal(newHeader.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		prevStateRoot, err := bState.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		newHeader.StateRoot = prevStateRoot[:]
 	}
-	newHeader.StateRoot = prevStateRoot[:]
 	parentRoot, err := newHeader.HashTreeRoot()
 	if err != nil {
 		return nil, err
 	}
 
-	var newSyncAggregate *ethpb.SyncAggregate
-	if conf.FullSyncAggregate {
-		newSyncAggregate, err = generateSyncAggregate(bState, privs, parentRoot)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed generating syncAggregate")
-		}
-	} else {
-		var syncCommitteeBits []byte
-		currSize := new(ethpb.SyncAggregate).SyncCommitteeBits.Len()
-		switch currSize {
-		case 512:
-			syncCommitteeBits = bitfield.NewBitvector512()
-		case 32:
-			syncCommitteeBits = bitfield.NewBitvector32()
-		default:
-			return nil, errors.New("invalid bit vector size")
-		}
-		newSyncAggregate = &ethpb.SyncAggregate{
-			SyncCommitteeBits:      syncCommitteeBits,
-			SyncCommitteeSignature: append([]byte{0xC0}, make([]byte, 95)...),
-		}
-	}
-
 	if slot == currentSlot {
 		slot = currentSlot + 1
 	}
 
-	stCopy := bState.Copy()
-	stCopy, err = transition.ProcessSlots(context.Background(), stCopy, slot)
+	stCopy, err := processBlockSlots(context.Background(), bState, conf, slot)
 	if err != nil {
 		return nil, err
 	}
+
+	newSyncAggregate, err := blockSyncAggregate(stCopy, privs, parentRoot, conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed generating syncAggregate")
+	}
+
 	reveal, err := RandaoReveal(stCopy, time.CurrentEpoch(stCopy), privs)
 	if err != nil {
 		return nil, err
 	}
 
-	idx, err := helpers.BeaconProposerIndex(ctx, stCopy)
+	idx, err := proposerIndexAtSlot(ctx, stCopy, stCopy.Slot())
 	if err != nil {
 		return nil, err
 	}
@@ -470,5 +419,11 @@ func GenerateFullBlockAltair(
 		return nil, err
 	}
 
-	return &ethpb.SignedBeaconBlockAltair{Block: block, Signature: signature.Marshal()}, nil
+	signed := &ethpb.SignedBeaconBlockAltair{Block: block, Signature: signature.Marshal()}
+	if conf.VerifyGenerated {
+		if err := verifyGeneratedBlock(ctx, bState, signed); err != nil {
+			return nil, err
+		}
+	}
+	return signed, nil
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
@@ -15,7 +18,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	blscommon "github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	attv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
@@ -60,6 +66,137 @@ func NewAttestationElectra() *ethpb.AttestationElectra {
 	}
 }
 
+// AttestationGeneratorOption modifies the attestations created by GenerateAttestations.
+type AttestationGeneratorOption func(*attestationGenerator)
+
+type attestationGenerator struct {
+	previousEpoch        bool
+	expired              bool
+	recordedParticipants bool
+	// committee restricts generation to a single committee of the slot when set.
+	committee   *primitives.CommitteeIndex
+	unsigned    bool
+	signers     *uint64
+	wrongHead   bool
+	overlapping bool
+	emptyBits   bool
+	// participationRate restricts the participants to a fraction of every committee when set.
+	participationRate *float64
+	// targetRoot and sourceRoot replace the checkpoint roots derived from the state when set.
+	targetRoot []byte
+	sourceRoot []byte
+}
+
+// WithPreviousEpochTarget makes GenerateAttestations treat the requested slot as the inclusion
+// slot and vote for the previous epoch's checkpoint instead. The attestations are made for the
+// slot exactly one epoch before the inclusion slot, the oldest slot still includable in any fork,
+// with the source set to the previous justified checkpoint as seen at the inclusion slot.
+func WithPreviousEpochTarget() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.previousEpoch = true
+	}
+}
+
+// WithExpiredSlot makes GenerateAttestations treat the requested slot as the inclusion slot and
+// attest to the previous epoch's checkpoint from the slot SLOTS_PER_EPOCH + 1 slots before it, one
+// slot too old to be included. The attestations are otherwise valid, so that only the inclusion
+// window check of ProcessAttestations rejects them. The inclusion slot can't be the first slot of an
+// epoch, since the attestation slot would then be two epochs before it. Only supported for states
+// before Deneb, which no longer bounds the inclusion window by the attestation slot.
+func WithExpiredSlot() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.expired = true
+	}
+}
+
+// WithRecordedParticipants makes GenerateAttestations only set the aggregation bits of validators
+// whose participation in the attestation's target epoch is already recorded in the state. The
+// resulting attestations repeat votes that were already included, which is useful to test that
+// including them again yields no additional reward. Only supported for Altair and later states.
+func WithRecordedParticipants() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.recordedParticipants = true
+	}
+}
+
+// WithoutSignature makes GenerateAttestations leave the signature of every attestation zeroed,
+// while the aggregation bits still mark all participants. The attestations are invalid and only
+// meant to test that signature verification rejects them.
+func WithoutSignature() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.unsigned = true
+	}
+}
+
+// WithPartialSignature makes GenerateAttestations aggregate only the signatures of the first n
+// participants of every attestation, while the aggregation bits still mark all participants. The
+// attestations are invalid whenever they have more than n participants, and are only meant to test
+// that signature verification rejects them. With n set to 0 the signature is left zeroed, as with
+// WithoutSignature.
+func WithPartialSignature(n uint64) AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.signers = &n
+	}
+}
+
+// WithWrongHeadVote makes GenerateAttestations vote for a head block root that is well-formed but
+// differs from the canonical block root at the attestation's slot, while the source and target
+// checkpoints stay correct. The attestations are valid and earn the timely source and target
+// flags, but not the timely head flag, which is useful to test rewards per participation flag.
+func WithWrongHeadVote() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.wrongHead = true
+	}
+}
+
+// WithOverlappingBits makes GenerateAttestations extend the aggregation bits of every attestation of a
+// committee, but the last one, with the first participant of the next attestation. Consecutive
+// attestations of a committee then overlap by one participant, so they can't be aggregated together,
+// while each one is still signed by all the participants it marks and is valid on its own.
+func WithOverlappingBits() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.overlapping = true
+	}
+}
+
+// WithEmptyBits makes GenerateAttestations clear the aggregation bits of every attestation, keeping
+// their length, and replace its signature with the point at infinity, the aggregate of no signatures.
+// The attestations have no participants and are invalid. They are only meant for negative testing, to
+// check that ProcessAttestations rejects attestations with empty participation.
+func WithEmptyBits() AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.emptyBits = true
+	}
+}
+
+// WithParticipationRate makes GenerateAttestations only set the aggregation bits of the first
+// fraction of the members of every committee, rounded down, e.g. half of every committee for 0.5.
+// The attestations are valid, and the remaining members are recorded as not having attested once
+// they are included, which is useful to test rewards and justification under partial participation.
+func WithParticipationRate(rate float64) AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.participationRate = &rate
+	}
+}
+
+// WithTargetRoot makes GenerateAttestations vote for the given target block root instead of the
+// one derived from the state, e.g. for a checkpoint root fixed by a checkpoint sync or weak
+// subjectivity scenario. The target epoch is unchanged. The root must be 32 bytes long.
+func WithTargetRoot(root []byte) AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.targetRoot = root
+	}
+}
+
+// WithSourceRoot makes GenerateAttestations vote for the given source block root instead of the
+// justified checkpoint root of the state. The source epoch is unchanged. The root must be 32 bytes
+// long.
+func WithSourceRoot(root []byte) AttestationGeneratorOption {
+	return func(g *attestationGenerator) {
+		g.sourceRoot = root
+	}
+}
+
 // GenerateAttestations creates attestations that are entirely valid, for all
 // the committees of the current state slot. This function expects attestations
 // requested to be cleanly divisible by committees per slot. If there is 1 committee
@@ -67,11 +204,109 @@ func NewAttestationElectra() *ethpb.AttestationElectra {
 // for the same data with their aggregation bits split uniformly.
 //
 // If you request 4 attestations, but there are 8 committees, you will get 4 fully aggregated attestations.
-func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numToGen uint64, slot primitives.Slot, randomRoot bool) ([]ethpb.Att, error) { // nolint:gocognit
-	var attestations []ethpb.Att
+// The attestations are in the format of the state's fork, i.e. Electra attestations for Electra states.
+func GenerateAttestations(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	numToGen uint64,
+	slot primitives.Slot,
+	randomRoot bool,
+	opts ...AttestationGeneratorOption,
+) ([]ethpb.Att, error) {
+	electra := bState.Version() >= version.Electra
+	newAtt := func(data *ethpb.AttestationData, committee primitives.CommitteeIndex, bits bitfield.Bitlist, sig []byte) ethpb.Att {
+		if electra {
+			return electraAttestation(data, committee, bits, sig)
+		}
+		return phase0Attestation(data, committee, bits, sig)
+	}
+	return generateAttestations(bState, privs, numToGen, slot, randomRoot, newAtt, opts...)
+}
+
+// generatePhase0Attestations creates attestations as GenerateAttestations does, for the blocks of
+// forks before Electra.
+func generatePhase0Attestations(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	numToGen uint64,
+	slot primitives.Slot,
+) ([]*ethpb.Attestation, error) {
+	if bState.Version() >= version.Electra {
+		return nil, fmt.Errorf("phase0 attestations can't be generated for %s states", version.String(bState.Version()))
+	}
+	return generateAttestations(bState, privs, numToGen, slot, false, phase0Attestation)
+}
+
+// generateElectraAttestations creates attestations as GenerateAttestations does, for the blocks of
+// Electra and later forks.
+func generateElectraAttestations(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	numToGen uint64,
+	slot primitives.Slot,
+) ([]*ethpb.AttestationElectra, error) {
+	if bState.Version() < version.Electra {
+		return nil, fmt.Errorf("electra attestations can't be generated for %s states", version.String(bState.Version()))
+	}
+	return generateAttestations(bState, privs, numToGen, slot, false, electraAttestation)
+}
+
+// generateAttestations implements GenerateAttestations, with newAtt building the attestation of the
+// needed format out of the attestation data, committee index, aggregation bits and signature.
+func generateAttestations[T ethpb.Att](
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	numToGen uint64,
+	slot primitives.Slot,
+	randomRoot bool,
+	newAtt func(data *ethpb.AttestationData, committee primitives.CommitteeIndex, bits bitfield.Bitlist, sig []byte) T,
+	opts ...AttestationGeneratorOption,
+) ([]T, error) { // nolint:gocognit
+	g := &attestationGenerator{}
+	for _, o := range opts {
+		o(g)
+	}
+	if g.targetRoot != nil && len(g.targetRoot) != fieldparams.RootLength {
+		return nil, fmt.Errorf("target root must be %d bytes, got %d", fieldparams.RootLength, len(g.targetRoot))
+	}
+	if g.sourceRoot != nil && len(g.sourceRoot) != fieldparams.RootLength {
+		return nil, fmt.Errorf("source root must be %d bytes, got %d", fieldparams.RootLength, len(g.sourceRoot))
+	}
+	if g.participationRate != nil && (*g.participationRate < 0 || *g.participationRate > 1) {
+		return nil, fmt.Errorf("participation rate %f is not between 0 and 1", *g.participationRate)
+	}
+
+	var attestations []T
 	generateHeadState := false
 	bState = bState.Copy()
-	if slot > bState.Slot() {
+	source := bState.CurrentJustifiedCheckpoint()
+	if g.previousEpoch && g.expired {
+		return nil, errors.New("previous epoch target and expired slot options can't be combined")
+	}
+	if g.previousEpoch || g.expired {
+		inclusionSlot := slot
+		var err error
+		if g.expired {
+			slot, err = expiredAttestationSlot(bState.Version(), inclusionSlot)
+		} else {
+			// The attestations are included by the state advanced to the inclusion slot, or by the
+			// state itself if it is already past it.
+			slot, err = previousEpochAttestationSlot(inclusionSlot, max(inclusionSlot, bState.Slot()))
+		}
+		if err != nil {
+			return nil, err
+		}
+		generateHeadState = slot >= bState.Slot()
+		inclusionState := bState
+		// Only the justified checkpoint of the inclusion state is read, so its state roots don't need to be exact.
+		if inclusionSlot > bState.Slot() {
+			inclusionState, err = transition.ProcessSlotsUnsafeFastForward(context.Background(), bState.Copy(), inclusionSlot)
+			if err != nil {
+				return nil, err
+			}
+		}
+		source = inclusionState.PreviousJustifiedCheckpoint()
+	} else if slot > bState.Slot() {
 		// Going back a slot here so there's no inclusion delay issues.
 		slot--
 		generateHeadState = true
@@ -166,6 +401,12 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 		if err != nil {
 			return nil, err
 		}
+		if g.previousEpoch || g.expired || g.recordedParticipants || g.wrongHead {
+			targetRoot, err = helpers.BlockRoot(bState, currentEpoch)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	if randomRoot {
 		randGen := rand.NewDeterministicGenerator()
@@ -176,6 +417,20 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 		}
 		headRoot = b
 	}
+	if g.wrongHead {
+		// The hash of the canonical root is a well-formed root that can't be the canonical root.
+		wrongRoot := hash.Hash(headRoot)
+		headRoot = wrongRoot[:]
+	}
+	if g.targetRoot != nil {
+		targetRoot = bytesutil.SafeCopyBytes(g.targetRoot)
+	}
+	if g.sourceRoot != nil {
+		source = &ethpb.Checkpoint{
+			Epoch: source.Epoch,
+			Root:  bytesutil.SafeCopyBytes(g.sourceRoot),
+		}
+	}
 
 	activeValidatorCount, err := helpers.ActiveValidatorCount(context.Background(), bState, currentEpoch)
 	if err != nil {
@@ -183,7 +438,16 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 	}
 	committeesPerSlot := helpers.SlotCommitteeCount(activeValidatorCount)
 
+	firstCommittee, endCommittee := primitives.CommitteeIndex(0), primitives.CommitteeIndex(committeesPerSlot)
 	if numToGen < committeesPerSlot {
+		endCommittee = primitives.CommitteeIndex(numToGen)
+	}
+	if g.committee != nil {
+		if uint64(*g.committee) >= committeesPerSlot {
+			return nil, fmt.Errorf("committee index %d is out of range for %d committees at slot %d", *g.committee, committeesPerSlot, slot)
+		}
+		firstCommittee, endCommittee = *g.committee, *g.committee+1
+	} else if numToGen < committeesPerSlot {
 		log.Printf(
 			"Warning: %d attestations requested is less than %d committees in current slot, not all validators will be attesting.",
 			numToGen,
@@ -198,6 +462,10 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 	}
 
 	attsPerCommittee := math.Max(float64(numToGen/committeesPerSlot), 1)
+	if g.committee != nil {
+		// All the requested attestations are for the single committee.
+		attsPerCommittee = math.Max(float64(numToGen), 1)
+	}
 	if math.Trunc(attsPerCommittee) != attsPerCommittee {
 		return nil, fmt.Errorf(
 			"requested attestations %d must be easily divisible by committees in slot %d, calculated %f",
@@ -207,11 +475,19 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 		)
 	}
 
+	var participation []byte
+	if g.recordedParticipants {
+		participation, err = recordedParticipation(bState, currentEpoch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	domain, err := signing.Domain(bState.Fork(), currentEpoch, params.BeaconConfig().DomainBeaconAttester, bState.GenesisValidatorsRoot())
 	if err != nil {
 		return nil, err
 	}
-	for c := primitives.CommitteeIndex(0); uint64(c) < committeesPerSlot && uint64(c) < numToGen; c++ {
+	for c := firstCommittee; c < endCommittee; c++ {
 		committee, err := helpers.BeaconCommitteeFromState(context.Background(), bState, slot, c)
 		if err != nil {
 			return nil, err
@@ -226,7 +502,7 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 			Slot:            slot,
 			CommitteeIndex:  ci,
 			BeaconBlockRoot: headRoot,
-			Source:          bState.CurrentJustifiedCheckpoint(),
+			Source:          source,
 			Target: &ethpb.Checkpoint{
 				Epoch: currentEpoch,
 				Root:  targetRoot,
@@ -240,11 +516,25 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 
 		committeeSize := uint64(len(committee))
 		bitsPerAtt := committeeSize / uint64(attsPerCommittee)
+		attesters := committeeSize
+		if g.participationRate != nil {
+			attesters = uint64(*g.participationRate * float64(committeeSize))
+		}
 		for i := uint64(0); i < committeeSize; i += bitsPerAtt {
-			aggregationBits := bitfield.NewBitlist(committeeSize)
+			var participants []primitives.ValidatorIndex
 			var sigs []bls.Signature
-			for b := i; b < i+bitsPerAtt; b++ {
-				aggregationBits.SetBitAt(b, true)
+			end := i + bitsPerAtt
+			if g.overlapping && end < committeeSize {
+				end++
+			}
+			for b := i; b < end; b++ {
+				if b >= attesters {
+					break
+				}
+				if participation != nil && participation[committee[b]] == 0 {
+					continue
+				}
+				participants = append(participants, committee[b])
 				sigs = append(sigs, privs[committee[b]].Sign(dataRoot[:]))
 			}
 
@@ -252,30 +542,231 @@ func GenerateAttestations(bState state.BeaconState, privs []bls.SecretKey, numTo
 			if len(sigs) == 0 {
 				continue
 			}
-
-			var att ethpb.Att
-			if bState.Version() >= version.Electra {
-				cb := primitives.NewAttestationCommitteeBits()
-				cb.SetBitAt(uint64(c), true)
-				att = &ethpb.AttestationElectra{
-					Data:            attData,
-					CommitteeBits:   cb,
-					AggregationBits: aggregationBits,
-					Signature:       bls.AggregateSignatures(sigs).Marshal(),
-				}
-			} else {
-				att = &ethpb.Attestation{
-					Data:            attData,
-					AggregationBits: aggregationBits,
-					Signature:       bls.AggregateSignatures(sigs).Marshal(),
-				}
+			if g.signers != nil && uint64(len(sigs)) > *g.signers {
+				sigs = sigs[:*g.signers]
+			}
+			sig := make([]byte, fieldparams.BLSSignatureLength)
+			if !g.unsigned && len(sigs) > 0 {
+				sig = bls.AggregateSignatures(sigs).Marshal()
 			}
-			attestations = append(attestations, att)
+			if g.emptyBits {
+				participants = nil
+				sig = make([]byte, fieldparams.BLSSignatureLength)
+				copy(sig, blscommon.InfiniteSignature[:])
+			}
+			aggregationBits, err := helpers.AttestationBits(context.Background(), bState, slot, c, participants)
+			if err != nil {
+				return nil, err
+			}
+
+			attestations = append(attestations, newAtt(attData, c, aggregationBits, sig))
 		}
 	}
 	return attestations, nil
 }
 
+// phase0Attestation builds an attestation in the format of the forks before Electra.
+func phase0Attestation(data *ethpb.AttestationData, _ primitives.CommitteeIndex, bits bitfield.Bitlist, sig []byte) *ethpb.Attestation {
+	return &ethpb.Attestation{
+		Data:            data,
+		AggregationBits: bits,
+		Signature:       sig,
+	}
+}
+
+// electraAttestation builds an Electra attestation, which records its committee in the committee
+// bits rather than in the attestation data.
+func electraAttestation(data *ethpb.AttestationData, committee primitives.CommitteeIndex, bits bitfield.Bitlist, sig []byte) *ethpb.AttestationElectra {
+	cb := primitives.NewAttestationCommitteeBits()
+	cb.SetBitAt(uint64(committee), true)
+	return &ethpb.AttestationElectra{
+		Data:            data,
+		CommitteeBits:   cb,
+		AggregationBits: bits,
+		Signature:       sig,
+	}
+}
+
+// GenerateCommitteeAttestation creates a single fully aggregated attestation, as made by
+// GenerateAttestations with the given options, for the committee with the given index at the slot.
+// An error is returned if the index is not below the number of committees of the slot.
+func GenerateCommitteeAttestation(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	slot primitives.Slot,
+	committeeIndex primitives.CommitteeIndex,
+	opts ...AttestationGeneratorOption,
+) (ethpb.Att, error) {
+	opts = append(opts, func(g *attestationGenerator) {
+		g.committee = &committeeIndex
+	})
+	atts, err := GenerateAttestations(bState, privs, 1, slot, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(atts) != 1 {
+		return nil, fmt.Errorf("expected 1 attestation for committee %d, generated %d", committeeIndex, len(atts))
+	}
+	return atts[0], nil
+}
+
+// GenerateOverlappingAggregates creates two aggregates of the committee with the given index at the
+// slot, as made by GenerateAttestations with WithOverlappingBits and the given options. Each aggregate
+// has a valid signature matching its aggregation bits, but the bits of the two aggregates overlap by
+// one participant, so they can't be aggregated together.
+func GenerateOverlappingAggregates(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	slot primitives.Slot,
+	committeeIndex primitives.CommitteeIndex,
+	opts ...AttestationGeneratorOption,
+) (ethpb.Att, ethpb.Att, error) {
+	opts = append(opts, WithOverlappingBits(), func(g *attestationGenerator) {
+		g.committee = &committeeIndex
+	})
+	atts, err := GenerateAttestations(bState, privs, 2, slot, false, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(atts) != 2 {
+		return nil, nil, fmt.Errorf("expected 2 aggregates for committee %d, generated %d", committeeIndex, len(atts))
+	}
+	return atts[0], atts[1], nil
+}
+
+// recordedParticipation returns the participation flags recorded in the state for the given epoch,
+// which must be the state's current or previous epoch.
+func recordedParticipation(st state.ReadOnlyBeaconState, epoch primitives.Epoch) ([]byte, error) {
+	if st.Version() < version.Altair {
+		return nil, fmt.Errorf("recorded participation is not supported for %s states", version.String(st.Version()))
+	}
+	switch epoch {
+	case time.CurrentEpoch(st):
+		return st.CurrentEpochParticipation()
+	case time.PrevEpoch(st):
+		return st.PreviousEpochParticipation()
+	default:
+		return nil, fmt.Errorf("epoch %d is neither the current nor the previous epoch of the state", epoch)
+	}
+}
+
+// previousEpochAttestationSlot returns the slot one epoch before the inclusion slot and verifies
+// that an attestation made at that slot can be included by a state at the given slot, that is
+// attestation slot + MIN_ATTESTATION_INCLUSION_DELAY <= state slot <= attestation slot + SLOTS_PER_EPOCH.
+func previousEpochAttestationSlot(inclusionSlot, stateSlot primitives.Slot) (primitives.Slot, error) {
+	spe := params.BeaconConfig().SlotsPerEpoch
+	if slots.ToEpoch(inclusionSlot) == 0 {
+		return 0, fmt.Errorf("inclusion slot %d has no previous epoch", inclusionSlot)
+	}
+	attSlot := inclusionSlot - spe
+	if attSlot+params.BeaconConfig().MinAttestationInclusionDelay > stateSlot {
+		return 0, fmt.Errorf("attestation slot %d + inclusion delay %d > state slot %d",
+			attSlot, params.BeaconConfig().MinAttestationInclusionDelay, stateSlot)
+	}
+	if stateSlot > attSlot+spe {
+		return 0, fmt.Errorf("state slot %d > attestation slot %d + SLOTS_PER_EPOCH %d", stateSlot, attSlot, spe)
+	}
+	return attSlot, nil
+}
+
+// expiredAttestationSlot returns the slot SLOTS_PER_EPOCH + 1 slots before the inclusion slot and
+// verifies that it is in the epoch before the inclusion slot, so that an attestation made at that
+// slot only fails the inclusion window check of states of the given version.
+func expiredAttestationSlot(v int, inclusionSlot primitives.Slot) (primitives.Slot, error) {
+	if v >= version.Deneb {
+		return 0, fmt.Errorf("attestations of %s states have no inclusion window bounded by their slot", version.String(v))
+	}
+	spe := params.BeaconConfig().SlotsPerEpoch
+	if inclusionSlot <= spe {
+		return 0, fmt.Errorf("inclusion slot %d has no expired slot", inclusionSlot)
+	}
+	attSlot := inclusionSlot - spe - 1
+	if slots.ToEpoch(attSlot)+1 != slots.ToEpoch(inclusionSlot) {
+		return 0, fmt.Errorf("expired slot %d is not in the epoch before inclusion slot %d", attSlot, inclusionSlot)
+	}
+	return attSlot, nil
+}
+
+// AggregateSingleAttestationsElectra merges unaggregated Electra attestations, each covering a
+// single committee, into one on-chain aggregate. All attestations must share the same data.
+// Attestations for the same committee are merged into one set of aggregation bits, and the
+// committees are then concatenated in ascending committee index order with the matching
+// committee bits set, as done by compute_on_chain_aggregate in the consensus spec.
+func AggregateSingleAttestationsElectra(atts []*ethpb.AttestationElectra) (*ethpb.AttestationElectra, error) {
+	if len(atts) == 0 {
+		return nil, errors.New("no attestations to aggregate")
+	}
+	dataRoot, err := atts[0].Data.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	bitsByCommittee := make(map[int]bitfield.Bitlist)
+	sigs := make([]bls.Signature, 0, len(atts))
+	for i, a := range atts {
+		r, err := a.Data.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if r != dataRoot {
+			return nil, fmt.Errorf("attestation %d has different data than attestation 0", i)
+		}
+		committees := a.CommitteeBits.BitIndices()
+		if len(committees) != 1 {
+			return nil, fmt.Errorf("attestation %d has %d committee bits set, expected 1", i, len(committees))
+		}
+		ci := committees[0]
+		if existing, ok := bitsByCommittee[ci]; ok {
+			overlaps, err := existing.Overlaps(a.AggregationBits)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not merge attestation %d", i)
+			}
+			if overlaps {
+				return nil, fmt.Errorf("attestation %d overlaps with another attestation for committee %d", i, ci)
+			}
+			merged, err := existing.Or(a.AggregationBits)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not merge attestation %d", i)
+			}
+			bitsByCommittee[ci] = merged
+		} else {
+			bitsByCommittee[ci] = bitfield.Bitlist(bytesutil.SafeCopyBytes(a.AggregationBits))
+		}
+		sig, err := bls.SignatureFromBytes(a.Signature)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal signature of attestation %d", i)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	committeeIndices := make([]int, 0, len(bitsByCommittee))
+	size := uint64(0)
+	for ci, bits := range bitsByCommittee {
+		committeeIndices = append(committeeIndices, ci)
+		size += bits.Len()
+	}
+	sort.Ints(committeeIndices)
+
+	committeeBits := primitives.NewAttestationCommitteeBits()
+	aggregationBits := bitfield.NewBitlist(size)
+	offset := uint64(0)
+	for _, ci := range committeeIndices {
+		committeeBits.SetBitAt(uint64(ci), true)
+		bits := bitsByCommittee[ci]
+		for _, b := range bits.BitIndices() {
+			aggregationBits.SetBitAt(offset+uint64(b), true)
+		}
+		offset += bits.Len()
+	}
+
+	return &ethpb.AttestationElectra{
+		AggregationBits: aggregationBits,
+		Data:            atts[0].Data,
+		CommitteeBits:   committeeBits,
+		Signature:       bls.AggregateSignatures(sigs).Marshal(),
+	}, nil
+}
+
 // HydrateAttestation hydrates an attestation object with correct field length sizes
 // to comply with fssz marshalling and unmarshalling rules.
 func HydrateAttestation(a *ethpb.Attestation) *ethpb.Attestation {
@@ -1,12 +1,30 @@
 package util
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	blscommon "github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation/aggregation"
+	attaggregation "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation/aggregation/attestations"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -56,3 +74,483 @@ func TestGenerateAttestations_EpochBoundary(t *testing.T) {
 	_, err := GenerateAttestations(gs, pk, 1, params.BeaconConfig().SlotsPerEpoch, false)
 	require.NoError(t, err)
 }
+
+func TestGenerateAttestations_ForkFormat(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	phase0State, phase0Keys := DeterministicGenesisState(t, 64)
+	electraState, electraKeys := DeterministicGenesisStateElectra(t, 64)
+
+	atts, err := GenerateAttestations(phase0State, phase0Keys, 1, 1, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	_, ok := atts[0].(*ethpb.Attestation)
+	assert.Equal(t, true, ok)
+	atts, err = GenerateAttestations(electraState, electraKeys, 1, 1, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	electraAtt, ok := atts[0].(*ethpb.AttestationElectra)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, []int{0}, electraAtt.CommitteeBitsVal().BitIndices())
+
+	phase0Atts, err := generatePhase0Attestations(phase0State, phase0Keys, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(phase0Atts))
+	electraAtts, err := generateElectraAttestations(electraState, electraKeys, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(electraAtts))
+
+	_, err = generatePhase0Attestations(electraState, electraKeys, 1, 1)
+	require.ErrorContains(t, "phase0 attestations can't be generated for electra states", err)
+	_, err = generateElectraAttestations(phase0State, phase0Keys, 1, 1)
+	require.ErrorContains(t, "electra attestations can't be generated for phase0 states", err)
+}
+
+func TestGenerateCommitteeAttestation(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig().Copy())
+	helpers.ClearCache()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	// 64 validators make two committees per slot in the minimal config.
+	gs, pk := DeterministicGenesisState(t, 64)
+	active, err := helpers.ActiveValidatorCount(context.Background(), gs, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), helpers.SlotCommitteeCount(active))
+
+	att, err := GenerateCommitteeAttestation(gs, pk, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.CommitteeIndex(1), att.GetData().CommitteeIndex)
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), gs, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(committee)), att.GetAggregationBits().Count())
+	indexed, err := attestation.ConvertToIndexed(context.Background(), att, committee)
+	require.NoError(t, err)
+	require.NoError(t, blocks.VerifyIndexedAttestation(context.Background(), gs, indexed))
+
+	_, err = GenerateCommitteeAttestation(gs, pk, 0, 2)
+	require.ErrorContains(t, "committee index 2 is out of range for 2 committees", err)
+}
+
+func TestGenerateOverlappingAggregates(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		genesis func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey)
+	}{
+		{name: "phase0", genesis: DeterministicGenesisState},
+		{name: "electra", genesis: DeterministicGenesisStateElectra},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			helpers.ClearCache()
+			gs, pk := tt.genesis(t, 256)
+			committee, err := helpers.BeaconCommitteeFromState(context.Background(), gs, 0, 0)
+			require.NoError(t, err)
+
+			a1, a2, err := GenerateOverlappingAggregates(gs, pk, 0, 0)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(committee)/2+1), a1.GetAggregationBits().Count())
+			assert.Equal(t, uint64(len(committee)/2), a2.GetAggregationBits().Count())
+			overlaps, err := a1.GetAggregationBits().Overlaps(a2.GetAggregationBits())
+			require.NoError(t, err)
+			assert.Equal(t, true, overlaps)
+			for _, a := range []ethpb.Att{a1, a2} {
+				indexed, err := attestation.ConvertToIndexed(context.Background(), a, committee)
+				require.NoError(t, err)
+				require.NoError(t, blocks.VerifyIndexedAttestation(context.Background(), gs, indexed))
+			}
+
+			aggregated, err := attaggregation.Aggregate([]ethpb.Att{a1, a2})
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(aggregated))
+			if a, ok := a1.(*ethpb.Attestation); ok {
+				_, err = attaggregation.AggregatePair(a, a2.(*ethpb.Attestation))
+				require.ErrorIs(t, err, aggregation.ErrBitsOverlap)
+			}
+		})
+	}
+}
+
+func TestGenerateAttestations_InvalidSignatures(t *testing.T) {
+	helpers.ClearCache()
+	gs, pk := DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), gs, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 1)
+
+	tests := []struct {
+		name string
+		opt  AttestationGeneratorOption
+		want []byte
+	}{
+		{
+			name: "without signature",
+			opt:  WithoutSignature(),
+			want: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		{
+			name: "no signers",
+			opt:  WithPartialSignature(0),
+			want: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		{
+			name: "partial signature",
+			opt:  WithPartialSignature(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			att, err := GenerateCommitteeAttestation(gs, pk, 0, 0, tt.opt)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(committee)), att.GetAggregationBits().Count())
+			if tt.want != nil {
+				assert.DeepEqual(t, tt.want, att.GetSignature())
+			} else {
+				domain, err := signing.Domain(gs.Fork(), 0, params.BeaconConfig().DomainBeaconAttester, gs.GenesisValidatorsRoot())
+				require.NoError(t, err)
+				root, err := signing.ComputeSigningRoot(att.GetData(), domain)
+				require.NoError(t, err)
+				assert.DeepEqual(t, pk[committee[0]].Sign(root[:]).Marshal(), att.GetSignature())
+			}
+			indexed, err := attestation.ConvertToIndexed(context.Background(), att, committee)
+			require.NoError(t, err)
+			assert.NotNil(t, blocks.VerifyIndexedAttestation(context.Background(), gs, indexed))
+		})
+	}
+}
+
+func TestGenerateAttestations_PreviousEpochTarget(t *testing.T) {
+	ctx := context.Background()
+	gs, pk := DeterministicGenesisState(t, 64)
+	spe := params.BeaconConfig().SlotsPerEpoch
+	st, err := transition.ProcessSlots(ctx, gs, spe+2)
+	require.NoError(t, err)
+
+	inclusionSlot := st.Slot() + 1
+	atts, err := GenerateAttestations(st, pk, 1, inclusionSlot, false, WithPreviousEpochTarget())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	data := atts[0].GetData()
+	require.Equal(t, inclusionSlot-spe, data.Slot)
+	require.Equal(t, primitives.Epoch(0), data.Target.Epoch)
+	require.DeepEqual(t, st.PreviousJustifiedCheckpoint(), data.Source)
+	targetRoot, err := helpers.BlockRoot(st, 0)
+	require.NoError(t, err)
+	require.DeepEqual(t, targetRoot, data.Target.Root)
+
+	st, err = transition.ProcessSlots(ctx, st, inclusionSlot)
+	require.NoError(t, err)
+	require.NoError(t, blocks.VerifyAttestationNoVerifySignature(ctx, st, atts[0]))
+}
+
+func TestGenerateAttestations_PreviousEpochTarget_NoPreviousEpoch(t *testing.T) {
+	gs, pk := DeterministicGenesisState(t, 32)
+	_, err := GenerateAttestations(gs, pk, 1, 1, false, WithPreviousEpochTarget())
+	require.ErrorContains(t, "has no previous epoch", err)
+}
+
+func TestGenerateAttestations_PreviousEpochTarget_StatePastInclusionWindow(t *testing.T) {
+	gs, pk := DeterministicGenesisState(t, 64)
+	spe := params.BeaconConfig().SlotsPerEpoch
+	st, err := transition.ProcessSlots(context.Background(), gs, 2*spe+2)
+	require.NoError(t, err)
+
+	// The attestation slot is 1, which the state at slot 2*SLOTS_PER_EPOCH+2 can no longer include.
+	_, err = GenerateAttestations(st, pk, 1, spe+1, false, WithPreviousEpochTarget())
+	require.ErrorContains(t, fmt.Sprintf("state slot %d > attestation slot 1 + SLOTS_PER_EPOCH", 2*spe+2), err)
+}
+
+func TestGenerateAttestations_ExpiredSlot(t *testing.T) {
+	ctx := context.Background()
+	gs, pk := DeterministicGenesisState(t, 64)
+	spe := params.BeaconConfig().SlotsPerEpoch
+	st, err := transition.ProcessSlots(ctx, gs, spe+2)
+	require.NoError(t, err)
+
+	inclusionSlot := st.Slot() + 1
+	atts, err := GenerateAttestations(st, pk, 1, inclusionSlot, false, WithExpiredSlot())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	data := atts[0].GetData()
+	require.Equal(t, inclusionSlot-spe-1, data.Slot)
+	require.Equal(t, primitives.Epoch(0), data.Target.Epoch)
+	require.DeepEqual(t, st.PreviousJustifiedCheckpoint(), data.Source)
+
+	// The attestation is valid up to the last slot of its inclusion window, the slot of the state.
+	lastSlotState := st.Copy()
+	require.Equal(t, data.Slot+spe, lastSlotState.Slot())
+	require.NoError(t, blocks.VerifyAttestationNoVerifySignature(ctx, lastSlotState, atts[0]))
+	committee, err := helpers.BeaconCommitteeFromState(ctx, lastSlotState, data.Slot, data.CommitteeIndex)
+	require.NoError(t, err)
+	indexed, err := attestation.ConvertToIndexed(ctx, atts[0], committee)
+	require.NoError(t, err)
+	require.NoError(t, blocks.VerifyIndexedAttestation(ctx, lastSlotState, indexed))
+
+	st, err = transition.ProcessSlots(ctx, st, inclusionSlot)
+	require.NoError(t, err)
+	err = blocks.VerifyAttestationNoVerifySignature(ctx, st, atts[0])
+	require.ErrorContains(t, "SLOTS_PER_EPOCH", err)
+}
+
+func TestGenerateAttestations_ExpiredSlot_Errors(t *testing.T) {
+	gs, pk := DeterministicGenesisState(t, 64)
+	spe := params.BeaconConfig().SlotsPerEpoch
+	_, err := GenerateAttestations(gs, pk, 1, spe, false, WithExpiredSlot())
+	require.ErrorContains(t, "has no expired slot", err)
+	_, err = GenerateAttestations(gs, pk, 1, 2*spe, false, WithExpiredSlot())
+	require.ErrorContains(t, "is not in the epoch before inclusion slot", err)
+	_, err = GenerateAttestations(gs, pk, 1, spe+1, false, WithExpiredSlot(), WithPreviousEpochTarget())
+	require.ErrorContains(t, "can't be combined", err)
+
+	denebState, denebKeys := DeterministicGenesisStateDeneb(t, 64)
+	_, err = GenerateAttestations(denebState, denebKeys, 1, spe+1, false, WithExpiredSlot())
+	require.ErrorContains(t, "no inclusion window bounded by their slot", err)
+}
+
+func TestGenerateAttestations_CheckpointRoots(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	gs, pk := DeterministicGenesisState(t, 64)
+	targetRoot := bytesutil.PadTo([]byte("target"), fieldparams.RootLength)
+	sourceRoot := bytesutil.PadTo([]byte("source"), fieldparams.RootLength)
+
+	canonical, err := GenerateCommitteeAttestation(gs, pk, 0, 0)
+	require.NoError(t, err)
+	att, err := GenerateCommitteeAttestation(gs, pk, 0, 0, WithTargetRoot(targetRoot), WithSourceRoot(sourceRoot))
+	require.NoError(t, err)
+	data := att.GetData()
+	assert.DeepEqual(t, targetRoot, data.Target.Root)
+	assert.Equal(t, canonical.GetData().Target.Epoch, data.Target.Epoch)
+	assert.DeepEqual(t, sourceRoot, data.Source.Root)
+	assert.Equal(t, canonical.GetData().Source.Epoch, data.Source.Epoch)
+	assert.DeepEqual(t, canonical.GetData().BeaconBlockRoot, data.BeaconBlockRoot)
+	// The state's justified checkpoint is left untouched.
+	assert.DeepEqual(t, make([]byte, fieldparams.RootLength), gs.CurrentJustifiedCheckpoint().Root)
+
+	// The attestation is signed over the overridden roots.
+	committee, err := helpers.BeaconCommitteeFromState(ctx, gs, 0, 0)
+	require.NoError(t, err)
+	indexed, err := attestation.ConvertToIndexed(ctx, att, committee)
+	require.NoError(t, err)
+	require.NoError(t, blocks.VerifyIndexedAttestation(ctx, gs, indexed))
+
+	_, err = GenerateCommitteeAttestation(gs, pk, 0, 0, WithTargetRoot([]byte{1}))
+	require.ErrorContains(t, "target root must be 32 bytes, got 1", err)
+	_, err = GenerateCommitteeAttestation(gs, pk, 0, 0, WithSourceRoot(make([]byte, 33)))
+	require.ErrorContains(t, "source root must be 32 bytes, got 33", err)
+}
+
+func TestGenerateAttestations_WrongHeadVote(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	gs, pk := DeterministicGenesisStateAltair(t, 64)
+	setSyncCommittees(t, gs)
+
+	atts, err := GenerateAttestations(gs, pk, 1, 1, false, WithWrongHeadVote())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	data := atts[0].GetData()
+	canonical, err := GenerateAttestations(gs, pk, 1, 1, false)
+	require.NoError(t, err)
+	require.DeepEqual(t, canonical[0].GetData().Source, data.Source)
+	require.DeepEqual(t, canonical[0].GetData().Target, data.Target)
+	require.DeepNotEqual(t, canonical[0].GetData().BeaconBlockRoot, data.BeaconBlockRoot)
+
+	st, err := transition.ProcessSlots(ctx, gs, 1)
+	require.NoError(t, err)
+	headRoot, err := helpers.BlockRootAtSlot(st, data.Slot)
+	require.NoError(t, err)
+	require.DeepNotEqual(t, headRoot, data.BeaconBlockRoot)
+	blk := NewBeaconBlockAltair()
+	blk.Block.Slot = st.Slot()
+	blk.Block.Body.Attestations = []*ethpb.Attestation{atts[0].(*ethpb.Attestation)}
+	rb, err := consensusblocks.NewBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, rb)
+	require.NoError(t, err)
+
+	participation, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, data.Slot, data.CommitteeIndex)
+	require.NoError(t, err)
+	cfg := params.BeaconConfig()
+	for _, idx := range committee {
+		flags := participation[idx]
+		has, err := altair.HasValidatorFlag(flags, cfg.TimelySourceFlagIndex)
+		require.NoError(t, err)
+		assert.Equal(t, true, has)
+		has, err = altair.HasValidatorFlag(flags, cfg.TimelyTargetFlagIndex)
+		require.NoError(t, err)
+		assert.Equal(t, true, has)
+		has, err = altair.HasValidatorFlag(flags, cfg.TimelyHeadFlagIndex)
+		require.NoError(t, err)
+		assert.Equal(t, false, has)
+	}
+}
+
+func TestAggregateSingleAttestationsElectra(t *testing.T) {
+	ctx := context.Background()
+	st, privs := DeterministicGenesisStateElectra(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 1)
+
+	data := HydrateAttestationData(&ethpb.AttestationData{Source: st.CurrentJustifiedCheckpoint()})
+	domain, err := signing.Domain(st.Fork(), 0, params.BeaconConfig().DomainBeaconAttester, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+
+	singles := make([]*ethpb.AttestationElectra, len(committee))
+	for i, idx := range committee {
+		cb := primitives.NewAttestationCommitteeBits()
+		cb.SetBitAt(0, true)
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(uint64(i), true)
+		singles[i] = &ethpb.AttestationElectra{
+			AggregationBits: bits,
+			Data:            data,
+			CommitteeBits:   cb,
+			Signature:       privs[idx].Sign(root[:]).Marshal(),
+		}
+	}
+
+	agg, err := AggregateSingleAttestationsElectra(singles)
+	require.NoError(t, err)
+	require.DeepEqual(t, []int{0}, agg.CommitteeBits.BitIndices())
+	require.Equal(t, uint64(len(committee)), agg.AggregationBits.Count())
+
+	require.NoError(t, st.SetSlot(1))
+	require.NoError(t, blocks.VerifyAttestationNoVerifySignature(ctx, st, agg))
+	set, err := blocks.AttestationSignatureBatch(ctx, st, []ethpb.Att{agg})
+	require.NoError(t, err)
+	valid, err := set.Verify()
+	require.NoError(t, err)
+	require.Equal(t, true, valid)
+}
+
+func TestAggregateSingleAttestationsElectra_CommitteeOrder(t *testing.T) {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := sk.Sign([]byte{'a'}).Marshal()
+	data := HydrateAttestationData(&ethpb.AttestationData{})
+	single := func(committee uint64, size uint64, bit uint64) *ethpb.AttestationElectra {
+		cb := primitives.NewAttestationCommitteeBits()
+		cb.SetBitAt(committee, true)
+		bits := bitfield.NewBitlist(size)
+		bits.SetBitAt(bit, true)
+		return &ethpb.AttestationElectra{AggregationBits: bits, Data: data, CommitteeBits: cb, Signature: sig}
+	}
+
+	agg, err := AggregateSingleAttestationsElectra([]*ethpb.AttestationElectra{
+		single(3, 2, 1),
+		single(1, 3, 0),
+		single(1, 3, 2),
+	})
+	require.NoError(t, err)
+	require.DeepEqual(t, []int{1, 3}, agg.CommitteeBits.BitIndices())
+	require.Equal(t, uint64(5), agg.AggregationBits.Len())
+	require.DeepEqual(t, []int{0, 2, 4}, agg.AggregationBits.BitIndices())
+
+	_, err = AggregateSingleAttestationsElectra([]*ethpb.AttestationElectra{single(1, 3, 0), single(1, 3, 0)})
+	require.ErrorContains(t, "overlaps", err)
+
+	other := single(2, 3, 0)
+	other.Data = HydrateAttestationData(&ethpb.AttestationData{Slot: 1})
+	_, err = AggregateSingleAttestationsElectra([]*ethpb.AttestationElectra{single(1, 3, 0), other})
+	require.ErrorContains(t, "different data", err)
+
+	_, err = AggregateSingleAttestationsElectra(nil)
+	require.ErrorContains(t, "no attestations", err)
+}
+
+func TestGenerateAttestations_RecordedParticipants(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	gs, pk := DeterministicGenesisStateAltair(t, 64)
+	setSyncCommittees(t, gs)
+	blk, err := GenerateFullBlockAltair(gs, pk, &BlockGenConfig{NumAttestations: 1}, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blk.Block.Body.Attestations))
+	included := blk.Block.Body.Attestations[0]
+	wsb, err := consensusblocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, gs, wsb)
+	require.NoError(t, err)
+
+	atts, err := GenerateAttestations(st, pk, 1, included.Data.Slot, false, WithRecordedParticipants())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	require.DeepEqual(t, included.Data, atts[0].GetData())
+	require.DeepEqual(t, included.AggregationBits, atts[0].GetAggregationBits())
+
+	st, err = transition.ProcessSlots(ctx, st, st.Slot()+1)
+	require.NoError(t, err)
+	balances := st.Balances()
+	participation, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	dup := NewBeaconBlockAltair()
+	dup.Block.Slot = st.Slot()
+	dup.Block.Body.Attestations = []*ethpb.Attestation{atts[0].(*ethpb.Attestation)}
+	rb, err := consensusblocks.NewBeaconBlock(dup.Block)
+	require.NoError(t, err)
+	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, rb)
+	require.NoError(t, err)
+	require.DeepEqual(t, balances, st.Balances())
+	newParticipation, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	require.DeepEqual(t, participation, newParticipation)
+
+	phase0, pk := DeterministicGenesisState(t, 64)
+	_, err = GenerateAttestations(phase0, pk, 1, 0, false, WithRecordedParticipants())
+	require.ErrorContains(t, "not supported", err)
+}
+
+func TestGenerateAttestations_EmptyBits(t *testing.T) {
+	ctx := context.Background()
+	gs, pk := DeterministicGenesisState(t, 64)
+	atts, err := GenerateAttestations(gs, pk, 1, 0, false, WithEmptyBits())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	att := atts[0]
+	data := att.GetData()
+	committee, err := helpers.BeaconCommitteeFromState(ctx, gs, data.Slot, data.CommitteeIndex)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(committee)), att.GetAggregationBits().Len())
+	require.Equal(t, uint64(0), att.GetAggregationBits().Count())
+	require.DeepEqual(t, blscommon.InfiniteSignature[:], att.GetSignature())
+
+	st, err := transition.ProcessSlots(ctx, gs, 1)
+	require.NoError(t, err)
+	blk := NewBeaconBlock()
+	blk.Block.Slot = 1
+	blk.Block.Body.Attestations = []*ethpb.Attestation{att.(*ethpb.Attestation)}
+	wsb, err := consensusblocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	_, err = blocks.ProcessAttestationsNoVerifySignature(ctx, st, wsb.Block())
+	require.ErrorContains(t, "expected non-empty attesting indices", err)
+}
+
+func TestGenerateAttestations_ParticipationRate(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	gs, pk := DeterministicGenesisState(t, 256)
+	committee, err := helpers.BeaconCommitteeFromState(ctx, gs, 0, 0)
+	require.NoError(t, err)
+	attesters := len(committee) / 2
+	require.Equal(t, true, attesters > 0)
+
+	att, err := GenerateCommitteeAttestation(gs, pk, 0, 0, WithParticipationRate(0.5))
+	require.NoError(t, err)
+	bits := att.GetAggregationBits()
+	require.Equal(t, uint64(len(committee)), bits.Len())
+	require.Equal(t, uint64(attesters), bits.Count())
+	for i := range committee {
+		assert.Equal(t, i < attesters, bits.BitAt(uint64(i)))
+	}
+	indexed, err := attestation.ConvertToIndexed(ctx, att, committee)
+	require.NoError(t, err)
+	require.NoError(t, blocks.VerifyIndexedAttestation(ctx, gs, indexed))
+
+	_, err = GenerateCommitteeAttestation(gs, pk, 0, 0, WithParticipationRate(1.5))
+	require.ErrorContains(t, "is not between 0 and 1", err)
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// TotalActiveBalance returns the combined effective balance of the validators active in the current
+// epoch of the state, with EFFECTIVE_BALANCE_INCREMENT as minimum, which is the denominator of the
+// rewards and penalties. Unlike helpers.TotalActiveBalance, the total balance cache is neither read
+// nor written: the balance is computed once per call from the validators of the state, so that it
+// reflects a state mutated by the test and doesn't leak into the code under test.
+func TotalActiveBalance(st state.ReadOnlyBeaconState) (uint64, error) {
+	epoch := slots.ToEpoch(st.Slot())
+	total := uint64(0)
+	if err := st.ReadFromEveryValidator(func(_ int, val state.ReadOnlyValidator) error {
+		if helpers.IsActiveValidatorUsingTrie(val, epoch) {
+			total += val.EffectiveBalance()
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if total < params.BeaconConfig().EffectiveBalanceIncrement {
+		return params.BeaconConfig().EffectiveBalanceIncrement, nil
+	}
+	return total, nil
+}
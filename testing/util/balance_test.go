@@ -0,0 +1,66 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestTotalActiveBalance(t *testing.T) {
+	helpers.ClearCache()
+	cfg := params.BeaconConfig()
+	farFuture := cfg.FarFutureEpoch
+	increment := cfg.EffectiveBalanceIncrement
+	st, err := NewBeaconState(func(st *ethpb.BeaconState) error {
+		st.Slot = cfg.SlotsPerEpoch.Mul(5)
+		st.Validators = []*ethpb.Validator{
+			// Active with various effective balances.
+			{EffectiveBalance: cfg.MaxEffectiveBalance, ExitEpoch: farFuture},
+			{EffectiveBalance: 17 * increment, ExitEpoch: farFuture},
+			{EffectiveBalance: increment, ExitEpoch: farFuture},
+			{EffectiveBalance: 0, ExitEpoch: farFuture},
+			// Slashed but not exited yet.
+			{EffectiveBalance: 31 * increment, ExitEpoch: 6, Slashed: true},
+			// Exited.
+			{EffectiveBalance: cfg.MaxEffectiveBalance, ExitEpoch: 5},
+			// Not activated yet.
+			{EffectiveBalance: cfg.MaxEffectiveBalance, ActivationEpoch: 6, ExitEpoch: farFuture},
+		}
+		st.Balances = make([]uint64, len(st.Validators))
+		return nil
+	})
+	require.NoError(t, err)
+
+	total, err := TotalActiveBalance(st)
+	require.NoError(t, err)
+	require.Equal(t, cfg.MaxEffectiveBalance+49*increment, total)
+	want, err := helpers.TotalActiveBalance(st)
+	require.NoError(t, err)
+	require.Equal(t, want, total)
+
+	// The balance is computed for the state as mutated, regardless of the total balance cache.
+	val, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	val.EffectiveBalance = 3 * increment
+	require.NoError(t, st.UpdateValidatorAtIndex(1, val))
+	total, err = TotalActiveBalance(st)
+	require.NoError(t, err)
+	require.Equal(t, cfg.MaxEffectiveBalance+35*increment, total)
+}
+
+func TestTotalActiveBalance_Minimum(t *testing.T) {
+	st, err := NewBeaconState(func(st *ethpb.BeaconState) error {
+		st.Validators = []*ethpb.Validator{
+			{EffectiveBalance: 0, ExitEpoch: params.BeaconConfig().FarFutureEpoch},
+		}
+		st.Balances = []uint64{0}
+		return nil
+	})
+	require.NoError(t, err)
+	total, err := TotalActiveBalance(st)
+	require.NoError(t, err)
+	require.Equal(t, params.BeaconConfig().EffectiveBalanceIncrement, total)
+}
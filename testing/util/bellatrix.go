@@ -1,18 +1,19 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"fmt"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
@@ -42,12 +43,16 @@ func GenerateFullBlockBellatrix(
 	if conf == nil {
 		conf = &BlockGenConfig{}
 	}
+	if conf.DepositRequests {
+		return nil, errors.New("deposit requests are only supported in Electra blocks")
+	}
 
 	var err error
+	slashed := make(map[primitives.ValidatorIndex]bool)
 	var pSlashings []*ethpb.ProposerSlashing
 	numToGen := conf.NumProposerSlashings
 	if numToGen > 0 {
-		pSlashings, err = generateProposerSlashings(bState, privs, numToGen)
+		pSlashings, err = generateProposerSlashings(bState, privs, numToGen, slot, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d proposer slashings:", numToGen)
 		}
@@ -56,7 +61,7 @@ func GenerateFullBlockBellatrix(
 	numToGen = conf.NumAttesterSlashings
 	var aSlashings []*ethpb.AttesterSlashing
 	if numToGen > 0 {
-		generated, err := generateAttesterSlashings(bState, privs, numToGen)
+		generated, err := generateAttesterSlashings(bState, privs, numToGen, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attester slashings:", numToGen)
 		}
@@ -73,25 +78,17 @@ func GenerateFullBlockBellatrix(
 	numToGen = conf.NumAttestations
 	var atts []*ethpb.Attestation
 	if numToGen > 0 {
-		generatedAtts, err := GenerateAttestations(bState, privs, numToGen, slot, false)
+		atts, err = generatePhase0Attestations(bState, privs, numToGen, slot)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attestations:", numToGen)
 		}
-		atts = make([]*ethpb.Attestation, len(generatedAtts))
-		var ok bool
-		for i, a := range generatedAtts {
-			atts[i], ok = a.(*ethpb.Attestation)
-			if !ok {
-				return nil, fmt.Errorf("attestation has the wrong type (expected %T, got %T)", &ethpb.Attestation{}, a)
-			}
-		}
 	}
 
 	numToGen = conf.NumDeposits
 	var newDeposits []*ethpb.Deposit
 	eth1Data := bState.Eth1Data()
 	if numToGen > 0 {
-		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen)
+		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen, conf.InvalidDepositSignatures)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d deposits:", numToGen)
 		}
@@ -115,14 +112,17 @@ func GenerateFullBlockBellatrix(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process randao mix")
 	}
+	prevRandao, err := payloadPrevRandao(conf, random)
+	if err != nil {
+		return nil, err
+	}
 
 	timestamp, err := slots.ToTime(bState.GenesisTime(), slot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get current timestamp")
 	}
 
-	stCopy := bState.Copy()
-	stCopy, err = transition.ProcessSlots(context.Background(), stCopy, slot)
+	stCopy, err := processBlockSlots(context.Background(), bState, conf, slot)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +138,7 @@ func GenerateFullBlockBellatrix(
 		StateRoot:     params.BeaconConfig().ZeroHash[:],
 		ReceiptsRoot:  params.BeaconConfig().ZeroHash[:],
 		LogsBloom:     make([]byte, 256),
-		PrevRandao:    random,
+		PrevRandao:    prevRandao,
 		BlockNumber:   uint64(slot),
 		ExtraData:     params.BeaconConfig().ZeroHash[:],
 		BaseFeePerGas: params.BeaconConfig().ZeroHash[:],
@@ -146,31 +146,35 @@ func GenerateFullBlockBellatrix(
 		Timestamp:     uint64(timestamp.Unix()),
 		Transactions:  newTransactions,
 	}
-	var syncCommitteeBits []byte
-	currSize := new(ethpb.SyncAggregate).SyncCommitteeBits.Len()
-	switch currSize {
-	case 512:
-		syncCommitteeBits = bitfield.NewBitvector512()
-	case 32:
-		syncCommitteeBits = bitfield.NewBitvector32()
-	default:
-		return nil, errors.New("invalid bit vector size")
-	}
-	newSyncAggregate := &ethpb.SyncAggregate{
-		SyncCommitteeBits:      syncCommitteeBits,
-		SyncCommitteeSignature: append([]byte{0xC0}, make([]byte, 95)...),
+	if conf.ExecutionBlockHash {
+		payload, err := blocks.WrappedExecutionPayload(newExecutionPayload)
+		if err != nil {
+			return nil, err
+		}
+		h, err := ExecutionBlockHash(payload, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute execution block hash")
+		}
+		newExecutionPayload.BlockHash = h[:]
 	}
-
 	newHeader := bState.LatestBlockHeader()
-	prevStateRoot, err := bState.HashTreeRoot(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not hash state")
+	// The header's state root is filled in by the next slot transition, so it is only
+	// still empty if the state has not been advanced past the header's slot.
+	if bytes.Equal(newHeader.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		prevStateRoot, err := bState.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash state")
+		}
+		newHeader.StateRoot = prevStateRoot[:]
 	}
-	newHeader.StateRoot = prevStateRoot[:]
 	parentRoot, err := newHeader.HashTreeRoot()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not hash the new header")
 	}
+	newSyncAggregate, err := blockSyncAggregate(stCopy, privs, parentRoot, conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed generating syncAggregate")
+	}
 
 	if slot == currentSlot {
 		slot = currentSlot + 1
@@ -181,7 +185,7 @@ func GenerateFullBlockBellatrix(
 		return nil, errors.Wrap(err, "could not compute randao reveal")
 	}
 
-	idx, err := helpers.BeaconProposerIndex(ctx, stCopy)
+	idx, err := proposerIndexAtSlot(ctx, stCopy, stCopy.Slot())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not compute beacon proposer index")
 	}
@@ -204,17 +208,208 @@ func GenerateFullBlockBellatrix(
 		},
 	}
 
+	// The post-state root is calculated with the randao mix as the payload's prev randao.
+	rootBlock := ethpb.CopyBeaconBlockBellatrix(block)
+	rootBlock.Body.ExecutionPayload.PrevRandao = random
 	// The fork can change after processing the state
-	signature, err := BlockSignature(bState, block, privs)
+	signature, err := blockSignatureWithPrevRandao(bState, block, rootBlock, privs)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block signature")
+	}
+
+	signed := &ethpb.SignedBeaconBlockBellatrix{Block: block, Signature: signature.Marshal()}
+	if conf.VerifyGenerated {
+		if err := verifyGeneratedBlock(ctx, bState, signed); err != nil {
+			return nil, err
+		}
+	}
+	return signed, nil
+}
+
+// GenerateMaxBlockBellatrix generates a valid Bellatrix block for the slot after the state's slot,
+// with every operation filled to its protocol maximum and a full transaction list, to benchmark
+// worst-case block processing. Slashed and exiting validators are all distinct and never include
+// the proposer. An error is returned if the state lacks enough validators eligible to exit or to be
+// slashed, or enough attesters in the committees of its slot. The state's eth1 data must commit to
+// exactly MaxDeposits pending deposits of the deterministic deposit set.
+func GenerateMaxBlockBellatrix(bState state.BeaconState, privs []bls.SecretKey) (*ethpb.SignedBeaconBlockBellatrix, error) {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+	slot := bState.Slot() + 1
+	st, err := transition.ProcessSlots(ctx, bState.Copy(), slot)
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := proposerIndexAtSlot(ctx, st, st.Slot())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute beacon proposer index")
+	}
+	exiting, slashed, err := maxBlockValidators(st, proposer, uint64(len(privs)))
+	if err != nil {
+		return nil, err
+	}
+
+	pSlashings := make([]*ethpb.ProposerSlashing, cfg.MaxProposerSlashings)
+	for i := range pSlashings {
+		idx := slashed[i]
+		pSlashings[i], err = GenerateProposerSlashingForValidator(st, privs[idx], idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not generate proposer slashing for validator %d", idx)
+		}
+	}
+	aSlashings := make([]*ethpb.AttesterSlashing, cfg.MaxAttesterSlashings)
+	for i := range aSlashings {
+		idx := slashed[cfg.MaxProposerSlashings+uint64(i)]
+		generated, err := GenerateAttesterSlashingForValidator(st, privs[idx], idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not generate attester slashing for validator %d", idx)
+		}
+		var ok bool
+		aSlashings[i], ok = generated.(*ethpb.AttesterSlashing)
+		if !ok {
+			return nil, fmt.Errorf("attester slashing has wrong type (expected %T, got %T)", &ethpb.AttesterSlashing{}, generated)
+		}
+	}
+	exits := make([]*ethpb.SignedVoluntaryExit, cfg.MaxVoluntaryExits)
+	for i := range exits {
+		idx := exiting[i]
+		exits[i], err = GenerateVoluntaryExits(st, privs[idx], idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not generate voluntary exit for validator %d", idx)
+		}
+	}
+	atts, err := maxBlockAttestations(bState, privs)
+	if err != nil {
+		return nil, err
+	}
+	// Deposits are generated along with the rest of the block, their proofs are only valid
+	// against the state's eth1 data if it commits to exactly the deposits to include.
+	if _, _, err := DepositTrieFromState(st); err != nil {
+		return nil, err
+	}
+	eth1Data := st.Eth1Data()
+	pending := eth1Data.DepositCount - min(st.Eth1DepositIndex(), eth1Data.DepositCount)
+	if pending != cfg.MaxDeposits {
+		return nil, fmt.Errorf("state has %d pending deposits, exactly %d are needed", pending, cfg.MaxDeposits)
+	}
+
+	conf := &BlockGenConfig{
+		NumDeposits:     cfg.MaxDeposits,
+		NumTransactions: fieldparams.MaxTxsPerPayloadLength,
+	}
+	signed, err := GenerateFullBlockBellatrix(bState, privs, conf, slot)
+	if err != nil {
+		return nil, err
+	}
+	body := signed.Block.Body
+	body.ProposerSlashings = pSlashings
+	body.AttesterSlashings = aSlashings
+	body.Attestations = atts
+	body.VoluntaryExits = exits
+	signature, err := BlockSignature(bState, signed.Block, privs)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not compute block signature")
 	}
+	signed.Signature = signature.Marshal()
+	return signed, nil
+}
 
-	return &ethpb.SignedBeaconBlockBellatrix{Block: block, Signature: signature.Marshal()}, nil
+// maxBlockValidators picks MaxVoluntaryExits validators that can exit and, separately, enough
+// slashable validators for MaxProposerSlashings and MaxAttesterSlashings, skipping the proposer.
+func maxBlockValidators(
+	st state.ReadOnlyBeaconState,
+	proposer primitives.ValidatorIndex,
+	numKeys uint64,
+) ([]primitives.ValidatorIndex, []primitives.ValidatorIndex, error) {
+	cfg := params.BeaconConfig()
+	epoch := time.CurrentEpoch(st)
+	numSlashings := cfg.MaxProposerSlashings + cfg.MaxAttesterSlashings
+	var exiting, slashed []primitives.ValidatorIndex
+	err := st.ReadFromEveryValidator(func(i int, v state.ReadOnlyValidator) error {
+		idx := primitives.ValidatorIndex(i)
+		if idx == proposer || uint64(i) >= numKeys || !helpers.IsSlashableValidatorUsingTrie(v, epoch) {
+			return nil
+		}
+		canExit := helpers.IsActiveValidatorUsingTrie(v, epoch) &&
+			v.ExitEpoch() == cfg.FarFutureEpoch &&
+			epoch >= v.ActivationEpoch()+cfg.ShardCommitteePeriod
+		if canExit && uint64(len(exiting)) < cfg.MaxVoluntaryExits {
+			exiting = append(exiting, idx)
+		} else if uint64(len(slashed)) < numSlashings {
+			slashed = append(slashed, idx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if uint64(len(exiting)) < cfg.MaxVoluntaryExits {
+		return nil, nil, fmt.Errorf("state has %d validators eligible to exit, %d are needed for voluntary exits", len(exiting), cfg.MaxVoluntaryExits)
+	}
+	if uint64(len(slashed)) < numSlashings {
+		return nil, nil, fmt.Errorf("state has %d slashable validators besides the exiting ones, %d are needed for proposer and attester slashings", len(slashed), numSlashings)
+	}
+	return exiting, slashed, nil
+}
+
+// maxBlockAttestations splits the aggregated attestations of every committee at the state's slot
+// into MaxAttestations attestations with disjoint aggregation bits.
+func maxBlockAttestations(bState state.BeaconState, privs []bls.SecretKey) ([]*ethpb.Attestation, error) {
+	ctx := context.Background()
+	maxAtts := params.BeaconConfig().MaxAttestations
+	activeCount, err := helpers.ActiveValidatorCount(ctx, bState, time.CurrentEpoch(bState))
+	if err != nil {
+		return nil, err
+	}
+	aggregated, err := GenerateAttestations(bState, privs, helpers.SlotCommitteeCount(activeCount), bState.Slot()+1, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate attestations")
+	}
+	if uint64(len(aggregated)) > maxAtts {
+		aggregated = aggregated[:maxAtts]
+	}
+	committees := make([][]primitives.ValidatorIndex, len(aggregated))
+	numAttesters := 0
+	for i, a := range aggregated {
+		committees[i], err = helpers.BeaconCommitteeFromState(ctx, bState, a.GetData().Slot, a.GetData().CommitteeIndex)
+		if err != nil {
+			return nil, err
+		}
+		numAttesters += len(committees[i])
+	}
+	if uint64(numAttesters) < maxAtts {
+		return nil, fmt.Errorf("state has %d attesters at slot %d, %d are needed for attestations", numAttesters, bState.Slot(), maxAtts)
+	}
+
+	atts := make([]*ethpb.Attestation, 0, maxAtts)
+	extra := maxAtts - uint64(len(aggregated))
+	for i, a := range aggregated {
+		committee := committees[i]
+		size := uint64(len(committee))
+		split := 1 + min(size-1, extra)
+		extra -= split - 1
+		for j := uint64(0); j < split; j++ {
+			var indices []primitives.ValidatorIndex
+			for b := j * size / split; b < (j+1)*size/split; b++ {
+				indices = append(indices, committee[b])
+			}
+			bits, err := helpers.AttestationBits(ctx, bState, a.GetData().Slot, a.GetData().CommitteeIndex, indices)
+			if err != nil {
+				return nil, err
+			}
+			sig, err := AttestationSignature(bState, a.GetData(), indices, privs)
+			if err != nil {
+				return nil, err
+			}
+			atts = append(atts, &ethpb.Attestation{AggregationBits: bits, Data: a.GetData(), Signature: sig.Marshal()})
+		}
+	}
+	return atts, nil
 }
 
-func indexToHash(i uint64) [32]byte {
+func indexToHash(i uint64) (h [32]byte) {
 	var b [8]byte
 	binary.LittleEndian.PutUint64(b[:], i)
-	return hash.Hash(b[:])
+	hash.HashInto(&h, b[:])
+	return h
 }
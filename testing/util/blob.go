@@ -1,10 +1,85 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	GoKZG "github.com/crate-crypto/go-kzg-4844"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
+var (
+	kzgStartErr  error
+	kzgStartOnce sync.Once
+)
+
+// GeneratedBlobs holds blobs together with their KZG commitments, proofs and versioned hashes.
+// The values at a given index all belong to the same blob, so Commitments can be used as a block's
+// BlobKzgCommitments and VersionedHashes as the blob versioned hashes of the execution layer.
+type GeneratedBlobs struct {
+	Blobs           [][]byte
+	Commitments     [][]byte
+	Proofs          [][]byte
+	VersionedHashes []common.Hash
+}
+
+// GenerateBlobs deterministically generates count valid blobs from the given seed and computes
+// their KZG commitments, proofs and versioned hashes with the trusted setup the node verifies blobs against.
+func GenerateBlobs(count int, seed int64) (*GeneratedBlobs, error) {
+	kzgStartOnce.Do(func() {
+		kzgStartErr = kzg.Start()
+	})
+	if kzgStartErr != nil {
+		return nil, errors.Wrap(kzgStartErr, "could not start kzg")
+	}
+	g := &GeneratedBlobs{
+		Blobs:           make([][]byte, count),
+		Commitments:     make([][]byte, count),
+		Proofs:          make([][]byte, count),
+		VersionedHashes: make([]common.Hash, count),
+	}
+	for i := 0; i < count; i++ {
+		blob := deterministicBlob(seed, i)
+		commitment, err := kzg.BlobToKZGCommitment(blob[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute commitment of blob %d", i)
+		}
+		proof, err := kzg.ComputeBlobKZGProof(blob[:], commitment)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute proof of blob %d", i)
+		}
+		g.Blobs[i] = blob[:]
+		g.Commitments[i] = commitment
+		g.Proofs[i] = proof
+		g.VersionedHashes[i] = kzg.ConvertKzgCommitmentToVersionedHash(commitment)
+	}
+	return g, nil
+}
+
+// deterministicBlob fills a blob with field elements derived from the seed and the blob index.
+// Every element is reduced modulo the BLS field, so the blob is always canonical.
+func deterministicBlob(seed int64, index int) GoKZG.Blob {
+	var blob GoKZG.Blob
+	buf := make([]byte, 24)
+	binary.LittleEndian.PutUint64(buf[0:8], uint64(seed))
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(index))
+	for i := 0; i < GoKZG.ScalarsPerBlob; i++ {
+		binary.LittleEndian.PutUint64(buf[16:24], uint64(i))
+		h := sha256.Sum256(buf)
+		var e fr.Element
+		e.SetBytes(h[:])
+		scalar := GoKZG.SerializeScalar(e)
+		copy(blob[i*GoKZG.SerializedScalarSize:], scalar[:])
+	}
+	return blob
+}
+
 // HydrateBlobSidecar hydrates a blob sidecar with correct field length sizes
 // to comply with SSZ marshalling and unmarshalling rules.
 func HydrateBlobSidecar(b *ethpb.BlobSidecar) *ethpb.BlobSidecar {
@@ -1,13 +1,18 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
+	coreBlock "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
@@ -25,19 +30,30 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assertions"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 // BlockGenConfig is used to define the requested conditions
 // for block generation.
 type BlockGenConfig struct {
-	NumProposerSlashings uint64
-	NumAttesterSlashings uint64
-	NumAttestations      uint64
-	NumDeposits          uint64
-	NumVoluntaryExits    uint64
-	NumTransactions      uint64 // Only for post Bellatrix blocks
-	FullSyncAggregate    bool
-	NumBLSChanges        uint64 // Only for post Capella blocks
+	NumProposerSlashings     uint64
+	NumAttesterSlashings     uint64
+	NumAttestations          uint64
+	NumDeposits              uint64
+	NumVoluntaryExits        uint64
+	NumTransactions          uint64 // Only for post Bellatrix blocks
+	InvalidDepositSignatures bool   // Sign the generated deposits over a different deposit message, keeping their proofs valid
+	DepositRequests          bool   // Generate the deposits as deposit requests of the execution payload instead of Eth1 deposits, only for Electra blocks
+	FullSyncAggregate        bool
+	SyncParticipation        float64 // Fraction of the sync committee in the sync aggregate when FullSyncAggregate is not set, only for Altair, Bellatrix and Electra blocks
+	NumBLSChanges            uint64  // Only for post Capella blocks
+	VerifyGenerated          bool    // Batch verify every signature in the generated block
+	ExecutionBlockHash       bool    // Set the payload's block hash to its execution block hash, only for Bellatrix and Capella blocks
+	PrevRandao               []byte  // Set the payload's prev randao to this value instead of the state's randao mix, only for post Bellatrix blocks
+
+	// Process the slots of the block through the next slot cache. Only set by the chain generators,
+	// whose states are the unmodified post-states of the blocks they prime the cache with.
+	useNextSlotCache bool
 }
 
 // DefaultBlockGenConfig returns the block config that utilizes the
@@ -54,6 +70,18 @@ func DefaultBlockGenConfig() *BlockGenConfig {
 	}
 }
 
+// payloadPrevRandao returns the prev randao of a generated payload, which is the randao mix unless
+// the config overrides it.
+func payloadPrevRandao(conf *BlockGenConfig, randaoMix []byte) ([]byte, error) {
+	if conf.PrevRandao == nil {
+		return randaoMix, nil
+	}
+	if len(conf.PrevRandao) != fieldparams.RootLength {
+		return nil, fmt.Errorf("prev randao must be %d bytes long, got %d", fieldparams.RootLength, len(conf.PrevRandao))
+	}
+	return bytesutil.SafeCopyBytes(conf.PrevRandao), nil
+}
+
 // NewBeaconBlock creates a beacon block with minimum marshalable fields.
 func NewBeaconBlock() *ethpb.SignedBeaconBlock {
 	return &ethpb.SignedBeaconBlock{
@@ -80,6 +108,8 @@ func NewBeaconBlock() *ethpb.SignedBeaconBlock {
 
 // GenerateFullBlock generates a fully valid block with the requested parameters.
 // Use BlockGenConfig to declare the conditions you would like the block generated under.
+// A slot equal to the slot of the state is bumped to the next slot, so that passing the slot of a
+// genesis state generates the block of slot 1. Use GenerateFirstBlock to generate that block explicitly.
 func GenerateFullBlock(
 	bState state.BeaconState,
 	privs []bls.SecretKey,
@@ -96,12 +126,16 @@ func GenerateFullBlock(
 	if conf == nil {
 		conf = &BlockGenConfig{}
 	}
+	if conf.DepositRequests {
+		return nil, errors.New("deposit requests are only supported in Electra blocks")
+	}
 
 	var err error
+	slashed := make(map[primitives.ValidatorIndex]bool)
 	var pSlashings []*ethpb.ProposerSlashing
 	numToGen := conf.NumProposerSlashings
 	if numToGen > 0 {
-		pSlashings, err = generateProposerSlashings(bState, privs, numToGen)
+		pSlashings, err = generateProposerSlashings(bState, privs, numToGen, slot, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d proposer slashings:", numToGen)
 		}
@@ -110,7 +144,7 @@ func GenerateFullBlock(
 	numToGen = conf.NumAttesterSlashings
 	var aSlashings []*ethpb.AttesterSlashing
 	if numToGen > 0 {
-		generated, err := generateAttesterSlashings(bState, privs, numToGen)
+		generated, err := generateAttesterSlashings(bState, privs, numToGen, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attester slashings:", numToGen)
 		}
@@ -127,25 +161,17 @@ func GenerateFullBlock(
 	numToGen = conf.NumAttestations
 	var atts []*ethpb.Attestation
 	if numToGen > 0 {
-		generatedAtts, err := GenerateAttestations(bState, privs, numToGen, slot, false)
+		atts, err = generatePhase0Attestations(bState, privs, numToGen, slot)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attestations:", numToGen)
 		}
-		atts = make([]*ethpb.Attestation, len(generatedAtts))
-		var ok bool
-		for i, a := range generatedAtts {
-			atts[i], ok = a.(*ethpb.Attestation)
-			if !ok {
-				return nil, fmt.Errorf("attestation has the wrong type (expected %T, got %T)", &ethpb.Attestation{}, a)
-			}
-		}
 	}
 
 	numToGen = conf.NumDeposits
 	var newDeposits []*ethpb.Deposit
 	eth1Data := bState.Eth1Data()
 	if numToGen > 0 {
-		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen)
+		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen, conf.InvalidDepositSignatures)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d deposits:", numToGen)
 		}
@@ -161,11 +187,15 @@ func GenerateFullBlock(
 	}
 
 	newHeader := bState.LatestBlockHeader()
-	prevStateRoot, err := bState.HashTreeRoot(ctx)
-	if err != nil {
-		return nil, err
+	// The header's state root is filled in by the next slot transition, so it is only
+	// still empty if the state has not been advanced past the header's slot.
+	if bytes.Equal(newHeader.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		prevStateRoot, err := bState.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		newHeader.StateRoot = prevStateRoot[:]
 	}
-	newHeader.StateRoot = prevStateRoot[:]
 	parentRoot, err := newHeader.HashTreeRoot()
 	if err != nil {
 		return nil, err
@@ -175,17 +205,18 @@ func GenerateFullBlock(
 		slot = currentSlot + 1
 	}
 
-	// Temporarily incrementing the beacon state slot here since BeaconProposerIndex is a
-	// function deterministic on beacon state slot.
-	if err := bState.SetSlot(slot); err != nil {
+	// The proposer and the randao reveal depend on the state at the block's slot, which includes the
+	// epoch transitions of any skipped slots in between, so slots are processed on a copy.
+	stCopy, err := processBlockSlots(ctx, bState, conf, slot)
+	if err != nil {
 		return nil, err
 	}
-	reveal, err := RandaoReveal(bState, time.CurrentEpoch(bState), privs)
+	reveal, err := RandaoReveal(stCopy, time.CurrentEpoch(stCopy), privs)
 	if err != nil {
 		return nil, err
 	}
 
-	idx, err := helpers.BeaconProposerIndex(ctx, bState)
+	idx, err := proposerIndexAtSlot(ctx, stCopy, stCopy.Slot())
 	if err != nil {
 		return nil, err
 	}
@@ -205,16 +236,175 @@ func GenerateFullBlock(
 			Graffiti:          make([]byte, fieldparams.RootLength),
 		},
 	}
-	if err := bState.SetSlot(currentSlot); err != nil {
-		return nil, err
-	}
 
 	signature, err := BlockSignature(bState, block, privs)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ethpb.SignedBeaconBlock{Block: block, Signature: signature.Marshal()}, nil
+	signed := &ethpb.SignedBeaconBlock{Block: block, Signature: signature.Marshal()}
+	if conf.VerifyGenerated {
+		if err := verifyGeneratedBlock(ctx, bState, signed); err != nil {
+			return nil, err
+		}
+	}
+	return signed, nil
+}
+
+// verifyGeneratedBlock processes the block on a copy of the pre-state and batch verifies all of its
+// signatures, returning an error describing the ones that are invalid.
+func verifyGeneratedBlock(ctx context.Context, bState state.BeaconState, blk interface{}) error {
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	if err != nil {
+		return err
+	}
+	set, _, err := transition.ExecuteStateTransitionNoVerifyAnySig(ctx, bState.Copy(), wsb)
+	if err != nil {
+		return errors.Wrap(err, "could not process generated block")
+	}
+	invalid, err := bls.VerifyBatch(set.Signatures, set.Messages, set.PublicKeys)
+	if err != nil {
+		return err
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	descs := make([]string, len(invalid))
+	for i, idx := range invalid {
+		descs[i] = set.Descriptions[idx]
+	}
+	return fmt.Errorf("generated block has %d invalid signatures: %s", len(invalid), strings.Join(descs, ", "))
+}
+
+// GenerateBlockWithAttestations generates a fully valid block at the given slot whose
+// only operations are numAtts attestations.
+func GenerateBlockWithAttestations(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	slot primitives.Slot,
+	numAtts uint64,
+) (*ethpb.SignedBeaconBlock, error) {
+	return GenerateFullBlock(bState, privs, &BlockGenConfig{NumAttestations: numAtts}, slot)
+}
+
+// GenerateChain generates and applies numBlocks consecutive full blocks on top of a copy of the given
+// state, returning the blocks and the resulting post-state. After each block the next slot cache is
+// primed with the post-state, so generating and processing the following block goes through the
+// same cached path as a production proposer.
+func GenerateChain(
+	ctx context.Context,
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+	numBlocks uint64,
+) ([]*ethpb.SignedBeaconBlock, state.BeaconState, error) {
+	conf = chainBlockGenConfig(conf)
+	st := bState.Copy()
+	blks := make([]*ethpb.SignedBeaconBlock, 0, numBlocks)
+	for i := uint64(0); i < numBlocks; i++ {
+		blk, err := GenerateFullBlock(st, privs, conf, st.Slot()+1)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not generate block %d", i)
+		}
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		if err != nil {
+			return nil, nil, err
+		}
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not process block %d", i)
+		}
+		root, err := blk.Block.HashTreeRoot()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := transition.UpdateNextSlotCache(ctx, root[:], st); err != nil {
+			return nil, nil, errors.Wrap(err, "could not prime next slot cache")
+		}
+		blks = append(blks, blk)
+	}
+	return blks, st, nil
+}
+
+// GenerateChainAcrossForks generates and applies numBlocks consecutive full blocks on top of a copy of
+// the given state, like GenerateChain, but with the generator of the fork active at each block's slot
+// according to the fork epochs of the active config, or of the state's fork if it is later. The first
+// block of a fork is generated on top of the pre-fork state, whose upgrade is applied by the slot
+// processing of the block, so that the blocks on each side of a fork boundary are of their own fork's
+// type. Each block goes through the full state transition of its fork, signatures included.
+func GenerateChainAcrossForks(
+	ctx context.Context,
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+	numBlocks uint64,
+) ([]interfaces.SignedBeaconBlock, state.BeaconState, error) {
+	conf = chainBlockGenConfig(conf)
+	st := bState.Copy()
+	blks := make([]interfaces.SignedBeaconBlock, 0, numBlocks)
+	for i := uint64(0); i < numBlocks; i++ {
+		slot := st.Slot() + 1
+		v := slots.ToForkVersion(slot)
+		if v < st.Version() {
+			v = st.Version()
+		}
+		blk, err := generateFullBlockForVersion(v, st, privs, conf, slot)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not generate block %d", i)
+		}
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		if err != nil {
+			return nil, nil, err
+		}
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not process %s block %d", version.String(v), i)
+		}
+		if st.Version() != v {
+			return nil, nil, fmt.Errorf("state was not upgraded to %s at slot %d", version.String(v), slot)
+		}
+		root, err := wsb.Block().HashTreeRoot()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := transition.UpdateNextSlotCache(ctx, root[:], st); err != nil {
+			return nil, nil, errors.Wrap(err, "could not prime next slot cache")
+		}
+		blks = append(blks, wsb)
+	}
+	return blks, st, nil
+}
+
+// chainBlockGenConfig returns a copy of the config of a chain generator, which processes the slots of
+// its blocks through the next slot cache.
+func chainBlockGenConfig(conf *BlockGenConfig) *BlockGenConfig {
+	chainConf := &BlockGenConfig{}
+	if conf != nil {
+		*chainConf = *conf
+	}
+	chainConf.useNextSlotCache = true
+	return chainConf
+}
+
+// processBlockSlots returns a copy of the state advanced to the slot of a block built on top of the
+// state's latest block. The slots are processed through the next slot cache if the config allows it.
+func processBlockSlots(ctx context.Context, bState state.BeaconState, conf *BlockGenConfig, slot primitives.Slot) (state.BeaconState, error) {
+	if !conf.useNextSlotCache {
+		return transition.ProcessSlots(ctx, bState.Copy(), slot)
+	}
+	header := bState.LatestBlockHeader()
+	if bytes.Equal(header.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		stateRoot, err := bState.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	parentRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return transition.ProcessSlotsUsingNextSlotCache(ctx, bState.Copy(), parentRoot[:], slot)
 }
 
 // GenerateProposerSlashingForValidator for a specific validator index.
@@ -257,17 +447,32 @@ func GenerateProposerSlashingForValidator(
 	}, nil
 }
 
+// generateProposerSlashings generates slashings of distinct validators, which are added to the validators already
+// slashed by the block, so that the attester slashings of the block do not target them again.
 func generateProposerSlashings(
 	bState state.BeaconState,
 	privs []bls.SecretKey,
 	numSlashings uint64,
+	slot primitives.Slot,
+	slashed map[primitives.ValidatorIndex]bool,
 ) ([]*ethpb.ProposerSlashing, error) {
+	candidates, err := proposerSlashingCandidates(bState, privs, slot, slashed)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(candidates)) < numSlashings {
+		return nil, fmt.Errorf("only %d validators can be slashed, %d proposer slashings requested", len(candidates), numSlashings)
+	}
 	proposerSlashings := make([]*ethpb.ProposerSlashing, numSlashings)
+	gen := rand.NewGenerator()
 	for i := uint64(0); i < numSlashings; i++ {
-		proposerIndex, err := randValIndex(bState)
-		if err != nil {
-			return nil, err
-		}
+		// Pick a random candidate, removing it so that it is not slashed twice by the block.
+		j := gen.Uint64() % uint64(len(candidates))
+		proposerIndex := candidates[j]
+		candidates[j] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+
+		slashed[proposerIndex] = true
 		slashing, err := GenerateProposerSlashingForValidator(bState, privs[proposerIndex], proposerIndex)
 		if err != nil {
 			return nil, err
@@ -277,6 +482,50 @@ func generateProposerSlashings(
 	return proposerSlashings, nil
 }
 
+// proposerSlashingCandidates returns the validators whose balance is reduced by a proposer slashing in the block
+// generated at the slot. These are the slashable validators with a balance and an effective balance of at least
+// one increment, so that the slashing penalty is not rounded down to zero. The proposer of the block is left out,
+// as the whistleblower reward it receives for the slashing can make up for its own penalty.
+func proposerSlashingCandidates(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	slot primitives.Slot,
+	slashed map[primitives.ValidatorIndex]bool,
+) ([]primitives.ValidatorIndex, error) {
+	ctx := context.Background()
+	if slot == bState.Slot() {
+		slot = bState.Slot() + 1
+	}
+	st, err := transition.ProcessSlots(ctx, bState.Copy(), slot)
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := proposerIndexAtSlot(ctx, st, st.Slot())
+	if err != nil {
+		return nil, err
+	}
+
+	epoch := time.CurrentEpoch(bState)
+	increment := params.BeaconConfig().EffectiveBalanceIncrement
+	balances := bState.Balances()
+	var candidates []primitives.ValidatorIndex
+	err = bState.ReadFromEveryValidator(func(i int, val state.ReadOnlyValidator) error {
+		idx := primitives.ValidatorIndex(i)
+		if idx == proposer || slashed[idx] || i >= len(privs) || i >= len(balances) {
+			return nil
+		}
+		if !helpers.IsSlashableValidatorUsingTrie(val, epoch) || val.EffectiveBalance() < increment || balances[i] < increment {
+			return nil
+		}
+		candidates = append(candidates, idx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
 // GenerateAttesterSlashingForValidator for a specific validator index.
 func GenerateAttesterSlashingForValidator(
 	bState state.BeaconState,
@@ -384,21 +633,102 @@ func GenerateAttesterSlashingForValidator(
 	}, nil
 }
 
+// SlashingCondition is the slashing rule violated by a pair of attestations.
+type SlashingCondition int
+
+const (
+	// DoubleVote is a pair of distinct attestations with the same target epoch.
+	DoubleVote SlashingCondition = iota
+	// SurroundVote is a pair of attestations where the first one surrounds the second.
+	SurroundVote
+)
+
+// GenerateSlashableAttestationPair generates two conflicting indexed attestations that are attested
+// by exactly the given validators and violate the requested slashing condition. The attestations
+// are signed with the state's fork and are of the Electra type for Electra states.
+func GenerateSlashableAttestationPair(
+	bState state.ReadOnlyBeaconState,
+	privs []bls.SecretKey,
+	indices []primitives.ValidatorIndex,
+	condition SlashingCondition,
+) (ethpb.IndexedAtt, ethpb.IndexedAtt, error) {
+	if len(indices) == 0 {
+		return nil, nil, errors.New("no validator indices")
+	}
+	sorted := make([]primitives.ValidatorIndex, len(indices))
+	copy(sorted, indices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	attesting := make([]uint64, len(sorted))
+	for i, idx := range sorted {
+		if i > 0 && idx == sorted[i-1] {
+			return nil, nil, fmt.Errorf("duplicate validator index %d", idx)
+		}
+		attesting[i] = uint64(idx)
+	}
+
+	currentEpoch := time.CurrentEpoch(bState)
+	checkpoint := func(epoch primitives.Epoch) *ethpb.Checkpoint {
+		return &ethpb.Checkpoint{Epoch: epoch, Root: params.BeaconConfig().ZeroHash[:]}
+	}
+	data1 := &ethpb.AttestationData{
+		Slot:            bState.Slot(),
+		BeaconBlockRoot: make([]byte, fieldparams.RootLength),
+	}
+	data2 := ethpb.CopyAttestationData(data1)
+	switch condition {
+	case DoubleVote:
+		data1.Source, data1.Target = checkpoint(currentEpoch), checkpoint(currentEpoch+1)
+		data2.Source, data2.Target = checkpoint(currentEpoch), checkpoint(currentEpoch+1)
+		data2.BeaconBlockRoot = bytesutil.PadTo([]byte{'d', 'o', 'u', 'b', 'l', 'e'}, fieldparams.RootLength)
+	case SurroundVote:
+		data1.Source, data1.Target = checkpoint(currentEpoch), checkpoint(currentEpoch+3)
+		data2.Source, data2.Target = checkpoint(currentEpoch+1), checkpoint(currentEpoch+2)
+	default:
+		return nil, nil, fmt.Errorf("unknown slashing condition %d", condition)
+	}
+
+	atts := make([]ethpb.IndexedAtt, 2)
+	for i, data := range []*ethpb.AttestationData{data1, data2} {
+		sig, err := AttestationSignature(bState, data, sorted, privs)
+		if err != nil {
+			return nil, nil, err
+		}
+		attestingCopy := make([]uint64, len(attesting))
+		copy(attestingCopy, attesting)
+		if bState.Version() >= version.Electra {
+			atts[i] = &ethpb.IndexedAttestationElectra{Data: data, AttestingIndices: attestingCopy, Signature: sig.Marshal()}
+		} else {
+			atts[i] = &ethpb.IndexedAttestation{Data: data, AttestingIndices: attestingCopy, Signature: sig.Marshal()}
+		}
+	}
+	return atts[0], atts[1], nil
+}
+
+// generateAttesterSlashings generates slashings of distinct members of the committees of the state's slot, leaving
+// out the validators already slashed by the block.
 func generateAttesterSlashings(
 	bState state.BeaconState,
 	privs []bls.SecretKey,
 	numSlashings uint64,
+	slashed map[primitives.ValidatorIndex]bool,
 ) ([]ethpb.AttSlashing, error) {
+	candidates, err := attesterSlashingCandidates(bState, privs, slashed)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(candidates)) < numSlashings {
+		return nil, fmt.Errorf("only %d committee members can be slashed, %d attester slashings requested", len(candidates), numSlashings)
+	}
 	attesterSlashings := make([]ethpb.AttSlashing, numSlashings)
 	randGen := rand.NewDeterministicGenerator()
 	for i := uint64(0); i < numSlashings; i++ {
-		committeeIndex := randGen.Uint64() % helpers.SlotCommitteeCount(uint64(bState.NumValidators()))
-		committee, err := helpers.BeaconCommitteeFromState(context.Background(), bState, bState.Slot(), primitives.CommitteeIndex(committeeIndex))
-		if err != nil {
-			return nil, err
-		}
-		randIndex := randGen.Uint64() % uint64(len(committee))
-		valIndex := committee[randIndex]
+		// Pick a random candidate, removing it so that it is not slashed twice by the block.
+		j := randGen.Uint64() % uint64(len(candidates))
+		valIndex := candidates[j]
+		candidates[j] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+
+		slashed[valIndex] = true
 		slashing, err := GenerateAttesterSlashingForValidator(bState, privs[valIndex], valIndex)
 		if err != nil {
 			return nil, err
@@ -408,19 +738,48 @@ func generateAttesterSlashings(
 	return attesterSlashings, nil
 }
 
+// attesterSlashingCandidates returns the members of the committees of the state's slot which are not slashed by
+// the block yet.
+func attesterSlashingCandidates(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	slashed map[primitives.ValidatorIndex]bool,
+) ([]primitives.ValidatorIndex, error) {
+	ctx := context.Background()
+	var candidates []primitives.ValidatorIndex
+	committeeCount := helpers.SlotCommitteeCount(uint64(bState.NumValidators()))
+	for i := uint64(0); i < committeeCount; i++ {
+		committee, err := helpers.BeaconCommitteeFromState(ctx, bState, bState.Slot(), primitives.CommitteeIndex(i))
+		if err != nil {
+			return nil, err
+		}
+		for _, idx := range committee {
+			if slashed[idx] || uint64(idx) >= uint64(len(privs)) {
+				continue
+			}
+			candidates = append(candidates, idx)
+		}
+	}
+	return candidates, nil
+}
+
 func generateDepositsAndEth1Data(
 	bState state.BeaconState,
 	numDeposits uint64,
+	invalidSignatures bool,
 ) (
 	[]*ethpb.Deposit,
 	*ethpb.Eth1Data,
 	error,
 ) {
 	previousDepsLen := bState.Eth1DepositIndex()
-	currentDeposits, _, err := DeterministicDepositsAndKeys(previousDepsLen + numDeposits)
+	currentDeposits, keys, err := DeterministicDepositsAndKeys(previousDepsLen + numDeposits)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not get deposits")
 	}
+	if invalidSignatures {
+		return depositsWithInvalidSignatures(currentDeposits, keys, previousDepsLen)
+	}
 	eth1Data, err := DeterministicEth1Data(len(currentDeposits))
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not get eth1data")
@@ -428,8 +787,79 @@ func generateDepositsAndEth1Data(
 	return currentDeposits[previousDepsLen:], eth1Data, nil
 }
 
+// generateDepositRequests generates the deposits that generateDepositsAndEth1Data would generate,
+// as deposit requests of an Electra execution payload rather than Eth1 deposits. The request index
+// of every deposit is its index in the deposit contract.
+func generateDepositRequests(
+	bState state.BeaconState,
+	numDeposits uint64,
+	invalidSignatures bool,
+) ([]*enginev1.DepositRequest, error) {
+	deposits, _, err := generateDepositsAndEth1Data(bState, numDeposits, invalidSignatures)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*enginev1.DepositRequest, len(deposits))
+	for i, d := range deposits {
+		requests[i] = &enginev1.DepositRequest{
+			Pubkey:                bytesutil.SafeCopyBytes(d.Data.PublicKey),
+			WithdrawalCredentials: bytesutil.SafeCopyBytes(d.Data.WithdrawalCredentials),
+			Amount:                d.Data.Amount,
+			Signature:             bytesutil.SafeCopyBytes(d.Data.Signature),
+			Index:                 bState.Eth1DepositIndex() + uint64(i),
+		}
+	}
+	return requests, nil
+}
+
+// depositsWithInvalidSignatures returns copies of the deposits after the first numProcessed ones,
+// each signed by its own key over a deposit message with a different amount, so that the signature
+// is well-formed but invalid. The deposit trie is rebuilt with the modified deposits, so that their
+// proofs and the returned eth1 data are valid.
+func depositsWithInvalidSignatures(
+	deposits []*ethpb.Deposit,
+	keys []bls.SecretKey,
+	numProcessed uint64,
+) ([]*ethpb.Deposit, *ethpb.Eth1Data, error) {
+	invalid := make([]*ethpb.Deposit, 0, uint64(len(deposits))-numProcessed)
+	for i := numProcessed; i < uint64(len(deposits)); i++ {
+		d := ethpb.CopyDeposit(deposits[i])
+		msg := &ethpb.DepositMessage{
+			PublicKey:             d.Data.PublicKey,
+			WithdrawalCredentials: d.Data.WithdrawalCredentials,
+			Amount:                d.Data.Amount + 1,
+		}
+		sig, err := DepositSignature(msg, keys[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		d.Data.Signature = sig
+		invalid = append(invalid, d)
+	}
+	all := append(append(make([]*ethpb.Deposit, 0, len(deposits)), deposits[:numProcessed]...), invalid...)
+	depositTrie, _, err := DepositTrieFromDeposits(all)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, d := range invalid {
+		d.Proof, err = depositTrie.MerkleProof(int(numProcessed) + i) // lint:ignore uintcast -- test code
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not create merkle proof")
+		}
+	}
+	root, err := depositTrie.HashTreeRoot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to compute deposit trie root")
+	}
+	eth1Data := &ethpb.Eth1Data{
+		BlockHash:    root[:],
+		DepositRoot:  root[:],
+		DepositCount: uint64(len(deposits)),
+	}
+	return invalid, eth1Data, nil
+}
+
 func GenerateVoluntaryExits(bState state.BeaconState, k bls.SecretKey, idx primitives.ValidatorIndex) (*ethpb.SignedVoluntaryExit, error) {
-	currentEpoch := time.CurrentEpoch(bState)
 	exit := &ethpb.SignedVoluntaryExit{
 		Exit: &ethpb.VoluntaryExit{
 			Epoch:          time.PrevEpoch(bState),
@@ -437,7 +867,7 @@ func GenerateVoluntaryExits(bState state.BeaconState, k bls.SecretKey, idx primi
 		},
 	}
 	var err error
-	exit.Signature, err = signing.ComputeDomainAndSign(bState, currentEpoch, exit.Exit, params.BeaconConfig().DomainVoluntaryExit, k)
+	exit.Signature, err = VoluntaryExitSignature(bState, exit.Exit, k)
 	if err != nil {
 		return nil, err
 	}
@@ -655,7 +1085,7 @@ func HydrateV2AltairBeaconBlockBody(b *v2.BeaconBlockBodyAltair) *v2.BeaconBlock
 	}
 	if b.SyncAggregate == nil {
 		b.SyncAggregate = &v1.SyncAggregate{
-			SyncCommitteeBits:      make([]byte, 64),
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
 			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
 		}
 	}
@@ -708,7 +1138,7 @@ func HydrateV2BellatrixBeaconBlockBody(b *v2.BeaconBlockBodyBellatrix) *v2.Beaco
 	}
 	if b.SyncAggregate == nil {
 		b.SyncAggregate = &v1.SyncAggregate{
-			SyncCommitteeBits:      make([]byte, 64),
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
 			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
 		}
 	}
@@ -775,7 +1205,7 @@ func HydrateBeaconBlockBodyAltair(b *ethpb.BeaconBlockBodyAltair) *ethpb.BeaconB
 	}
 	if b.SyncAggregate == nil {
 		b.SyncAggregate = &ethpb.SyncAggregate{
-			SyncCommitteeBits:      make([]byte, 64),
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
 			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
 		}
 	}
@@ -962,7 +1392,7 @@ func HydrateV2BlindedBeaconBlockBodyBellatrix(b *v2.BlindedBeaconBlockBodyBellat
 	}
 	if b.SyncAggregate == nil {
 		b.SyncAggregate = &v1.SyncAggregate{
-			SyncCommitteeBits:      make([]byte, 64),
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
 			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
 		}
 	}
@@ -1165,7 +1595,7 @@ func HydrateV2BlindedBeaconBlockBodyCapella(b *v2.BlindedBeaconBlockBodyCapella)
 	}
 	if b.SyncAggregate == nil {
 		b.SyncAggregate = &v1.SyncAggregate{
-			SyncCommitteeBits:      make([]byte, 64),
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
 			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
 		}
 	}
@@ -1187,6 +1617,138 @@ func HydrateV2BlindedBeaconBlockBodyCapella(b *v2.BlindedBeaconBlockBodyCapella)
 	return b
 }
 
+// WrapSignedBlock wraps a signed block of any fork, as returned by the block generators of this
+// package, so it can be passed to the APIs that process blocks of all forks.
+func WrapSignedBlock(signed interface{}) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	switch b := signed.(type) {
+	case *ethpb.SignedBeaconBlock,
+		*ethpb.SignedBeaconBlockAltair,
+		*ethpb.SignedBeaconBlockBellatrix,
+		*ethpb.SignedBlindedBeaconBlockBellatrix,
+		*ethpb.SignedBeaconBlockCapella,
+		*ethpb.SignedBlindedBeaconBlockCapella,
+		*ethpb.SignedBeaconBlockDeneb,
+		*ethpb.SignedBlindedBeaconBlockDeneb,
+		*ethpb.SignedBeaconBlockElectra,
+		*ethpb.SignedBlindedBeaconBlockElectra:
+		return blocks.NewSignedBeaconBlock(b)
+	default:
+		return nil, fmt.Errorf("unsupported signed block type %T", signed)
+	}
+}
+
+// GenerateFullBlockWithRoot generates a full block for the given slot with the generator of the
+// state's fork, and returns it wrapped along with its block root. This allows fork choice tests,
+// such as the ones for proposer boost, to insert the block with a controlled receive time and to
+// look up its weight by root.
+func GenerateFullBlockWithRoot(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+	slot primitives.Slot,
+) (interfaces.ReadOnlySignedBeaconBlock, [32]byte, error) {
+	wsb, err := GenerateFullBlockForState(bState, privs, conf, slot)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	root, err := wsb.Block().HashTreeRoot()
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not compute block root")
+	}
+	return wsb, root, nil
+}
+
+// GenerateFullBlockWithStateRoot generates a full block for the given slot with the generator of
+// the state's fork, replaces its post-state root with the given one and signs it again. The block is
+// valid except for its state root, so that tests can check that the state transition rejects blocks
+// whose post-state root doesn't match. The given root must differ from the actual post-state root.
+func GenerateFullBlockWithStateRoot(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+	slot primitives.Slot,
+	stateRoot [32]byte,
+) (interfaces.SignedBeaconBlock, error) {
+	wsb, err := GenerateFullBlockForState(bState, privs, conf, slot)
+	if err != nil {
+		return nil, err
+	}
+	if wsb.Block().StateRoot() == stateRoot {
+		return nil, errors.Errorf("state root %#x is the post-state root of the block", stateRoot)
+	}
+	return MutateAndResignBlock(bState, privs, wsb, MutateStateRoot(stateRoot))
+}
+
+// GenerateFirstBlock generates a fully valid block for slot 1 with the generator of the state's fork, on
+// top of a genesis state whose latest block header is the header of the genesis block. The parent root of
+// the block is checked to be the root of the genesis block built from the state, as the node does at genesis.
+func GenerateFirstBlock(
+	genesis state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+) (interfaces.SignedBeaconBlock, error) {
+	if genesis.Slot() != 0 || genesis.LatestBlockHeader().Slot != 0 {
+		return nil, errors.Errorf("state at slot %d is not a genesis state", genesis.Slot())
+	}
+	genesisBlock, err := coreBlock.NewGenesisBlockForState(context.Background(), genesis)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build genesis block")
+	}
+	genesisRoot, err := genesisBlock.Block().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	wsb, err := GenerateFullBlockForState(genesis, privs, conf, 1)
+	if err != nil {
+		return nil, err
+	}
+	if parentRoot := wsb.Block().ParentRoot(); parentRoot != genesisRoot {
+		return nil, errors.Errorf("parent root %#x of the block is not the genesis block root %#x", parentRoot, genesisRoot)
+	}
+	return wsb, nil
+}
+
+// GenerateFullBlockForState generates a fully valid block for the given slot with the generator of the
+// state's fork, and returns it behind the interfaces.SignedBeaconBlock abstraction, so that tests
+// running over several forks don't need to switch over the state's version.
+func GenerateFullBlockForState(
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+	slot primitives.Slot,
+) (interfaces.SignedBeaconBlock, error) {
+	blk, err := generateFullBlockForVersion(bState.Version(), bState, privs, conf, slot)
+	if err != nil {
+		return nil, err
+	}
+	return blocks.NewSignedBeaconBlock(blk)
+}
+
+// generateFullBlockForVersion generates a full block with the generator of the given fork. The fork
+// can be later than the state's, when the block is the first one after a fork boundary.
+func generateFullBlockForVersion(
+	v int,
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+	conf *BlockGenConfig,
+	slot primitives.Slot,
+) (interface{}, error) {
+	switch v {
+	case version.Phase0:
+		return GenerateFullBlock(bState, privs, conf, slot)
+	case version.Altair:
+		return GenerateFullBlockAltair(bState, privs, conf, slot)
+	case version.Bellatrix:
+		return GenerateFullBlockBellatrix(bState, privs, conf, slot)
+	case version.Capella:
+		return GenerateFullBlockCapella(bState, privs, conf, slot)
+	case version.Electra:
+		return GenerateFullBlockElectra(bState, privs, conf, slot)
+	default:
+		return nil, fmt.Errorf("blocks can't be generated for %s states", version.String(v))
+	}
+}
+
 func SaveBlock(tb assertions.AssertionTestingTB, ctx context.Context, db iface.NoHeadAccessDatabase, b interface{}) interfaces.SignedBeaconBlock {
 	wsb, err := blocks.NewSignedBeaconBlock(b)
 	require.NoError(tb, err)
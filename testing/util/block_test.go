@@ -2,20 +2,31 @@ package util
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	coreBlock "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition/stateutils"
+	p2pType "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 func TestGenerateFullBlock_PassesStateTransition(t *testing.T) {
@@ -98,6 +109,64 @@ func TestGenerateFullBlock_ValidProposerSlashings(t *testing.T) {
 	}
 }
 
+func TestGenerateFullBlock_ProposerSlashingsReduceBalances(t *testing.T) {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+
+	// requireSlashed checks that the validators slashed by the block lose at least the slashing penalty.
+	requireSlashed := func(t *testing.T, pre, post state.BeaconState, blk interfaces.ReadOnlyBeaconBlock, quotient uint64, exact bool) {
+		slashings := blk.Body().ProposerSlashings()
+		require.NotEqual(t, 0, len(slashings))
+		for _, s := range slashings {
+			idx := s.Header_1.Header.ProposerIndex
+			require.NotEqual(t, blk.ProposerIndex(), idx)
+			val, err := pre.ValidatorAtIndexReadOnly(idx)
+			require.NoError(t, err)
+			penalty := val.EffectiveBalance() / quotient
+			require.Equal(t, true, penalty > 0)
+			before, err := pre.BalanceAtIndex(idx)
+			require.NoError(t, err)
+			after, err := post.BalanceAtIndex(idx)
+			require.NoError(t, err)
+			if exact {
+				require.Equal(t, before-penalty, after)
+			} else {
+				require.Equal(t, true, before-after >= penalty, "balance of validator %d decreased by %d, less than the penalty %d", idx, before-after, penalty)
+			}
+		}
+	}
+
+	t.Run("phase0", func(t *testing.T) {
+		genesis, privs := DeterministicGenesisState(t, 64)
+		blk, err := GenerateFullBlock(genesis, privs, &BlockGenConfig{NumProposerSlashings: 4}, 1)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(blk.Block.Body.ProposerSlashings))
+		requireSlashed(t, genesis, st, wsb.Block(), cfg.MinSlashingPenaltyQuotient, true)
+	})
+	t.Run("electra", func(t *testing.T) {
+		genesis, privs := DeterministicGenesisStateElectra(t, 64)
+		setSyncCommittees(t, genesis)
+		blk, err := GenerateFullBlockElectra(genesis, privs, &BlockGenConfig{NumProposerSlashings: 4}, 1)
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+		require.NoError(t, err)
+		// Sync committee members not in the sync aggregate of the block are penalized as well.
+		requireSlashed(t, genesis, st, wsb.Block(), cfg.MinSlashingPenaltyQuotientElectra, false)
+	})
+	t.Run("not enough slashable validators", func(t *testing.T) {
+		genesis, privs := DeterministicGenesisState(t, 8)
+		// The proposer of the block can not be slashed by it.
+		_, err := GenerateFullBlock(genesis, privs, &BlockGenConfig{NumProposerSlashings: 8}, 1)
+		require.ErrorContains(t, "only 7 validators can be slashed", err)
+	})
+}
+
 func TestGenerateFullBlock_ValidAttesterSlashings(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	params.OverrideBeaconConfig(params.MainnetConfig().Copy())
@@ -119,6 +188,45 @@ func TestGenerateFullBlock_ValidAttesterSlashings(t *testing.T) {
 	}
 }
 
+func TestGenerateFullBlock_NotEnoughAttesterSlashingCandidates(t *testing.T) {
+	beaconState, privs := DeterministicGenesisState(t, 64)
+	committeeCount := helpers.SlotCommitteeCount(uint64(beaconState.NumValidators()))
+	members := 0
+	for i := uint64(0); i < committeeCount; i++ {
+		committee, err := helpers.BeaconCommitteeFromState(context.Background(), beaconState, beaconState.Slot(), primitives.CommitteeIndex(i))
+		require.NoError(t, err)
+		members += len(committee)
+	}
+	conf := &BlockGenConfig{NumAttesterSlashings: uint64(members) + 1}
+	_, err := GenerateFullBlock(beaconState, privs, conf, 1)
+	require.ErrorContains(t, fmt.Sprintf("only %d committee members can be slashed", members), err)
+}
+
+func TestGenerateSlashableAttestationPair(t *testing.T) {
+	indices := []primitives.ValidatorIndex{9, 2, 33}
+	for _, condition := range []SlashingCondition{DoubleVote, SurroundVote} {
+		for name, genesis := range map[string]func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey){
+			"phase0":  DeterministicGenesisState,
+			"electra": DeterministicGenesisStateElectra,
+		} {
+			t.Run(fmt.Sprintf("%s condition %d", name, condition), func(t *testing.T) {
+				st, privs := genesis(t, 64)
+				att1, att2, err := GenerateSlashableAttestationPair(st, privs, indices, condition)
+				require.NoError(t, err)
+				require.DeepEqual(t, []uint64{2, 9, 33}, att1.GetAttestingIndices())
+				require.DeepEqual(t, []uint64{2, 9, 33}, att2.GetAttestingIndices())
+				require.Equal(t, true, coreBlock.IsSlashableAttestationData(att1.GetData(), att2.GetData()))
+				require.NoError(t, coreBlock.VerifyIndexedAttestation(context.Background(), st, att1))
+				require.NoError(t, coreBlock.VerifyIndexedAttestation(context.Background(), st, att2))
+			})
+		}
+	}
+
+	st, privs := DeterministicGenesisState(t, 64)
+	_, _, err := GenerateSlashableAttestationPair(st, privs, []primitives.ValidatorIndex{1, 1}, DoubleVote)
+	require.ErrorContains(t, "duplicate validator index", err)
+}
+
 func TestGenerateFullBlock_ValidAttestations(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	params.OverrideBeaconConfig(params.MainnetConfig().Copy())
@@ -140,6 +248,228 @@ func TestGenerateFullBlock_ValidAttestations(t *testing.T) {
 	}
 }
 
+func TestGenerateFullBlock_VerifyGenerated(t *testing.T) {
+	beaconState, privs := DeterministicGenesisState(t, 128)
+	conf := &BlockGenConfig{
+		NumAttestations:      2,
+		NumProposerSlashings: 1,
+		VerifyGenerated:      true,
+	}
+	_, err := GenerateFullBlock(beaconState, privs, conf, beaconState.Slot())
+	require.NoError(t, err)
+
+	// Keys that do not match the validator set produce a block that fails verification.
+	badPrivs := make([]bls.SecretKey, len(privs))
+	for i := range badPrivs {
+		badPrivs[i] = privs[(i+1)%len(privs)]
+	}
+	conf = &BlockGenConfig{VerifyGenerated: true}
+	_, err = GenerateFullBlock(beaconState, badPrivs, conf, beaconState.Slot())
+	require.ErrorContains(t, "invalid signatures", err)
+}
+
+func TestGenerateBlockWithAttestations(t *testing.T) {
+	beaconState, privs := DeterministicGenesisState(t, 128)
+	block, err := GenerateBlockWithAttestations(beaconState, privs, beaconState.Slot(), 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(block.Block.Body.Attestations))
+	require.Equal(t, 0, len(block.Block.Body.ProposerSlashings))
+	require.Equal(t, 0, len(block.Block.Body.AttesterSlashings))
+	require.Equal(t, 0, len(block.Block.Body.Deposits))
+	require.Equal(t, 0, len(block.Block.Body.VoluntaryExits))
+	wsb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+	_, err = transition.ExecuteStateTransition(context.Background(), beaconState, wsb)
+	require.NoError(t, err)
+}
+
+func TestGenerateChain_PrimedCacheMatchesColdPath(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privs := DeterministicGenesisState(t, 64)
+	conf := &BlockGenConfig{NumAttestations: 1}
+	numBlocks := uint64(params.BeaconConfig().SlotsPerEpoch + 2)
+
+	transition.ClearNextSlotCache()
+	blks, postState, err := GenerateChain(ctx, beaconState, privs, conf, numBlocks)
+	require.NoError(t, err)
+	require.Equal(t, int(numBlocks), len(blks))
+
+	st := beaconState.Copy()
+	for i := uint64(0); i < numBlocks; i++ {
+		transition.ClearNextSlotCache()
+		blk, err := GenerateFullBlock(st, privs, conf, st.Slot()+1)
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, blk, blks[i])
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+	}
+
+	want, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	got, err := postState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestGenerateChain_ReadsNextSlotCache(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privs := DeterministicGenesisState(t, 64)
+	conf := &BlockGenConfig{}
+
+	transition.ClearNextSlotCache()
+	_, postState, err := GenerateChain(ctx, beaconState, privs, conf, 1)
+	require.NoError(t, err)
+	root, lastState := transition.LastCachedState()
+	require.NotNil(t, lastState)
+
+	// Replace the cached state of the head block with a state of another fork. The randao reveal of the next
+	// block is signed over the fork of the state its slots are processed to, so it only changes if the block
+	// is generated from the cached state.
+	poisoned := postState.Copy()
+	require.NoError(t, poisoned.SetFork(&ethpbalpha.Fork{
+		PreviousVersion: []byte{9, 9, 9, 9},
+		CurrentVersion:  []byte{9, 9, 9, 9},
+	}))
+	require.NoError(t, transition.UpdateNextSlotCache(ctx, root, poisoned))
+	cached, err := GenerateFullBlock(postState, privs, chainBlockGenConfig(conf), postState.Slot()+1)
+	require.NoError(t, err)
+
+	transition.ClearNextSlotCache()
+	cold, err := GenerateFullBlock(postState, privs, conf, postState.Slot()+1)
+	require.NoError(t, err)
+	require.Equal(t, cold.Block.Slot, cached.Block.Slot)
+	require.DeepNotEqual(t, cold.Block.Body.RandaoReveal, cached.Block.Body.RandaoReveal)
+}
+
+func TestGenerateChainAcrossForks(t *testing.T) {
+	tests := []struct {
+		name    string
+		genesis func(t testing.TB, numValidators uint64) (state.BeaconState, []bls.SecretKey)
+		setup   func(cfg *params.BeaconChainConfig)
+		pre     int
+		post    int
+	}{
+		{
+			name:    "phase0 to altair",
+			genesis: DeterministicGenesisState,
+			setup: func(cfg *params.BeaconChainConfig) {
+				cfg.AltairForkEpoch = 1
+			},
+			pre:  version.Phase0,
+			post: version.Altair,
+		},
+		{
+			name:    "bellatrix to capella",
+			genesis: DeterministicGenesisStateBellatrix,
+			setup: func(cfg *params.BeaconChainConfig) {
+				cfg.AltairForkEpoch = 0
+				cfg.BellatrixForkEpoch = 0
+				cfg.CapellaForkEpoch = 1
+			},
+			pre:  version.Bellatrix,
+			post: version.Capella,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params.SetupTestConfigCleanup(t)
+			cfg := params.BeaconConfig().Copy()
+			tt.setup(cfg)
+			params.OverrideBeaconConfig(cfg)
+
+			ctx := context.Background()
+			beaconState, privs := tt.genesis(t, 64)
+			numBlocks := uint64(params.BeaconConfig().SlotsPerEpoch + 2)
+			blks, postState, err := GenerateChainAcrossForks(ctx, beaconState, privs, &BlockGenConfig{NumAttestations: 1}, numBlocks)
+			require.NoError(t, err)
+			require.Equal(t, int(numBlocks), len(blks))
+			for _, blk := range blks {
+				if slots.ToEpoch(blk.Block().Slot()) < 1 {
+					require.Equal(t, version.String(tt.pre), version.String(blk.Version()), "block at slot %d", blk.Block().Slot())
+				} else {
+					require.Equal(t, version.String(tt.post), version.String(blk.Version()), "block at slot %d", blk.Block().Slot())
+				}
+			}
+			require.Equal(t, tt.post, postState.Version())
+			require.DeepEqual(t, beaconState.Fork().CurrentVersion, postState.Fork().PreviousVersion)
+
+			// Each block is valid under its fork's rules, signatures included.
+			st := beaconState.Copy()
+			for _, blk := range blks {
+				st, err = transition.ExecuteStateTransition(ctx, st, blk)
+				require.NoError(t, err)
+			}
+			want, err := st.HashTreeRoot(ctx)
+			require.NoError(t, err)
+			got, err := postState.HashTreeRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestGenerateChainAcrossForks_UnsupportedFork(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.DenebForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	beaconState, privs := DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, beaconState.SetSlot(params.BeaconConfig().SlotsPerEpoch-1))
+	_, _, err := GenerateChainAcrossForks(context.Background(), beaconState, privs, DefaultBlockGenConfig(), 1)
+	require.ErrorContains(t, "blocks can't be generated for deneb states", err)
+}
+
+func TestMutateAndResignBlock(t *testing.T) {
+	beaconState, privs := DeterministicGenesisState(t, 64)
+	block, err := GenerateFullBlock(beaconState, privs, DefaultBlockGenConfig(), beaconState.Slot())
+	require.NoError(t, err)
+	wsb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		mutate BlockMutator
+	}{
+		{name: "proposer index", mutate: MutateProposerIndex(wsb.Block().ProposerIndex() + 1)},
+		{name: "parent root", mutate: MutateParentRoot([32]byte{'a'})},
+		{name: "state root", mutate: MutateStateRoot([32]byte{'b'})},
+		{name: "graffiti", mutate: MutateGraffiti([32]byte{'c'})},
+		{name: "randao reveal", mutate: MutateRandaoReveal(privs[0].Sign([]byte{'d'}).Marshal())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mutated, err := MutateAndResignBlock(beaconState, privs, wsb, tt.mutate)
+			require.NoError(t, err)
+			_, err = transition.ExecuteStateTransition(context.Background(), beaconState.Copy(), mutated)
+			require.NotNil(t, err)
+		})
+	}
+
+	// The original block is not modified.
+	_, err = transition.ExecuteStateTransition(context.Background(), beaconState.Copy(), wsb)
+	require.NoError(t, err)
+}
+
+func TestMutateBlock_KeepsSignature(t *testing.T) {
+	beaconState, privs := DeterministicGenesisState(t, 64)
+	block, err := GenerateFullBlock(beaconState, privs, nil, beaconState.Slot())
+	require.NoError(t, err)
+	wsb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+
+	mutated, err := MutateBlock(wsb, MutateSlot(wsb.Block().Slot()+1))
+	require.NoError(t, err)
+	require.Equal(t, wsb.Block().Slot()+1, mutated.Block().Slot())
+	require.Equal(t, wsb.Signature(), mutated.Signature())
+	require.Equal(t, block.Block.Slot, wsb.Block().Slot())
+}
+
 func TestGenerateFullBlock_ValidDeposits(t *testing.T) {
 	beaconState, privs := DeterministicGenesisState(t, 256)
 	deposits, _, err := DeterministicDepositsAndKeys(257)
@@ -170,6 +500,43 @@ func TestGenerateFullBlock_ValidDeposits(t *testing.T) {
 	}
 }
 
+func TestGenerateFullBlock_InvalidDepositSignatures(t *testing.T) {
+	beaconState, privs := DeterministicGenesisState(t, 256)
+	_, eth1Data, err := generateDepositsAndEth1Data(beaconState, 1, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(257), eth1Data.DepositCount)
+	require.NoError(t, beaconState.SetEth1Data(eth1Data))
+	conf := &BlockGenConfig{
+		NumDeposits:              1,
+		InvalidDepositSignatures: true,
+	}
+	block, err := GenerateFullBlock(beaconState, privs, conf, beaconState.Slot())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(block.Block.Body.Deposits))
+	deposit := block.Block.Body.Deposits[0]
+
+	// The deposit proof is valid against the generated eth1 data.
+	require.DeepEqual(t, eth1Data, block.Block.Body.Eth1Data)
+	leaf, err := deposit.Data.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, true, trie.VerifyMerkleProof(eth1Data.DepositRoot, leaf[:], 256, deposit.Proof))
+
+	// The deposit is processed, but its validator is not added.
+	wsb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+	beaconState, err = transition.ExecuteStateTransition(context.Background(), beaconState, wsb)
+	require.NoError(t, err)
+	require.Equal(t, uint64(257), beaconState.Eth1DepositIndex())
+	require.Equal(t, 256, beaconState.NumValidators())
+	_, ok := beaconState.ValidatorIndexByPubkey(bytesutil.ToBytes48(deposit.Data.PublicKey))
+	require.Equal(t, false, ok)
+
+	// The cached deposits keep their valid signatures.
+	deposits, _, err := DeterministicDepositsAndKeys(257)
+	require.NoError(t, err)
+	require.DeepNotEqual(t, deposit.Data.Signature, deposits[256].Data.Signature)
+}
+
 func TestGenerateFullBlock_ValidVoluntaryExits(t *testing.T) {
 	beaconState, privs := DeterministicGenesisState(t, 256)
 	// Moving the state 2048 epochs forward due to PERSISTENT_COMMITTEE_PERIOD.
@@ -194,6 +561,22 @@ func TestGenerateFullBlock_ValidVoluntaryExits(t *testing.T) {
 	}
 }
 
+func TestGenerateMaxBlockBellatrix(t *testing.T) {
+	cfg := params.BeaconConfig()
+	// The committees of a single slot must hold MaxAttestations attesters.
+	numValidators := cfg.MaxAttestations * uint64(cfg.SlotsPerEpoch)
+	beaconState, privs := DeterministicGenesisStateBellatrix(t, numValidators)
+
+	_, err := GenerateMaxBlockBellatrix(beaconState, privs)
+	require.ErrorContains(t, "eligible to exit", err)
+
+	// Moving the state forward so that validators can exit. The eth1 data of the state has no pending deposits.
+	// Generating and processing a full block is covered by BenchmarkExecuteStateTransition_MaxBlockBellatrix.
+	require.NoError(t, beaconState.SetSlot(cfg.SlotsPerEpoch.Mul(uint64(cfg.ShardCommitteePeriod)).Add(3)))
+	_, err = GenerateMaxBlockBellatrix(beaconState, privs)
+	require.ErrorContains(t, "pending deposits", err)
+}
+
 func TestHydrateSignedBeaconBlock_NoError(t *testing.T) {
 	b := &ethpbalpha.SignedBeaconBlock{}
 	b = HydrateSignedBeaconBlock(b)
@@ -372,3 +755,408 @@ func TestGenerateVoluntaryExits(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, coreBlock.VerifyExitAndSignature(val, beaconState, exit))
 }
+
+func TestGenerateFullBlockAltair_SyncCommitteePeriodBoundary(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.EpochsPerSyncCommitteePeriod = 1
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	helpers.ClearCache()
+	genesis, privs := DeterministicGenesisStateAltair(t, 64)
+	// Use disjoint committees, so that the aggregate only verifies against the committee of the
+	// block's period.
+	current, next := &ethpbalpha.SyncCommittee{}, &ethpbalpha.SyncCommittee{}
+	for i := uint64(0); i < cfg.SyncCommitteeSize; i++ {
+		pk := genesis.PubkeyAtIndex(primitives.ValidatorIndex(i % 32))
+		current.Pubkeys = append(current.Pubkeys, pk[:])
+		pk = genesis.PubkeyAtIndex(primitives.ValidatorIndex(32 + i%32))
+		next.Pubkeys = append(next.Pubkeys, pk[:])
+	}
+	for _, c := range []*ethpbalpha.SyncCommittee{current, next} {
+		agg, err := bls.AggregatePublicKeys(c.Pubkeys)
+		require.NoError(t, err)
+		c.AggregatePubkey = agg.Marshal()
+	}
+	require.NoError(t, genesis.SetCurrentSyncCommittee(current))
+	require.NoError(t, genesis.SetNextSyncCommittee(next))
+	boundary := primitives.Slot(cfg.EpochsPerSyncCommitteePeriod) * cfg.SlotsPerEpoch
+	st, err := transition.ProcessSlots(ctx, genesis, boundary-1)
+	require.NoError(t, err)
+	nextCommittee, err := st.NextSyncCommittee()
+	require.NoError(t, err)
+
+	blk, err := GenerateFullBlockAltair(st, privs, &BlockGenConfig{FullSyncAggregate: true}, boundary)
+	require.NoError(t, err)
+	require.Equal(t, boundary, blk.Block.Slot)
+	require.Equal(t, uint64(len(nextCommittee.Pubkeys)), blk.Block.Body.SyncAggregate.SyncCommitteeBits.Count())
+
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	post, err := transition.ExecuteStateTransition(ctx, st, wsb)
+	require.NoError(t, err)
+	currCommittee, err := post.CurrentSyncCommittee()
+	require.NoError(t, err)
+	require.DeepEqual(t, nextCommittee.Pubkeys, currCommittee.Pubkeys)
+}
+
+func TestGenerateFullBlockAltair_SyncParticipation(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	genesis, privs := DeterministicGenesisStateAltair(t, 64)
+	setSyncCommittees(t, genesis)
+
+	blk, err := GenerateFullBlockAltair(genesis, privs, &BlockGenConfig{SyncParticipation: 0.25}, 1)
+	require.NoError(t, err)
+	bits := blk.Block.Body.SyncAggregate.SyncCommitteeBits
+	require.Equal(t, params.BeaconConfig().SyncCommitteeSize/4, bits.Count())
+	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSize; i++ {
+		require.Equal(t, i < params.BeaconConfig().SyncCommitteeSize/4, bits.BitAt(i))
+	}
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	_, err = transition.ExecuteStateTransition(ctx, genesis, wsb)
+	require.NoError(t, err)
+
+	_, err = GenerateFullBlockAltair(genesis, privs, &BlockGenConfig{SyncParticipation: 1.5}, 1)
+	require.ErrorContains(t, "sync participation 1.500000 is not between 0 and 1", err)
+}
+
+func TestGenerateFullBlock_AfterSkippedSlots(t *testing.T) {
+	tests := []struct {
+		name     string
+		genesis  func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey)
+		generate func(state.BeaconState, []bls.SecretKey, primitives.Slot) (interface{}, error)
+	}{
+		{
+			name:    "phase0",
+			genesis: DeterministicGenesisState,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlock(st, privs, DefaultBlockGenConfig(), slot)
+			},
+		},
+		{
+			name:    "altair",
+			genesis: DeterministicGenesisStateAltair,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockAltair(st, privs, DefaultBlockGenConfig(), slot)
+			},
+		},
+		{
+			name:    "bellatrix",
+			genesis: DeterministicGenesisStateBellatrix,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockBellatrix(st, privs, DefaultBlockGenConfig(), slot)
+			},
+		},
+		{
+			name:    "capella",
+			genesis: DeterministicGenesisStateCapella,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockCapella(st, privs, DefaultBlockGenConfig(), slot)
+			},
+		},
+		{
+			name:    "electra",
+			genesis: DeterministicGenesisStateElectra,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockElectra(st, privs, DefaultBlockGenConfig(), slot)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			helpers.ClearCache()
+			transition.SkipSlotCache.Disable()
+			defer transition.SkipSlotCache.Enable()
+			genesis, privs := tt.genesis(t, 64)
+			if genesis.Version() >= version.Altair {
+				setSyncCommittees(t, genesis)
+			}
+			parent, err := tt.generate(genesis, privs, 1)
+			require.NoError(t, err)
+			wsb, err := WrapSignedBlock(parent)
+			require.NoError(t, err)
+			st, err := transition.ExecuteStateTransition(ctx, genesis, wsb)
+			require.NoError(t, err)
+			parentRoot, err := wsb.Block().HashTreeRoot()
+			require.NoError(t, err)
+
+			// Skip the rest of the first epoch and the first slot of the next one.
+			slot := params.BeaconConfig().SlotsPerEpoch + 1
+			b, err := tt.generate(st, privs, slot)
+			require.NoError(t, err)
+			wsb, err = WrapSignedBlock(b)
+			require.NoError(t, err)
+			blk := wsb.Block()
+			require.Equal(t, slot, blk.Slot())
+			require.Equal(t, parentRoot, blk.ParentRoot())
+			advanced, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+			require.NoError(t, err)
+			proposer, err := helpers.BeaconProposerIndex(ctx, advanced)
+			require.NoError(t, err)
+			require.Equal(t, proposer, blk.ProposerIndex())
+			reveal, err := RandaoReveal(advanced, params.BeaconConfig().GenesisEpoch+1, privs)
+			require.NoError(t, err)
+			randao := blk.Body().RandaoReveal()
+			require.DeepEqual(t, reveal, randao[:])
+
+			_, err = transition.ExecuteStateTransition(ctx, st, wsb)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerateFullBlockForState(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		genesis func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey)
+	}{
+		{name: "phase0", genesis: DeterministicGenesisState},
+		{name: "altair", genesis: DeterministicGenesisStateAltair},
+		{name: "bellatrix", genesis: DeterministicGenesisStateBellatrix},
+		{name: "capella", genesis: DeterministicGenesisStateCapella},
+		{name: "electra", genesis: DeterministicGenesisStateElectra},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helpers.ClearCache()
+			genesis, privs := tt.genesis(t, 64)
+			if genesis.Version() >= version.Altair {
+				setSyncCommittees(t, genesis)
+			}
+			wsb, err := GenerateFullBlockForState(genesis, privs, DefaultBlockGenConfig(), 1)
+			require.NoError(t, err)
+			require.Equal(t, genesis.Version(), wsb.Version())
+			require.Equal(t, primitives.Slot(1), wsb.Block().Slot())
+			_, err = transition.ExecuteStateTransition(ctx, genesis, wsb)
+			require.NoError(t, err)
+		})
+	}
+
+	deneb, privs := DeterministicGenesisStateDeneb(t, 64)
+	_, err := GenerateFullBlockForState(deneb, privs, DefaultBlockGenConfig(), 1)
+	require.ErrorContains(t, "blocks can't be generated for deneb states", err)
+}
+
+func TestGenerateFullBlockWithRoot(t *testing.T) {
+	ctx := context.Background()
+	helpers.ClearCache()
+	genesis, privs := DeterministicGenesisStateAltair(t, 64)
+	setSyncCommittees(t, genesis)
+
+	wsb, root, err := GenerateFullBlockWithRoot(genesis, privs, DefaultBlockGenConfig(), 3)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(3), wsb.Block().Slot())
+	expected, err := wsb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+	signed, err := wsb.Copy()
+	require.NoError(t, err)
+	_, err = transition.ExecuteStateTransition(ctx, genesis, signed)
+	require.NoError(t, err)
+
+	deneb, privs := DeterministicGenesisStateDeneb(t, 64)
+	_, _, err = GenerateFullBlockWithRoot(deneb, privs, DefaultBlockGenConfig(), 1)
+	require.ErrorContains(t, "blocks can't be generated for deneb states", err)
+}
+
+func TestGenerateFullBlockWithStateRoot(t *testing.T) {
+	ctx := context.Background()
+	genesis, privs := DeterministicGenesisState(t, 64)
+	valid, err := GenerateFullBlockForState(genesis, privs, DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+
+	wrongRoot := [32]byte{'w', 'r', 'o', 'n', 'g'}
+	wsb, err := GenerateFullBlockWithStateRoot(genesis, privs, DefaultBlockGenConfig(), 1, wrongRoot)
+	require.NoError(t, err)
+	require.Equal(t, wrongRoot, wsb.Block().StateRoot())
+	require.NotEqual(t, valid.Signature(), wsb.Signature())
+
+	// The block is signed by its proposer, so only the state root check fails.
+	sig := wsb.Signature()
+	sigSet, err := coreBlock.BlockSignatureBatch(genesis, wsb.Block().ProposerIndex(), sig[:], wsb.Block().HashTreeRoot)
+	require.NoError(t, err)
+	verified, err := sigSet.Verify()
+	require.NoError(t, err)
+	require.Equal(t, true, verified)
+	_, err = transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+	require.ErrorContains(t, "could not validate state root", err)
+
+	_, err = GenerateFullBlockWithStateRoot(genesis, privs, DefaultBlockGenConfig(), 1, valid.Block().StateRoot())
+	require.ErrorContains(t, "is the post-state root of the block", err)
+}
+
+func TestGenerateFullBlock_PartialSyncAggregateVerifies(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	helpers.ClearCache()
+	st, privs := DeterministicGenesisStateBellatrix(t, 64)
+	setSyncCommittees(t, st)
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	// A third of the committee does not end on a byte boundary of the bitvector.
+	numParticipants := params.BeaconConfig().SyncCommitteeSize / 3
+
+	blk, err := GenerateFullBlockBellatrix(st, privs, &BlockGenConfig{SyncParticipation: 1.0 / 3}, 1)
+	require.NoError(t, err)
+	aggregate := blk.Block.Body.SyncAggregate
+	require.Equal(t, numParticipants, aggregate.SyncCommitteeBits.Count())
+	var participants, all []bls.PublicKey
+	for i, k := range committee.Pubkeys {
+		pubkey, err := bls.PublicKeyFromBytes(k)
+		require.NoError(t, err)
+		all = append(all, pubkey)
+		require.Equal(t, uint64(i) < numParticipants, aggregate.SyncCommitteeBits.BitAt(uint64(i)))
+		if aggregate.SyncCommitteeBits.BitAt(uint64(i)) {
+			participants = append(participants, pubkey)
+		}
+	}
+
+	d, err := signing.Domain(st.Fork(), 0, params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	parentRoot := p2pType.SSZBytes(blk.Block.ParentRoot)
+	root, err := signing.ComputeSigningRoot(&parentRoot, d)
+	require.NoError(t, err)
+	sig, err := bls.SignatureFromBytes(aggregate.SyncCommitteeSignature)
+	require.NoError(t, err)
+	require.Equal(t, true, sig.FastAggregateVerify(participants, root))
+	require.Equal(t, false, sig.FastAggregateVerify(all, root))
+
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	_, err = transition.ExecuteStateTransition(context.Background(), st, wsb)
+	require.NoError(t, err)
+}
+
+func TestGenerateFullBlockBellatrix_DevnetConfig(t *testing.T) {
+	cfg := params.LoadChainConfigFileForTest(t, "../../config/params/testdata/devnet_config.yaml")
+	helpers.ClearCache()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	st, privs := DeterministicGenesisStateBellatrix(t, 64)
+	// The sync committee of the config is smaller than the SSZ length of mainnet builds, so its
+	// members are repeated to fill the committee of the state.
+	committee, err := altair.NextSyncCommittee(context.Background(), st)
+	require.NoError(t, err)
+	require.Equal(t, int(cfg.SyncCommitteeSize), len(committee.Pubkeys))
+	for len(committee.Pubkeys) < fieldparams.SyncCommitteeLength {
+		committee.Pubkeys = append(committee.Pubkeys, committee.Pubkeys[len(committee.Pubkeys)%int(cfg.SyncCommitteeSize)])
+	}
+	require.NoError(t, st.SetCurrentSyncCommittee(committee))
+	require.NoError(t, st.SetNextSyncCommittee(committee))
+
+	conf := DefaultBlockGenConfig()
+	conf.NumAttestations = 1
+	for i := 0; i < 3; i++ {
+		blk, err := GenerateFullBlockBellatrix(st, privs, conf, st.Slot()+1)
+		require.NoError(t, err)
+		// Payload timestamps are checked against the slot time of the config.
+		wantTime := st.GenesisTime() + uint64(blk.Block.Slot)*cfg.SecondsPerSlot
+		require.Equal(t, wantTime, blk.Block.Body.ExecutionPayload.Timestamp)
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(context.Background(), st, wsb)
+		require.NoError(t, err)
+	}
+	// The blocks are signed with the fork version of the config.
+	forkCfg, err := params.ByVersion(bytesutil.ToBytes4(st.Fork().CurrentVersion))
+	require.NoError(t, err)
+	require.Equal(t, cfg.ConfigName, forkCfg.ConfigName)
+}
+
+func TestGenerateFullBlockBellatrix_PrevRandao(t *testing.T) {
+	st, privs := DeterministicGenesisStateBellatrix(t, 64)
+	mix, err := helpers.RandaoMix(st, slots.ToEpoch(st.Slot()))
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		blk, err := GenerateFullBlockBellatrix(st, privs, DefaultBlockGenConfig(), 1)
+		require.NoError(t, err)
+		require.DeepEqual(t, mix, blk.Block.Body.ExecutionPayload.PrevRandao)
+	})
+	t.Run("override", func(t *testing.T) {
+		conf := DefaultBlockGenConfig()
+		conf.PrevRandao = bytesutil.PadTo([]byte("wrong prev randao"), fieldparams.RootLength)
+		blk, err := GenerateFullBlockBellatrix(st, privs, conf, 1)
+		require.NoError(t, err)
+		require.DeepEqual(t, conf.PrevRandao, blk.Block.Body.ExecutionPayload.PrevRandao)
+
+		// The block is signed by its proposer, so only the payload processing rejects it.
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		slotState, err := transition.ProcessSlots(context.Background(), st.Copy(), blk.Block.Slot)
+		require.NoError(t, err)
+		require.NoError(t, coreBlock.VerifyBlockSignature(slotState, blk.Block.ProposerIndex, blk.Signature, blk.Block.HashTreeRoot))
+		_, err = transition.ExecuteStateTransition(context.Background(), st.Copy(), wsb)
+		require.ErrorContains(t, coreBlock.ErrInvalidPayloadPrevRandao.Error(), err)
+	})
+	t.Run("invalid length", func(t *testing.T) {
+		conf := DefaultBlockGenConfig()
+		conf.PrevRandao = make([]byte, fieldparams.RootLength-1)
+		_, err := GenerateFullBlockBellatrix(st, privs, conf, 1)
+		require.ErrorContains(t, "prev randao must be 32 bytes long, got 31", err)
+	})
+}
+
+func TestWrapSignedBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   interface{}
+		version int
+	}{
+		{name: "phase0", block: NewBeaconBlock(), version: version.Phase0},
+		{name: "altair", block: NewBeaconBlockAltair(), version: version.Altair},
+		{name: "bellatrix", block: NewBeaconBlockBellatrix(), version: version.Bellatrix},
+		{name: "capella", block: NewBeaconBlockCapella(), version: version.Capella},
+		{name: "blinded capella", block: NewBlindedBeaconBlockCapella(), version: version.Capella},
+		{name: "deneb", block: NewBeaconBlockDeneb(), version: version.Deneb},
+		{name: "electra", block: NewBeaconBlockElectra(), version: version.Electra},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wsb, err := WrapSignedBlock(tt.block)
+			require.NoError(t, err)
+			require.Equal(t, tt.version, wsb.Version())
+		})
+	}
+
+	_, err := WrapSignedBlock(NewBeaconBlock().Block)
+	require.ErrorContains(t, "unsupported signed block type *eth.BeaconBlock", err)
+	_, err = WrapSignedBlock(nil)
+	require.ErrorContains(t, "unsupported signed block type <nil>", err)
+}
+
+func TestGenerateFirstBlock(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	ctx := context.Background()
+
+	genesis, privs := DeterministicGenesisState(t, 64)
+	genesisBlock, err := coreBlock.NewGenesisBlockForState(ctx, genesis)
+	require.NoError(t, err)
+	genesisRoot, err := genesisBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	wsb, err := GenerateFirstBlock(genesis, privs, DefaultBlockGenConfig())
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(1), wsb.Block().Slot())
+	require.Equal(t, genesisRoot, wsb.Block().ParentRoot())
+	_, err = transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+	require.NoError(t, err)
+
+	// Requesting the block of the genesis slot bumps it to slot 1, on top of the genesis block.
+	blk, err := GenerateFullBlock(genesis, privs, DefaultBlockGenConfig(), 0)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(1), blk.Block.Slot)
+	require.DeepEqual(t, genesisRoot[:], blk.Block.ParentRoot)
+
+	st, err := transition.ProcessSlots(ctx, genesis.Copy(), 1)
+	require.NoError(t, err)
+	_, err = GenerateFirstBlock(st, privs, DefaultBlockGenConfig())
+	require.ErrorContains(t, "state at slot 1 is not a genesis state", err)
+}
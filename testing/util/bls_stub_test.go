@@ -0,0 +1,54 @@
+//go:build bls_stub
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// TestBLSStub_StateTransitionAcceptsGeneratedBlocks checks that blocks generated with the stubbed
+// BLS implementation pass the state transition with signature verification enabled.
+func TestBLSStub_StateTransitionAcceptsGeneratedBlocks(t *testing.T) {
+	ctx := context.Background()
+	t.Run("phase0", func(t *testing.T) {
+		st, privs := DeterministicGenesisState(t, 64)
+		conf := &BlockGenConfig{NumAttestations: 1}
+		for i := 0; i < 3; i++ {
+			// Slashings are only included in the last block, so that no later proposer is slashed.
+			if i == 2 {
+				conf = &BlockGenConfig{
+					NumAttestations:      1,
+					NumProposerSlashings: 1,
+					NumAttesterSlashings: 1,
+				}
+			}
+			blk, err := GenerateFullBlock(st, privs, conf, st.Slot()+1)
+			require.NoError(t, err)
+			wsb, err := blocks.NewSignedBeaconBlock(blk)
+			require.NoError(t, err)
+			st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+			require.NoError(t, err)
+		}
+	})
+	t.Run("altair", func(t *testing.T) {
+		st, privs := DeterministicGenesisStateAltair(t, 64)
+		committee, err := altair.NextSyncCommittee(ctx, st)
+		require.NoError(t, err)
+		require.NoError(t, st.SetCurrentSyncCommittee(committee))
+		conf := &BlockGenConfig{NumAttestations: 1, FullSyncAggregate: true}
+		for i := 0; i < 3; i++ {
+			blk, err := GenerateFullBlockAltair(st, privs, conf, st.Slot()+1)
+			require.NoError(t, err)
+			wsb, err := blocks.NewSignedBeaconBlock(blk)
+			require.NoError(t, err)
+			st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+			require.NoError(t, err)
+		}
+	})
+}
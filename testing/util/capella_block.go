@@ -1,18 +1,18 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -40,12 +40,16 @@ func GenerateFullBlockCapella(
 	if conf == nil {
 		conf = &BlockGenConfig{}
 	}
+	if conf.DepositRequests {
+		return nil, errors.New("deposit requests are only supported in Electra blocks")
+	}
 
 	var err error
+	slashed := make(map[primitives.ValidatorIndex]bool)
 	var pSlashings []*ethpb.ProposerSlashing
 	numToGen := conf.NumProposerSlashings
 	if numToGen > 0 {
-		pSlashings, err = generateProposerSlashings(bState, privs, numToGen)
+		pSlashings, err = generateProposerSlashings(bState, privs, numToGen, slot, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d proposer slashings:", numToGen)
 		}
@@ -54,7 +58,7 @@ func GenerateFullBlockCapella(
 	numToGen = conf.NumAttesterSlashings
 	var aSlashings []*ethpb.AttesterSlashing
 	if numToGen > 0 {
-		generated, err := generateAttesterSlashings(bState, privs, numToGen)
+		generated, err := generateAttesterSlashings(bState, privs, numToGen, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attester slashings:", numToGen)
 		}
@@ -71,25 +75,17 @@ func GenerateFullBlockCapella(
 	numToGen = conf.NumAttestations
 	var atts []*ethpb.Attestation
 	if numToGen > 0 {
-		generatedAtts, err := GenerateAttestations(bState, privs, numToGen, slot, false)
+		atts, err = generatePhase0Attestations(bState, privs, numToGen, slot)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attestations:", numToGen)
 		}
-		atts = make([]*ethpb.Attestation, len(generatedAtts))
-		var ok bool
-		for i, a := range generatedAtts {
-			atts[i], ok = a.(*ethpb.Attestation)
-			if !ok {
-				return nil, fmt.Errorf("attestation has the wrong type (expected %T, got %T)", &ethpb.Attestation{}, a)
-			}
-		}
 	}
 
 	numToGen = conf.NumDeposits
 	var newDeposits []*ethpb.Deposit
 	eth1Data := bState.Eth1Data()
 	if numToGen > 0 {
-		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen)
+		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen, conf.InvalidDepositSignatures)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d deposits:", numToGen)
 		}
@@ -109,20 +105,22 @@ func GenerateFullBlockCapella(
 	for i := uint64(0); i < numToGen; i++ {
 		newTransactions[i] = bytesutil.Uint64ToBytesLittleEndian(i)
 	}
-	newWithdrawals := make([]*v1.Withdrawal, 0)
 
 	random, err := helpers.RandaoMix(bState, time.CurrentEpoch(bState))
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process randao mix")
 	}
+	prevRandao, err := payloadPrevRandao(conf, random)
+	if err != nil {
+		return nil, err
+	}
 
 	timestamp, err := slots.ToTime(bState.GenesisTime(), slot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get current timestamp")
 	}
 
-	stCopy := bState.Copy()
-	stCopy, err = transition.ProcessSlots(context.Background(), stCopy, slot)
+	stCopy, err := processBlockSlots(context.Background(), bState, conf, slot)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +129,12 @@ func GenerateFullBlockCapella(
 	if err != nil {
 		return nil, err
 	}
+	// The payload must carry the withdrawals expected from the state at the block's slot, even
+	// when it has no transactions.
+	newWithdrawals, _, err := stCopy.ExpectedWithdrawals()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute expected withdrawals")
+	}
 	blockHash := indexToHash(uint64(slot))
 	newExecutionPayloadCapella := &v1.ExecutionPayloadCapella{
 		ParentHash:    parentExecution.BlockHash(),
@@ -138,7 +142,7 @@ func GenerateFullBlockCapella(
 		StateRoot:     params.BeaconConfig().ZeroHash[:],
 		ReceiptsRoot:  params.BeaconConfig().ZeroHash[:],
 		LogsBloom:     make([]byte, 256),
-		PrevRandao:    random,
+		PrevRandao:    prevRandao,
 		BlockNumber:   uint64(slot),
 		ExtraData:     params.BeaconConfig().ZeroHash[:],
 		BaseFeePerGas: params.BeaconConfig().ZeroHash[:],
@@ -147,27 +151,29 @@ func GenerateFullBlockCapella(
 		Transactions:  newTransactions,
 		Withdrawals:   newWithdrawals,
 	}
-	var syncCommitteeBits []byte
-	currSize := new(ethpb.SyncAggregate).SyncCommitteeBits.Len()
-	switch currSize {
-	case 512:
-		syncCommitteeBits = bitfield.NewBitvector512()
-	case 32:
-		syncCommitteeBits = bitfield.NewBitvector32()
-	default:
-		return nil, errors.New("invalid bit vector size")
-	}
-	newSyncAggregate := &ethpb.SyncAggregate{
-		SyncCommitteeBits:      syncCommitteeBits,
-		SyncCommitteeSignature: append([]byte{0xC0}, make([]byte, 95)...),
+	if conf.ExecutionBlockHash {
+		payload, err := blocks.WrappedExecutionPayloadCapella(newExecutionPayloadCapella)
+		if err != nil {
+			return nil, err
+		}
+		h, err := ExecutionBlockHash(payload, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute execution block hash")
+		}
+		newExecutionPayloadCapella.BlockHash = h[:]
 	}
+	newSyncAggregate := emptySyncAggregate()
 
 	newHeader := bState.LatestBlockHeader()
-	prevStateRoot, err := bState.HashTreeRoot(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not hash state")
+	// The header's state root is filled in by the next slot transition, so it is only
+	// still empty if the state has not been advanced past the header's slot.
+	if bytes.Equal(newHeader.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		prevStateRoot, err := bState.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash state")
+		}
+		newHeader.StateRoot = prevStateRoot[:]
 	}
-	newHeader.StateRoot = prevStateRoot[:]
 	parentRoot, err := newHeader.HashTreeRoot()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not hash the new header")
@@ -182,7 +188,7 @@ func GenerateFullBlockCapella(
 		return nil, errors.Wrap(err, "could not compute randao reveal")
 	}
 
-	idx, err := helpers.BeaconProposerIndex(ctx, stCopy)
+	idx, err := proposerIndexAtSlot(ctx, stCopy, stCopy.Slot())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not compute beacon proposer index")
 	}
@@ -214,13 +220,22 @@ func GenerateFullBlockCapella(
 		},
 	}
 
+	// The post-state root is calculated with the randao mix as the payload's prev randao.
+	rootBlock := ethpb.CopyBeaconBlockCapella(block)
+	rootBlock.Body.ExecutionPayload.PrevRandao = random
 	// The fork can change after processing the state
-	signature, err := BlockSignature(bState, block, privs)
+	signature, err := blockSignatureWithPrevRandao(bState, block, rootBlock, privs)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not compute block signature")
 	}
 
-	return &ethpb.SignedBeaconBlockCapella{Block: block, Signature: signature.Marshal()}, nil
+	signed := &ethpb.SignedBeaconBlockCapella{Block: block, Signature: signature.Marshal()}
+	if conf.VerifyGenerated {
+		if err := verifyGeneratedBlock(ctx, bState, signed); err != nil {
+			return nil, err
+		}
+	}
+	return signed, nil
 }
 
 // GenerateBLSToExecutionChange generates a valid bls to exec change for validator `val` and its private key `priv` with the given beacon state `st`.
@@ -1,13 +1,22 @@
 package util
 
 import (
+	"context"
+	"math/bits"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
 	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -33,3 +42,48 @@ func TestGenerateBLSToExecutionChange(t *testing.T) {
 
 	require.NoError(t, signing.VerifySigningRoot(message, fromPubkey, change.Signature, domain))
 }
+
+func TestGenerateFullBlockCapella_WithdrawalsWithoutTransactions(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	st, keys := DeterministicGenesisStateCapella(t, 64)
+	for i := 0; i < 3; i++ {
+		idx := primitives.ValidatorIndex(i)
+		val, err := st.ValidatorAtIndex(idx)
+		require.NoError(t, err)
+		val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+		require.NoError(t, st.UpdateValidatorAtIndex(idx, val))
+		require.NoError(t, st.UpdateBalancesAtIndex(idx, params.BeaconConfig().MaxEffectiveBalance+params.BeaconConfig().MinDepositAmount))
+	}
+
+	conf := DefaultBlockGenConfig()
+	conf.NumTransactions = 0
+	blk, err := GenerateFullBlockCapella(st, keys, conf, 1)
+	require.NoError(t, err)
+	payload := blk.Block.Body.ExecutionPayload
+	require.Equal(t, 0, len(payload.Transactions))
+	require.Equal(t, 3, len(payload.Withdrawals))
+
+	wrapped, err := consensusblocks.WrappedExecutionPayloadCapella(payload)
+	require.NoError(t, err)
+	header, err := consensusblocks.PayloadToHeaderCapella(wrapped)
+	require.NoError(t, err)
+	// The root of an empty list is the zero hash of the list's depth mixed in with a zero length.
+	depth := bits.TrailingZeros64(fieldparams.MaxTxsPerPayloadLength)
+	emptyListRoot := hash.Hash(append(trie.ZeroHashes[depth][:], make([]byte, 32)...))
+	require.DeepEqual(t, emptyListRoot[:], header.TransactionsRoot)
+	withdrawalsRoot, err := ssz.WithdrawalSliceRoot(payload.Withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+	require.NoError(t, err)
+	require.DeepEqual(t, withdrawalsRoot[:], header.WithdrawalsRoot)
+
+	st, err = transition.ProcessSlots(context.Background(), st, blk.Block.Slot)
+	require.NoError(t, err)
+	_, err = blocks.ProcessWithdrawals(st.Copy(), wrapped)
+	require.NoError(t, err)
+	// Payload processing processes the withdrawals as well.
+	st, err = blocks.ProcessPayload(st, wrapped)
+	require.NoError(t, err)
+	balance, err := st.BalanceAtIndex(0)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().MaxEffectiveBalance, balance)
+}
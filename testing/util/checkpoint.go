@@ -0,0 +1,99 @@
+package util
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// maxEpochsToFinalize bounds the chain generated to reach finality, which takes a few epochs
+// with full participation.
+const maxEpochsToFinalize = 8
+
+// FinalizedCheckpoint is the origin data a node needs to be checkpoint synced from a finalized
+// checkpoint, as downloaded from the finalized state and block endpoints of a beacon node.
+type FinalizedCheckpoint struct {
+	// Checkpoint is the finalized checkpoint, whose root is the root of Block.
+	Checkpoint *ethpb.Checkpoint
+	// State is the post-state of Block, which is at the start slot of the checkpoint epoch.
+	State state.BeaconState
+	// StateRoot is the root of State.
+	StateRoot [32]byte
+	// Block is the block at the start slot of the checkpoint epoch.
+	Block *ethpb.SignedBeaconBlock
+	// Blocks are all the blocks generated on top of the given state, in order. The last one is the
+	// head of the chain, whose post-state finalized the checkpoint.
+	Blocks []*ethpb.SignedBeaconBlock
+}
+
+// GenerateFinalizedCheckpoint generates a phase0 chain on top of a copy of the given state, with a
+// block at every slot attested by every committee, until an epoch later than the one of the state
+// is finalized. It returns the finalized checkpoint along with its block and post-state, so that
+// tests can feed a realistic weak subjectivity checkpoint into the sync pipeline. Unlike AdvanceStateEpochs,
+// it doesn't use transition.ProcessSlotsUnsafeFastForward: every slot has a block, so no run of empty slots
+// can be fast forwarded, and the checkpoint state must have the state roots a syncing node verifies.
+func GenerateFinalizedCheckpoint(
+	ctx context.Context,
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+) (*FinalizedCheckpoint, error) {
+	activeCount, err := helpers.ActiveValidatorCount(ctx, bState, slots.ToEpoch(bState.Slot()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator count")
+	}
+	conf := &BlockGenConfig{NumAttestations: helpers.SlotCommitteeCount(activeCount)}
+
+	startEpoch := bState.FinalizedCheckpointEpoch()
+	maxSlot := bState.Slot() + params.BeaconConfig().SlotsPerEpoch.Mul(maxEpochsToFinalize)
+	// The blocks at the start slot of an epoch, and their post-states, are the candidate checkpoints.
+	type candidate struct {
+		blk *ethpb.SignedBeaconBlock
+		st  state.BeaconState
+	}
+	candidates := make(map[[32]byte]candidate)
+	var blks []*ethpb.SignedBeaconBlock
+	st := bState.Copy()
+	for st.FinalizedCheckpointEpoch() == startEpoch {
+		if st.Slot() >= maxSlot {
+			return nil, errors.Errorf("chain was not finalized after %d epochs", maxEpochsToFinalize)
+		}
+		generated, postState, err := GenerateChain(ctx, st, privs, conf, 1)
+		if err != nil {
+			return nil, err
+		}
+		st = postState
+		blk := generated[0]
+		blks = append(blks, blk)
+		if slots.IsEpochStart(blk.Block.Slot) {
+			root, err := blk.Block.HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			candidates[root] = candidate{blk: blk, st: st.Copy()}
+		}
+	}
+
+	cp := st.FinalizedCheckpoint()
+	c, ok := candidates[bytesutil.ToBytes32(cp.Root)]
+	if !ok {
+		return nil, errors.Errorf("finalized checkpoint root %#x is not the root of a generated block at an epoch start", cp.Root)
+	}
+	stateRoot, err := c.st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute checkpoint state root")
+	}
+	return &FinalizedCheckpoint{
+		Checkpoint: cp,
+		State:      c.st,
+		StateRoot:  stateRoot,
+		Block:      c.blk,
+		Blocks:     blks,
+	}, nil
+}
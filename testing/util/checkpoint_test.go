@@ -0,0 +1,52 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGenerateFinalizedCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	genesis, privs := DeterministicGenesisState(t, 64)
+
+	fc, err := GenerateFinalizedCheckpoint(ctx, genesis, privs)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, fc.Checkpoint.Epoch)
+
+	root, err := fc.Block.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.DeepEqual(t, fc.Checkpoint.Root, root[:])
+	startSlot, err := slots.EpochStart(fc.Checkpoint.Epoch)
+	require.NoError(t, err)
+	require.Equal(t, startSlot, fc.Block.Block.Slot)
+	require.Equal(t, startSlot, fc.State.Slot())
+
+	// The state is the post-state of the block, as served for checkpoint sync.
+	require.DeepEqual(t, fc.StateRoot[:], fc.Block.Block.StateRoot)
+	bodyRoot, err := fc.Block.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.DeepEqual(t, bodyRoot[:], fc.State.LatestBlockHeader().BodyRoot)
+
+	// Replaying the chain from the checkpoint state reaches the head that finalized it.
+	st := fc.State.Copy()
+	replayed := 0
+	for _, blk := range fc.Blocks {
+		if blk.Block.Slot <= startSlot {
+			continue
+		}
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		replayed++
+	}
+	require.NotEqual(t, 0, replayed)
+	require.DeepEqual(t, fc.Checkpoint, st.FinalizedCheckpoint())
+}
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// ChurnBoundaryActiveValidatorCount returns the smallest active validator count for which the
+// validator churn limit is churnLimit. One validator less results in a churn limit of churnLimit-1.
+func ChurnBoundaryActiveValidatorCount(churnLimit uint64) (uint64, error) {
+	cfg := params.BeaconConfig()
+	if churnLimit <= cfg.MinPerEpochChurnLimit {
+		return 0, fmt.Errorf("churn limit %d is not above MIN_PER_EPOCH_CHURN_LIMIT %d", churnLimit, cfg.MinPerEpochChurnLimit)
+	}
+	return churnLimit * cfg.ChurnLimitQuotient, nil
+}
+
+// BalanceChurnBoundaryActiveBalance returns the smallest total active balance for which the Electra
+// balance churn limit is churn. Any lower balance results in a churn limit one increment lower.
+func BalanceChurnBoundaryActiveBalance(churn primitives.Gwei) (primitives.Gwei, error) {
+	cfg := params.BeaconConfig()
+	if uint64(churn)%cfg.EffectiveBalanceIncrement != 0 {
+		return 0, fmt.Errorf("churn %d is not a multiple of EFFECTIVE_BALANCE_INCREMENT", churn)
+	}
+	if uint64(churn) <= cfg.MinPerEpochChurnLimitElectra {
+		return 0, fmt.Errorf("churn %d is not above MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA %d", churn, cfg.MinPerEpochChurnLimitElectra)
+	}
+	return churn * primitives.Gwei(cfg.ChurnLimitQuotient), nil
+}
+
+// NewBeaconStateWithActiveValidators returns a phase 0 state at genesis with exactly numActive active
+// validators and numPending validators that are eligible for, but not yet scheduled for, activation.
+// The validators have distinct, not signable public keys, which is enough to exercise the churn limits.
+func NewBeaconStateWithActiveValidators(numActive, numPending uint64) (state.BeaconState, error) {
+	vals, bals := churnValidators(numActive, numPending, params.BeaconConfig().MaxEffectiveBalance)
+	return NewBeaconState(func(st *ethpb.BeaconState) error {
+		st.Validators = vals
+		st.Balances = bals
+		return nil
+	})
+}
+
+// NewBeaconStateElectraWithActiveValidators works like NewBeaconStateWithActiveValidators for an
+// Electra state, with every validator having the given effective balance so that the total active
+// balance, and therefore the balance churn, can be set precisely.
+func NewBeaconStateElectraWithActiveValidators(numActive, numPending, effectiveBalance uint64) (state.BeaconState, error) {
+	if effectiveBalance%params.BeaconConfig().EffectiveBalanceIncrement != 0 {
+		return nil, fmt.Errorf("effective balance %d is not a multiple of EFFECTIVE_BALANCE_INCREMENT", effectiveBalance)
+	}
+	vals, bals := churnValidators(numActive, numPending, effectiveBalance)
+	return NewBeaconStateElectra(func(st *ethpb.BeaconStateElectra) error {
+		st.Validators = vals
+		st.Balances = bals
+		st.PreviousEpochParticipation = make([]byte, len(vals))
+		st.CurrentEpochParticipation = make([]byte, len(vals))
+		st.InactivityScores = make([]uint64, len(vals))
+		return nil
+	})
+}
+
+func churnValidators(numActive, numPending, effectiveBalance uint64) ([]*ethpb.Validator, []uint64) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	total := numActive + numPending
+	vals := make([]*ethpb.Validator, total)
+	bals := make([]uint64, total)
+	for i := uint64(0); i < total; i++ {
+		pubkey := make([]byte, fieldparams.BLSPubkeyLength)
+		binary.LittleEndian.PutUint64(pubkey, i)
+		vals[i] = &ethpb.Validator{
+			PublicKey:                  pubkey,
+			WithdrawalCredentials:      make([]byte, fieldparams.RootLength),
+			EffectiveBalance:           effectiveBalance,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  farFuture,
+			WithdrawableEpoch:          farFuture,
+		}
+		if i >= numActive {
+			vals[i].ActivationEpoch = farFuture
+		}
+		bals[i] = effectiveBalance
+	}
+	return vals, bals
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestNewBeaconStateWithActiveValidators_ChurnBoundary(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ChurnLimitQuotient = 8
+	params.OverrideBeaconConfig(cfg)
+
+	boundary, err := ChurnBoundaryActiveValidatorCount(cfg.MinPerEpochChurnLimit + 1)
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		numActive uint64
+		churn     uint64
+	}{
+		{numActive: boundary - 1, churn: cfg.MinPerEpochChurnLimit},
+		{numActive: boundary, churn: cfg.MinPerEpochChurnLimit + 1},
+	} {
+		helpers.ClearCache()
+		st, err := NewBeaconStateWithActiveValidators(tt.numActive, 3)
+		require.NoError(t, err)
+		require.Equal(t, int(tt.numActive+3), st.NumValidators())
+		count, err := helpers.ActiveValidatorCount(context.Background(), st, 0)
+		require.NoError(t, err)
+		require.Equal(t, tt.numActive, count)
+		require.Equal(t, tt.churn, helpers.ValidatorActivationChurnLimit(count))
+	}
+
+	_, err = ChurnBoundaryActiveValidatorCount(cfg.MinPerEpochChurnLimit)
+	require.ErrorContains(t, "is not above MIN_PER_EPOCH_CHURN_LIMIT", err)
+}
+
+func TestNewBeaconStateElectraWithActiveValidators_BalanceChurnBoundary(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ChurnLimitQuotient = 8
+	params.OverrideBeaconConfig(cfg)
+
+	increment := cfg.EffectiveBalanceIncrement
+	floor := primitives.Gwei(cfg.MinPerEpochChurnLimitElectra)
+	boundary, err := BalanceChurnBoundaryActiveBalance(floor + primitives.Gwei(increment))
+	require.NoError(t, err)
+	numActive := uint64(boundary) / increment
+	for _, tt := range []struct {
+		numActive uint64
+		churn     primitives.Gwei
+	}{
+		{numActive: numActive - 1, churn: floor},
+		{numActive: numActive, churn: floor + primitives.Gwei(increment)},
+	} {
+		helpers.ClearCache()
+		st, err := NewBeaconStateElectraWithActiveValidators(tt.numActive, 2, increment)
+		require.NoError(t, err)
+		churn, err := helpers.BalanceChurnLimitFromState(st)
+		require.NoError(t, err)
+		require.Equal(t, tt.churn, churn)
+	}
+
+	_, err = BalanceChurnBoundaryActiveBalance(floor + 1)
+	require.ErrorContains(t, "is not a multiple of EFFECTIVE_BALANCE_INCREMENT", err)
+	_, err = NewBeaconStateElectraWithActiveValidators(1, 0, increment+1)
+	require.ErrorContains(t, "is not a multiple of EFFECTIVE_BALANCE_INCREMENT", err)
+}
@@ -153,6 +153,38 @@ func GenerateTestDenebBlockWithSidecar(t *testing.T, parent [32]byte, slot primi
 	return rob, sidecars
 }
 
+// GenerateTestDenebBlockContents generates a Deneb block with nblobs blob sidecars, like
+// GenerateTestDenebBlockWithSidecar, and returns them bundled as the block contents of the publish block API.
+func GenerateTestDenebBlockContents(t *testing.T, parent [32]byte, slot primitives.Slot, nblobs int, opts ...DenebBlockGeneratorOption) *ethpb.SignedBeaconBlockContentsDeneb {
+	blk, sidecars := GenerateTestDenebBlockWithSidecar(t, parent, slot, nblobs, opts...)
+	return DenebBlockContents(t, blk, sidecars)
+}
+
+// DenebBlockContents assembles the block contents of the publish block API from a Deneb block and
+// its blob sidecars. The sidecars must belong to the block and be ordered by index.
+func DenebBlockContents(t *testing.T, blk blocks.ROBlock, sidecars []blocks.ROBlob) *ethpb.SignedBeaconBlockContentsDeneb {
+	m, err := blk.Proto()
+	require.NoError(t, err)
+	pb, ok := m.(*ethpb.SignedBeaconBlockDeneb)
+	require.Equal(t, true, ok, "not a Deneb block")
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	require.NoError(t, err)
+	require.Equal(t, len(commitments), len(sidecars))
+	proofs := make([][]byte, len(sidecars))
+	blobs := make([][]byte, len(sidecars))
+	for i, sc := range sidecars {
+		require.Equal(t, blk.Root(), sc.BlockRoot())
+		require.Equal(t, uint64(i), sc.Index)
+		proofs[i] = bytesutil.SafeCopyBytes(sc.KzgProof)
+		blobs[i] = bytesutil.SafeCopyBytes(sc.Blob)
+	}
+	return &ethpb.SignedBeaconBlockContentsDeneb{
+		Block:     pb,
+		KzgProofs: proofs,
+		Blobs:     blobs,
+	}
+}
+
 func GenerateTestDenebBlobSidecar(t *testing.T, root [32]byte, header *ethpb.SignedBeaconBlockHeader, index int, commitment []byte, incProof [][]byte) blocks.ROBlob {
 	blob := make([]byte, fieldparams.BlobSize)
 	binary.LittleEndian.PutUint64(blob, uint64(index))
@@ -183,14 +215,14 @@ func fakeEmptyProof(_ *testing.T, _ *ethpb.BlobSidecar) [][]byte {
 func ExtendBlocksPlusBlobs(t *testing.T, blks []blocks.ROBlock, size int) ([]blocks.ROBlock, []blocks.ROBlob) {
 	blobs := make([]blocks.ROBlob, 0)
 	if len(blks) == 0 {
-		blk, blb := GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 0, 6)
+		blk, blb := GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 0, fieldparams.MaxBlobsPerBlock)
 		blobs = append(blobs, blb...)
 		blks = append(blks, blk)
 	}
 
 	for i := 0; i < size; i++ {
 		prev := blks[len(blks)-1]
-		blk, blb := GenerateTestDenebBlockWithSidecar(t, prev.Root(), prev.Block().Slot()+1, 6)
+		blk, blb := GenerateTestDenebBlockWithSidecar(t, prev.Root(), prev.Block().Slot()+1, fieldparams.MaxBlobsPerBlock)
 		blobs = append(blobs, blb...)
 		blks = append(blks, blk)
 	}
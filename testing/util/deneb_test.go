@@ -1,10 +1,14 @@
 package util
 
 import (
+	"crypto/sha256"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 )
 
@@ -14,3 +18,53 @@ func TestInclusionProofs(t *testing.T) {
 		require.NoError(t, blocks.VerifyKZGInclusionProof(blobs[i]))
 	}
 }
+
+func TestGenerateBlobs(t *testing.T) {
+	g, err := GenerateBlobs(3, 42)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(g.Blobs))
+	require.Equal(t, 3, len(g.Commitments))
+	require.Equal(t, 3, len(g.Proofs))
+	require.Equal(t, 3, len(g.VersionedHashes))
+	for i, c := range g.Commitments {
+		require.Equal(t, fieldparams.BlobLength, len(g.Blobs[i]))
+		h := sha256.Sum256(c)
+		h[0] = 0x01
+		require.DeepEqual(t, h[:], g.VersionedHashes[i].Bytes())
+	}
+	require.NotEqual(t, g.VersionedHashes[0], g.VersionedHashes[1])
+
+	again, err := GenerateBlobs(3, 42)
+	require.NoError(t, err)
+	require.DeepEqual(t, g, again)
+}
+
+func TestDenebBlockContents(t *testing.T) {
+	blk, sidecars := GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 3)
+	contents := DenebBlockContents(t, blk, sidecars)
+	root, err := contents.Block.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, blk.Root(), root)
+	require.Equal(t, 3, len(contents.Blobs))
+	require.Equal(t, 3, len(contents.KzgProofs))
+	for i, sc := range sidecars {
+		require.DeepEqual(t, sc.Blob, contents.Blobs[i])
+		require.DeepEqual(t, sc.KzgProof, contents.KzgProofs[i])
+	}
+
+	// The contents survive the conversion to and from the beacon API representation.
+	apiContents, err := structs.SignedBeaconBlockContentsDenebFromConsensus(contents)
+	require.NoError(t, err)
+	generic, err := apiContents.ToGeneric()
+	require.NoError(t, err)
+	require.DeepEqual(t, contents, generic.GetDeneb())
+}
+
+func TestGenerateTestDenebBlockContents(t *testing.T) {
+	contents := GenerateTestDenebBlockContents(t, [32]byte{'a'}, 2, 0)
+	require.Equal(t, primitives.Slot(2), contents.Block.Block.Slot)
+	require.DeepEqual(t, bytesutil.PadTo([]byte{'a'}, 32), contents.Block.Block.ParentRoot)
+	require.Equal(t, 0, len(contents.Block.Block.Body.BlobKzgCommitments))
+	require.Equal(t, 0, len(contents.Blobs))
+	require.Equal(t, 0, len(contents.KzgProofs))
+}
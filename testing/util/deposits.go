@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"sync"
 	"testing"
@@ -176,16 +177,7 @@ func signedDeposit(
 		WithdrawalCredentials: withdrawalCreds[:],
 	}
 
-	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainDeposit, nil, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not compute domain")
-	}
-	root, err := depositMessage.HashTreeRoot()
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get signing root of deposit data")
-	}
-
-	sigRoot, err := (&ethpb.SigningData{ObjectRoot: root[:], Domain: domain}).HashTreeRoot()
+	sig, err := DepositSignature(depositMessage, secretKey)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +185,7 @@ func signedDeposit(
 		PublicKey:             publicKey,
 		Amount:                balance,
 		WithdrawalCredentials: withdrawalCreds[:],
-		Signature:             secretKey.Sign(sigRoot[:]).Marshal(),
+		Signature:             sig,
 	}
 
 	deposit := &ethpb.Deposit{
@@ -223,17 +215,7 @@ func DepositTrieSubset(sparseTrie *trie.SparseMerkleTrie, size int) (*trie.Spars
 		return nil, [][32]byte{}, errors.New("requested a larger tree than amount of deposits")
 	}
 
-	items = items[:size]
-	depositTrie, err := trie.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		return nil, [][32]byte{}, errors.Wrapf(err, "could not generate trie of %d length", size)
-	}
-
-	roots := make([][32]byte, len(items))
-	for i, dep := range items {
-		roots[i] = bytesutil.ToBytes32(dep)
-	}
-	return depositTrie, roots, nil
+	return depositTrieFromItems(items[:size])
 }
 
 // DeterministicEth1Data takes an array of deposits and returns the eth1Data made from the deposit trie.
@@ -275,21 +257,50 @@ func DeterministicGenesisState(t testing.TB, numValidators uint64) (state.Beacon
 // DepositTrieFromDeposits takes an array of deposits and returns the deposit trie.
 func DepositTrieFromDeposits(deposits []*ethpb.Deposit) (*trie.SparseMerkleTrie, [][32]byte, error) {
 	encodedDeposits := make([][]byte, len(deposits))
-	roots := make([][32]byte, len(deposits))
 	for i := 0; i < len(encodedDeposits); i++ {
 		hashedDeposit, err := deposits[i].Data.HashTreeRoot()
 		if err != nil {
 			return nil, [][32]byte{}, errors.Wrap(err, "could not tree hash deposit data")
 		}
 		encodedDeposits[i] = hashedDeposit[:]
-		roots[i] = hashedDeposit
 	}
 
-	depositTrie, err := trie.GenerateTrieFromItems(encodedDeposits, params.BeaconConfig().DepositContractTreeDepth)
+	return depositTrieFromItems(encodedDeposits)
+}
+
+// depositTrieFromItems returns the deposit trie of the given deposit data roots, along with the roots.
+func depositTrieFromItems(items [][]byte) (*trie.SparseMerkleTrie, [][32]byte, error) {
+	depositTrie, err := trie.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
 	if err != nil {
-		return nil, [][32]byte{}, errors.Wrap(err, "Could not generate deposit trie")
+		return nil, nil, errors.Wrapf(err, "could not generate deposit trie of %d length", len(items))
+	}
+
+	roots := make([][32]byte, len(items))
+	for i, dep := range items {
+		roots[i] = bytesutil.ToBytes32(dep)
 	}
+	return depositTrie, roots, nil
+}
 
+// DepositTrieFromState returns the deposit trie of the deterministic deposits included in the state,
+// i.e. the first eth1 deposit count deposits, along with their deposit data roots. It fails if the eth1
+// deposit root of the state was not made from the deterministic deposits.
+func DepositTrieFromState(st state.ReadOnlyBeaconState) (*trie.SparseMerkleTrie, [][32]byte, error) {
+	eth1Data := st.Eth1Data()
+	if eth1Data == nil {
+		return nil, nil, errors.New("state has no eth1 data")
+	}
+	depositTrie, roots, err := DeterministicDepositTrie(int(eth1Data.DepositCount)) // lint:ignore uintcast -- test code
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := depositTrie.HashTreeRoot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to compute deposit trie root")
+	}
+	if !bytes.Equal(root[:], eth1Data.DepositRoot) {
+		return nil, nil, errors.Errorf("deposit root %#x of the state does not match the root %#x of its %d deterministic deposits", eth1Data.DepositRoot, root, eth1Data.DepositCount)
+	}
 	return depositTrie, roots, nil
 }
 
@@ -8,6 +8,8 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"google.golang.org/protobuf/proto"
 )
@@ -284,3 +286,28 @@ func TestDepositTrieFromDeposits(t *testing.T) {
 		t.Fatal("expected deposit trie root to equal eth1data deposit root")
 	}
 }
+
+func TestDepositTrieFromState(t *testing.T) {
+	beaconState, _ := DeterministicGenesisState(t, 16)
+
+	depositTrie, roots, err := DepositTrieFromState(beaconState)
+	require.NoError(t, err)
+	require.Equal(t, 16, len(roots))
+	root, err := depositTrie.HashTreeRoot()
+	require.NoError(t, err)
+	require.DeepEqual(t, beaconState.Eth1Data().DepositRoot, root[:])
+	for i, r := range roots {
+		proof, err := depositTrie.MerkleProof(i)
+		require.NoError(t, err)
+		require.Equal(t, true, trie.VerifyMerkleProof(root[:], r[:], uint64(i), proof))
+	}
+
+	t.Run("mismatched deposit root", func(t *testing.T) {
+		st := beaconState.Copy()
+		eth1Data := proto.Clone(st.Eth1Data()).(*ethpb.Eth1Data)
+		eth1Data.DepositRoot = make([]byte, 32)
+		require.NoError(t, st.SetEth1Data(eth1Data))
+		_, _, err := DepositTrieFromState(st)
+		require.ErrorContains(t, "does not match the root", err)
+	})
+}
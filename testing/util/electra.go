@@ -1,14 +1,21 @@
 package util
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
+	"fmt"
 	"math"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -17,6 +24,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/network/forks"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
@@ -198,3 +207,259 @@ func generateTestWithdrawalRequests(offset, n uint64) []*enginev1.WithdrawalRequ
 	}
 	return r
 }
+
+// WithdrawalRequestOption configures GenerateWithdrawalRequests.
+type WithdrawalRequestOption func(*withdrawalRequestGenerator)
+
+type withdrawalRequestGenerator struct {
+	wrongSource bool
+}
+
+// WithMismatchedSourceAddress makes GenerateWithdrawalRequests use a source address that differs from the
+// validator's withdrawal credentials, producing requests that must be rejected.
+func WithMismatchedSourceAddress() WithdrawalRequestOption {
+	return func(g *withdrawalRequestGenerator) {
+		g.wrongSource = true
+	}
+}
+
+// GenerateWithdrawalRequests returns execution layer withdrawal requests for the given validators of the state.
+// An amount of zero requests a full exit, any other amount a partial withdrawal. The source address is taken
+// from the validator's withdrawal credentials, which must therefore be execution withdrawal credentials.
+func GenerateWithdrawalRequests(
+	st state.ReadOnlyBeaconState,
+	indices []primitives.ValidatorIndex,
+	amount uint64,
+	opts ...WithdrawalRequestOption,
+) ([]*enginev1.WithdrawalRequest, error) {
+	g := &withdrawalRequestGenerator{}
+	for _, o := range opts {
+		o(g)
+	}
+	r := make([]*enginev1.WithdrawalRequest, len(indices))
+	for i, idx := range indices {
+		val, err := st.ValidatorAtIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		if !helpers.HasExecutionWithdrawalCredentials(val) {
+			return nil, fmt.Errorf("validator %d does not have execution withdrawal credentials", idx)
+		}
+		source := bytesutil.SafeCopyBytes(val.WithdrawalCredentials[12:])
+		if g.wrongSource {
+			for j := range source {
+				source[j] ^= 0xff
+			}
+		}
+		r[i] = &enginev1.WithdrawalRequest{
+			SourceAddress:   source,
+			ValidatorPubkey: bytesutil.SafeCopyBytes(val.PublicKey),
+			Amount:          amount,
+		}
+	}
+	return r, nil
+}
+
+// GenerateVoluntaryExitWithPendingConsolidation seeds a pending consolidation of the validator at idx into the
+// validator at target and returns an otherwise valid signed exit of the validator at idx. As when a consolidation
+// request is processed, the exit of the source validator is initiated at the earliest consolidation epoch, so
+// ProcessVoluntaryExits rejects the exit. The consolidation churn of the state is left untouched.
+func GenerateVoluntaryExitWithPendingConsolidation(
+	st state.BeaconState,
+	k bls.SecretKey,
+	idx, target primitives.ValidatorIndex,
+) (*ethpb.SignedVoluntaryExit, error) {
+	if st.Version() < version.Electra {
+		return nil, fmt.Errorf("pending consolidations are not supported for %s states", version.String(st.Version()))
+	}
+	if idx == target {
+		return nil, fmt.Errorf("validator %d cannot consolidate into itself", idx)
+	}
+	exit, err := GenerateVoluntaryExits(st, k, idx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate voluntary exit")
+	}
+	source, err := st.ValidatorAtIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	earliestEpoch, err := st.EarliestConsolidationEpoch()
+	if err != nil {
+		return nil, err
+	}
+	source.ExitEpoch = max(earliestEpoch, helpers.ActivationExitEpoch(slots.ToEpoch(st.Slot())))
+	source.WithdrawableEpoch = source.ExitEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	if err := st.UpdateValidatorAtIndex(idx, source); err != nil {
+		return nil, err
+	}
+	if err := st.AppendPendingConsolidation(&ethpb.PendingConsolidation{SourceIndex: idx, TargetIndex: target}); err != nil {
+		return nil, errors.Wrap(err, "could not append pending consolidation")
+	}
+	return exit, nil
+}
+
+// GenerateConsolidation returns a consolidation of the source validator into the target validator, valid from the
+// current epoch of the state and signed by both validators. An error describes why the validators can't consolidate
+// when they don't both have execution withdrawal credentials to the same address.
+func GenerateConsolidation(
+	st state.ReadOnlyBeaconState,
+	privs []bls.SecretKey,
+	source, target primitives.ValidatorIndex,
+) (*ethpb.SignedConsolidation, error) {
+	if source == target {
+		return nil, fmt.Errorf("validator %d cannot consolidate into itself", source)
+	}
+	if uint64(source) >= uint64(len(privs)) || uint64(target) >= uint64(len(privs)) {
+		return nil, fmt.Errorf("no private keys for validators %d and %d", source, target)
+	}
+	sourceVal, err := st.ValidatorAtIndex(source)
+	if err != nil {
+		return nil, err
+	}
+	targetVal, err := st.ValidatorAtIndex(target)
+	if err != nil {
+		return nil, err
+	}
+	if !helpers.HasExecutionWithdrawalCredentials(sourceVal) {
+		return nil, fmt.Errorf("source validator %d does not have execution withdrawal credentials", source)
+	}
+	if !helpers.HasExecutionWithdrawalCredentials(targetVal) {
+		return nil, fmt.Errorf("target validator %d does not have execution withdrawal credentials", target)
+	}
+	if !helpers.IsSameWithdrawalCredentials(sourceVal, targetVal) {
+		return nil, fmt.Errorf(
+			"source validator %d withdraws to %#x, but target validator %d withdraws to %#x",
+			source,
+			sourceVal.WithdrawalCredentials[12:],
+			target,
+			targetVal.WithdrawalCredentials[12:],
+		)
+	}
+
+	c := &ethpb.Consolidation{
+		SourceIndex: source,
+		TargetIndex: target,
+		Epoch:       slots.ToEpoch(st.Slot()),
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainConsolidation, nil, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	sr, err := signing.ComputeSigningRoot(c, domain)
+	if err != nil {
+		return nil, err
+	}
+	sig := bls.AggregateSignatures([]bls.Signature{privs[source].Sign(sr[:]), privs[target].Sign(sr[:])})
+	return &ethpb.SignedConsolidation{Message: c, Signature: sig.Marshal()}, nil
+}
+
+// DeterministicConsolidationStateAndBlock returns an Electra genesis state with the given number of validators,
+// where the source and target validators have compounding withdrawal credentials to the same address, along with
+// the keys of the validators and a block for the next slot that consolidates the source into the target. Applying
+// the block initiates the exit of the source and appends a pending consolidation to the state.
+//
+// Consolidations are only processed when the consolidation churn limit exceeds the minimum activation balance.
+// With the mainnet config, this takes a total active balance of millions of ETH, so tests usually lower the
+// MaxPerEpochActivationExitChurnLimit of the config instead.
+func DeterministicConsolidationStateAndBlock(
+	t testing.TB,
+	numValidators uint64,
+	source, target primitives.ValidatorIndex,
+) (state.BeaconState, *ethpb.SignedBeaconBlockElectra, []bls.SecretKey, error) {
+	st, privs := DeterministicGenesisStateElectra(t, numValidators)
+	address := bytes.Repeat([]byte{0xc0}, common.AddressLength)
+	for _, idx := range []primitives.ValidatorIndex{source, target} {
+		val, err := st.ValidatorAtIndex(idx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		val.WithdrawalCredentials = append([]byte{params.BeaconConfig().CompoundingWithdrawalPrefixByte}, make([]byte, 11)...)
+		val.WithdrawalCredentials = append(val.WithdrawalCredentials, address...)
+		if err := st.UpdateValidatorAtIndex(idx, val); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	churn, err := helpers.ConsolidationChurnLimitFromState(st)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if uint64(churn) <= params.BeaconConfig().MinActivationBalance {
+		return nil, nil, nil, fmt.Errorf(
+			"consolidation churn limit %d of %d validators does not exceed the minimum activation balance",
+			churn,
+			numValidators,
+		)
+	}
+
+	c, err := GenerateConsolidation(st, privs, source, target)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate consolidation")
+	}
+	blk, err := GenerateFullBlockElectra(st, privs, &BlockGenConfig{}, st.Slot()+1)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate block")
+	}
+	blk.Block.Body.Consolidations = []*ethpb.SignedConsolidation{c}
+	// The block is signed again, as its state root and signature cover the consolidation.
+	sig, err := BlockSignature(st, blk.Block, privs)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not compute block signature")
+	}
+	blk.Signature = sig.Marshal()
+	return st, blk, privs, nil
+}
+
+// DeterministicCompoundingRewardsStateAndBlock returns an Electra state at the last slot of epoch 1 where the given
+// validators have compounding withdrawal credentials and the given balance as their balance and effective balance,
+// along with the keys of the validators and a block for the next slot. Every validator took part in both epochs of
+// the state with all participation flags set, so applying the block processes the end of epoch 1 and rewards the
+// validators in proportion to their effective balances, which may exceed the minimum activation balance up to the
+// Electra max effective balance. The state is advanced with transition.ProcessSlotsUnsafeFastForward, so the state
+// roots recorded for its empty slots do not match the spec.
+func DeterministicCompoundingRewardsStateAndBlock(
+	t testing.TB,
+	numValidators uint64,
+	compounding []primitives.ValidatorIndex,
+	balance uint64,
+) (state.BeaconState, *ethpb.SignedBeaconBlockElectra, []bls.SecretKey, error) {
+	cfg := params.BeaconConfig()
+	if balance > cfg.MaxEffectiveBalanceElectra {
+		return nil, nil, nil, fmt.Errorf("balance %d exceeds the max effective balance %d", balance, cfg.MaxEffectiveBalanceElectra)
+	}
+	if balance%cfg.EffectiveBalanceIncrement != 0 {
+		return nil, nil, nil, fmt.Errorf("balance %d is not a multiple of the effective balance increment %d", balance, cfg.EffectiveBalanceIncrement)
+	}
+	st, privs := DeterministicGenesisStateElectra(t, numValidators)
+	for _, idx := range compounding {
+		val, err := st.ValidatorAtIndex(idx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		val.WithdrawalCredentials = append([]byte{cfg.CompoundingWithdrawalPrefixByte}, val.WithdrawalCredentials[1:]...)
+		val.EffectiveBalance = balance
+		if err := st.UpdateValidatorAtIndex(idx, val); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := st.UpdateBalancesAtIndex(idx, balance); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// Rewards are not applied at the end of the genesis epoch, so the block is generated for the first slot of epoch 2.
+	st, err := transition.ProcessSlotsUnsafeFastForward(context.Background(), st, cfg.SlotsPerEpoch*2-1)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not process slots")
+	}
+	participation := bytes.Repeat([]byte{1<<cfg.TimelySourceFlagIndex | 1<<cfg.TimelyTargetFlagIndex | 1<<cfg.TimelyHeadFlagIndex}, int(numValidators))
+	if err := st.SetPreviousParticipationBits(participation); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := st.SetCurrentParticipationBits(bytes.Clone(participation)); err != nil {
+		return nil, nil, nil, err
+	}
+	blk, err := GenerateFullBlockElectra(st, privs, &BlockGenConfig{}, st.Slot()+1)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate block")
+	}
+	return st, blk, privs, nil
+}
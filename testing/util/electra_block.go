@@ -1,14 +1,13 @@
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -41,10 +40,11 @@ func GenerateFullBlockElectra(
 	}
 
 	var err error
+	slashed := make(map[primitives.ValidatorIndex]bool)
 	var pSlashings []*ethpb.ProposerSlashing
 	numToGen := conf.NumProposerSlashings
 	if numToGen > 0 {
-		pSlashings, err = generateProposerSlashings(bState, privs, numToGen)
+		pSlashings, err = generateProposerSlashings(bState, privs, numToGen, slot, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d proposer slashings:", numToGen)
 		}
@@ -53,7 +53,7 @@ func GenerateFullBlockElectra(
 	numToGen = conf.NumAttesterSlashings
 	var aSlashings []*ethpb.AttesterSlashingElectra
 	if numToGen > 0 {
-		generated, err := generateAttesterSlashings(bState, privs, numToGen)
+		generated, err := generateAttesterSlashings(bState, privs, numToGen, slashed)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attester slashings:", numToGen)
 		}
@@ -70,25 +70,23 @@ func GenerateFullBlockElectra(
 	numToGen = conf.NumAttestations
 	var atts []*ethpb.AttestationElectra
 	if numToGen > 0 {
-		generatedAtts, err := GenerateAttestations(bState, privs, numToGen, slot, false)
+		atts, err = generateElectraAttestations(bState, privs, numToGen, slot)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d attestations:", numToGen)
 		}
-		atts = make([]*ethpb.AttestationElectra, len(generatedAtts))
-		var ok bool
-		for i, a := range generatedAtts {
-			atts[i], ok = a.(*ethpb.AttestationElectra)
-			if !ok {
-				return nil, fmt.Errorf("attestation has the wrong type (expected %T, got %T)", &ethpb.AttestationElectra{}, a)
-			}
-		}
 	}
 
 	numToGen = conf.NumDeposits
 	var newDeposits []*ethpb.Deposit
+	var depositRequests []*v1.DepositRequest
 	eth1Data := bState.Eth1Data()
-	if numToGen > 0 {
-		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen)
+	if numToGen > 0 && conf.DepositRequests {
+		depositRequests, err = generateDepositRequests(bState, numToGen, conf.InvalidDepositSignatures)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed generating %d deposit requests:", numToGen)
+		}
+	} else if numToGen > 0 {
+		newDeposits, eth1Data, err = generateDepositsAndEth1Data(bState, numToGen, conf.InvalidDepositSignatures)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed generating %d deposits:", numToGen)
 		}
@@ -114,14 +112,17 @@ func GenerateFullBlockElectra(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process randao mix")
 	}
+	prevRandao, err := payloadPrevRandao(conf, random)
+	if err != nil {
+		return nil, err
+	}
 
 	timestamp, err := slots.ToTime(bState.GenesisTime(), slot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get current timestamp")
 	}
 
-	stCopy := bState.Copy()
-	stCopy, err = transition.ProcessSlots(context.Background(), stCopy, slot)
+	stCopy, err := processBlockSlots(context.Background(), bState, conf, slot)
 	if err != nil {
 		return nil, err
 	}
@@ -132,45 +133,39 @@ func GenerateFullBlockElectra(
 	}
 	blockHash := indexToHash(uint64(slot))
 	newExecutionPayloadCapella := &v1.ExecutionPayloadElectra{
-		ParentHash:    parentExecution.BlockHash(),
-		FeeRecipient:  make([]byte, 20),
-		StateRoot:     params.BeaconConfig().ZeroHash[:],
-		ReceiptsRoot:  params.BeaconConfig().ZeroHash[:],
-		LogsBloom:     make([]byte, 256),
-		PrevRandao:    random,
-		BlockNumber:   uint64(slot),
-		ExtraData:     params.BeaconConfig().ZeroHash[:],
-		BaseFeePerGas: params.BeaconConfig().ZeroHash[:],
-		BlockHash:     blockHash[:],
-		Timestamp:     uint64(timestamp.Unix()),
-		Transactions:  newTransactions,
-		Withdrawals:   newWithdrawals,
-	}
-	var syncCommitteeBits []byte
-	currSize := new(ethpb.SyncAggregate).SyncCommitteeBits.Len()
-	switch currSize {
-	case 512:
-		syncCommitteeBits = bitfield.NewBitvector512()
-	case 32:
-		syncCommitteeBits = bitfield.NewBitvector32()
-	default:
-		return nil, errors.New("invalid bit vector size")
-	}
-	newSyncAggregate := &ethpb.SyncAggregate{
-		SyncCommitteeBits:      syncCommitteeBits,
-		SyncCommitteeSignature: append([]byte{0xC0}, make([]byte, 95)...),
+		ParentHash:      parentExecution.BlockHash(),
+		FeeRecipient:    make([]byte, 20),
+		StateRoot:       params.BeaconConfig().ZeroHash[:],
+		ReceiptsRoot:    params.BeaconConfig().ZeroHash[:],
+		LogsBloom:       make([]byte, 256),
+		PrevRandao:      prevRandao,
+		BlockNumber:     uint64(slot),
+		ExtraData:       params.BeaconConfig().ZeroHash[:],
+		BaseFeePerGas:   params.BeaconConfig().ZeroHash[:],
+		BlockHash:       blockHash[:],
+		Timestamp:       uint64(timestamp.Unix()),
+		Transactions:    newTransactions,
+		Withdrawals:     newWithdrawals,
+		DepositRequests: depositRequests,
 	}
-
 	newHeader := bState.LatestBlockHeader()
-	prevStateRoot, err := bState.HashTreeRoot(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not hash state")
+	// The header's state root is filled in by the next slot transition, so it is only
+	// still empty if the state has not been advanced past the header's slot.
+	if bytes.Equal(newHeader.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		prevStateRoot, err := bState.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash state")
+		}
+		newHeader.StateRoot = prevStateRoot[:]
 	}
-	newHeader.StateRoot = prevStateRoot[:]
 	parentRoot, err := newHeader.HashTreeRoot()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not hash the new header")
 	}
+	newSyncAggregate, err := blockSyncAggregate(stCopy, privs, parentRoot, conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed generating syncAggregate")
+	}
 
 	if slot == currentSlot {
 		slot = currentSlot + 1
@@ -181,7 +176,7 @@ func GenerateFullBlockElectra(
 		return nil, errors.Wrap(err, "could not compute randao reveal")
 	}
 
-	idx, err := helpers.BeaconProposerIndex(ctx, stCopy)
+	idx, err := proposerIndexAtSlot(ctx, stCopy, stCopy.Slot())
 	if err != nil {
 		return nil, errors.Wrap(err, "could not compute beacon proposer index")
 	}
@@ -213,11 +208,20 @@ func GenerateFullBlockElectra(
 		},
 	}
 
+	// The post-state root is calculated with the randao mix as the payload's prev randao.
+	rootBlock := ethpb.CopyBeaconBlockElectra(block)
+	rootBlock.Body.ExecutionPayload.PrevRandao = random
 	// The fork can change after processing the state
-	signature, err := BlockSignature(bState, block, privs)
+	signature, err := blockSignatureWithPrevRandao(bState, block, rootBlock, privs)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not compute block signature")
 	}
 
-	return &ethpb.SignedBeaconBlockElectra{Block: block, Signature: signature.Marshal()}, nil
+	signed := &ethpb.SignedBeaconBlockElectra{Block: block, Signature: signature.Marshal()}
+	if conf.VerifyGenerated {
+		if err := verifyGeneratedBlock(ctx, bState, signed); err != nil {
+			return nil, err
+		}
+	}
+	return signed, nil
 }
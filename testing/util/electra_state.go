@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -275,3 +276,49 @@ func buildGenesisBeaconStateElectra(genesisTime uint64, preState state.BeaconSta
 
 	return state_native.InitializeFromProtoElectra(st)
 }
+
+// AppendPendingBalanceDeposits validates and appends the given pending balance deposits to the
+// Electra pending deposit queue of the state. Every deposit must have a non-zero amount and refer
+// to an existing validator.
+func AppendPendingBalanceDeposits(st state.BeaconState, deposits []*ethpb.PendingBalanceDeposit) error {
+	existing, err := st.PendingBalanceDeposits()
+	if err != nil {
+		return err
+	}
+	if uint64(len(existing)+len(deposits)) > params.BeaconConfig().PendingBalanceDepositLimit {
+		return fmt.Errorf("pending balance deposits exceed limit of %d", params.BeaconConfig().PendingBalanceDepositLimit)
+	}
+	numVals := primitives.ValidatorIndex(st.NumValidators())
+	for i, d := range deposits {
+		if d.Amount == 0 {
+			return fmt.Errorf("pending balance deposit %d has zero amount", i)
+		}
+		if d.Index >= numVals {
+			return fmt.Errorf("pending balance deposit %d refers to validator %d, state has %d validators", i, d.Index, numVals)
+		}
+	}
+	for _, d := range deposits {
+		if err := st.AppendPendingBalanceDeposit(d.Index, d.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FillPendingBalanceDeposits appends n pending balance deposits of the given amount to the state,
+// cycling through the validator indices. It is useful to test the churn limited processing of the
+// pending deposit queue.
+func FillPendingBalanceDeposits(st state.BeaconState, n, amount uint64) error {
+	numVals := uint64(st.NumValidators())
+	if numVals == 0 {
+		return errors.New("state has no validators")
+	}
+	deposits := make([]*ethpb.PendingBalanceDeposit, n)
+	for i := uint64(0); i < n; i++ {
+		deposits[i] = &ethpb.PendingBalanceDeposit{
+			Index:  primitives.ValidatorIndex(i % numVals),
+			Amount: amount,
+		}
+	}
+	return AppendPendingBalanceDeposits(st, deposits)
+}
@@ -0,0 +1,200 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	coreBlock "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestGenerateWithdrawalRequests(t *testing.T) {
+	st, _ := DeterministicGenesisStateElectra(t, 4)
+	address := bytes.Repeat([]byte{0xab}, 20)
+	for _, idx := range []primitives.ValidatorIndex{0, 1} {
+		val, err := st.ValidatorAtIndex(idx)
+		require.NoError(t, err)
+		val.WithdrawalCredentials = append([]byte{params.BeaconConfig().ETH1AddressWithdrawalPrefixByte}, make([]byte, 11)...)
+		val.WithdrawalCredentials = append(val.WithdrawalCredentials, address...)
+		require.NoError(t, st.UpdateValidatorAtIndex(idx, val))
+	}
+
+	exits, err := GenerateWithdrawalRequests(st, []primitives.ValidatorIndex{0, 1}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(exits))
+	for i, r := range exits {
+		pubkey := st.PubkeyAtIndex(primitives.ValidatorIndex(i))
+		require.DeepEqual(t, address, r.SourceAddress)
+		require.DeepEqual(t, pubkey[:], r.ValidatorPubkey)
+		require.Equal(t, uint64(0), r.Amount)
+	}
+
+	partial, err := GenerateWithdrawalRequests(st, []primitives.ValidatorIndex{1}, params.BeaconConfig().MinActivationBalance)
+	require.NoError(t, err)
+	require.Equal(t, params.BeaconConfig().MinActivationBalance, partial[0].Amount)
+
+	mismatched, err := GenerateWithdrawalRequests(st, []primitives.ValidatorIndex{0}, 0, WithMismatchedSourceAddress())
+	require.NoError(t, err)
+	require.DeepNotEqual(t, address, mismatched[0].SourceAddress)
+	require.Equal(t, 20, len(mismatched[0].SourceAddress))
+
+	_, err = GenerateWithdrawalRequests(st, []primitives.ValidatorIndex{2}, 0)
+	require.ErrorContains(t, "does not have execution withdrawal credentials", err)
+}
+
+func TestGenerateVoluntaryExitWithPendingConsolidation(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ShardCommitteePeriod = 0
+	params.OverrideBeaconConfig(cfg)
+
+	st, keys := DeterministicGenesisStateElectra(t, 64)
+	pre := st.Copy()
+	exit, err := GenerateVoluntaryExitWithPendingConsolidation(st, keys[3], 3, 4)
+	require.NoError(t, err)
+
+	pending, err := st.PendingConsolidations()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pending))
+	require.Equal(t, primitives.ValidatorIndex(3), pending[0].SourceIndex)
+	require.Equal(t, primitives.ValidatorIndex(4), pending[0].TargetIndex)
+
+	// The exit is valid without the pending consolidation.
+	_, err = coreBlock.ProcessVoluntaryExits(context.Background(), pre, []*ethpb.SignedVoluntaryExit{exit})
+	require.NoError(t, err)
+	_, err = coreBlock.ProcessVoluntaryExits(context.Background(), st, []*ethpb.SignedVoluntaryExit{exit})
+	require.ErrorContains(t, coreBlock.ValidatorAlreadyExitedMsg, err)
+
+	_, err = GenerateVoluntaryExitWithPendingConsolidation(st, keys[5], 5, 5)
+	require.ErrorContains(t, "cannot consolidate into itself", err)
+
+	deneb, keys := DeterministicGenesisStateDeneb(t, 8)
+	_, err = GenerateVoluntaryExitWithPendingConsolidation(deneb, keys[0], 0, 1)
+	require.ErrorContains(t, "not supported for deneb states", err)
+}
+
+func TestDeterministicConsolidationStateAndBlock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	ctx := context.Background()
+
+	_, _, _, err := DeterministicConsolidationStateAndBlock(t, 64, 3, 4)
+	require.ErrorContains(t, "does not exceed the minimum activation balance", err)
+
+	// Leave part of the balance churn to consolidations.
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxPerEpochActivationExitChurnLimit = cfg.MinPerEpochChurnLimitElectra / 2
+	params.OverrideBeaconConfig(cfg)
+	st, blk, privs, err := DeterministicConsolidationStateAndBlock(t, 64, 3, 4)
+	require.NoError(t, err)
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	post, err := transition.ExecuteStateTransition(ctx, st, wsb)
+	require.NoError(t, err)
+	pending, err := post.PendingConsolidations()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pending))
+	require.Equal(t, primitives.ValidatorIndex(3), pending[0].SourceIndex)
+	require.Equal(t, primitives.ValidatorIndex(4), pending[0].TargetIndex)
+	source, err := post.ValidatorAtIndexReadOnly(3)
+	require.NoError(t, err)
+	require.NotEqual(t, params.BeaconConfig().FarFutureEpoch, source.ExitEpoch())
+
+	// Validator 5 withdraws to another address and validator 6 has BLS withdrawal credentials.
+	val, err := st.ValidatorAtIndex(5)
+	require.NoError(t, err)
+	val.WithdrawalCredentials = append([]byte{params.BeaconConfig().ETH1AddressWithdrawalPrefixByte}, make([]byte, 11)...)
+	val.WithdrawalCredentials = append(val.WithdrawalCredentials, bytes.Repeat([]byte{0xab}, 20)...)
+	require.NoError(t, st.UpdateValidatorAtIndex(5, val))
+	_, err = GenerateConsolidation(st, privs, 3, 5)
+	require.ErrorContains(t, "source validator 3 withdraws to", err)
+	_, err = GenerateConsolidation(st, privs, 3, 6)
+	require.ErrorContains(t, "target validator 6 does not have execution withdrawal credentials", err)
+	_, err = GenerateConsolidation(st, privs, 6, 3)
+	require.ErrorContains(t, "source validator 6 does not have execution withdrawal credentials", err)
+	_, err = GenerateConsolidation(st, privs, 3, 3)
+	require.ErrorContains(t, "cannot consolidate into itself", err)
+}
+
+func TestDeterministicCompoundingRewardsStateAndBlock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	cfg := params.BeaconConfig()
+
+	_, _, _, err := DeterministicCompoundingRewardsStateAndBlock(t, 64, []primitives.ValidatorIndex{1}, cfg.MaxEffectiveBalanceElectra+cfg.EffectiveBalanceIncrement)
+	require.ErrorContains(t, "exceeds the max effective balance", err)
+	_, _, _, err = DeterministicCompoundingRewardsStateAndBlock(t, 64, []primitives.ValidatorIndex{1}, cfg.MinActivationBalance+1)
+	require.ErrorContains(t, "is not a multiple of the effective balance increment", err)
+
+	balance := cfg.MaxEffectiveBalanceElectra
+	st, blk, _, err := DeterministicCompoundingRewardsStateAndBlock(t, 64, []primitives.ValidatorIndex{1}, balance)
+	require.NoError(t, err)
+	val, err := st.ValidatorAtIndexReadOnly(1)
+	require.NoError(t, err)
+	require.Equal(t, true, helpers.HasCompoundingWithdrawalCredential(val))
+	require.Equal(t, balance, val.EffectiveBalance())
+	pre := st.Balances()
+
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	post, err := transition.ExecuteStateTransition(context.Background(), st, wsb)
+	require.NoError(t, err)
+	val, err = post.ValidatorAtIndexReadOnly(1)
+	require.NoError(t, err)
+	require.Equal(t, balance, val.EffectiveBalance())
+
+	// The rewards of the compounding validator are not capped at the minimum activation balance.
+	compoundingReward := post.Balances()[1] - pre[1]
+	reward := post.Balances()[2] - pre[2]
+	require.Equal(t, true, reward > 0)
+	ratio := balance / cfg.MinActivationBalance
+	require.Equal(t, true, compoundingReward > (ratio-1)*reward, "reward %d of the compounding validator does not scale with its balance, reward %d otherwise", compoundingReward, reward)
+}
+
+func TestGenerateFullBlockElectra_DepositRequests(t *testing.T) {
+	ctx := context.Background()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	genesis, privs := DeterministicGenesisStateElectra(t, 64)
+	setSyncCommittees(t, genesis)
+
+	legacy, err := GenerateFullBlockElectra(genesis, privs, &BlockGenConfig{NumDeposits: 2}, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(legacy.Block.Body.Deposits))
+	require.Equal(t, 0, len(legacy.Block.Body.ExecutionPayload.DepositRequests))
+
+	blk, err := GenerateFullBlockElectra(genesis, privs, &BlockGenConfig{NumDeposits: 2, DepositRequests: true}, 1)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(blk.Block.Body.Deposits))
+	require.DeepEqual(t, genesis.Eth1Data(), blk.Block.Body.Eth1Data)
+	requests := blk.Block.Body.ExecutionPayload.DepositRequests
+	require.Equal(t, 2, len(requests))
+	for i, r := range requests {
+		data := legacy.Block.Body.Deposits[i].Data
+		require.Equal(t, genesis.Eth1DepositIndex()+uint64(i), r.Index)
+		require.DeepEqual(t, data.PublicKey, r.Pubkey)
+		require.DeepEqual(t, data.WithdrawalCredentials, r.WithdrawalCredentials)
+		require.Equal(t, data.Amount, r.Amount)
+		require.DeepEqual(t, data.Signature, r.Signature)
+	}
+
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+	require.NoError(t, err)
+	// The deposits are not taken from the Eth1 deposit contract.
+	require.Equal(t, genesis.Eth1DepositIndex(), st.Eth1DepositIndex())
+}
+
+func TestGenerateFullBlock_DepositRequestsBeforeElectra(t *testing.T) {
+	genesis, privs := DeterministicGenesisStateCapella(t, 64)
+	_, err := GenerateFullBlockCapella(genesis, privs, &BlockGenConfig{NumDeposits: 1, DepositRequests: true}, 1)
+	require.ErrorContains(t, "deposit requests are only supported in Electra blocks", err)
+}
@@ -0,0 +1,83 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// GenerateEth1DataMajorityBlock generates a full block for the slot after the state's slot, which
+// votes for the eth1 data with the most votes in the current voting period. The state must be one
+// vote short of a majority for that eth1 data, so that processing the block updates the state's
+// eth1 data. An error is returned if the tally can't be completed by this block, in particular if
+// the voting period is past the point where a majority is still possible. The voted eth1 data must
+// not require deposits beyond the ones already processed, as the block does not include any.
+func GenerateEth1DataMajorityBlock(bState state.BeaconState, privs []bls.SecretKey) (interfaces.SignedBeaconBlock, error) {
+	slot := bState.Slot() + 1
+	// Votes are reset at the start of a voting period, so they are tallied at the block's slot.
+	st, err := transition.ProcessSlots(context.Background(), bState.Copy(), slot)
+	if err != nil {
+		return nil, err
+	}
+	votes := st.Eth1DataVotes()
+	if len(votes) == 0 {
+		return nil, errors.New("no eth1 data votes in the current voting period")
+	}
+	counts := make(map[[32]byte]uint64, len(votes))
+	var vote *ethpb.Eth1Data
+	var voteCount uint64
+	for _, v := range votes {
+		r, err := v.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		counts[r]++
+		if counts[r] > voteCount {
+			vote, voteCount = v, counts[r]
+		}
+	}
+
+	period := uint64(params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerEth1VotingPeriod)))
+	needed := period/2 + 1
+	remaining := period - uint64(slot)%period
+	switch {
+	case voteCount >= needed:
+		return nil, fmt.Errorf("eth1 data vote already has a majority of %d votes", voteCount)
+	case voteCount+remaining < needed:
+		return nil, fmt.Errorf(
+			"majority is no longer possible: leading eth1 data vote has %d of the %d votes needed with %d slots left in the voting period",
+			voteCount,
+			needed,
+			remaining,
+		)
+	case voteCount+1 < needed:
+		return nil, fmt.Errorf("leading eth1 data vote has %d of the %d votes needed, a single block can't complete the tally", voteCount, needed)
+	}
+	if vote.DepositCount > st.Eth1DepositIndex() {
+		return nil, fmt.Errorf("eth1 data vote requires %d deposits beyond the processed ones", vote.DepositCount-st.Eth1DepositIndex())
+	}
+
+	blk, err := generateFullBlockForVersion(bState.Version(), bState, privs, DefaultBlockGenConfig(), slot)
+	if err != nil {
+		return nil, err
+	}
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	if err != nil {
+		return nil, err
+	}
+	wsb.SetEth1Data(ethpb.CopyETH1Data(vote))
+	sig, err := signBlock(bState, wsb, privs)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block signature")
+	}
+	wsb.SetSignature(sig.Marshal())
+	return wsb, nil
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestGenerateEth1DataMajorityBlock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.EpochsPerEth1VotingPeriod = 1
+	params.OverrideBeaconConfig(cfg)
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	// The voting period spans 32 slots, so a majority takes 17 votes.
+	genesis, privs := DeterministicGenesisState(t, 64)
+	vote := ethpb.CopyETH1Data(genesis.Eth1Data())
+	vote.BlockHash = bytesutil.PadTo([]byte("majority"), fieldparams.RootLength)
+	withVotes := func(t *testing.T, slot primitives.Slot, n int, v *ethpb.Eth1Data) state.BeaconState {
+		st := genesis.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		for i := 0; i < n; i++ {
+			require.NoError(t, st.AppendEth1DataVotes(v))
+		}
+		return st
+	}
+
+	t.Run("completes the tally", func(t *testing.T) {
+		st := withVotes(t, 0, 16, vote)
+		other := ethpb.CopyETH1Data(vote)
+		other.BlockHash = bytesutil.PadTo([]byte("minority"), fieldparams.RootLength)
+		require.NoError(t, st.AppendEth1DataVotes(other))
+		blk, err := GenerateEth1DataMajorityBlock(st, privs)
+		require.NoError(t, err)
+		assert.DeepEqual(t, vote, blk.Block().Body().Eth1Data())
+		st, err = transition.ExecuteStateTransition(context.Background(), st, blk)
+		require.NoError(t, err)
+		assert.DeepEqual(t, vote, st.Eth1Data())
+	})
+	t.Run("no votes", func(t *testing.T) {
+		_, err := GenerateEth1DataMajorityBlock(genesis.Copy(), privs)
+		require.ErrorContains(t, "no eth1 data votes", err)
+	})
+	t.Run("more than one vote short", func(t *testing.T) {
+		_, err := GenerateEth1DataMajorityBlock(withVotes(t, 0, 15, vote), privs)
+		require.ErrorContains(t, "has 15 of the 17 votes needed, a single block can't complete the tally", err)
+	})
+	t.Run("already a majority", func(t *testing.T) {
+		_, err := GenerateEth1DataMajorityBlock(withVotes(t, 0, 17, vote), privs)
+		require.ErrorContains(t, "already has a majority", err)
+	})
+	t.Run("majority no longer possible", func(t *testing.T) {
+		_, err := GenerateEth1DataMajorityBlock(withVotes(t, 20, 2, vote), privs)
+		require.ErrorContains(t, "majority is no longer possible", err)
+	})
+	t.Run("votes reset at the next voting period", func(t *testing.T) {
+		_, err := GenerateEth1DataMajorityBlock(withVotes(t, 31, 16, vote), privs)
+		require.ErrorContains(t, "no eth1 data votes", err)
+	})
+	t.Run("pending deposits", func(t *testing.T) {
+		withDeposits := ethpb.CopyETH1Data(vote)
+		withDeposits.DepositCount++
+		_, err := GenerateEth1DataMajorityBlock(withVotes(t, 0, 16, withDeposits), privs)
+		require.ErrorContains(t, "requires 1 deposits beyond the processed ones", err)
+	})
+}
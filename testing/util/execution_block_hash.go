@@ -0,0 +1,196 @@
+package util
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+)
+
+// ExecutionBlockHash computes the hash of the execution block header that an execution client
+// derives from the payload, i.e. the keccak hash of the RLP encoded header with empty ommers, zero
+// difficulty and nonce, and the transactions and withdrawals roots computed from the payload. The
+// parent beacon block root is only part of the header from Deneb on, and is ignored for earlier
+// payloads. From Electra on, the header also commits to the EIP-7685 requests root of the payload's
+// deposit and withdrawal requests. Blinded payloads are not supported.
+func ExecutionBlockHash(payload interfaces.ExecutionData, parentBeaconRoot []byte) ([32]byte, error) {
+	if payload == nil || payload.IsNil() {
+		return [32]byte{}, errors.New("nil execution payload")
+	}
+	if payload.IsBlinded() {
+		return [32]byte{}, errors.New("the block hash of a blinded payload can't be computed")
+	}
+	txs, err := payload.Transactions()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not get transactions")
+	}
+	header := &gethtypes.Header{
+		ParentHash:  common.BytesToHash(payload.ParentHash()),
+		UncleHash:   gethtypes.EmptyUncleHash,
+		Coinbase:    common.BytesToAddress(payload.FeeRecipient()),
+		Root:        common.BytesToHash(payload.StateRoot()),
+		TxHash:      gethtypes.DeriveSha(rawList(txs), trie.NewStackTrie(nil)),
+		ReceiptHash: common.BytesToHash(payload.ReceiptsRoot()),
+		Bloom:       gethtypes.BytesToBloom(payload.LogsBloom()),
+		Difficulty:  common.Big0,
+		Number:      new(big.Int).SetUint64(payload.BlockNumber()),
+		GasLimit:    payload.GasLimit(),
+		GasUsed:     payload.GasUsed(),
+		Time:        payload.Timestamp(),
+		Extra:       payload.ExtraData(),
+		MixDigest:   common.BytesToHash(payload.PrevRandao()),
+		BaseFee:     bytesutil.LittleEndianBytesToBigInt(payload.BaseFeePerGas()),
+	}
+
+	switch p := payload.Proto().(type) {
+	case *enginev1.ExecutionPayload:
+	case *enginev1.ExecutionPayloadCapella:
+		header.WithdrawalsHash = withdrawalsHash(p.Withdrawals)
+	case *enginev1.ExecutionPayloadDeneb:
+		header.WithdrawalsHash = withdrawalsHash(p.Withdrawals)
+		if err := setBlobFields(header, p.BlobGasUsed, p.ExcessBlobGas, parentBeaconRoot); err != nil {
+			return [32]byte{}, err
+		}
+	case *enginev1.ExecutionPayloadElectra:
+		header.WithdrawalsHash = withdrawalsHash(p.Withdrawals)
+		if err := setBlobFields(header, p.BlobGasUsed, p.ExcessBlobGas, parentBeaconRoot); err != nil {
+			return [32]byte{}, err
+		}
+		requestsHash, err := executionRequestsHash(p.DepositRequests, p.WithdrawalRequests)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return headerHashWithRequests(header, requestsHash)
+	default:
+		return [32]byte{}, errors.Errorf("the block hash of a %T payload can't be computed", p)
+	}
+	return header.Hash(), nil
+}
+
+func setBlobFields(header *gethtypes.Header, blobGasUsed, excessBlobGas uint64, parentBeaconRoot []byte) error {
+	if len(parentBeaconRoot) != fieldparams.RootLength {
+		return errors.Errorf("invalid parent beacon block root length %d", len(parentBeaconRoot))
+	}
+	header.BlobGasUsed = &blobGasUsed
+	header.ExcessBlobGas = &excessBlobGas
+	root := common.BytesToHash(parentBeaconRoot)
+	header.ParentBeaconRoot = &root
+	return nil
+}
+
+func withdrawalsHash(withdrawals []*enginev1.Withdrawal) *common.Hash {
+	ws := make(gethtypes.Withdrawals, len(withdrawals))
+	for i, w := range withdrawals {
+		ws[i] = &gethtypes.Withdrawal{
+			Index:     w.Index,
+			Validator: uint64(w.ValidatorIndex),
+			Address:   common.BytesToAddress(w.Address),
+			Amount:    w.Amount,
+		}
+	}
+	h := gethtypes.DeriveSha(ws, trie.NewStackTrie(nil))
+	return &h
+}
+
+// executionRequestsHash computes the EIP-7685 requests root, the root of the trie of the typed
+// encodings of the requests, deposit requests first.
+func executionRequestsHash(deposits []*enginev1.DepositRequest, withdrawals []*enginev1.WithdrawalRequest) (common.Hash, error) {
+	requests := make(rawList, 0, len(deposits)+len(withdrawals))
+	for _, d := range deposits {
+		enc, err := rlp.EncodeToBytes([]interface{}{d.Pubkey, d.WithdrawalCredentials, d.Amount, d.Signature, d.Index})
+		if err != nil {
+			return common.Hash{}, errors.Wrap(err, "could not encode deposit request")
+		}
+		requests = append(requests, append([]byte{depositRequestType}, enc...))
+	}
+	for _, w := range withdrawals {
+		enc, err := rlp.EncodeToBytes([]interface{}{w.SourceAddress, w.ValidatorPubkey, w.Amount})
+		if err != nil {
+			return common.Hash{}, errors.Wrap(err, "could not encode withdrawal request")
+		}
+		requests = append(requests, append([]byte{withdrawalRequestType}, enc...))
+	}
+	return gethtypes.DeriveSha(requests, trie.NewStackTrie(nil)), nil
+}
+
+const (
+	depositRequestType    = 0x00
+	withdrawalRequestType = 0x01
+)
+
+// requestsHeader is the Electra execution block header. The go-ethereum version in use predates
+// EIP-7685, so its header has no requests hash and the Electra header is encoded here instead.
+type requestsHeader struct {
+	ParentHash       common.Hash
+	UncleHash        common.Hash
+	Coinbase         common.Address
+	Root             common.Hash
+	TxHash           common.Hash
+	ReceiptHash      common.Hash
+	Bloom            gethtypes.Bloom
+	Difficulty       *big.Int
+	Number           *big.Int
+	GasLimit         uint64
+	GasUsed          uint64
+	Time             uint64
+	Extra            []byte
+	MixDigest        common.Hash
+	Nonce            gethtypes.BlockNonce
+	BaseFee          *big.Int
+	WithdrawalsHash  common.Hash
+	BlobGasUsed      uint64
+	ExcessBlobGas    uint64
+	ParentBeaconRoot common.Hash
+	RequestsHash     common.Hash
+}
+
+func headerHashWithRequests(h *gethtypes.Header, requestsHash common.Hash) ([32]byte, error) {
+	enc, err := rlp.EncodeToBytes(&requestsHeader{
+		ParentHash:       h.ParentHash,
+		UncleHash:        h.UncleHash,
+		Coinbase:         h.Coinbase,
+		Root:             h.Root,
+		TxHash:           h.TxHash,
+		ReceiptHash:      h.ReceiptHash,
+		Bloom:            h.Bloom,
+		Difficulty:       h.Difficulty,
+		Number:           h.Number,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Time:             h.Time,
+		Extra:            h.Extra,
+		MixDigest:        h.MixDigest,
+		Nonce:            h.Nonce,
+		BaseFee:          h.BaseFee,
+		WithdrawalsHash:  *h.WithdrawalsHash,
+		BlobGasUsed:      *h.BlobGasUsed,
+		ExcessBlobGas:    *h.ExcessBlobGas,
+		ParentBeaconRoot: *h.ParentBeaconRoot,
+		RequestsHash:     requestsHash,
+	})
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not encode header")
+	}
+	return crypto.Keccak256Hash(enc), nil
+}
+
+// rawList lists items by their binary encoding, which is what the transactions and requests tries
+// commit to. This lets fixtures use arbitrary bytes as transactions.
+type rawList [][]byte
+
+func (r rawList) Len() int {
+	return len(r)
+}
+
+func (r rawList) EncodeIndex(i int, w *bytes.Buffer) {
+	w.Write(r[i])
+}
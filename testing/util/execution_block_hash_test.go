@@ -0,0 +1,326 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExecutionBlockHash(t *testing.T) {
+	txs := signedTransactions(t, 3)
+	withdrawals := []*enginev1.Withdrawal{
+		{Index: 7, ValidatorIndex: 11, Address: bytesutil.PadTo([]byte{0xaa}, fieldparams.FeeRecipientLength), Amount: 1234},
+		{Index: 8, ValidatorIndex: 12, Address: bytesutil.PadTo([]byte{0xbb}, fieldparams.FeeRecipientLength), Amount: 5678},
+	}
+	baseFee := bytesutil.PadTo(bytesutil.ReverseByteOrder(big.NewInt(7_000_000_000).Bytes()), fieldparams.RootLength)
+	beaconRoot := bytesutil.PadTo([]byte{0x42}, fieldparams.RootLength)
+
+	bellatrix := &enginev1.ExecutionPayload{
+		ParentHash:    bytesutil.PadTo([]byte{0x01}, fieldparams.RootLength),
+		FeeRecipient:  bytesutil.PadTo([]byte{0x02}, fieldparams.FeeRecipientLength),
+		StateRoot:     bytesutil.PadTo([]byte{0x03}, fieldparams.RootLength),
+		ReceiptsRoot:  bytesutil.PadTo([]byte{0x04}, fieldparams.RootLength),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:    bytesutil.PadTo([]byte{0x05}, fieldparams.RootLength),
+		BlockNumber:   100,
+		GasLimit:      30_000_000,
+		GasUsed:       63_000,
+		Timestamp:     1_700_000_000,
+		ExtraData:     []byte("prysm"),
+		BaseFeePerGas: baseFee,
+		Transactions:  txs,
+	}
+	capella := &enginev1.ExecutionPayloadCapella{
+		ParentHash:    bellatrix.ParentHash,
+		FeeRecipient:  bellatrix.FeeRecipient,
+		StateRoot:     bellatrix.StateRoot,
+		ReceiptsRoot:  bellatrix.ReceiptsRoot,
+		LogsBloom:     bellatrix.LogsBloom,
+		PrevRandao:    bellatrix.PrevRandao,
+		BlockNumber:   bellatrix.BlockNumber,
+		GasLimit:      bellatrix.GasLimit,
+		GasUsed:       bellatrix.GasUsed,
+		Timestamp:     bellatrix.Timestamp,
+		ExtraData:     bellatrix.ExtraData,
+		BaseFeePerGas: baseFee,
+		Transactions:  txs,
+		Withdrawals:   withdrawals,
+	}
+	deneb := &enginev1.ExecutionPayloadDeneb{
+		ParentHash:    bellatrix.ParentHash,
+		FeeRecipient:  bellatrix.FeeRecipient,
+		StateRoot:     bellatrix.StateRoot,
+		ReceiptsRoot:  bellatrix.ReceiptsRoot,
+		LogsBloom:     bellatrix.LogsBloom,
+		PrevRandao:    bellatrix.PrevRandao,
+		BlockNumber:   bellatrix.BlockNumber,
+		GasLimit:      bellatrix.GasLimit,
+		GasUsed:       bellatrix.GasUsed,
+		Timestamp:     bellatrix.Timestamp,
+		ExtraData:     bellatrix.ExtraData,
+		BaseFeePerGas: baseFee,
+		Transactions:  txs,
+		Withdrawals:   withdrawals,
+		BlobGasUsed:   0,
+		ExcessBlobGas: 393_216,
+	}
+
+	// geth's engine API conversion recomputes the block hash from the payload and rejects any
+	// mismatch, so it serves as the reference implementation.
+	tests := []struct {
+		name     string
+		payload  func() (interfaces.ExecutionData, error)
+		setHash  func([32]byte)
+		data     func() engine.ExecutableData
+		withRoot bool
+	}{
+		{
+			name:    "bellatrix",
+			payload: func() (interfaces.ExecutionData, error) { return consensusblocks.WrappedExecutionPayload(bellatrix) },
+			setHash: func(h [32]byte) { bellatrix.BlockHash = h[:] },
+			data:    func() engine.ExecutableData { return executableData(bellatrix.BlockHash, txs, nil) },
+		},
+		{
+			name: "capella",
+			payload: func() (interfaces.ExecutionData, error) {
+				return consensusblocks.WrappedExecutionPayloadCapella(capella)
+			},
+			setHash: func(h [32]byte) { capella.BlockHash = h[:] },
+			data:    func() engine.ExecutableData { return executableData(capella.BlockHash, txs, withdrawals) },
+		},
+		{
+			name:    "deneb",
+			payload: func() (interfaces.ExecutionData, error) { return consensusblocks.WrappedExecutionPayloadDeneb(deneb) },
+			setHash: func(h [32]byte) { deneb.BlockHash = h[:] },
+			data: func() engine.ExecutableData {
+				d := executableData(deneb.BlockHash, txs, withdrawals)
+				d.BlobGasUsed = &deneb.BlobGasUsed
+				d.ExcessBlobGas = &deneb.ExcessBlobGas
+				return d
+			},
+			withRoot: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := tt.payload()
+			require.NoError(t, err)
+			h, err := ExecutionBlockHash(payload, beaconRoot)
+			require.NoError(t, err)
+			tt.setHash(h)
+			var root *common.Hash
+			if tt.withRoot {
+				r := common.BytesToHash(beaconRoot)
+				root = &r
+			}
+			block, err := engine.ExecutableDataToBlock(tt.data(), nil, root)
+			require.NoError(t, err)
+			assert.Equal(t, common.Hash(h), block.Hash())
+		})
+	}
+
+	t.Run("deneb without parent beacon root", func(t *testing.T) {
+		payload, err := consensusblocks.WrappedExecutionPayloadDeneb(deneb)
+		require.NoError(t, err)
+		_, err = ExecutionBlockHash(payload, nil)
+		require.ErrorContains(t, "invalid parent beacon block root length", err)
+	})
+	t.Run("blinded", func(t *testing.T) {
+		header, err := consensusblocks.WrappedExecutionPayloadHeader(&enginev1.ExecutionPayloadHeader{})
+		require.NoError(t, err)
+		_, err = ExecutionBlockHash(header, nil)
+		require.ErrorContains(t, "blinded payload", err)
+	})
+	t.Run("electra without parent beacon root", func(t *testing.T) {
+		payload, err := consensusblocks.WrappedExecutionPayloadElectra(&enginev1.ExecutionPayloadElectra{})
+		require.NoError(t, err)
+		_, err = ExecutionBlockHash(payload, nil)
+		require.ErrorContains(t, "invalid parent beacon block root length", err)
+	})
+}
+
+// TestExecutionBlockHash_Golden pins the hashes of fixed Deneb and Electra blocks. The Deneb hash
+// is the one go-ethereum computes for the block's header, the Electra hash additionally commits
+// to the requests root of one deposit and one withdrawal request.
+func TestExecutionBlockHash_Golden(t *testing.T) {
+	tx := hexutil.MustDecode("0x02f8670180018502540be40082520894000000000000000000000000000000000000dead0180c001a09e127929b46fa105e99ba5edaf476a2305450b4f40531df491fb7bd339cf149da06c25776ec4ca6a76ae2572273d38044b5eb27c50e5202d3a7f5b9c04ff61a756")
+	withdrawals := []*enginev1.Withdrawal{
+		{Index: 7, ValidatorIndex: 11, Address: common.HexToAddress("0xaa").Bytes(), Amount: 1234},
+	}
+	deneb := &enginev1.ExecutionPayloadDeneb{
+		ParentHash:    common.HexToHash("0x01").Bytes(),
+		FeeRecipient:  common.HexToAddress("0x02").Bytes(),
+		StateRoot:     common.HexToHash("0x03").Bytes(),
+		ReceiptsRoot:  common.HexToHash("0x04").Bytes(),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:    common.HexToHash("0x05").Bytes(),
+		BlockNumber:   100,
+		GasLimit:      30_000_000,
+		GasUsed:       21_000,
+		Timestamp:     1_700_000_000,
+		ExtraData:     []byte("prysm"),
+		BaseFeePerGas: bytesutil.PadTo(bytesutil.ReverseByteOrder(big.NewInt(7_000_000_000).Bytes()), fieldparams.RootLength),
+		Transactions:  [][]byte{tx},
+		Withdrawals:   withdrawals,
+		BlobGasUsed:   131_072,
+		ExcessBlobGas: 393_216,
+	}
+	electra := &enginev1.ExecutionPayloadElectra{
+		ParentHash:    deneb.ParentHash,
+		FeeRecipient:  deneb.FeeRecipient,
+		StateRoot:     deneb.StateRoot,
+		ReceiptsRoot:  deneb.ReceiptsRoot,
+		LogsBloom:     deneb.LogsBloom,
+		PrevRandao:    deneb.PrevRandao,
+		BlockNumber:   deneb.BlockNumber,
+		GasLimit:      deneb.GasLimit,
+		GasUsed:       deneb.GasUsed,
+		Timestamp:     deneb.Timestamp,
+		ExtraData:     deneb.ExtraData,
+		BaseFeePerGas: deneb.BaseFeePerGas,
+		Transactions:  deneb.Transactions,
+		Withdrawals:   withdrawals,
+		BlobGasUsed:   deneb.BlobGasUsed,
+		ExcessBlobGas: deneb.ExcessBlobGas,
+		DepositRequests: []*enginev1.DepositRequest{{
+			Pubkey:                bytes.Repeat([]byte{0x11}, fieldparams.BLSPubkeyLength),
+			WithdrawalCredentials: hexutil.MustDecode("0x0100000000000000000000002222222222222222222222222222222222222222"),
+			Amount:                32_000_000_000,
+			Signature:             bytes.Repeat([]byte{0x33}, fieldparams.BLSSignatureLength),
+			Index:                 5,
+		}},
+		WithdrawalRequests: []*enginev1.WithdrawalRequest{{
+			SourceAddress:   common.HexToAddress("0x22").Bytes(),
+			ValidatorPubkey: bytes.Repeat([]byte{0x11}, fieldparams.BLSPubkeyLength),
+			Amount:          1_000_000_000,
+		}},
+	}
+	beaconRoot := common.HexToHash("0x42").Bytes()
+
+	t.Run("deneb", func(t *testing.T) {
+		payload, err := consensusblocks.WrappedExecutionPayloadDeneb(deneb)
+		require.NoError(t, err)
+		h, err := ExecutionBlockHash(payload, beaconRoot)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash("0x24d53177766b0a39c695640d9eadb6075839d78d2d5e0e422c74c6f30fc48e77"), common.Hash(h))
+	})
+	t.Run("electra", func(t *testing.T) {
+		payload, err := consensusblocks.WrappedExecutionPayloadElectra(electra)
+		require.NoError(t, err)
+		h, err := ExecutionBlockHash(payload, beaconRoot)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash("0xbc0db262060e3a55509854c40991e718135841976a009ccb10ae7fe2d37e4af9"), common.Hash(h))
+	})
+	t.Run("electra without requests", func(t *testing.T) {
+		e := proto.Clone(electra).(*enginev1.ExecutionPayloadElectra)
+		e.DepositRequests = nil
+		e.WithdrawalRequests = nil
+		payload, err := consensusblocks.WrappedExecutionPayloadElectra(e)
+		require.NoError(t, err)
+		h, err := ExecutionBlockHash(payload, beaconRoot)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash("0x8f34661266d9f79978590c4e890154e2a9bfd0f598ac51035d59dfc391590c47"), common.Hash(h))
+	})
+}
+
+func TestGenerateFullBlockBellatrix_ExecutionBlockHash(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	st, privs := DeterministicGenesisStateBellatrix(t, 64)
+	setSyncCommittees(t, st)
+	conf := DefaultBlockGenConfig()
+	conf.NumTransactions = 2
+	conf.ExecutionBlockHash = true
+
+	var parentHash []byte
+	for i := 0; i < 2; i++ {
+		blk, err := GenerateFullBlockBellatrix(st, privs, conf, st.Slot()+1)
+		require.NoError(t, err)
+		payload, err := consensusblocks.WrappedExecutionPayload(blk.Block.Body.ExecutionPayload)
+		require.NoError(t, err)
+		want, err := ExecutionBlockHash(payload, nil)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want[:], payload.BlockHash())
+		if parentHash != nil {
+			assert.DeepEqual(t, parentHash, payload.ParentHash())
+		}
+		parentHash = payload.BlockHash()
+
+		wsb, err := consensusblocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(context.Background(), st, wsb)
+		require.NoError(t, err)
+	}
+}
+
+func signedTransactions(t *testing.T, n int) [][]byte {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := gethtypes.LatestSignerForChainID(big.NewInt(1))
+	to := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	txs := make([][]byte, n)
+	for i := range txs {
+		tx, err := gethtypes.SignNewTx(key, signer, &gethtypes.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     uint64(i),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(10_000_000_000),
+			Gas:       21_000,
+			To:        &to,
+			Value:     big.NewInt(int64(i + 1)),
+		})
+		require.NoError(t, err)
+		txs[i], err = tx.MarshalBinary()
+		require.NoError(t, err)
+	}
+	return txs
+}
+
+// executableData converts the payload fields shared by the test payloads to geth's engine API
+// representation.
+func executableData(blockHash []byte, txs [][]byte, withdrawals []*enginev1.Withdrawal) engine.ExecutableData {
+	d := engine.ExecutableData{
+		ParentHash:    common.BytesToHash(bytesutil.PadTo([]byte{0x01}, fieldparams.RootLength)),
+		FeeRecipient:  common.BytesToAddress(bytesutil.PadTo([]byte{0x02}, fieldparams.FeeRecipientLength)),
+		StateRoot:     common.BytesToHash(bytesutil.PadTo([]byte{0x03}, fieldparams.RootLength)),
+		ReceiptsRoot:  common.BytesToHash(bytesutil.PadTo([]byte{0x04}, fieldparams.RootLength)),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		Random:        common.BytesToHash(bytesutil.PadTo([]byte{0x05}, fieldparams.RootLength)),
+		Number:        100,
+		GasLimit:      30_000_000,
+		GasUsed:       63_000,
+		Timestamp:     1_700_000_000,
+		ExtraData:     []byte("prysm"),
+		BaseFeePerGas: big.NewInt(7_000_000_000),
+		BlockHash:     common.BytesToHash(blockHash),
+		Transactions:  txs,
+	}
+	if withdrawals != nil {
+		d.Withdrawals = make([]*gethtypes.Withdrawal, len(withdrawals))
+		for i, w := range withdrawals {
+			d.Withdrawals[i] = &gethtypes.Withdrawal{
+				Index:     w.Index,
+				Validator: uint64(w.ValidatorIndex),
+				Address:   common.BytesToAddress(w.Address),
+				Amount:    w.Amount,
+			}
+		}
+	}
+	return d
+}
@@ -5,20 +5,20 @@ import (
 	"encoding/binary"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
-	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
-	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 // RandaoReveal returns a signature of the requested epoch using the beacon proposer private key.
@@ -36,108 +36,114 @@ func RandaoReveal(beaconState state.ReadOnlyBeaconState, epoch primitives.Epoch,
 	return signing.ComputeDomainAndSign(beaconState, epoch, &sszEpoch, params.BeaconConfig().DomainRandao, privKeys[proposerIdx])
 }
 
-// BlockSignature calculates the post-state root of the block and returns the signature.
-func BlockSignature(
-	bState state.BeaconState,
-	block interface{},
-	privKeys []bls.SecretKey,
-) (bls.Signature, error) {
-	var wsb interfaces.ReadOnlySignedBeaconBlock
-	var err error
-	// copy the state since we need to process slots
-	bState = bState.Copy()
-	switch b := block.(type) {
-	case *ethpb.BeaconBlock:
-		wsb, err = blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlock{Block: b})
-	case *ethpb.BeaconBlockAltair:
-		wsb, err = blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockAltair{Block: b})
-	case *ethpb.BeaconBlockBellatrix:
-		wsb, err = blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockBellatrix{Block: b})
-	case *ethpb.BeaconBlockCapella:
-		wsb, err = blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockCapella{Block: b})
-	case *ethpb.BeaconBlockDeneb:
-		wsb, err = blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockDeneb{Block: b})
-	case *ethpb.BeaconBlockElectra:
-		wsb, err = blocks.NewSignedBeaconBlock(&ethpb.SignedBeaconBlockElectra{Block: b})
-	default:
-		return nil, fmt.Errorf("unsupported block type %T", b)
-	}
+// RandaoRevealAtEpoch returns a randao reveal for the given epoch signed by the proposer of the
+// state's slot if the epoch is the state's current epoch, or of the epoch's first slot otherwise.
+// The signing domain uses the fork scheduled for the epoch, which may differ from the state's fork
+// when the epoch is past an upcoming fork boundary.
+func RandaoRevealAtEpoch(st state.ReadOnlyBeaconState, epoch primitives.Epoch, privKeys []bls.SecretKey) ([]byte, error) {
+	fork := st.Fork()
+	scheduled, err := forks.Fork(epoch)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not wrap block")
+		return nil, err
 	}
-	s, err := transition.CalculateStateRoot(context.Background(), bState, wsb)
-	if err != nil {
-		return nil, errors.Wrap(err, "could not calculate state root")
+	if scheduled.Epoch > fork.Epoch {
+		fork = scheduled
 	}
+	return randaoRevealWithFork(st, epoch, privKeys, fork)
+}
 
-	switch b := block.(type) {
-	case *ethpb.BeaconBlock:
-		b.StateRoot = s[:]
-	case *ethpb.BeaconBlockAltair:
-		b.StateRoot = s[:]
-	case *ethpb.BeaconBlockBellatrix:
-		b.StateRoot = s[:]
-	case *ethpb.BeaconBlockCapella:
-		b.StateRoot = s[:]
-	case *ethpb.BeaconBlockDeneb:
-		b.StateRoot = s[:]
-	case *ethpb.BeaconBlockElectra:
-		b.StateRoot = s[:]
+// RandaoRevealAtEpochWithForkVersion works like RandaoRevealAtEpoch but signs with the given fork
+// version, e.g. a stale one, to produce reveals that must be rejected.
+func RandaoRevealAtEpochWithForkVersion(st state.ReadOnlyBeaconState, epoch primitives.Epoch, privKeys []bls.SecretKey, version []byte) ([]byte, error) {
+	fork := &ethpb.Fork{
+		PreviousVersion: version,
+		CurrentVersion:  version,
+		Epoch:           epoch,
 	}
+	return randaoRevealWithFork(st, epoch, privKeys, fork)
+}
 
-	// Temporarily increasing the beacon state slot here since BeaconProposerIndex is a
-	// function deterministic on beacon state slot.
-	var blockSlot primitives.Slot
-	switch b := block.(type) {
-	case *ethpb.BeaconBlock:
-		blockSlot = b.Slot
-	case *ethpb.BeaconBlockAltair:
-		blockSlot = b.Slot
-	case *ethpb.BeaconBlockBellatrix:
-		blockSlot = b.Slot
-	case *ethpb.BeaconBlockCapella:
-		blockSlot = b.Slot
-	case *ethpb.BeaconBlockDeneb:
-		blockSlot = b.Slot
-	case *ethpb.BeaconBlockElectra:
-		blockSlot = b.Slot
+func randaoRevealWithFork(st state.ReadOnlyBeaconState, epoch primitives.Epoch, privKeys []bls.SecretKey, fork *ethpb.Fork) ([]byte, error) {
+	slot := st.Slot()
+	if slots.ToEpoch(slot) != epoch {
+		var err error
+		slot, err = slots.EpochStart(epoch)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	// process slots to get the right fork
-	bState, err = transition.ProcessSlots(context.Background(), bState, blockSlot)
+	proposerIdx, err := proposerIndexAtSlot(context.Background(), st, slot)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "could not get beacon proposer index")
+	}
+	if uint64(proposerIdx) >= uint64(len(privKeys)) {
+		return nil, fmt.Errorf("no private key for proposer %d", proposerIdx)
 	}
+	sszEpoch := primitives.SSZUint64(epoch)
+	return signing.ComputeDomainAndSignWithoutState(fork, epoch, params.BeaconConfig().DomainRandao, st.GenesisValidatorsRoot(), &sszEpoch, privKeys[proposerIdx])
+}
 
-	domain, err := signing.Domain(bState.Fork(), time.CurrentEpoch(bState), params.BeaconConfig().DomainBeaconProposer, bState.GenesisValidatorsRoot())
+// AdvanceStateEpochs advances the state by the given number of epochs with empty slots. It uses
+// transition.ProcessSlotsUnsafeFastForward, so the state roots recorded for the skipped slots are
+// not spec compliant and the returned state must only be used in tests.
+func AdvanceStateEpochs(ctx context.Context, st state.BeaconState, epochs primitives.Epoch) (state.BeaconState, error) {
+	if epochs == 0 {
+		return st, nil
+	}
+	target, err := slots.EpochStart(slots.ToEpoch(st.Slot()) + epochs)
 	if err != nil {
 		return nil, err
 	}
+	return transition.ProcessSlotsUnsafeFastForward(ctx, st, target)
+}
 
-	var blockRoot [32]byte
-	switch b := block.(type) {
-	case *ethpb.BeaconBlock:
-		blockRoot, err = signing.ComputeSigningRoot(b, domain)
-	case *ethpb.BeaconBlockAltair:
-		blockRoot, err = signing.ComputeSigningRoot(b, domain)
-	case *ethpb.BeaconBlockBellatrix:
-		blockRoot, err = signing.ComputeSigningRoot(b, domain)
-	case *ethpb.BeaconBlockCapella:
-		blockRoot, err = signing.ComputeSigningRoot(b, domain)
-	case *ethpb.BeaconBlockDeneb:
-		blockRoot, err = signing.ComputeSigningRoot(b, domain)
-	case *ethpb.BeaconBlockElectra:
-		blockRoot, err = signing.ComputeSigningRoot(b, domain)
+// ProposerScheduleForEpoch returns the proposer index of each slot of the given epoch, which must not be
+// before the state's epoch. A copy of the state is advanced with empty slots to the start of the epoch, from
+// which the proposers of every slot of the epoch are computed.
+func ProposerScheduleForEpoch(st state.BeaconState, epoch primitives.Epoch) (map[primitives.Slot]primitives.ValidatorIndex, error) {
+	ctx := context.Background()
+	if currentEpoch := slots.ToEpoch(st.Slot()); epoch < currentEpoch {
+		return nil, fmt.Errorf("epoch %d is before the state's epoch %d", epoch, currentEpoch)
 	}
+	start, err := slots.EpochStart(epoch)
 	if err != nil {
 		return nil, err
 	}
+	stCopy := st.Copy()
+	if stCopy.Slot() < start {
+		stCopy, err = transition.ProcessSlots(ctx, stCopy, start)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", start)
+		}
+	}
+	proposerIndices, err := helpers.ProposerIndicesForEpoch(ctx, stCopy, epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get proposer indices of epoch %d", epoch)
+	}
+	schedule := make(map[primitives.Slot]primitives.ValidatorIndex, len(proposerIndices))
+	for i, idx := range proposerIndices {
+		schedule[start+primitives.Slot(i)] = idx
+	}
+	return schedule, nil
+}
 
-	proposerIdx, err := helpers.BeaconProposerIndex(context.Background(), bState)
+// proposerIndexAtSlot returns the proposer of the slot, read from the proposer indices of the slot's epoch
+// from the point of view of the state. Generating a chain looks up the proposer of every slot of an epoch,
+// whose proposer indices are only computed once and cached.
+func proposerIndexAtSlot(ctx context.Context, st state.ReadOnlyBeaconState, slot primitives.Slot) (primitives.ValidatorIndex, error) {
+	proposerIndices, err := helpers.ProposerIndicesForEpoch(ctx, st, slots.ToEpoch(slot))
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return privKeys[proposerIdx].Sign(blockRoot[:]), nil
+	return proposerIndices[slot%params.BeaconConfig().SlotsPerEpoch], nil
+}
+
+// AlignedGenesisTime returns the genesis time for which now is exactly the start of the given slot.
+// It is meant to set up states and tickers that are driven by a fake clock, for example with
+// AlignedGenesisTime(clock.Now(), slot). Start the fake clock at a whole second if the genesis time
+// is stored in a state, since states only keep the genesis time in seconds.
+func AlignedGenesisTime(now time.Time, slot primitives.Slot) time.Time {
+	return now.Add(-slots.SlotDuration() * time.Duration(slot))
 }
 
 // Random32Bytes generates a random 32 byte slice.
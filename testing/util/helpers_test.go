@@ -5,16 +5,25 @@ import (
 	"context"
 	"encoding/binary"
 	"testing"
+	gotime "time"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	coreBlocks "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	slottest "github.com/prysmaticlabs/prysm/v5/time/slots/testing"
 )
 
 func TestBlockSignature(t *testing.T) {
@@ -59,3 +68,267 @@ func TestRandaoReveal(t *testing.T) {
 		t.Errorf("Expected randao reveals to be equal, received %#x != %#x", randaoReveal, epochSignature)
 	}
 }
+
+func TestAdvanceStateEpochs(t *testing.T) {
+	beaconState, _ := DeterministicGenesisState(t, 64)
+	st, err := AdvanceStateEpochs(context.Background(), beaconState, 3)
+	require.NoError(t, err)
+	require.Equal(t, params.BeaconConfig().SlotsPerEpoch*3, st.Slot())
+
+	st, err = AdvanceStateEpochs(context.Background(), st, 0)
+	require.NoError(t, err)
+	require.Equal(t, params.BeaconConfig().SlotsPerEpoch*3, st.Slot())
+}
+
+func TestProposerScheduleForEpoch(t *testing.T) {
+	helpers.ClearCache()
+	// Genesis states share their latest block header, so cached skipped slots of other tests' states would be reused.
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	beaconState, _ := DeterministicGenesisState(t, 64)
+	epoch := primitives.Epoch(2)
+	schedule, err := ProposerScheduleForEpoch(beaconState, epoch)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), beaconState.Slot(), "Input state was mutated")
+	require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(schedule))
+
+	start, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	st := beaconState.Copy()
+	for slot := start; slot < start+params.BeaconConfig().SlotsPerEpoch; slot++ {
+		st, err = transition.ProcessSlots(context.Background(), st, slot)
+		require.NoError(t, err)
+		want, err := helpers.BeaconProposerIndex(context.Background(), st)
+		require.NoError(t, err)
+		idx, ok := schedule[slot]
+		require.Equal(t, true, ok, "Missing proposer for slot %d", slot)
+		require.Equal(t, want, idx, "Wrong proposer for slot %d", slot)
+	}
+
+	mid, err := transition.ProcessSlots(context.Background(), beaconState.Copy(), start+3)
+	require.NoError(t, err)
+	midSchedule, err := ProposerScheduleForEpoch(mid, epoch)
+	require.NoError(t, err)
+	require.DeepEqual(t, schedule, midSchedule)
+
+	_, err = ProposerScheduleForEpoch(mid, epoch-1)
+	require.ErrorContains(t, "is before the state's epoch", err)
+}
+
+func TestBlockSignature_FirstSlotOfFork(t *testing.T) {
+	tests := []struct {
+		name     string
+		genesis  func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey)
+		forks    map[int]primitives.Epoch
+		version  func(*params.BeaconChainConfig) []byte
+		generate func(state.BeaconState, []bls.SecretKey, primitives.Slot) (interface{}, error)
+	}{
+		{
+			name:    "altair",
+			genesis: DeterministicGenesisState,
+			forks:   map[int]primitives.Epoch{version.Altair: 1},
+			version: func(cfg *params.BeaconChainConfig) []byte { return cfg.AltairForkVersion },
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockAltair(st, privs, nil, slot)
+			},
+		},
+		{
+			name:    "bellatrix",
+			genesis: DeterministicGenesisStateAltair,
+			forks:   map[int]primitives.Epoch{version.Altair: 0, version.Bellatrix: 1},
+			version: func(cfg *params.BeaconChainConfig) []byte { return cfg.BellatrixForkVersion },
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockBellatrix(st, privs, nil, slot)
+			},
+		},
+		{
+			name:    "capella",
+			genesis: DeterministicGenesisStateBellatrix,
+			forks:   map[int]primitives.Epoch{version.Altair: 0, version.Bellatrix: 0, version.Capella: 1},
+			version: func(cfg *params.BeaconChainConfig) []byte { return cfg.CapellaForkVersion },
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockCapella(st, privs, nil, slot)
+			},
+		},
+		{
+			name:    "deneb",
+			genesis: DeterministicGenesisStateCapella,
+			forks: map[int]primitives.Epoch{
+				version.Altair:    0,
+				version.Bellatrix: 0,
+				version.Capella:   0,
+				version.Deneb:     1,
+			},
+			version:  func(cfg *params.BeaconChainConfig) []byte { return cfg.DenebForkVersion },
+			generate: emptyBlockDeneb,
+		},
+		{
+			name:    "electra",
+			genesis: DeterministicGenesisStateDeneb,
+			forks: map[int]primitives.Epoch{
+				version.Altair:    0,
+				version.Bellatrix: 0,
+				version.Capella:   0,
+				version.Deneb:     0,
+				version.Electra:   1,
+			},
+			version: func(cfg *params.BeaconChainConfig) []byte { return cfg.ElectraForkVersion },
+			generate: func(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+				return GenerateFullBlockElectra(st, privs, nil, slot)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params.SetupTestConfigWithForkSchedule(t, tt.forks)
+			cfg := params.BeaconConfig()
+
+			// Deterministic genesis states share their latest block header, so skipped slots cached
+			// by other tests could be returned for this state.
+			transition.SkipSlotCache.Disable()
+			defer transition.SkipSlotCache.Enable()
+			helpers.ClearCache()
+
+			ctx := context.Background()
+			genesis, privs := tt.genesis(t, 64)
+			if genesis.Version() >= version.Altair {
+				setSyncCommittees(t, genesis)
+			}
+			forkSlot := cfg.SlotsPerEpoch
+			st, err := transition.ProcessSlots(ctx, genesis, forkSlot-1)
+			require.NoError(t, err)
+
+			generated, err := tt.generate(st, privs, forkSlot)
+			require.NoError(t, err)
+			wsb, err := blocks.NewSignedBeaconBlock(generated)
+			require.NoError(t, err)
+			require.Equal(t, forkSlot, wsb.Block().Slot())
+
+			postSlots, err := transition.ProcessSlots(ctx, st.Copy(), forkSlot)
+			require.NoError(t, err)
+			require.DeepEqual(t, tt.version(cfg), postSlots.Fork().CurrentVersion)
+			sig := wsb.Signature()
+			require.NoError(t, coreBlocks.VerifyBlockSignature(postSlots, wsb.Block().ProposerIndex(), sig[:], wsb.Block().HashTreeRoot))
+		})
+	}
+}
+
+// emptyBlockDeneb returns a signed Deneb block without operations for the slot. There is no full
+// block generator for Deneb, so the block only carries what its state transition requires.
+func emptyBlockDeneb(st state.BeaconState, privs []bls.SecretKey, slot primitives.Slot) (interface{}, error) {
+	ctx := context.Background()
+	postSlots, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+	if err != nil {
+		return nil, err
+	}
+	random, err := helpers.RandaoMix(postSlots, time.CurrentEpoch(postSlots))
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := slots.ToTime(postSlots.GenesisTime(), slot)
+	if err != nil {
+		return nil, err
+	}
+	parentExecution, err := postSlots.LatestExecutionPayloadHeader()
+	if err != nil {
+		return nil, err
+	}
+	withdrawals, _, err := postSlots.ExpectedWithdrawals()
+	if err != nil {
+		return nil, err
+	}
+	parentRoot, err := postSlots.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	reveal, err := RandaoReveal(postSlots, time.CurrentEpoch(postSlots), privs)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := helpers.BeaconProposerIndex(ctx, postSlots)
+	if err != nil {
+		return nil, err
+	}
+
+	blk := NewBeaconBlockDeneb()
+	blk.Block.Slot = slot
+	blk.Block.ProposerIndex = idx
+	blk.Block.ParentRoot = parentRoot[:]
+	blk.Block.Body.RandaoReveal = reveal
+	blk.Block.Body.Eth1Data = postSlots.Eth1Data()
+	blk.Block.Body.SyncAggregate = emptySyncAggregate()
+	blk.Block.Body.ExecutionPayload.ParentHash = parentExecution.BlockHash()
+	blk.Block.Body.ExecutionPayload.PrevRandao = random
+	blk.Block.Body.ExecutionPayload.Timestamp = uint64(timestamp.Unix())
+	blk.Block.Body.ExecutionPayload.Withdrawals = withdrawals
+
+	sig, err := BlockSignature(st, blk.Block, privs)
+	if err != nil {
+		return nil, err
+	}
+	blk.Signature = sig.Marshal()
+	return blk, nil
+}
+
+// setSyncCommittees replaces the empty sync committees of an Altair genesis state with committees
+// of its validators, so that blocks built on it pass sync aggregate processing.
+func setSyncCommittees(t *testing.T, st state.BeaconState) {
+	committee, err := altair.NextSyncCommittee(context.Background(), st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(committee))
+	require.NoError(t, st.SetNextSyncCommittee(committee))
+}
+
+func TestRandaoRevealAtEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privKeys := DeterministicGenesisState(t, 64)
+
+	t.Run("current epoch", func(t *testing.T) {
+		want, err := RandaoReveal(beaconState, 0, privKeys)
+		require.NoError(t, err)
+		got, err := RandaoRevealAtEpoch(beaconState, 0, privKeys)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, got)
+	})
+
+	t.Run("across fork boundary", func(t *testing.T) {
+		params.SetupTestConfigWithForkSchedule(t, map[int]primitives.Epoch{version.Altair: 1})
+		cfg := params.BeaconConfig()
+		transition.SkipSlotCache.Disable()
+		defer transition.SkipSlotCache.Enable()
+
+		reveal, err := RandaoRevealAtEpoch(beaconState, 1, privKeys)
+		require.NoError(t, err)
+		stale, err := RandaoRevealAtEpochWithForkVersion(beaconState, 1, privKeys, cfg.GenesisForkVersion)
+		require.NoError(t, err)
+
+		st, err := transition.ProcessSlots(ctx, beaconState.Copy(), cfg.SlotsPerEpoch)
+		require.NoError(t, err)
+		require.DeepEqual(t, cfg.AltairForkVersion, st.Fork().CurrentVersion)
+
+		set, err := coreBlocks.RandaoSignatureBatch(ctx, st, reveal)
+		require.NoError(t, err)
+		valid, err := set.Verify()
+		require.NoError(t, err)
+		require.Equal(t, true, valid)
+
+		set, err = coreBlocks.RandaoSignatureBatch(ctx, st, stale)
+		require.NoError(t, err)
+		valid, err = set.Verify()
+		require.NoError(t, err)
+		require.Equal(t, false, valid)
+	})
+}
+
+func TestAlignedGenesisTime(t *testing.T) {
+	clock := slottest.NewFakeClock(gotime.Unix(1_700_000_000, 0))
+	genesis := AlignedGenesisTime(clock.Now(), 5)
+	require.Equal(t, clock.Now(), slots.BeginsAt(5, genesis))
+	require.Equal(t, clock.Now(), AlignedGenesisTime(clock.Now(), 0))
+
+	ticker := slots.NewSlotTickerWithClock(genesis, params.BeaconConfig().SecondsPerSlot, clock)
+	defer ticker.Done()
+	clock.BlockUntil(1)
+	clock.AdvanceToSlot(genesis, 6)
+	require.Equal(t, primitives.Slot(6), <-ticker.C())
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// AppendHistoricalSummaries appends n historical summaries to a Capella or later state. Each
+// summary is the root of a full period of block and state roots, where the roots of a period are
+// the little endian encodings of its slots, with the last byte of state roots set to 0xff so that
+// the two never collide. Summaries continue from the number already in the state, so repeated
+// calls keep producing distinct entries.
+func AppendHistoricalSummaries(st state.BeaconState, n int) error {
+	if st.Version() < version.Capella {
+		return errors.Errorf("historical summaries are not supported for %s states", version.String(st.Version()))
+	}
+	existing, err := st.HistoricalSummaries()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		summary, err := periodSummary(uint64(len(existing) + i))
+		if err != nil {
+			return errors.Wrapf(err, "could not compute historical summary %d", len(existing)+i)
+		}
+		if err := st.AppendHistoricalSummaries(summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HistoricalSummaryAtBoundary returns the historical summary that epoch processing appends when the
+// state's current epoch is the last of a period, i.e. the roots of its current block and state root
+// vectors. It is meant to be compared against the last entry of a state after
+// ProcessHistoricalDataUpdate has run on it.
+func HistoricalSummaryAtBoundary(st state.ReadOnlyBeaconState) (*ethpb.HistoricalSummary, error) {
+	if st.Version() < version.Capella {
+		return nil, errors.Errorf("historical summaries are not supported for %s states", version.String(st.Version()))
+	}
+	br, err := stateutil.ArraysRoot(st.BlockRoots(), fieldparams.BlockRootsLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block roots root")
+	}
+	sr, err := stateutil.ArraysRoot(st.StateRoots(), fieldparams.StateRootsLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state roots root")
+	}
+	return &ethpb.HistoricalSummary{BlockSummaryRoot: br[:], StateSummaryRoot: sr[:]}, nil
+}
+
+func periodSummary(period uint64) (*ethpb.HistoricalSummary, error) {
+	blockRoots := make([][]byte, fieldparams.BlockRootsLength)
+	stateRoots := make([][]byte, fieldparams.StateRootsLength)
+	for j := range blockRoots {
+		slot := period*fieldparams.BlockRootsLength + uint64(j)
+		blockRoots[j] = bytesutil.PadTo(bytesutil.Bytes8(slot), fieldparams.RootLength)
+		stateRoots[j] = bytesutil.PadTo(bytesutil.Bytes8(slot), fieldparams.RootLength)
+		stateRoots[j][fieldparams.RootLength-1] = 0xff
+	}
+	br, err := stateutil.ArraysRoot(blockRoots, fieldparams.BlockRootsLength)
+	if err != nil {
+		return nil, err
+	}
+	sr, err := stateutil.ArraysRoot(stateRoots, fieldparams.StateRootsLength)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.HistoricalSummary{BlockSummaryRoot: br[:], StateSummaryRoot: sr[:]}, nil
+}
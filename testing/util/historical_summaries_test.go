@@ -0,0 +1,57 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestAppendHistoricalSummaries(t *testing.T) {
+	st, _ := DeterministicGenesisStateCapella(t, 8)
+	require.NoError(t, AppendHistoricalSummaries(st, 3))
+	first, err := st.HistoricalSummaries()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(first))
+
+	require.NoError(t, AppendHistoricalSummaries(st, 2))
+	all, err := st.HistoricalSummaries()
+	require.NoError(t, err)
+	require.Equal(t, 5, len(all))
+	require.DeepEqual(t, first, all[:3])
+	seen := make(map[[32]byte]bool)
+	for _, s := range all {
+		assert.Equal(t, fieldparams.RootLength, len(s.BlockSummaryRoot))
+		assert.DeepNotEqual(t, s.BlockSummaryRoot, s.StateSummaryRoot)
+		seen[[32]byte(s.BlockSummaryRoot)] = true
+	}
+	assert.Equal(t, len(all), len(seen), "summaries are not distinct")
+
+	phase0, _ := DeterministicGenesisState(t, 8)
+	require.ErrorContains(t, "not supported", AppendHistoricalSummaries(phase0, 1))
+}
+
+func TestHistoricalSummaryAtBoundary(t *testing.T) {
+	st, _ := DeterministicGenesisStateCapella(t, 8)
+	require.NoError(t, AppendHistoricalSummaries(st, 2))
+	roots, err := PrepareRoots(fieldparams.BlockRootsLength)
+	require.NoError(t, err)
+	require.NoError(t, st.SetBlockRoots(roots))
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerHistoricalRoot-1))
+
+	want, err := HistoricalSummaryAtBoundary(st)
+	require.NoError(t, err)
+	st, err = epoch.ProcessHistoricalDataUpdate(st)
+	require.NoError(t, err)
+	summaries, err := st.HistoricalSummaries()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(summaries))
+	require.DeepEqual(t, want, summaries[2])
+
+	phase0, _ := DeterministicGenesisState(t, 8)
+	_, err = HistoricalSummaryAtBoundary(phase0)
+	require.ErrorContains(t, "not supported", err)
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// GenerateJustifyingBlocks generates a phase0 block at every slot of the epoch of the given state,
+// but its start slot, each attested by every committee of the slot before it. The attestations of
+// all but the last slot of the epoch are then included within the epoch, which is more than the
+// two thirds of the active balance needed for the epoch transition to justify it. The state must
+// be at the start slot of an epoch after the first two, for which justification is skipped. The
+// blocks are generated on a copy of the state and returned in order, for the caller to apply.
+func GenerateJustifyingBlocks(
+	ctx context.Context,
+	bState state.BeaconState,
+	privs []bls.SecretKey,
+) ([]*ethpb.SignedBeaconBlock, error) {
+	if !slots.IsEpochStart(bState.Slot()) {
+		return nil, errors.Errorf("state slot %d is not the start slot of an epoch", bState.Slot())
+	}
+	epoch := slots.ToEpoch(bState.Slot())
+	if epoch <= params.BeaconConfig().GenesisEpoch+1 {
+		return nil, errors.Errorf("epoch %d can't be justified, justification starts after epoch %d", epoch, params.BeaconConfig().GenesisEpoch+1)
+	}
+	activeCount, err := helpers.ActiveValidatorCount(ctx, bState, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validator count")
+	}
+	conf := &BlockGenConfig{NumAttestations: helpers.SlotCommitteeCount(activeCount)}
+
+	blks, st, err := GenerateChain(ctx, bState, privs, conf, uint64(params.BeaconConfig().SlotsPerEpoch-1))
+	if err != nil {
+		return nil, err
+	}
+	// The epoch is justified by the transition to the next one, which is processed on a copy to
+	// check that the blocks carry enough attesting balance.
+	st, err = transition.ProcessSlots(ctx, st.Copy(), st.Slot()+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process epoch transition")
+	}
+	if st.CurrentJustifiedCheckpoint().Epoch != epoch {
+		return nil, errors.Errorf("generated blocks did not justify epoch %d, justified epoch is %d", epoch, st.CurrentJustifiedCheckpoint().Epoch)
+	}
+	return blks, nil
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestGenerateJustifyingBlocks(t *testing.T) {
+	ctx := context.Background()
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+	genesis, privs := DeterministicGenesisState(t, 64)
+
+	_, err := GenerateJustifyingBlocks(ctx, genesis, privs)
+	require.ErrorContains(t, "can't be justified", err)
+
+	epochStart, err := slots.EpochStart(2)
+	require.NoError(t, err)
+	st, err := transition.ProcessSlots(ctx, genesis.Copy(), epochStart+1)
+	require.NoError(t, err)
+	_, err = GenerateJustifyingBlocks(ctx, st, privs)
+	require.ErrorContains(t, "not the start slot of an epoch", err)
+
+	st, err = transition.ProcessSlots(ctx, genesis.Copy(), epochStart)
+	require.NoError(t, err)
+	blks, err := GenerateJustifyingBlocks(ctx, st, privs)
+	require.NoError(t, err)
+	require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch)-1, len(blks))
+	// The state is left untouched and the caller applies the blocks.
+	require.Equal(t, epochStart, st.Slot())
+	require.NotEqual(t, primitives.Epoch(2), st.CurrentJustifiedCheckpoint().Epoch)
+
+	for _, blk := range blks {
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+	}
+	st, err = transition.ProcessSlots(ctx, st, st.Slot()+1)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(2), st.CurrentJustifiedCheckpoint().Epoch)
+}
@@ -0,0 +1,161 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// TestLightClient is a light client fixture: a block at the second slot of the Altair fork along
+// with its post state, and its parent block, the attested block, along with the parent's post
+// state, which is the attested state. The block's sync aggregate only has participation bits
+// set, its signature is empty.
+type TestLightClient struct {
+	T              *testing.T
+	Ctx            context.Context
+	State          state.BeaconState
+	Block          interfaces.ReadOnlySignedBeaconBlock
+	AttestedState  state.BeaconState
+	AttestedBlock  interfaces.ReadOnlySignedBeaconBlock
+	AttestedHeader *ethpb.BeaconBlockHeader
+}
+
+// TestLightClientConfig configures the states and blocks of a light client fixture.
+type TestLightClientConfig struct {
+	// Version is the fork version of the states and blocks, Altair or Capella.
+	Version int
+	// Participants is the number of participation bits set in the block's sync aggregate.
+	Participants uint64
+	// AttestedSlotBeforeBlock places the attested block at the slot before the block, as on a
+	// real chain, instead of at the same slot as the block.
+	AttestedSlotBeforeBlock bool
+}
+
+// NewTestLightClient returns an empty light client fixture, to be populated with SetupTest.
+func NewTestLightClient(t *testing.T) *TestLightClient {
+	return &TestLightClient{T: t}
+}
+
+// SetupTest populates the fixture with Capella states and blocks at the same slot. The block's
+// sync aggregate has the minimum number of participants required for an update.
+func (l *TestLightClient) SetupTest() *TestLightClient {
+	return l.SetupTestWithConfig(TestLightClientConfig{
+		Version:      version.Capella,
+		Participants: params.BeaconConfig().MinSyncCommitteeParticipants,
+	})
+}
+
+// SetupTestWithConfig populates the fixture with states and blocks as described by the config.
+func (l *TestLightClient) SetupTestWithConfig(cfg TestLightClientConfig) *TestLightClient {
+	ctx := context.Background()
+
+	slot := primitives.Slot(params.BeaconConfig().AltairForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
+	attestedSlot := slot
+	if cfg.AttestedSlotBeforeBlock {
+		attestedSlot = slot.Sub(1)
+	}
+
+	attestedState, parent := l.newStateAndBlock(cfg.Version)
+	err := attestedState.SetSlot(attestedSlot)
+	require.NoError(l.T, err)
+	parent.SetSlot(attestedSlot)
+
+	parentHeader, err := parent.Header()
+	require.NoError(l.T, err)
+	// copy the header, as it shares its roots with the block
+	attestedHeader := ethpb.CopyBeaconBlockHeader(parentHeader.Header)
+
+	err = attestedState.SetLatestBlockHeader(attestedHeader)
+	require.NoError(l.T, err)
+	attestedStateRoot, err := attestedState.HashTreeRoot(ctx)
+	require.NoError(l.T, err)
+
+	// set the state root so the root of the block is updated with the new state root
+	parent.SetStateRoot(attestedStateRoot[:])
+
+	state, block := l.newStateAndBlock(cfg.Version)
+	err = state.SetSlot(slot)
+	require.NoError(l.T, err)
+
+	parentRoot, err := parent.Block().HashTreeRoot()
+	require.NoError(l.T, err)
+
+	block.SetSlot(slot)
+	block.SetParentRoot(parentRoot[:])
+
+	syncAggregate, err := block.Block().Body().SyncAggregate()
+	require.NoError(l.T, err)
+	for i := uint64(0); i < cfg.Participants; i++ {
+		syncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	}
+	require.NoError(l.T, block.SetSyncAggregate(syncAggregate))
+
+	h, err := block.Header()
+	require.NoError(l.T, err)
+
+	err = state.SetLatestBlockHeader(ethpb.CopyBeaconBlockHeader(h.Header))
+	require.NoError(l.T, err)
+	stateRoot, err := state.HashTreeRoot(ctx)
+	require.NoError(l.T, err)
+
+	// set the state root so the root of the block is updated with the new state root
+	block.SetStateRoot(stateRoot[:])
+
+	l.State = state
+	l.AttestedState = attestedState
+	l.AttestedBlock = parent
+	l.AttestedHeader = attestedHeader
+	l.Block = block
+	l.Ctx = ctx
+
+	return l
+}
+
+func (l *TestLightClient) newStateAndBlock(v int) (state.BeaconState, interfaces.SignedBeaconBlock) {
+	var st state.BeaconState
+	var b interface{}
+	var err error
+	switch v {
+	case version.Altair:
+		st, err = NewBeaconStateAltair()
+		b = NewBeaconBlockAltair()
+	case version.Capella:
+		st, err = NewBeaconStateCapella()
+		b = NewBeaconBlockCapella()
+	default:
+		l.T.Fatalf("unsupported light client fixture version %s", version.String(v))
+	}
+	require.NoError(l.T, err)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(l.T, err)
+	return st, blk
+}
+
+// CheckAttestedHeader checks that the update's attested header is the header of the fixture's attested block.
+func (l *TestLightClient) CheckAttestedHeader(update *ethpbv2.LightClientUpdate) {
+	require.Equal(l.T, l.AttestedHeader.Slot, update.AttestedHeader.Slot, "Attested header slot is not equal")
+	require.Equal(l.T, l.AttestedHeader.ProposerIndex, update.AttestedHeader.ProposerIndex, "Attested header proposer index is not equal")
+	require.DeepSSZEqual(l.T, l.AttestedHeader.ParentRoot, update.AttestedHeader.ParentRoot, "Attested header parent root is not equal")
+	require.DeepSSZEqual(l.T, l.AttestedHeader.BodyRoot, update.AttestedHeader.BodyRoot, "Attested header body root is not equal")
+
+	attestedStateRoot, err := l.AttestedState.HashTreeRoot(l.Ctx)
+	require.NoError(l.T, err)
+	require.DeepSSZEqual(l.T, attestedStateRoot[:], update.AttestedHeader.StateRoot, "Attested header state root is not equal")
+}
+
+// CheckSyncAggregate checks that the update's sync aggregate is the sync aggregate of the fixture's block.
+func (l *TestLightClient) CheckSyncAggregate(update *ethpbv2.LightClientUpdate) {
+	syncAggregate, err := l.Block.Block().Body().SyncAggregate()
+	require.NoError(l.T, err)
+	require.DeepSSZEqual(l.T, syncAggregate.SyncCommitteeBits, update.SyncAggregate.SyncCommitteeBits, "SyncAggregate bits is not equal")
+	require.DeepSSZEqual(l.T, syncAggregate.SyncCommitteeSignature, update.SyncAggregate.SyncCommitteeSignature, "SyncAggregate signature is not equal")
+}
@@ -0,0 +1,91 @@
+//go:build minimal
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// TestGenerateFullBlock_MinimalPreset generates and processes one full block per fork, to make sure
+// the generators size every field from the preset of the build.
+func TestGenerateFullBlock_MinimalPreset(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig().Copy())
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	tests := []struct {
+		name     string
+		genesis  func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey)
+		generate func(state.BeaconState, []bls.SecretKey, *BlockGenConfig) (interface{}, error)
+	}{
+		{
+			name:    "phase0",
+			genesis: DeterministicGenesisState,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, conf *BlockGenConfig) (interface{}, error) {
+				return GenerateFullBlock(st, privs, conf, st.Slot()+1)
+			},
+		},
+		{
+			name:    "altair",
+			genesis: DeterministicGenesisStateAltair,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, conf *BlockGenConfig) (interface{}, error) {
+				return GenerateFullBlockAltair(st, privs, conf, st.Slot()+1)
+			},
+		},
+		{
+			name:    "bellatrix",
+			genesis: DeterministicGenesisStateBellatrix,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, conf *BlockGenConfig) (interface{}, error) {
+				return GenerateFullBlockBellatrix(st, privs, conf, st.Slot()+1)
+			},
+		},
+		{
+			name:    "capella",
+			genesis: DeterministicGenesisStateCapella,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, conf *BlockGenConfig) (interface{}, error) {
+				return GenerateFullBlockCapella(st, privs, conf, st.Slot()+1)
+			},
+		},
+		{
+			name:    "electra",
+			genesis: DeterministicGenesisStateElectra,
+			generate: func(st state.BeaconState, privs []bls.SecretKey, conf *BlockGenConfig) (interface{}, error) {
+				return GenerateFullBlockElectra(st, privs, conf, st.Slot()+1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			helpers.ClearCache()
+			st, privs := tt.genesis(t, 64)
+			if st.Version() >= version.Altair {
+				committee, err := altair.NextSyncCommittee(ctx, st)
+				require.NoError(t, err)
+				require.NoError(t, st.SetCurrentSyncCommittee(committee))
+				require.NoError(t, st.SetNextSyncCommittee(committee))
+			}
+			conf := DefaultBlockGenConfig()
+			conf.FullSyncAggregate = true
+			blk, err := tt.generate(st, privs, conf)
+			require.NoError(t, err)
+			wsb, err := blocks.NewSignedBeaconBlock(blk)
+			require.NoError(t, err)
+			st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+			require.NoError(t, err)
+			require.Equal(t, wsb.Block().Slot(), st.Slot())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package util
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// BlockMutator changes a single field of a signed beacon block. It is meant to be used
+// with MutateBlock to table-drive negative block processing tests.
+type BlockMutator func(interfaces.SignedBeaconBlock) error
+
+// MutateSlot sets the block slot.
+func MutateSlot(slot primitives.Slot) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetSlot(slot)
+		return nil
+	}
+}
+
+// MutateProposerIndex sets the block proposer index.
+func MutateProposerIndex(idx primitives.ValidatorIndex) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetProposerIndex(idx)
+		return nil
+	}
+}
+
+// MutateParentRoot sets the block parent root.
+func MutateParentRoot(root [32]byte) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetParentRoot(root[:])
+		return nil
+	}
+}
+
+// MutateStateRoot sets the block state root.
+func MutateStateRoot(root [32]byte) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetStateRoot(root[:])
+		return nil
+	}
+}
+
+// MutateRandaoReveal sets the randao reveal of the block body.
+func MutateRandaoReveal(reveal []byte) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetRandaoReveal(reveal)
+		return nil
+	}
+}
+
+// MutateGraffiti sets the graffiti of the block body.
+func MutateGraffiti(graffiti [32]byte) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetGraffiti(graffiti[:])
+		return nil
+	}
+}
+
+// MutateEth1Data sets the eth1 data vote of the block body.
+func MutateEth1Data(data *ethpb.Eth1Data) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetEth1Data(data)
+		return nil
+	}
+}
+
+// MutateSignature sets the block signature.
+func MutateSignature(sig []byte) BlockMutator {
+	return func(b interfaces.SignedBeaconBlock) error {
+		b.SetSignature(sig)
+		return nil
+	}
+}
+
+// MutateBlock returns a copy of the block with the mutation applied. The original block is left
+// untouched and the signature of the copy is not updated.
+func MutateBlock(blk interfaces.ReadOnlySignedBeaconBlock, mutate BlockMutator) (interfaces.SignedBeaconBlock, error) {
+	if blk == nil || blk.IsNil() {
+		return nil, errors.New("nil block")
+	}
+	cp, err := blk.Copy()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not copy block")
+	}
+	if err := mutate(cp); err != nil {
+		return nil, errors.Wrap(err, "could not mutate block")
+	}
+	return cp, nil
+}
+
+// MutateAndResignBlock is like MutateBlock but signs the mutated block again with the key of its
+// (possibly mutated) proposer, so only the mutated field makes the block invalid. Unlike
+// BlockSignature, the state root of the block is kept as is.
+func MutateAndResignBlock(
+	st state.ReadOnlyBeaconState,
+	privs []bls.SecretKey,
+	blk interfaces.ReadOnlySignedBeaconBlock,
+	mutate BlockMutator,
+) (interfaces.SignedBeaconBlock, error) {
+	cp, err := MutateBlock(blk, mutate)
+	if err != nil {
+		return nil, err
+	}
+	idx := cp.Block().ProposerIndex()
+	if uint64(idx) >= uint64(len(privs)) {
+		return nil, errors.Errorf("no private key for proposer index %d", idx)
+	}
+	sig, err := signing.ComputeDomainAndSign(st, slots.ToEpoch(cp.Block().Slot()), cp.Block(), params.BeaconConfig().DomainBeaconProposer, privs[idx])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign mutated block")
+	}
+	cp.SetSignature(sig)
+	return cp, nil
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+)
+
+// PayloadToHeader converts the execution payload into the execution payload header of the same
+// fork, with the transactions root and, from Capella on, the withdrawals root computed from the
+// payload. As with any header, its hash tree root is the one of the payload.
+func PayloadToHeader(payload interfaces.ExecutionData) (interfaces.ExecutionData, error) {
+	if payload == nil || payload.IsNil() {
+		return nil, errors.New("nil execution payload")
+	}
+	if payload.IsBlinded() {
+		return nil, errors.New("execution payload is already a header")
+	}
+	switch p := payload.Proto().(type) {
+	case *enginev1.ExecutionPayload:
+		h, err := blocks.PayloadToHeader(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert payload to header")
+		}
+		return blocks.WrappedExecutionPayloadHeader(h)
+	case *enginev1.ExecutionPayloadCapella:
+		h, err := blocks.PayloadToHeaderCapella(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert payload to header")
+		}
+		return blocks.WrappedExecutionPayloadHeaderCapella(h)
+	case *enginev1.ExecutionPayloadDeneb:
+		h, err := blocks.PayloadToHeaderDeneb(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert payload to header")
+		}
+		return blocks.WrappedExecutionPayloadHeaderDeneb(h)
+	case *enginev1.ExecutionPayloadElectra:
+		electraPayload, ok := payload.(interfaces.ExecutionDataElectra)
+		if !ok {
+			return nil, errors.Errorf("%T does not hold electra execution data", payload)
+		}
+		h, err := blocks.PayloadToHeaderElectra(electraPayload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert payload to header")
+		}
+		return blocks.WrappedExecutionPayloadHeaderElectra(h)
+	default:
+		return nil, errors.Errorf("unsupported execution payload type %T", p)
+	}
+}
@@ -0,0 +1,86 @@
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestPayloadToHeader(t *testing.T) {
+	txs := [][]byte{[]byte("tx0"), []byte("tx1")}
+	withdrawals := []*enginev1.Withdrawal{
+		{Index: 1, ValidatorIndex: 2, Address: bytesutil.PadTo([]byte{0xaa}, fieldparams.FeeRecipientLength), Amount: 3},
+	}
+
+	bellatrix := NewBeaconBlockBellatrix().Block.Body.ExecutionPayload
+	bellatrix.Transactions = txs
+	capella := NewBeaconBlockCapella().Block.Body.ExecutionPayload
+	capella.Transactions = txs
+	capella.Withdrawals = withdrawals
+	deneb := NewBeaconBlockDeneb().Block.Body.ExecutionPayload
+	deneb.Transactions = txs
+	deneb.Withdrawals = withdrawals
+	deneb.BlobGasUsed = 4
+	deneb.ExcessBlobGas = 5
+	electra := NewBeaconBlockElectra().Block.Body.ExecutionPayload
+	electra.Transactions = txs
+	electra.Withdrawals = withdrawals
+	electra.WithdrawalRequests = []*enginev1.WithdrawalRequest{{
+		SourceAddress:   make([]byte, fieldparams.FeeRecipientLength),
+		ValidatorPubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		Amount:          6,
+	}}
+
+	tests := []struct {
+		name    string
+		payload func() (interfaces.ExecutionData, error)
+		header  interface{}
+	}{
+		{
+			name:    "bellatrix",
+			payload: func() (interfaces.ExecutionData, error) { return blocks.WrappedExecutionPayload(bellatrix) },
+			header:  &enginev1.ExecutionPayloadHeader{},
+		},
+		{
+			name:    "capella",
+			payload: func() (interfaces.ExecutionData, error) { return blocks.WrappedExecutionPayloadCapella(capella) },
+			header:  &enginev1.ExecutionPayloadHeaderCapella{},
+		},
+		{
+			name:    "deneb",
+			payload: func() (interfaces.ExecutionData, error) { return blocks.WrappedExecutionPayloadDeneb(deneb) },
+			header:  &enginev1.ExecutionPayloadHeaderDeneb{},
+		},
+		{
+			name:    "electra",
+			payload: func() (interfaces.ExecutionData, error) { return blocks.WrappedExecutionPayloadElectra(electra) },
+			header:  &enginev1.ExecutionPayloadHeaderElectra{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := tt.payload()
+			require.NoError(t, err)
+			header, err := PayloadToHeader(payload)
+			require.NoError(t, err)
+			assert.Equal(t, true, header.IsBlinded())
+			assert.Equal(t, fmt.Sprintf("%T", tt.header), fmt.Sprintf("%T", header.Proto()))
+			// The header commits to the payload's lists by their roots, so both have the same root.
+			want, err := payload.HashTreeRoot()
+			require.NoError(t, err)
+			got, err := header.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			_, err = PayloadToHeader(header)
+			require.ErrorContains(t, "already a header", err)
+		})
+	}
+}
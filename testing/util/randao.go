@@ -0,0 +1,22 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+// SetRandaoMix sets the randao mix of the given epoch, at index epoch % EPOCHS_PER_HISTORICAL_VECTOR
+// of the state's randao mixes. The seed of an epoch is derived from the mix of MIN_SEED_LOOKAHEAD + 1
+// epochs before, so setting that mix makes the proposers and committees of the epoch known in advance.
+func SetRandaoMix(st state.BeaconState, epoch primitives.Epoch, mix []byte) error {
+	if len(mix) != fieldparams.RootLength {
+		return fmt.Errorf("randao mix has length %d, expected %d", len(mix), fieldparams.RootLength)
+	}
+	idx := uint64(epoch % params.BeaconConfig().EpochsPerHistoricalVector)
+	return st.UpdateRandaoMixesAtIndex(idx, bytesutil.ToBytes32(mix))
+}
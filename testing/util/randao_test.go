@@ -0,0 +1,61 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestSetRandaoMix(t *testing.T) {
+	st, _ := DeterministicGenesisState(t, 64)
+	mix := bytes.Repeat([]byte{'a'}, 32)
+	epoch := params.BeaconConfig().EpochsPerHistoricalVector + 3
+
+	require.NoError(t, SetRandaoMix(st, epoch, mix))
+	got, err := st.RandaoMixAtIndex(3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, mix, got)
+	got, err = helpers.RandaoMix(st, epoch)
+	require.NoError(t, err)
+	assert.DeepEqual(t, mix, got)
+
+	err = SetRandaoMix(st, epoch, mix[:31])
+	assert.ErrorContains(t, "randao mix has length 31, expected 32", err)
+}
+
+func TestSetRandaoMix_KnownProposer(t *testing.T) {
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	// The proposers of epoch MIN_SEED_LOOKAHEAD + 1 are derived from the mix of the genesis epoch.
+	epoch := params.BeaconConfig().MinSeedLookahead + 1
+	slot, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	domain := params.BeaconConfig().DomainBeaconProposer
+
+	for _, mix := range [][]byte{bytes.Repeat([]byte{'a'}, 32), bytes.Repeat([]byte{'b'}, 32)} {
+		helpers.ClearCache()
+		st, privs := DeterministicGenesisState(t, 64)
+		require.NoError(t, SetRandaoMix(st, 0, mix))
+
+		seed := helpers.SeedForEpoch(mix, epoch, domain)
+		seedWithSlot := hash.Hash(append(seed[:], bytesutil.Bytes8(uint64(slot))...))
+		indices, err := helpers.ActiveValidatorIndices(context.Background(), st, epoch)
+		require.NoError(t, err)
+		want, err := helpers.ComputeProposerIndex(st, indices, seedWithSlot)
+		require.NoError(t, err)
+
+		blk, err := GenerateFullBlock(st, privs, DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		assert.Equal(t, want, blk.Block.ProposerIndex)
+	}
+}
@@ -0,0 +1,221 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// BlockSignature calculates the post-state root of the block, sets it on the block and returns the
+// proposer signature. The block is a beacon block proto of any fork. The signing domain is computed from
+// the post-state, which is at the block's slot, so a block at the first slot of a fork is signed with the
+// fork version it will be verified against.
+func BlockSignature(
+	bState state.BeaconState,
+	block interface{},
+	privKeys []bls.SecretKey,
+) (bls.Signature, error) {
+	wsb, err := signedBlockFromProto(block)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signBlock(bState, wsb, privKeys)
+	if err != nil {
+		return nil, err
+	}
+	stateRoot := wsb.Block().StateRoot()
+	if err := setProtoStateRoot(block, stateRoot[:]); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// blockSignatureWithPrevRandao returns the proposer signature of a post Bellatrix block proto whose payload's
+// prev randao may differ from the randao mix of the state. Such a payload fails the payload processing, so the
+// post-state root is calculated on rootBlock, a copy of the block whose payload has the randao mix as prev
+// randao, and set on the block, which is then signed with its own prev randao.
+func blockSignatureWithPrevRandao(
+	bState state.BeaconState,
+	block interface{},
+	rootBlock interface{},
+	privKeys []bls.SecretKey,
+) (bls.Signature, error) {
+	ctx := context.Background()
+	rootWsb, err := signedBlockFromProto(rootBlock)
+	if err != nil {
+		return nil, err
+	}
+	postState, err := setPostStateRoot(ctx, bState, rootWsb)
+	if err != nil {
+		return nil, err
+	}
+	stateRoot := rootWsb.Block().StateRoot()
+	if err := setProtoStateRoot(block, stateRoot[:]); err != nil {
+		return nil, err
+	}
+	wsb, err := signedBlockFromProto(block)
+	if err != nil {
+		return nil, err
+	}
+	return proposerSignature(ctx, postState, wsb.Block(), privKeys)
+}
+
+// signBlock calculates the post-state root of the block, sets it on the block and returns the proposer
+// signature, like BlockSignature does for block protos.
+func signBlock(bState state.BeaconState, block interfaces.SignedBeaconBlock, privKeys []bls.SecretKey) (bls.Signature, error) {
+	ctx := context.Background()
+	postState, err := setPostStateRoot(ctx, bState, block)
+	if err != nil {
+		return nil, err
+	}
+	return proposerSignature(ctx, postState, block.Block(), privKeys)
+}
+
+// signedBlockFromProto wraps the beacon block proto in a signed block with an empty signature.
+func signedBlockFromProto(block interface{}) (interfaces.SignedBeaconBlock, error) {
+	blk, err := blocks.NewBeaconBlock(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not wrap block")
+	}
+	return blocks.BuildSignedBeaconBlock(blk, make([]byte, fieldparams.BLSSignatureLength))
+}
+
+// setProtoStateRoot sets the state root of the beacon block proto.
+func setProtoStateRoot(block interface{}, stateRoot []byte) error {
+	switch b := block.(type) {
+	case *ethpb.BeaconBlock:
+		b.StateRoot = stateRoot
+	case *ethpb.BeaconBlockAltair:
+		b.StateRoot = stateRoot
+	case *ethpb.BeaconBlockBellatrix:
+		b.StateRoot = stateRoot
+	case *ethpb.BeaconBlockCapella:
+		b.StateRoot = stateRoot
+	case *ethpb.BeaconBlockDeneb:
+		b.StateRoot = stateRoot
+	case *ethpb.BeaconBlockElectra:
+		b.StateRoot = stateRoot
+	default:
+		return fmt.Errorf("unsupported block type %T", b)
+	}
+	return nil
+}
+
+// setPostStateRoot runs the block's state transition on a copy of the state, sets the root of the
+// post-state on the block and returns the post-state.
+func setPostStateRoot(ctx context.Context, bState state.BeaconState, block interfaces.SignedBeaconBlock) (state.BeaconState, error) {
+	postState, s, err := transition.CalculatePostStateAndRoot(ctx, bState, block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not calculate state root")
+	}
+	block.SetStateRoot(s[:])
+	return postState, nil
+}
+
+// proposerSignature signs the block with the key of its proposer. The state must be at the block's
+// slot, the proposer and the fork are read from it.
+func proposerSignature(
+	ctx context.Context,
+	st state.ReadOnlyBeaconState,
+	blk interfaces.ReadOnlyBeaconBlock,
+	privKeys []bls.SecretKey,
+) (bls.Signature, error) {
+	if st.Slot() != blk.Slot() {
+		return nil, fmt.Errorf("state slot %d does not match block slot %d", st.Slot(), blk.Slot())
+	}
+	domain, err := signing.Domain(st.Fork(), slots.ToEpoch(blk.Slot()), params.BeaconConfig().DomainBeaconProposer, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	blockRoot, err := signing.Data(blk.HashTreeRoot, domain)
+	if err != nil {
+		return nil, err
+	}
+	proposerIdx, err := proposerIndexAtSlot(ctx, st, st.Slot())
+	if err != nil {
+		return nil, err
+	}
+	if uint64(proposerIdx) >= uint64(len(privKeys)) {
+		return nil, fmt.Errorf("no private key for proposer %d", proposerIdx)
+	}
+	return privKeys[proposerIdx].Sign(blockRoot[:]), nil
+}
+
+// AttestationSignature returns the aggregate signature of the attestation data by the given
+// validators. The signing domain is taken from the state's fork at the data's target epoch.
+func AttestationSignature(
+	st state.ReadOnlyBeaconState,
+	data *ethpb.AttestationData,
+	indices []primitives.ValidatorIndex,
+	privKeys []bls.SecretKey,
+) (bls.Signature, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("no attesting indices")
+	}
+	domain, err := signing.Domain(st.Fork(), data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	root, err := signing.ComputeSigningRoot(data, domain)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]bls.Signature, len(indices))
+	for i, idx := range indices {
+		if uint64(idx) >= uint64(len(privKeys)) {
+			return nil, fmt.Errorf("no private key for validator %d", idx)
+		}
+		sigs[i] = privKeys[idx].Sign(root[:])
+	}
+	return bls.AggregateSignatures(sigs), nil
+}
+
+// VoluntaryExitSignature returns the signature of the exit by the given key. The signing domain is
+// taken from the state's fork at the exit epoch, or from the Capella fork for Deneb and later
+// states as required by EIP-7044.
+func VoluntaryExitSignature(st state.ReadOnlyBeaconState, exit *ethpb.VoluntaryExit, privKey bls.SecretKey) ([]byte, error) {
+	fork := st.Fork()
+	if st.Version() >= version.Deneb {
+		fork = &ethpb.Fork{
+			PreviousVersion: params.BeaconConfig().CapellaForkVersion,
+			CurrentVersion:  params.BeaconConfig().CapellaForkVersion,
+			Epoch:           params.BeaconConfig().CapellaForkEpoch,
+		}
+	}
+	domain, err := signing.Domain(fork, exit.Epoch, params.BeaconConfig().DomainVoluntaryExit, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	root, err := signing.ComputeSigningRoot(exit, domain)
+	if err != nil {
+		return nil, err
+	}
+	return privKey.Sign(root[:]).Marshal(), nil
+}
+
+// DepositSignature returns the signature of the deposit message by the given key. Deposits are
+// signed with the genesis fork version and no validators root, so no state is needed.
+func DepositSignature(msg *ethpb.DepositMessage, privKey bls.SecretKey) ([]byte, error) {
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainDeposit, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute domain")
+	}
+	root, err := signing.ComputeSigningRoot(msg, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get signing root of deposit data")
+	}
+	return privKey.Sign(root[:]).Marshal(), nil
+}
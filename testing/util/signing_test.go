@@ -0,0 +1,74 @@
+package util
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	coreBlock "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestAttestationSignature(t *testing.T) {
+	st, privs := DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), st, 0, 0)
+	require.NoError(t, err)
+	sort.Slice(committee, func(i, j int) bool { return committee[i] < committee[j] })
+	data := HydrateAttestationData(&ethpbalpha.AttestationData{})
+	sig, err := AttestationSignature(st, data, committee, privs)
+	require.NoError(t, err)
+
+	indices := make([]uint64, len(committee))
+	for i, idx := range committee {
+		indices[i] = uint64(idx)
+	}
+	indexed := &ethpbalpha.IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             data,
+		Signature:        sig.Marshal(),
+	}
+	require.NoError(t, coreBlock.VerifyIndexedAttestation(context.Background(), st, indexed))
+
+	_, err = AttestationSignature(st, data, []primitives.ValidatorIndex{64}, privs)
+	require.ErrorContains(t, "no private key", err)
+}
+
+func TestVoluntaryExitSignature(t *testing.T) {
+	t.Run("phase0", func(t *testing.T) {
+		st, privs := DeterministicGenesisState(t, 64)
+		exit := &ethpbalpha.VoluntaryExit{Epoch: 0, ValidatorIndex: 1}
+		sig, err := VoluntaryExitSignature(st, exit, privs[1])
+		require.NoError(t, err)
+		domain, err := signing.Domain(st.Fork(), exit.Epoch, params.BeaconConfig().DomainVoluntaryExit, st.GenesisValidatorsRoot())
+		require.NoError(t, err)
+		require.NoError(t, signing.VerifySigningRoot(exit, privs[1].PublicKey().Marshal(), sig, domain))
+	})
+	t.Run("deneb uses the capella fork version", func(t *testing.T) {
+		st, privs := DeterministicGenesisStateDeneb(t, 64)
+		exit := &ethpbalpha.VoluntaryExit{Epoch: 0, ValidatorIndex: 1}
+		sig, err := VoluntaryExitSignature(st, exit, privs[1])
+		require.NoError(t, err)
+		domain, err := signing.ComputeDomain(params.BeaconConfig().DomainVoluntaryExit, params.BeaconConfig().CapellaForkVersion, st.GenesisValidatorsRoot())
+		require.NoError(t, err)
+		require.NoError(t, signing.VerifySigningRoot(exit, privs[1].PublicKey().Marshal(), sig, domain))
+	})
+}
+
+func TestDepositSignature(t *testing.T) {
+	_, privs := DeterministicGenesisState(t, 1)
+	msg := &ethpbalpha.DepositMessage{
+		PublicKey:             privs[0].PublicKey().Marshal(),
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                params.BeaconConfig().MaxEffectiveBalance,
+	}
+	sig, err := DepositSignature(msg, privs[0])
+	require.NoError(t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainDeposit, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, signing.VerifySigningRoot(msg, msg.PublicKey, sig, domain))
+}
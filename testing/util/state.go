@@ -130,7 +130,7 @@ func NewBeaconState(options ...NewBeaconStateOption) (state.BeaconState, error)
 
 // NewBeaconStateAltair creates a beacon state with minimum marshalable fields.
 func NewBeaconStateAltair(options ...func(state *ethpb.BeaconStateAltair) error) (state.BeaconState, error) {
-	pubkeys := make([][]byte, 512)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
 	for i := range pubkeys {
 		pubkeys[i] = make([]byte, 48)
 	}
@@ -185,7 +185,7 @@ func NewBeaconStateAltair(options ...func(state *ethpb.BeaconStateAltair) error)
 
 // NewBeaconStateBellatrix creates a beacon state with minimum marshalable fields.
 func NewBeaconStateBellatrix(options ...func(state *ethpb.BeaconStateBellatrix) error) (state.BeaconState, error) {
-	pubkeys := make([][]byte, 512)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
 	for i := range pubkeys {
 		pubkeys[i] = make([]byte, 48)
 	}
@@ -252,7 +252,7 @@ func NewBeaconStateBellatrix(options ...func(state *ethpb.BeaconStateBellatrix)
 
 // NewBeaconStateCapella creates a beacon state with minimum marshalable fields.
 func NewBeaconStateCapella(options ...func(state *ethpb.BeaconStateCapella) error) (state.BeaconState, error) {
-	pubkeys := make([][]byte, 512)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
 	for i := range pubkeys {
 		pubkeys[i] = make([]byte, 48)
 	}
@@ -320,7 +320,7 @@ func NewBeaconStateCapella(options ...func(state *ethpb.BeaconStateCapella) erro
 
 // NewBeaconStateDeneb creates a beacon state with minimum marshalable fields.
 func NewBeaconStateDeneb(options ...func(state *ethpb.BeaconStateDeneb) error) (state.BeaconState, error) {
-	pubkeys := make([][]byte, 512)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
 	for i := range pubkeys {
 		pubkeys[i] = make([]byte, 48)
 	}
@@ -388,7 +388,7 @@ func NewBeaconStateDeneb(options ...func(state *ethpb.BeaconStateDeneb) error) (
 
 // NewBeaconStateElectra creates a beacon state with minimum marshalable fields.
 func NewBeaconStateElectra(options ...func(state *ethpb.BeaconStateElectra) error) (state.BeaconState, error) {
-	pubkeys := make([][]byte, 512)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
 	for i := range pubkeys {
 		pubkeys[i] = make([]byte, 48)
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -95,3 +97,25 @@ func TestNewBeaconState_HashTreeRoot(t *testing.T) {
 	_, err = st.HashTreeRoot(context.Background())
 	require.NoError(t, err)
 }
+
+func TestFillPendingBalanceDeposits(t *testing.T) {
+	st, _ := DeterministicGenesisStateElectra(t, 4)
+	require.NoError(t, FillPendingBalanceDeposits(st, 6, params.BeaconConfig().MinActivationBalance))
+	deposits, err := st.PendingBalanceDeposits()
+	require.NoError(t, err)
+	require.Equal(t, 6, len(deposits))
+	for i, d := range deposits {
+		require.Equal(t, primitives.ValidatorIndex(i%4), d.Index)
+		require.Equal(t, params.BeaconConfig().MinActivationBalance, d.Amount)
+	}
+
+	require.ErrorContains(t, "zero amount", FillPendingBalanceDeposits(st, 1, 0))
+	err = AppendPendingBalanceDeposits(st, []*ethpb.PendingBalanceDeposit{{Index: 4, Amount: 1}})
+	require.ErrorContains(t, "refers to validator 4", err)
+	deposits, err = st.PendingBalanceDeposits()
+	require.NoError(t, err)
+	require.Equal(t, 6, len(deposits))
+
+	phase0, _ := DeterministicGenesisState(t, 4)
+	require.ErrorContains(t, "not supported", FillPendingBalanceDeposits(phase0, 1, 1))
+}
@@ -1,11 +1,12 @@
 package util
 
 import (
-	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-bitfield"
+	"fmt"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	p2pType "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -13,56 +14,48 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
+// generateSyncAggregate produces a sync aggregate signed by the full sync committee over parentRoot.
+// The provided state must already be advanced to the slot of the block that will include the aggregate,
+// so that the committee of the block slot's sync committee period is selected and the signing domain
+// is computed for the previous slot, as done in process_sync_aggregate.
 func generateSyncAggregate(st state.BeaconState, privs []bls.SecretKey, parentRoot [32]byte) (*ethpb.SyncAggregate, error) {
-	nextSlotEpoch := slots.ToEpoch(st.Slot() + 1)
-	currEpoch := slots.ToEpoch(st.Slot())
+	return generatePartialSyncAggregate(st, privs, parentRoot, params.BeaconConfig().SyncCommitteeSize)
+}
 
-	var syncCommittee *ethpb.SyncCommittee
-	var err error
-	if slots.SyncCommitteePeriod(currEpoch) == slots.SyncCommitteePeriod(nextSlotEpoch) {
-		syncCommittee, err = st.CurrentSyncCommittee()
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		syncCommittee, err = st.NextSyncCommittee()
-		if err != nil {
-			return nil, err
-		}
+// generatePartialSyncAggregate produces a sync aggregate as generateSyncAggregate does, signed only by
+// the members at the first numParticipants positions of the sync committee.
+func generatePartialSyncAggregate(st state.BeaconState, privs []bls.SecretKey, parentRoot [32]byte, numParticipants uint64) (*ethpb.SyncAggregate, error) {
+	syncCommittee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, err
 	}
-	sigs := make([]bls.Signature, 0, len(syncCommittee.Pubkeys))
-	var bVector []byte
-	currSize := new(ethpb.SyncAggregate).SyncCommitteeBits.Len()
-	switch currSize {
-	case 512:
-		bVector = bitfield.NewBitvector512()
-	case 32:
-		bVector = bitfield.NewBitvector32()
-	default:
-		return nil, errors.New("invalid bit vector size")
+	prevSlot := st.Slot()
+	if prevSlot > 0 {
+		prevSlot--
 	}
-
+	d, err := signing.Domain(st.Fork(), slots.ToEpoch(prevSlot), params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	sszBytes := p2pType.SSZBytes(parentRoot[:])
+	r, err := signing.ComputeSigningRoot(&sszBytes, d)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]bls.Signature, 0, len(syncCommittee.Pubkeys))
+	bVector := emptySyncAggregate().SyncCommitteeBits
 	for i, p := range syncCommittee.Pubkeys {
+		if uint64(i) >= numParticipants {
+			break
+		}
 		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(p))
 		if !ok {
 			continue
 		}
-		d, err := signing.Domain(st.Fork(), slots.ToEpoch(st.Slot()), params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
-		if err != nil {
-			return nil, err
-		}
-		sszBytes := p2pType.SSZBytes(parentRoot[:])
-		r, err := signing.ComputeSigningRoot(&sszBytes, d)
-		if err != nil {
-			return nil, err
-		}
 		sigs = append(sigs, privs[idx].Sign(r[:]))
-		if currSize == 512 {
-			bitfield.Bitvector512(bVector).SetBitAt(uint64(i), true)
-		}
-		if currSize == 32 {
-			bitfield.Bitvector32(bVector).SetBitAt(uint64(i), true)
-		}
+		// The bitvector type of the proto is sized for mainnet, so bits are set directly to
+		// support every preset.
+		bVector[i/8] |= 1 << (i % 8)
 	}
 	if len(sigs) == 0 {
 		fakeSig := [96]byte{0xC0}
@@ -71,3 +64,38 @@ func generateSyncAggregate(st state.BeaconState, privs []bls.SecretKey, parentRo
 	aggSig := bls.AggregateSignatures(sigs)
 	return &ethpb.SyncAggregate{SyncCommitteeSignature: aggSig.Marshal(), SyncCommitteeBits: bVector}, nil
 }
+
+// blockSyncAggregate returns the sync aggregate of a block generated with the given config over parentRoot,
+// signed either by the full sync committee, by the configured fraction of it, or by nobody.
+func blockSyncAggregate(st state.BeaconState, privs []bls.SecretKey, parentRoot [32]byte, conf *BlockGenConfig) (*ethpb.SyncAggregate, error) {
+	switch {
+	case conf.FullSyncAggregate:
+		return generateSyncAggregate(st, privs, parentRoot)
+	case conf.SyncParticipation > 0:
+		n, err := syncParticipants(conf.SyncParticipation)
+		if err != nil {
+			return nil, err
+		}
+		return generatePartialSyncAggregate(st, privs, parentRoot, n)
+	default:
+		return emptySyncAggregate(), nil
+	}
+}
+
+// syncParticipants returns the number of sync committee members that participate in the sync
+// aggregate of a block generated with the given fraction of participation.
+func syncParticipants(participation float64) (uint64, error) {
+	if participation < 0 || participation > 1 {
+		return 0, fmt.Errorf("sync participation %f is not between 0 and 1", participation)
+	}
+	return uint64(participation * float64(params.BeaconConfig().SyncCommitteeSize)), nil
+}
+
+// emptySyncAggregate returns a sync aggregate without participants, with sync committee bits sized
+// for the preset of the build and the infinity signature.
+func emptySyncAggregate() *ethpb.SyncAggregate {
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+		SyncCommitteeSignature: append([]byte{0xC0}, make([]byte, fieldparams.BLSSignatureLength-1)...),
+	}
+}
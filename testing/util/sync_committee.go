@@ -1,10 +1,21 @@
 package util
 
 import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	p2pType "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 // HydrateSyncCommittee hydrates the provided sync committee message.
@@ -35,3 +46,52 @@ func ConvertToCommittee(inputKeys [][]byte) *ethpb.SyncCommittee {
 		AggregatePubkey: bytesutil.PadTo([]byte{}, params.BeaconConfig().BLSPubkeyLength),
 	}
 }
+
+// SyncCommitteeSigningRoot returns the signing root of a sync committee message for the slot, along
+// with its domain. The message signs the root of the head block at the slot, which is the latest block
+// of the state unless the slot is before the state's slot. As done by the node when validating sync
+// committee messages, the domain is computed with the fork of the state advanced to the slot.
+func SyncCommitteeSigningRoot(st state.BeaconState, slot primitives.Slot) ([32]byte, []byte, error) {
+	blockRoot, err := headBlockRootAtSlot(st, slot)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	headState := st
+	if st.Slot() < slot {
+		headState, err = transition.ProcessSlots(context.Background(), st.Copy(), slot)
+		if err != nil {
+			return [32]byte{}, nil, errors.Wrap(err, "could not process slots")
+		}
+	}
+	d, err := signing.Domain(headState.Fork(), slots.ToEpoch(slot), params.BeaconConfig().DomainSyncCommittee, headState.GenesisValidatorsRoot())
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	sszBytes := p2pType.SSZBytes(blockRoot[:])
+	r, err := signing.ComputeSigningRoot(&sszBytes, d)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return r, d, nil
+}
+
+// headBlockRootAtSlot returns the root of the latest block of the state at or before the slot.
+func headBlockRootAtSlot(st state.BeaconState, slot primitives.Slot) ([32]byte, error) {
+	if slot < st.Slot() {
+		r, err := helpers.BlockRootAtSlot(st, slot)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return bytesutil.ToBytes32(r), nil
+	}
+	header := st.LatestBlockHeader()
+	// The header's state root is only filled in by the next slot transition.
+	if bytes.Equal(header.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		stateRoot, err := st.HashTreeRoot(context.Background())
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not hash state")
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	return header.HashTreeRoot()
+}
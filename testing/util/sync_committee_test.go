@@ -0,0 +1,87 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestSyncCommitteeSigningRoot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	genesis, privs := DeterministicGenesisStateAltair(t, 64)
+	blk, err := GenerateFullBlockAltair(genesis, privs, DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+	require.NoError(t, err)
+
+	// verify signs the root and checks the signature as the node does when processing the sync aggregate of
+	// a block on top of verifyState, which signs the root of the block at the slot before verifyState's slot.
+	verify := func(t *testing.T, r [32]byte, d []byte, verifyState state.BeaconState) {
+		ps := verifyState.Slot() - 1
+		wantDomain, err := signing.Domain(verifyState.Fork(), slots.ToEpoch(ps), params.BeaconConfig().DomainSyncCommittee, verifyState.GenesisValidatorsRoot())
+		require.NoError(t, err)
+		require.DeepEqual(t, wantDomain, d)
+		sig := privs[0].Sign(r[:])
+		require.NoError(t, altair.VerifySyncCommitteeSig(verifyState, []bls.PublicKey{privs[0].PublicKey()}, sig.Marshal()))
+	}
+	// advance returns a copy of the state processed to the slot.
+	advance := func(t *testing.T, slot primitives.Slot) state.BeaconState {
+		advanced, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+		require.NoError(t, err)
+		return advanced
+	}
+
+	t.Run("head slot", func(t *testing.T) {
+		r, d, err := SyncCommitteeSigningRoot(st, 1)
+		require.NoError(t, err)
+		verify(t, r, d, advance(t, 2))
+	})
+	t.Run("skipped slot", func(t *testing.T) {
+		r, d, err := SyncCommitteeSigningRoot(st, 3)
+		require.NoError(t, err)
+		verify(t, r, d, advance(t, 4))
+	})
+	t.Run("past slot", func(t *testing.T) {
+		advanced := advance(t, 3)
+		r, d, err := SyncCommitteeSigningRoot(advanced, 0)
+		require.NoError(t, err)
+		verify(t, r, d, st)
+
+		r, d, err = SyncCommitteeSigningRoot(advanced, 2)
+		require.NoError(t, err)
+		verify(t, r, d, advanced)
+	})
+	t.Run("next fork", func(t *testing.T) {
+		slot := params.BeaconConfig().SlotsPerEpoch
+		r, d, err := SyncCommitteeSigningRoot(st, slot)
+		require.NoError(t, err)
+		verifyState := advance(t, slot+1)
+		require.Equal(t, version.Bellatrix, verifyState.Version())
+		verify(t, r, d, verifyState)
+	})
+	t.Run("wrong slot", func(t *testing.T) {
+		r, _, err := SyncCommitteeSigningRoot(st, 0)
+		require.NoError(t, err)
+		sig := privs[0].Sign(r[:])
+		require.ErrorContains(t, "invalid sync committee signature", altair.VerifySyncCommitteeSig(advance(t, 2), []bls.PublicKey{privs[0].PublicKey()}, sig.Marshal()))
+	})
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ValidatorEpochs holds the epochs of the lifecycle of a validator. Epochs a validator has not
+// reached yet are set to FAR_FUTURE_EPOCH.
+type ValidatorEpochs struct {
+	ActivationEligibilityEpoch primitives.Epoch
+	ActivationEpoch            primitives.Epoch
+	ExitEpoch                  primitives.Epoch
+	WithdrawableEpoch          primitives.Epoch
+}
+
+// Validate checks that the epochs are ordered the way the state transition orders them:
+//   - a validator is not activated before it became eligible for activation, genesis validators
+//     being eligible and activated at the same epoch,
+//   - a validator only exits after it was activated,
+//   - a validator is only withdrawable MIN_VALIDATOR_WITHDRAWABILITY_DELAY epochs after its exit.
+func (e ValidatorEpochs) Validate() error {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	if e.ActivationEpoch != farFuture && e.ActivationEligibilityEpoch > e.ActivationEpoch {
+		return fmt.Errorf("activation epoch %d is before activation eligibility epoch %d", e.ActivationEpoch, e.ActivationEligibilityEpoch)
+	}
+	if e.ExitEpoch != farFuture && e.ActivationEpoch >= e.ExitEpoch {
+		return fmt.Errorf("exit epoch %d is not after activation epoch %d", e.ExitEpoch, e.ActivationEpoch)
+	}
+	if e.ExitEpoch == farFuture {
+		if e.WithdrawableEpoch != farFuture {
+			return fmt.Errorf("withdrawable epoch %d is set for a validator that does not exit", e.WithdrawableEpoch)
+		}
+		return nil
+	}
+	minWithdrawable, err := e.ExitEpoch.SafeAdd(uint64(params.BeaconConfig().MinValidatorWithdrawabilityDelay))
+	if err != nil {
+		return errors.Wrap(err, "could not compute minimum withdrawable epoch")
+	}
+	if e.WithdrawableEpoch < minWithdrawable {
+		return fmt.Errorf("withdrawable epoch %d is before exit epoch %d plus MIN_VALIDATOR_WITHDRAWABILITY_DELAY", e.WithdrawableEpoch, e.ExitEpoch)
+	}
+	return nil
+}
+
+// SetValidatorEpochs sets the lifecycle epochs of the validator at the given index, which allows to
+// set up validators in the middle of their activation or exit without processing the epochs in
+// between. The epochs are validated with ValidatorEpochs.Validate.
+func SetValidatorEpochs(st state.BeaconState, idx primitives.ValidatorIndex, epochs ValidatorEpochs) error {
+	if err := epochs.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid epochs for validator %d", idx)
+	}
+	val, err := st.ValidatorAtIndex(idx)
+	if err != nil {
+		return err
+	}
+	val.ActivationEligibilityEpoch = epochs.ActivationEligibilityEpoch
+	val.ActivationEpoch = epochs.ActivationEpoch
+	val.ExitEpoch = epochs.ExitEpoch
+	val.WithdrawableEpoch = epochs.WithdrawableEpoch
+	return st.UpdateValidatorAtIndex(idx, val)
+}
+
+// SetValidatorsEpochs sets the lifecycle epochs of several validators with SetValidatorEpochs. The
+// indices and epochs of all validators are validated before any validator is changed.
+func SetValidatorsEpochs(st state.BeaconState, epochs map[primitives.ValidatorIndex]ValidatorEpochs) error {
+	for idx, e := range epochs {
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			return fmt.Errorf("validator index %d is out of range of %d validators", idx, st.NumValidators())
+		}
+		if err := e.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid epochs for validator %d", idx)
+		}
+	}
+	for idx, e := range epochs {
+		if err := SetValidatorEpochs(st, idx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestValidatorEpochs_Validate(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	delay := params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	tests := []struct {
+		name   string
+		epochs ValidatorEpochs
+		err    string
+	}{
+		{
+			name:   "pending",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: farFuture, ActivationEpoch: farFuture, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+		},
+		{
+			name:   "active",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 1, ActivationEpoch: 5, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+		},
+		{
+			name:   "exited",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 1, ActivationEpoch: 5, ExitEpoch: 10, WithdrawableEpoch: 10 + delay},
+		},
+		{
+			name:   "genesis",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 0, ActivationEpoch: 0, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+		},
+		{
+			name:   "activation before eligibility",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 6, ActivationEpoch: 5, ExitEpoch: farFuture, WithdrawableEpoch: farFuture},
+			err:    "activation epoch 5 is before activation eligibility epoch 6",
+		},
+		{
+			name:   "exit not after activation",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 1, ActivationEpoch: farFuture, ExitEpoch: 10, WithdrawableEpoch: 10 + delay},
+			err:    "exit epoch 10 is not after activation epoch",
+		},
+		{
+			name:   "withdrawable without exit",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 1, ActivationEpoch: 5, ExitEpoch: farFuture, WithdrawableEpoch: 20},
+			err:    "withdrawable epoch 20 is set for a validator that does not exit",
+		},
+		{
+			name:   "withdrawable too early",
+			epochs: ValidatorEpochs{ActivationEligibilityEpoch: 1, ActivationEpoch: 5, ExitEpoch: 10, WithdrawableEpoch: 10 + delay - 1},
+			err:    "is before exit epoch 10 plus MIN_VALIDATOR_WITHDRAWABILITY_DELAY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.epochs.Validate()
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSetValidatorEpochs(t *testing.T) {
+	st, _ := DeterministicGenesisState(t, 16)
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	activating := ValidatorEpochs{ActivationEligibilityEpoch: 0, ActivationEpoch: 2, ExitEpoch: farFuture, WithdrawableEpoch: farFuture}
+	require.NoError(t, SetValidatorEpochs(st, 3, activating))
+
+	val, err := st.ValidatorAtIndexReadOnly(3)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(2), val.ActivationEpoch())
+	assert.Equal(t, false, helpers.IsActiveValidatorUsingTrie(val, 1))
+	assert.Equal(t, true, helpers.IsActiveValidatorUsingTrie(val, 2))
+
+	invalid := ValidatorEpochs{ActivationEligibilityEpoch: 2, ActivationEpoch: 1, ExitEpoch: farFuture, WithdrawableEpoch: farFuture}
+	require.ErrorContains(t, "invalid epochs for validator 4", SetValidatorEpochs(st, 4, invalid))
+	val, err = st.ValidatorAtIndexReadOnly(4)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(0), val.ActivationEpoch())
+}
+
+func TestSetValidatorsEpochs(t *testing.T) {
+	st, _ := DeterministicGenesisState(t, 16)
+	delay := params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	exiting := ValidatorEpochs{ActivationEligibilityEpoch: 0, ActivationEpoch: 1, ExitEpoch: 3, WithdrawableEpoch: 3 + delay}
+	require.NoError(t, SetValidatorsEpochs(st, map[primitives.ValidatorIndex]ValidatorEpochs{1: exiting, 2: exiting}))
+	for _, idx := range []primitives.ValidatorIndex{1, 2} {
+		val, err := st.ValidatorAtIndexReadOnly(idx)
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Epoch(3), val.ExitEpoch())
+		assert.Equal(t, 3+delay, val.WithdrawableEpoch())
+		assert.Equal(t, true, helpers.IsActiveValidatorUsingTrie(val, 2))
+		assert.Equal(t, false, helpers.IsActiveValidatorUsingTrie(val, 3))
+	}
+
+	// No validator is changed when the epochs of one of them are invalid.
+	invalid := exiting
+	invalid.WithdrawableEpoch = 3
+	err := SetValidatorsEpochs(st, map[primitives.ValidatorIndex]ValidatorEpochs{5: exiting, 6: invalid})
+	require.ErrorContains(t, "invalid epochs for validator 6", err)
+	val, err := st.ValidatorAtIndexReadOnly(5)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().FarFutureEpoch, val.ExitEpoch())
+
+	err = SetValidatorsEpochs(st, map[primitives.ValidatorIndex]ValidatorEpochs{16: exiting})
+	require.ErrorContains(t, "validator index 16 is out of range of 16 validators", err)
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestAdvanceWithdrawalIndices(t *testing.T) {
+	tests := []struct {
+		name        string
+		withdrawing int
+		start       primitives.ValidatorIndex
+	}{
+		{name: "no withdrawals"},
+		{name: "partial payload", withdrawing: 3, start: 10},
+		{name: "full payload", withdrawing: 64},
+		{name: "full payload ends at last validator", withdrawing: 64, start: 48},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, _ := DeterministicGenesisStateCapella(t, 64)
+			for i := 0; i < tt.withdrawing; i++ {
+				idx := primitives.ValidatorIndex(i)
+				val, err := st.ValidatorAtIndex(idx)
+				require.NoError(t, err)
+				val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+				require.NoError(t, st.UpdateValidatorAtIndex(idx, val))
+				require.NoError(t, st.UpdateBalancesAtIndex(idx, params.BeaconConfig().MaxEffectiveBalance+params.BeaconConfig().MinDepositAmount))
+			}
+			require.NoError(t, st.SetNextWithdrawalIndex(7))
+			require.NoError(t, st.SetNextWithdrawalValidatorIndex(tt.start))
+			withdrawals, _, err := st.ExpectedWithdrawals()
+			require.NoError(t, err)
+			require.Equal(t, min(tt.withdrawing, int(params.BeaconConfig().MaxWithdrawalsPerPayload)), len(withdrawals))
+
+			payload, err := consensusblocks.WrappedExecutionPayloadCapella(&v1.ExecutionPayloadCapella{Withdrawals: withdrawals})
+			require.NoError(t, err)
+			want, err := blocks.ProcessWithdrawals(st.Copy(), payload)
+			require.NoError(t, err)
+			got := st.Copy()
+			require.NoError(t, AdvanceWithdrawalIndices(got, withdrawals))
+
+			wantIndex, err := want.NextWithdrawalIndex()
+			require.NoError(t, err)
+			gotIndex, err := got.NextWithdrawalIndex()
+			require.NoError(t, err)
+			assert.Equal(t, wantIndex, gotIndex)
+			wantValidator, err := want.NextWithdrawalValidatorIndex()
+			require.NoError(t, err)
+			gotValidator, err := got.NextWithdrawalValidatorIndex()
+			require.NoError(t, err)
+			assert.Equal(t, wantValidator, gotValidator)
+		})
+	}
+
+	st, _ := DeterministicGenesisStateBellatrix(t, 8)
+	require.ErrorContains(t, "not supported", AdvanceWithdrawalIndices(st, nil))
+}
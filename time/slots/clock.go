@@ -0,0 +1,81 @@
+package slots
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+)
+
+// Clock is the source of time used by the slot tickers. Production code uses SystemClock, while
+// tests can provide a clock they control to drive slot deadlines deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) ClockTicker
+}
+
+// ClockTicker is a ticker created by a Clock.
+type ClockTicker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is a Clock backed by the local system time.
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return prysmTime.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTicker returns a ticker firing every d.
+func (SystemClock) NewTicker(d time.Duration) ClockTicker {
+	return &systemTicker{t: time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (s *systemTicker) C() <-chan time.Time {
+	return s.t.C
+}
+
+func (s *systemTicker) Stop() {
+	s.t.Stop()
+}
+
+// NewSlotTickerWithClock works like NewSlotTicker, but reads the time from the given clock.
+func NewSlotTickerWithClock(genesisTime time.Time, secondsPerSlot uint64, clock Clock) *SlotTicker {
+	if genesisTime.IsZero() {
+		panic("zero genesis time")
+	}
+	ticker := &SlotTicker{
+		c:    make(chan primitives.Slot),
+		done: make(chan struct{}),
+	}
+	since := func(t time.Time) time.Duration {
+		return clock.Now().Sub(t)
+	}
+	until := func(t time.Time) time.Duration {
+		return t.Sub(clock.Now())
+	}
+	ticker.start(genesisTime, secondsPerSlot, since, until, clock.After)
+	return ticker
+}
+
+// NewSlotTickerWithIntervalsAndClock works like NewSlotTickerWithIntervals, but reads the time
+// from the given clock.
+func NewSlotTickerWithIntervalsAndClock(genesisTime time.Time, intervals []time.Duration, clock Clock) *SlotIntervalTicker {
+	ticker := newSlotIntervalTicker(genesisTime, intervals)
+	ticker.startWithIntervals(genesisTime, clock.Now, clock.After, intervals)
+	return ticker
+}
@@ -145,11 +145,14 @@ func (s *SlotTicker) start(
 // less than secondsPerSlot
 func (s *SlotIntervalTicker) startWithIntervals(
 	genesisTime time.Time,
-	until func(time.Time) time.Duration,
+	now func() time.Time,
 	after func(time.Duration) <-chan time.Time,
 	intervals []time.Duration) {
+	until := func(t time.Time) time.Duration {
+		return t.Sub(now())
+	}
 	go func() {
-		slot := Since(genesisTime)
+		slot := Duration(genesisTime, now())
 		slot++
 		interval := 0
 		nextTickTime := startFromTime(genesisTime, slot).Add(intervals[0])
@@ -177,6 +180,12 @@ func (s *SlotIntervalTicker) startWithIntervals(
 // Caller is responsible to input the intervals in increasing order and none bigger or equal than
 // SecondsPerSlot
 func NewSlotTickerWithIntervals(genesisTime time.Time, intervals []time.Duration) *SlotIntervalTicker {
+	ticker := newSlotIntervalTicker(genesisTime, intervals)
+	ticker.startWithIntervals(genesisTime, prysmTime.Now, time.After, intervals)
+	return ticker
+}
+
+func newSlotIntervalTicker(genesisTime time.Time, intervals []time.Duration) *SlotIntervalTicker {
 	if genesisTime.Unix() == 0 {
 		panic("zero genesis time")
 	}
@@ -194,10 +203,8 @@ func NewSlotTickerWithIntervals(genesisTime time.Time, intervals []time.Duration
 		}
 		lastOffset = offset
 	}
-	ticker := &SlotIntervalTicker{
+	return &SlotIntervalTicker{
 		c:    make(chan SlotInterval),
 		done: make(chan struct{}),
 	}
-	ticker.startWithIntervals(genesisTime, prysmTime.Until, time.After, intervals)
-	return ticker
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	mathutil "github.com/prysmaticlabs/prysm/v5/math"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
 )
 
@@ -22,11 +23,11 @@ func startFromTime(genesis time.Time, slot primitives.Slot) time.Time {
 	return genesis.Add(duration) // lint:ignore uintcast -- Genesis timestamp will not exceed int64 in your lifetime.
 }
 
-// StartTime returns the start time in terms of its unix epoch
-// value.
+// StartTime returns the start time of the slot, given the genesis time as its unix epoch
+// value. The time has the full precision of the slot duration, it is not truncated to seconds.
 func StartTime(genesis uint64, slot primitives.Slot) time.Time {
 	genesisTime := time.Unix(int64(genesis), 0) // lint:ignore uintcast -- Genesis timestamp will not exceed int64 in your lifetime.
-	return startFromTime(genesisTime, slot)
+	return BeginsAt(slot, genesisTime)
 }
 
 // SinceGenesis returns the number of slots since
@@ -80,6 +81,25 @@ func ToEpoch(slot primitives.Slot) primitives.Epoch {
 	return primitives.Epoch(slot.DivSlot(params.BeaconConfig().SlotsPerEpoch))
 }
 
+// ToForkVersion returns the version of the fork active at the input slot.
+func ToForkVersion(slot primitives.Slot) int {
+	epoch := ToEpoch(slot)
+	switch {
+	case epoch >= params.BeaconConfig().ElectraForkEpoch:
+		return version.Electra
+	case epoch >= params.BeaconConfig().DenebForkEpoch:
+		return version.Deneb
+	case epoch >= params.BeaconConfig().CapellaForkEpoch:
+		return version.Capella
+	case epoch >= params.BeaconConfig().BellatrixForkEpoch:
+		return version.Bellatrix
+	case epoch >= params.BeaconConfig().AltairForkEpoch:
+		return version.Altair
+	default:
+		return version.Phase0
+	}
+}
+
 // EpochStart returns the first slot number of the
 // current epoch.
 //
@@ -173,9 +193,43 @@ func ToTime(genesisTimeSec uint64, slot primitives.Slot) (time.Time, error) {
 	return time.Unix(int64(sTime), 0), nil // lint:ignore uintcast -- A timestamp will not exceed int64 in your lifetime.
 }
 
+// SlotDuration returns the duration of a slot. Sub-second slot arithmetic goes through this function,
+// so a slot duration configured in milliseconds only needs to be handled here.
+func SlotDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+}
+
+// ToTimeMillis takes the given slot and genesis time to determine the start time of the slot in
+// milliseconds since the unix epoch.
+func ToTimeMillis(genesisTimeSec uint64, slot primitives.Slot) (uint64, error) {
+	sinceGenesis, err := slot.SafeMul(uint64(SlotDuration().Milliseconds()))
+	if err != nil {
+		return 0, fmt.Errorf("slot (%d) is in the far distant future: %w", slot, err)
+	}
+	genesisMillis, err := mathutil.Mul64(genesisTimeSec, 1000)
+	if err != nil {
+		return 0, fmt.Errorf("genesis time (%d) is in the far distant future: %w", genesisTimeSec, err)
+	}
+	t, err := sinceGenesis.SafeAdd(genesisMillis)
+	if err != nil {
+		return 0, fmt.Errorf("slot (%d) is in the far distant future: %w", slot, err)
+	}
+	return uint64(t), nil
+}
+
+// IntoSlotFraction returns the fraction of the current slot, in [0, 1), that has elapsed at the given
+// time. It returns 0 if the time is before genesis.
+func IntoSlotFraction(genesis, now time.Time) float64 {
+	if now.Before(genesis) {
+		return 0
+	}
+	slotDuration := SlotDuration()
+	return float64(now.Sub(genesis)%slotDuration) / float64(slotDuration)
+}
+
 // BeginsAt computes the timestamp where the given slot begins, relative to the genesis timestamp.
 func BeginsAt(slot primitives.Slot, genesis time.Time) time.Time {
-	sd := time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Duration(slot)
+	sd := SlotDuration() * time.Duration(slot)
 	return genesis.Add(sd)
 }
 
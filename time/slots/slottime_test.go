@@ -7,6 +7,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
@@ -607,3 +608,86 @@ func TestWithinVotingWindow(t *testing.T) {
 	genesisTime = uint64(time.Now().Add(-40 * time.Second).Unix())
 	require.Equal(t, false, WithinVotingWindow(genesisTime, 3))
 }
+
+func TestToTimeMillis(t *testing.T) {
+	slotMillis := params.BeaconConfig().SecondsPerSlot * 1000
+	got, err := ToTimeMillis(1606824023, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1606824023000)+2*slotMillis, got)
+
+	start, err := ToTime(1606824023, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(start.UnixMilli()), got)
+
+	_, err = ToTimeMillis(math.MaxUint64/1000+1, 0)
+	require.ErrorContains(t, "genesis time", err)
+	_, err = ToTimeMillis(math.MaxUint64/1000, 1)
+	require.ErrorContains(t, "is in the far distant future", err)
+	_, err = ToTimeMillis(0, primitives.Slot(math.MaxUint64/slotMillis+1))
+	require.ErrorContains(t, "is in the far distant future", err)
+}
+
+func TestIntoSlotFraction(t *testing.T) {
+	// time.Now carries a monotonic clock reading, so the arithmetic below does not depend on the wall clock.
+	genesis := time.Now()
+	slotDuration := SlotDuration()
+	require.Equal(t, float64(0), IntoSlotFraction(genesis, genesis.Add(-time.Second)))
+	require.Equal(t, float64(0), IntoSlotFraction(genesis, genesis))
+	require.Equal(t, float64(1)/3, IntoSlotFraction(genesis, genesis.Add(slotDuration/3)))
+	require.Equal(t, float64(2)/3, IntoSlotFraction(genesis, genesis.Add(10*slotDuration+2*slotDuration/3)))
+	require.Equal(t, float64(0), IntoSlotFraction(genesis, genesis.Add(5*slotDuration)))
+	require.Equal(t, 0.5, IntoSlotFraction(genesis, genesis.Add(slotDuration/2)))
+}
+
+func TestStartTime(t *testing.T) {
+	start := StartTime(1606824023, 2)
+	require.Equal(t, time.Unix(1606824023, 0).Add(2*SlotDuration()), start)
+	millis, err := ToTimeMillis(1606824023, 2)
+	require.NoError(t, err)
+	require.Equal(t, millis, uint64(start.UnixMilli()))
+	require.Equal(t, BeginsAt(2, time.Unix(1606824023, 0)), start)
+}
+
+func TestSlotDuration_ShortSlots(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.SecondsPerSlot = 2
+	params.OverrideBeaconConfig(cfg)
+	require.Equal(t, 2*time.Second, SlotDuration())
+	genesis := time.Unix(1000, 0)
+	require.Equal(t, 0.25, IntoSlotFraction(genesis, genesis.Add(500*time.Millisecond)))
+	require.Equal(t, 0.0005, IntoSlotFraction(genesis, genesis.Add(2*time.Second+time.Millisecond)))
+	got, err := ToTimeMillis(1000, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1006000), got)
+	require.Equal(t, genesis.Add(6*time.Second), BeginsAt(3, genesis))
+	require.Equal(t, genesis.Add(6*time.Second), StartTime(1000, 3))
+}
+
+func TestToForkVersion(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	cfg.BellatrixForkEpoch = 2
+	cfg.CapellaForkEpoch = 3
+	cfg.DenebForkEpoch = 4
+	cfg.ElectraForkEpoch = 5
+	params.OverrideBeaconConfig(cfg)
+
+	tests := []struct {
+		slot primitives.Slot
+		want int
+	}{
+		{slot: 0, want: version.Phase0},
+		{slot: params.BeaconConfig().SlotsPerEpoch - 1, want: version.Phase0},
+		{slot: params.BeaconConfig().SlotsPerEpoch, want: version.Altair},
+		{slot: 2 * params.BeaconConfig().SlotsPerEpoch, want: version.Bellatrix},
+		{slot: 3 * params.BeaconConfig().SlotsPerEpoch, want: version.Capella},
+		{slot: 4 * params.BeaconConfig().SlotsPerEpoch, want: version.Deneb},
+		{slot: 5*params.BeaconConfig().SlotsPerEpoch - 1, want: version.Deneb},
+		{slot: 5 * params.BeaconConfig().SlotsPerEpoch, want: version.Electra},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ToForkVersion(tt.slot), "Wrong version for slot %d", tt.slot)
+	}
+}
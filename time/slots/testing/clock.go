@@ -0,0 +1,147 @@
+package testing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var _ slots.Clock = (*FakeClock)(nil)
+
+// FakeClock is a slots.Clock whose time only moves when Advance or AdvanceToSlot is called.
+// Timers and tickers created from it fire once the fake time reaches their deadline.
+type FakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration
+	c        chan time.Time
+}
+
+// NewFakeClock returns a fake clock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time.
+func (f *FakeClock) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+// After returns a channel receiving the fake time once it has advanced by d.
+// A non-positive duration fires immediately.
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	w := &fakeWaiter{deadline: f.now.Add(d), c: make(chan time.Time, 1)}
+	f.schedule(w)
+	return w.c
+}
+
+// NewTicker returns a ticker firing every d of fake time. Like time.Ticker, ticks are dropped
+// if the receiver is not keeping up.
+func (f *FakeClock) NewTicker(d time.Duration) slots.ClockTicker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	w := &fakeWaiter{deadline: f.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	return &fakeTicker{clock: f, w: w}
+}
+
+// Advance moves the fake time forward by d, firing every timer and ticker that is due
+// in deadline order.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	target := f.now.Add(d)
+	for {
+		sort.SliceStable(f.waiters, func(i, j int) bool {
+			return f.waiters[i].deadline.Before(f.waiters[j].deadline)
+		})
+		if len(f.waiters) == 0 || f.waiters[0].deadline.After(target) {
+			break
+		}
+		w := f.waiters[0]
+		f.waiters = f.waiters[1:]
+		f.now = w.deadline
+		f.fire(w)
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+			f.waiters = append(f.waiters, w)
+		}
+	}
+	f.now = target
+}
+
+// BlockUntil waits until at least n timers or tickers are pending on the clock. Use it before
+// advancing the clock to make sure a goroutine reading the clock has scheduled its next wake-up.
+func (f *FakeClock) BlockUntil(n int) {
+	for {
+		f.lock.Lock()
+		pending := len(f.waiters)
+		f.lock.Unlock()
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// AdvanceToSlot moves the fake time forward to the start of the given slot. It does nothing if
+// the slot has already started.
+func (f *FakeClock) AdvanceToSlot(genesis time.Time, slot primitives.Slot) {
+	start := genesis.Add(time.Duration(slot) * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	if d := start.Sub(f.Now()); d > 0 {
+		f.Advance(d)
+	}
+}
+
+// schedule fires the waiter if it is already due, or queues it otherwise.
+// This assumes the lock is held.
+func (f *FakeClock) schedule(w *fakeWaiter) {
+	if !w.deadline.After(f.now) {
+		f.fire(w)
+		return
+	}
+	f.waiters = append(f.waiters, w)
+}
+
+func (f *FakeClock) fire(w *fakeWaiter) {
+	select {
+	case w.c <- f.now:
+	default:
+	}
+}
+
+type fakeTicker struct {
+	clock *FakeClock
+	w     *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.w.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	for i, w := range t.clock.waiters {
+		if w == t.w {
+			t.clock.waiters = append(t.clock.waiters[:i], t.clock.waiters[i+1:]...)
+			return
+		}
+	}
+}
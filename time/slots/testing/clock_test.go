@@ -0,0 +1,101 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestFakeClock_After(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := NewFakeClock(start)
+	c := clock.After(2 * time.Second)
+
+	clock.Advance(time.Second)
+	select {
+	case <-c:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(2*time.Second), <-c)
+	require.Equal(t, start.Add(2*time.Second), clock.Now())
+
+	require.Equal(t, clock.Now(), <-clock.After(0))
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := NewFakeClock(start)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(time.Second), <-ticker.C())
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(2*time.Second), <-ticker.C())
+
+	// Ticks are dropped while the receiver is not keeping up.
+	clock.Advance(3 * time.Second)
+	require.Equal(t, start.Add(3*time.Second), <-ticker.C())
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker fired")
+	default:
+	}
+}
+
+func TestFakeClock_DrivesSlotTicker(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	clock := NewFakeClock(genesis)
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	ticker := slots.NewSlotTickerWithClock(genesis, secondsPerSlot, clock)
+	defer ticker.Done()
+
+	require.Equal(t, primitives.Slot(0), <-ticker.C())
+	for _, slot := range []primitives.Slot{1, 2, 3} {
+		// Wait for the ticker to wait on the clock, or advancing would not wake it up.
+		clock.BlockUntil(1)
+		clock.AdvanceToSlot(genesis, slot)
+		require.Equal(t, slot, <-ticker.C())
+	}
+	require.Equal(t, genesis.Add(3*time.Duration(secondsPerSlot)*time.Second), clock.Now())
+}
+
+func TestFakeClock_DrivesSlotIntervalTicker(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	clock := NewFakeClock(genesis)
+	interval := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3
+	ticker := slots.NewSlotTickerWithIntervalsAndClock(genesis, []time.Duration{0, interval}, clock)
+	defer ticker.Done()
+
+	clock.BlockUntil(1)
+	clock.AdvanceToSlot(genesis, 1)
+	require.Equal(t, slots.SlotInterval{Slot: 1, Interval: 0}, <-ticker.C())
+	clock.BlockUntil(1)
+	clock.Advance(interval)
+	require.Equal(t, slots.SlotInterval{Slot: 1, Interval: 1}, <-ticker.C())
+	clock.BlockUntil(1)
+	clock.AdvanceToSlot(genesis, 2)
+	require.Equal(t, slots.SlotInterval{Slot: 2, Interval: 0}, <-ticker.C())
+}
+
+func TestFakeClock_BlockUntil(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1000, 0))
+	done := make(chan struct{})
+	go func() {
+		clock.BlockUntil(1)
+		close(done)
+	}()
+	c := clock.After(time.Second)
+	<-done
+	clock.Advance(time.Second)
+	<-c
+}
@@ -26,6 +26,7 @@ import (
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/proto"
@@ -463,6 +464,16 @@ func (v *validator) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyL
 		return bytesutil.PadTo(v.graffiti, 32), nil
 	}
 
+	v.graffitiLock.Lock()
+	defer v.graffitiLock.Unlock()
+
+	// The graffiti file is re-read for every proposal, so that it can be edited without restarting the validator client.
+	if v.graffitiFile != "" {
+		if err := v.reloadGraffitiFile(ctx); err != nil {
+			log.WithError(err).WithField("path", v.graffitiFile).Warn("Could not reload graffiti file, using previously loaded graffiti")
+		}
+	}
+
 	if v.graffitiStruct == nil {
 		return nil, errors.New("graffitiStruct can't be nil")
 	}
@@ -504,6 +515,26 @@ func (v *validator) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyL
 	return []byte{}, nil
 }
 
+// reloadGraffitiFile parses the graffiti file and uses it when its content changed. The position in the ordered
+// graffiti list is restarted for a new file content, and kept across proposals and restarts otherwise.
+func (v *validator) reloadGraffitiFile(ctx context.Context) error {
+	g, err := graffiti.ParseGraffitiFile(v.graffitiFile)
+	if err != nil {
+		return err
+	}
+	if v.graffitiStruct != nil && v.graffitiStruct.Hash == g.Hash {
+		return nil
+	}
+	orderedIndex, err := v.db.GraffitiOrderedIndex(ctx, g.Hash)
+	if err != nil {
+		return errors.Wrap(err, "could not read graffiti ordered index")
+	}
+	v.graffitiStruct = g
+	v.graffitiOrderedIndex = orderedIndex
+	log.WithField("path", v.graffitiFile).Info("Loaded new graffiti file content")
+	return nil
+}
+
 func (v *validator) SetGraffiti(ctx context.Context, pubkey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
 	ctx, span := trace.StartSpan(ctx, "validator.SetGraffiti")
 	defer span.End()
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -1119,6 +1121,66 @@ func TestGetGraffitiOrdered_Ok(t *testing.T) {
 	}
 }
 
+func TestGetGraffiti_ReloadsFile(t *testing.T) {
+	for _, isSlashingProtectionMinimal := range [...]bool{false, true} {
+		t.Run(fmt.Sprintf("SlashingProtectionMinimal:%v", isSlashingProtectionMinimal), func(t *testing.T) {
+			pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+			valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, isSlashingProtectionMinimal)
+			ctrl := gomock.NewController(t)
+			m := &mocks{
+				validatorClient: validatormock.NewMockValidatorClient(ctrl),
+			}
+			m.validatorClient.EXPECT().
+				ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+				AnyTimes().
+				Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+
+			graffitiFile := filepath.Join(t.TempDir(), "graffiti.yaml")
+			writeGraffiti := func(content string) {
+				require.NoError(t, os.WriteFile(graffitiFile, []byte(content), 0600))
+			}
+			newValidator := func() *validator {
+				return &validator{
+					db:              valDB,
+					validatorClient: m.validatorClient,
+					graffitiStruct:  &graffiti.Graffiti{},
+					graffitiFile:    graffitiFile,
+				}
+			}
+			requireGraffiti := func(v *validator, want string) {
+				got, err := v.Graffiti(context.Background(), pubKey)
+				require.NoError(t, err)
+				require.DeepEqual(t, bytesutil.PadTo([]byte(want), 32), got)
+			}
+
+			writeGraffiti("ordered:\n  - a\n  - b\n  - c\ndefault: d\n")
+			v := newValidator()
+			requireGraffiti(v, "a")
+			requireGraffiti(v, "b")
+
+			// The rotation carries on from where it was with a restarted validator client.
+			v = newValidator()
+			requireGraffiti(v, "c")
+			requireGraffiti(v, "d")
+
+			// A changed file is used without restarting, and its rotation starts over.
+			writeGraffiti("ordered:\n  - e\ndefault: f\nspecific:\n  3: g\n")
+			requireGraffiti(v, "e")
+			requireGraffiti(v, "f")
+
+			// Specific graffiti takes precedence over the ordered list.
+			writeGraffiti("ordered:\n  - e\nspecific:\n  2: h\n")
+			requireGraffiti(v, "h")
+
+			// The previously loaded graffiti is used when the file can not be parsed.
+			writeGraffiti("ordered: [e\n")
+			requireGraffiti(v, "h")
+			require.NoError(t, os.Remove(graffitiFile))
+			requireGraffiti(v, "h")
+		})
+	}
+}
+
 func Test_validator_DeleteGraffiti(t *testing.T) {
 	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
 	tests := []struct {
@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-chain-client-factory"
@@ -49,6 +50,7 @@ type ValidatorService struct {
 	walletInitializedFeed   *event.Feed
 	graffiti                []byte
 	graffitiStruct          *graffiti.Graffiti
+	graffitiFile            string
 	interopKeysConfig       *local.InteropKeymanagerConfig
 	web3SignerConfig        *remoteweb3signer.SetupConfig
 	proposerSettings        *proposer.Settings
@@ -57,6 +59,7 @@ type ValidatorService struct {
 	emitAccountMetrics      bool
 	logValidatorPerformance bool
 	distributed             bool
+	clock                   slots.Clock
 }
 
 // Config for the validator service.
@@ -75,6 +78,7 @@ type Config struct {
 	BeaconApiTimeout        time.Duration
 	Graffiti                string
 	GraffitiStruct          *graffiti.Graffiti
+	GraffitiFile            string
 	InteropKmConfig         *local.InteropKeymanagerConfig
 	Web3SignerConfig        *remoteweb3signer.SetupConfig
 	ProposerSettings        *proposer.Settings
@@ -83,6 +87,7 @@ type Config struct {
 	LogValidatorPerformance bool
 	EmitAccountMetrics      bool
 	Distributed             bool
+	Clock                   slots.Clock
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -98,6 +103,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		walletInitializedFeed:   cfg.WalletInitializedFeed,
 		graffiti:                []byte(cfg.Graffiti),
 		graffitiStruct:          cfg.GraffitiStruct,
+		graffitiFile:            cfg.GraffitiFile,
 		interopKeysConfig:       cfg.InteropKmConfig,
 		web3SignerConfig:        cfg.Web3SignerConfig,
 		proposerSettings:        cfg.ProposerSettings,
@@ -106,6 +112,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		emitAccountMetrics:      cfg.EmitAccountMetrics,
 		logValidatorPerformance: cfg.LogValidatorPerformance,
 		distributed:             cfg.Distributed,
+		clock:                   cfg.Clock,
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -191,6 +198,7 @@ func (v *ValidatorService) Start() {
 		graffiti:                       v.graffiti,
 		graffitiStruct:                 v.graffitiStruct,
 		graffitiOrderedIndex:           graffitiOrderedIndex,
+		graffitiFile:                   v.graffitiFile,
 		beaconNodeHosts:                hosts,
 		currentHostIndex:               0,
 		validatorClient:                validatorClient,
@@ -215,6 +223,7 @@ func (v *ValidatorService) Start() {
 		emitAccountMetrics:             v.emitAccountMetrics,
 		useWeb:                         v.useWeb,
 		distributed:                    v.distributed,
+		clock:                          v.clock,
 	}
 
 	v.validator = valStruct
@@ -84,6 +84,7 @@ type validator struct {
 	graffiti                           []byte
 	graffitiStruct                     *graffiti.Graffiti
 	graffitiOrderedIndex               uint64
+	graffitiFile                       string
 	beaconNodeHosts                    []string
 	currentHostIndex                   uint64
 	validatorClient                    iface.ValidatorClient
@@ -108,6 +109,7 @@ type validator struct {
 	emitAccountMetrics                 bool
 	useWeb                             bool
 	distributed                        bool
+	clock                              slots.Clock
 	domainDataLock                     sync.RWMutex
 	attLogsLock                        sync.Mutex
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
@@ -116,6 +118,7 @@ type validator struct {
 	blacklistedPubkeysLock             sync.RWMutex
 	attSelectionLock                   sync.Mutex
 	dutiesLock                         sync.RWMutex
+	graffitiLock                       sync.Mutex
 }
 
 type validatorStatus struct {
@@ -317,7 +320,11 @@ func (v *validator) WaitForChainStart(ctx context.Context) error {
 func (v *validator) setTicker() {
 	// Once the ChainStart log is received, we update the genesis time of the validator client
 	// and begin a slot ticker used to track the current slot the beacon node is in.
-	v.ticker = slots.NewSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
+	clock := v.clock
+	if clock == nil {
+		clock = slots.SystemClock{}
+	}
+	v.ticker = slots.NewSlotTickerWithClock(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot, clock)
 	log.WithField("genesisTime", time.Unix(int64(v.genesisTime), 0)).Info("Beacon chain started")
 }
 
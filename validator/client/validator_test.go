@@ -31,6 +31,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	slottest "github.com/prysmaticlabs/prysm/v5/time/slots/testing"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	dbTest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
@@ -302,6 +303,22 @@ func TestWaitForChainStart_ReceiveErrorFromStream(t *testing.T) {
 	assert.ErrorContains(t, want, err)
 }
 
+func TestSetTicker_UsesClock(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	clock := slottest.NewFakeClock(genesis)
+	v := validator{
+		genesisTime: uint64(genesis.Unix()),
+		clock:       clock,
+	}
+	v.setTicker()
+	defer v.ticker.Done()
+
+	require.Equal(t, primitives.Slot(0), <-v.NextSlot())
+	clock.BlockUntil(1)
+	clock.AdvanceToSlot(genesis, 1)
+	require.Equal(t, primitives.Slot(1), <-v.NextSlot())
+}
+
 func TestCanonicalHeadSlot_FailedRPC(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -496,12 +496,15 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 
 	// Configure graffiti.
 	graffitiStruct := &g.Graffiti{}
+	graffitiFilePath := ""
 	if c.cliCtx.IsSet(flags.GraffitiFileFlag.Name) {
-		graffitiFilePath := c.cliCtx.String(flags.GraffitiFileFlag.Name)
+		graffitiFilePath = c.cliCtx.String(flags.GraffitiFileFlag.Name)
 
-		graffitiStruct, err = g.ParseGraffitiFile(graffitiFilePath)
+		parsed, err := g.ParseGraffitiFile(graffitiFilePath)
 		if err != nil {
 			log.WithError(err).Warn("Could not parse graffiti file")
+		} else {
+			graffitiStruct = parsed
 		}
 	}
 
@@ -529,6 +532,7 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		BeaconApiTimeout:        time.Second * 30,
 		Graffiti:                g.ParseHexGraffiti(c.cliCtx.String(flags.GraffitiFlag.Name)),
 		GraffitiStruct:          graffitiStruct,
+		GraffitiFile:            graffitiFilePath,
 		InteropKmConfig:         interopKmConfig,
 		Web3SignerConfig:        web3signerConfig,
 		ProposerSettings:        ps,